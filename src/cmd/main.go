@@ -2,7 +2,9 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
+	"os"
 	"timetracker/cmd/time_tracker"
 
 	"github.com/BurntSushi/toml"
@@ -16,8 +18,19 @@ var (
 func init() {
 	flag.StringVar(&configPath, "config-path", "./config.toml", "path to config file")
 	flag.StringVar(&sessionDB, "sesseion-db", "redis", "what db the application uses for session")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [migrate up|down|status]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
 }
 
+// @title        timetracker API
+// @version      1.0
+// @description  Versioned routes are under /api/v1. The routes outside of it are deprecated aliases,
+// @description  their responses have the Deprecation header and a Link to the route that replaces them.
+// @description  The POST /<item>/edit aliases require If-Match like the PATCH routes, without it they get 428 if_match_required.
+// @BasePath     /
 func main() {
 	flag.Parse()
 
@@ -28,6 +41,19 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if flag.Arg(0) == "migrate" {
+		if flag.NArg() != 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+
+		err = timeTracker.Migrate(flag.Arg(1), os.Stdout)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	err = timeTracker.Run(sessionDB)
 
 	if err != nil {
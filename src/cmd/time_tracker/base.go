@@ -1,25 +1,68 @@
 package time_tracker
 
 import (
-	"github.com/labstack/echo/v4"
+	"context"
+	"fmt"
 	"timetracker/cmd/time_tracker/flags"
+	"timetracker/internal/lifecycle"
+	"timetracker/internal/logger"
+	"timetracker/internal/metrics"
+	"timetracker/internal/tracing"
+
+	"github.com/labstack/echo/v4"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type base struct {
-	Logger   flags.LoggerFlags `toml:"logger"`
+	Logger   flags.LoggerFlags  `toml:"logger"`
+	Tracing  flags.TracingFlags `toml:"tracing"`
 	services *baseServices
 }
 
 type baseServices struct {
-	Logger echo.Logger
-	// Tracer          *otel.Tracer
-	// MetricsRegistry *metrics.Registry
+	Logger    *logger.Logger
+	Lifecycle *lifecycle.Lifecycle
+	// TracerProvider is nil with the none exporter
+	TracerProvider  *sdktrace.TracerProvider
+	MetricsRegistry *metrics.Registry
 }
 
 func (b *base) Init(e *echo.Echo) (*baseServices, error) {
 	services := &baseServices{}
-	logger := b.Logger.Init(e)
-	services.Logger = logger
+	log, closeLogFile, err := b.Logger.Init(e)
+	if err != nil {
+		return nil, fmt.Errorf("can not init logger: %w", err)
+	}
+	services.Logger = log
+
+	// the log file is registered first, so it is closed after everything else is stopped
+	services.Lifecycle = lifecycle.New(log.Named("lifecycle"))
+	services.Lifecycle.OnStop("log file", func(ctx context.Context) error {
+		log.Sync()
+		return closeLogFile()
+	})
+
+	exporter, closeTraceFile, err := b.Tracing.Init(context.Background())
+	if err != nil {
+		log.Error("can not init tracing exporter: ", err)
+		services.Lifecycle.Stop(context.Background())
+		return nil, err
+	}
+
+	services.TracerProvider = tracing.NewProvider(exporter, b.Tracing.GetSampleRatio(), ServiceName, Version)
+	if services.TracerProvider != nil {
+		// flushes the spans of the last requests, the http server is stopped by then
+		services.Lifecycle.OnStop("tracer provider", func(ctx context.Context) error {
+			err := services.TracerProvider.Shutdown(ctx)
+			if closeErr := closeTraceFile(); err == nil {
+				err = closeErr
+			}
+
+			return err
+		})
+	}
+
+	services.MetricsRegistry = metrics.New()
 	b.services = services
 
 	return services, nil
@@ -1,6 +1,33 @@
 package time_tracker
 
+import (
+	"context"
+	"timetracker/internal/Health/usecase"
+	"timetracker/internal/migrations"
+	"timetracker/models"
+)
+
+// set at build time: go build -ldflags "-X timetracker/cmd/time_tracker.Version=..."
 var (
-	ServiceName string
-	Version     string
+	ServiceName = "timetracker"
+	Version     = "dev"
+	Commit      string
 )
+
+func buildInfo() models.BuildInfo {
+	return models.BuildInfo{
+		Service: ServiceName,
+		Version: Version,
+		Commit:  Commit,
+	}
+}
+
+func schemaVersion(migrator *migrations.Migrator) usecase.SchemaVersionFunc {
+	if migrator == nil {
+		return nil
+	}
+
+	return func(ctx context.Context) (uint64, error) {
+		return migrator.WithContext(ctx).Version()
+	}
+}
@@ -0,0 +1,11 @@
+package flags
+
+import "time"
+
+type AccountFlags struct {
+	DeletionGracePeriod time.Duration `toml:"deletion-grace-period"`
+	PurgeInterval       time.Duration `toml:"purge-interval"`
+	// ExportTTL is how long a data export is kept before it has to be requested again.
+	ExportTTL          time.Duration `toml:"export-ttl"`
+	ExportPollInterval time.Duration `toml:"export-poll-interval"`
+}
@@ -0,0 +1,9 @@
+package flags
+
+import "time"
+
+type AuditFlags struct {
+	// Retention is how long audit records are kept, zero keeps them forever.
+	Retention     time.Duration `toml:"retention"`
+	PurgeInterval time.Duration `toml:"purge-interval"`
+}
@@ -1,28 +1,78 @@
 package flags
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"timetracker/internal/logger"
 
 	"github.com/labstack/echo/v4"
-	elog "github.com/labstack/gommon/log"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	LogOutputStdout = "stdout"
+	LogOutputFile   = "file"
 )
 
 type LoggerFlags struct {
-	LogLevel      uint8  `toml:"level"`
-	LogHeader     string `toml:"header"`
-	LogHttpFormat string `toml:"log-http-format"`
-	LogFilePath   string `toml:"log-file-path"`
+	// Level is debug, info, warn or error
+	Level string `toml:"level"`
+	// Packages override the level of single packages, e.g. http = 'warn'
+	Packages map[string]string `toml:"packages"`
+	// Format is json or console
+	Format string `toml:"format"`
+	// Outputs are stdout and/or file, stdout by default
+	Outputs     []string `toml:"outputs"`
+	LogFilePath string   `toml:"log-file-path"`
+	// the log file is rotated at MaxSizeMB, 100 by default,
+	// MaxBackups and MaxAgeDays of 0 keep every rotated file
+	MaxSizeMB  int  `toml:"max-size-mb"`
+	MaxBackups int  `toml:"max-backups"`
+	MaxAgeDays int  `toml:"max-age-days"`
+	Compress   bool `toml:"compress"`
 }
 
-func (f LoggerFlags) Init(e *echo.Echo) echo.Logger {
-	e.Logger.SetLevel(elog.Lvl(f.LogLevel))
-	e.Logger.SetHeader(f.LogHeader)
+// Init makes the logger the one of e. The log file is appended to,
+// closeFile closes it on exit.
+func (f LoggerFlags) Init(e *echo.Echo) (log *logger.Logger, closeFile func() error, err error) {
+	levels, err := logger.NewLevels(f.Level, f.Packages)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var writers []io.Writer
+	closeFile = func() error { return nil }
+
+	outputs := f.Outputs
+	if len(outputs) == 0 {
+		outputs = []string{LogOutputStdout}
+	}
+
+	for _, output := range outputs {
+		switch output {
+		case LogOutputStdout:
+			writers = append(writers, os.Stdout)
+		case LogOutputFile:
+			rotated := &lumberjack.Logger{
+				Filename:   f.LogFilePath,
+				MaxSize:    f.MaxSizeMB,
+				MaxBackups: f.MaxBackups,
+				MaxAge:     f.MaxAgeDays,
+				Compress:   f.Compress,
+			}
+			writers = append(writers, rotated)
+			closeFile = rotated.Close
+		default:
+			return nil, nil, fmt.Errorf("unknown log output %q, expected %s or %s", output, LogOutputStdout, LogOutputFile)
+		}
+	}
 
-	file, err := os.Create(f.LogFilePath)
+	log, err = logger.New(io.MultiWriter(writers...), f.Format, levels)
 	if err != nil {
-		e.Logger.Fatal("Failed to create log file:", err)
+		return nil, nil, err
 	}
 
-	e.Logger.SetOutput(file)
-	return e.Logger
+	e.Logger = log
+	return log, closeFile, nil
 }
@@ -0,0 +1,31 @@
+package flags
+
+import (
+	"timetracker/internal/memory"
+
+	"github.com/pkg/errors"
+)
+
+type MemoryFlags struct {
+	// SeedUsers is the number of fake users created on start, every one signs in with SeedPassword.
+	SeedUsers    int    `toml:"seed-users"`
+	SeedPassword string `toml:"seed-password"`
+}
+
+func (f MemoryFlags) Init() (*memory.DB, []string, error) {
+	db := memory.NewDB()
+	if f.SeedUsers <= 0 {
+		return db, nil, nil
+	}
+
+	if f.SeedPassword == "" {
+		return nil, nil, errors.New("memory seed-password is empty")
+	}
+
+	emails, err := db.Seed(f.SeedUsers, f.SeedPassword)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db, emails, nil
+}
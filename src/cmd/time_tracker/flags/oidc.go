@@ -0,0 +1,28 @@
+package flags
+
+import (
+	"context"
+	"timetracker/internal/Auth/provider"
+)
+
+type OIDCFlags struct {
+	Enabled           bool     `toml:"enabled"`
+	ProviderName      string   `toml:"provider-name"`
+	IssuerURL         string   `toml:"issuer-url"`
+	ClientID          string   `toml:"client-id"`
+	ClientSecret      string   `toml:"client-secret"`
+	RedirectURL       string   `toml:"redirect-url"`
+	Scopes            []string `toml:"scopes"`
+	PostLoginRedirect string   `toml:"post-login-redirect"`
+}
+
+func (f OIDCFlags) Init() (provider.ProviderI, error) {
+	return provider.NewOIDCProvider(context.Background(), provider.Config{
+		Name:         f.ProviderName,
+		IssuerURL:    f.IssuerURL,
+		ClientID:     f.ClientID,
+		ClientSecret: f.ClientSecret,
+		RedirectURL:  f.RedirectURL,
+		Scopes:       f.Scopes,
+	})
+}
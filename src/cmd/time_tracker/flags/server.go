@@ -6,11 +6,21 @@ import (
 	"time"
 )
 
+const (
+	defaultShutdownTimeout  = 15 * time.Second
+	defaultReadinessTimeout = 2 * time.Second
+)
+
 type ServerFlags struct {
 	Addr              string        `toml:"addr"`
 	ReadTimeout       time.Duration `toml:"read-timeout"`
 	ReadHeaderTimeout time.Duration `toml:"read-header-timeout"`
 	WriteTimeout      time.Duration `toml:"write-timeout"`
+	SecureCookies     bool          `toml:"secure-cookies"`
+	// ShutdownTimeout bounds the graceful shutdown, requests still running after it are cut off
+	ShutdownTimeout time.Duration `toml:"shutdown-timeout"`
+	// ReadinessTimeout bounds every dependency ping of /readyz
+	ReadinessTimeout time.Duration `toml:"readiness-timeout"`
 }
 
 func (f ServerFlags) Init(e *echo.Echo) *http.Server {
@@ -22,3 +32,19 @@ func (f ServerFlags) Init(e *echo.Echo) *http.Server {
 		WriteTimeout:      f.WriteTimeout,
 	}
 }
+
+func (f ServerFlags) GetShutdownTimeout() time.Duration {
+	if f.ShutdownTimeout <= 0 {
+		return defaultShutdownTimeout
+	}
+
+	return f.ShutdownTimeout
+}
+
+func (f ServerFlags) GetReadinessTimeout() time.Duration {
+	if f.ReadinessTimeout <= 0 {
+		return defaultReadinessTimeout
+	}
+
+	return f.ReadinessTimeout
+}
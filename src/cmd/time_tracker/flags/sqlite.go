@@ -0,0 +1,37 @@
+package flags
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type SQLiteFlags struct {
+	Path               string        `toml:"path"`
+	MaxOpenConnections int           `toml:"max-open-connections"`
+	BusyTimeout        time.Duration `toml:"busy-timeout"`
+}
+
+// Init opens the database file with foreign keys enforced on every connection,
+// they are off by default in sqlite and the schema relies on ON DELETE CASCADE.
+func (f SQLiteFlags) Init() (*gorm.DB, error) {
+	params := url.Values{}
+	params.Set("_foreign_keys", "on")
+	params.Set("_journal_mode", "WAL")
+	params.Set("_busy_timeout", strconv.FormatInt(f.BusyTimeout.Milliseconds(), 10))
+
+	db, err := gorm.Open(sqlite.Open("file:"+f.Path+"?"+params.Encode()),
+		&gorm.Config{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(f.MaxOpenConnections)
+
+	return db, nil
+}
@@ -0,0 +1,78 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	TracingNone   = "none"
+	TracingStdout = "stdout"
+	TracingFile   = "file"
+	TracingOTLP   = "otlp"
+
+	defaultSampleRatio = 1.0
+)
+
+type TracingFlags struct {
+	// Exporter is none, stdout or file for local debugging, or otlp
+	Exporter string `toml:"exporter"`
+	// Endpoint of the OTLP gRPC collector, host:port
+	Endpoint string `toml:"endpoint"`
+	Insecure bool   `toml:"insecure"`
+	// FilePath receives the spans as JSON with the file exporter, appended across restarts
+	FilePath string `toml:"file-path"`
+	// SampleRatio of the traces started here, the traces of the callers keep their own decision
+	SampleRatio float64 `toml:"sample-ratio"`
+}
+
+// Init builds the span exporter, nil with the none exporter.
+// closeFile releases the file of the file exporter, it is called after the exporter is shut down.
+func (f TracingFlags) Init(ctx context.Context) (exporter sdktrace.SpanExporter, closeFile func() error, err error) {
+	closeFile = func() error { return nil }
+
+	switch f.Exporter {
+	case "", TracingNone:
+		return nil, closeFile, nil
+	case TracingStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case TracingFile:
+		var file *os.File
+		file, err = os.OpenFile(f.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, closeFile, err
+		}
+
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, closeFile, err
+		}
+		closeFile = file.Close
+	case TracingOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(f.Endpoint)}
+		if f.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err = otlptracegrpc.New(ctx, options...)
+	default:
+		err = fmt.Errorf("unknown tracing exporter %q, expected %s, %s, %s or %s",
+			f.Exporter, TracingNone, TracingStdout, TracingFile, TracingOTLP)
+	}
+
+	return exporter, closeFile, err
+}
+
+func (f TracingFlags) GetSampleRatio() float64 {
+	if f.SampleRatio <= 0 {
+		return defaultSampleRatio
+	}
+
+	return f.SampleRatio
+}
@@ -0,0 +1,9 @@
+package flags
+
+import "time"
+
+type TrashFlags struct {
+	// Retention is how long deleted items can be restored, zero keeps them forever.
+	Retention     time.Duration `toml:"retention"`
+	PurgeInterval time.Duration `toml:"purge-interval"`
+}
@@ -0,0 +1,110 @@
+package time_tracker
+
+import (
+	"context"
+	"time"
+	accountUsecase "timetracker/internal/Account/usecase"
+	auditUsecase "timetracker/internal/Audit/usecase"
+	trashUsecase "timetracker/internal/Trash/usecase"
+	"timetracker/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// every returns a worker running job every interval until its context is done,
+// a non positive interval disables the job.
+func every(interval time.Duration, job func(ctx context.Context)) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		runEvery(ctx, interval, job)
+	}
+}
+
+func runEvery(ctx context.Context, interval time.Duration, job func(ctx context.Context)) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job(ctx)
+		}
+	}
+}
+
+func auditRetentionJob(auditUC auditUsecase.UsecaseI, logger echo.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		deleted, err := auditUC.PurgeExpired(ctx)
+		if err != nil {
+			logger.Error("can not purge audit log: ", err)
+			return
+		}
+
+		if deleted > 0 {
+			logger.Infof("purged %d expired audit records", deleted)
+		}
+	}
+}
+
+func trashPurgeJob(trashUC trashUsecase.UsecaseI, logger echo.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		purged, err := trashUC.PurgeExpired(ctx)
+		if err != nil {
+			logger.Error("can not purge trash: ", err)
+		}
+
+		if purged > 0 {
+			logger.Infof("purged %d deleted items from the trash", purged)
+		}
+	}
+}
+
+func accountDeletionJob(accountUC accountUsecase.UsecaseI, auditUC auditUsecase.UsecaseI, logger echo.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		deleted, err := accountUC.PurgeDeletedAccounts(ctx)
+		if err != nil {
+			logger.Error("can not purge deleted accounts: ", err)
+		}
+
+		for _, userID := range deleted {
+			userID := userID
+			err = auditUC.Record(ctx, &models.AuditRecord{
+				Action:     models.AuditUserDelete,
+				TargetType: models.AuditTargetUser,
+				TargetID:   &userID,
+				Details:    "grace period is over",
+			})
+			if err != nil {
+				logger.Error(err)
+			}
+		}
+
+		exports, err := accountUC.PurgeExpiredExports(ctx)
+		if err != nil {
+			logger.Error("can not purge data exports: ", err)
+		} else if exports > 0 {
+			logger.Infof("purged %d expired data exports", exports)
+		}
+	}
+}
+
+// dataExportJob drains the queue of pending exports.
+func dataExportJob(accountUC accountUsecase.UsecaseI, logger echo.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		for {
+			processed, err := accountUC.ProcessPendingExport(ctx)
+			if err != nil {
+				logger.Error("can not build data export: ", err)
+			}
+
+			if !processed {
+				return
+			}
+		}
+	}
+}
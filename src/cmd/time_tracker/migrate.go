@@ -0,0 +1,90 @@
+package time_tracker
+
+import (
+	"fmt"
+	"io"
+	"timetracker/internal/migrations"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	MigrateUp     = "up"
+	MigrateDown   = "down"
+	MigrateStatus = "status"
+)
+
+// Migrate runs a `migrate` subcommand against the configured storage and reports to out
+func (tt TimeTracker) Migrate(command string, out io.Writer) error {
+	migrator, err := tt.initMigrator()
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case MigrateUp:
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %04d_%s\n", migration.Version, migration.Name)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			fmt.Fprintf(out, "schema is up to date (version %d)\n", migrator.Latest())
+		}
+	case MigrateDown:
+		migration, err := migrator.Down()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "rolled back %04d_%s\n", migration.Version, migration.Name)
+	case MigrateStatus:
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			fmt.Fprintln(out, status)
+		}
+	default:
+		return errors.Errorf("unknown migrate command %q, expected %s, %s or %s", command, MigrateUp, MigrateDown, MigrateStatus)
+	}
+
+	return nil
+}
+
+func (tt TimeTracker) initMigrator() (*migrations.Migrator, error) {
+	switch tt.Storage {
+	case "", StoragePostgres:
+		postgresClient, err := tt.PostgresClient.Init()
+		if err != nil {
+			return nil, errors.Wrap(err, "can not connect to Postgres client")
+		}
+
+		return migrations.NewPostgres(postgresClient)
+	case StorageSQLite:
+		sqliteClient, err := tt.SQLiteClient.Init()
+		if err != nil {
+			return nil, errors.Wrap(err, "can not open SQLite database")
+		}
+
+		return migrations.NewSQLite(sqliteClient)
+	default:
+		return nil, errors.Errorf("%s storage has no schema to migrate", tt.Storage)
+	}
+}
+
+// checkSchema refuses to start the server unless the schema matches the embedded migrations
+func checkSchema(migrator *migrations.Migrator) error {
+	err := migrator.Check()
+	if errors.Is(err, migrations.ErrSchemaOutdated) {
+		return errors.Wrap(err, "run `migrate up` before starting the server")
+	}
+
+	return err
+}
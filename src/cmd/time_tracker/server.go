@@ -1,15 +1,37 @@
 package time_tracker
 
 import (
-	"log"
+	"context"
 	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 )
 
 type Server struct {
-	http.Server
+	*http.Server
+	logger echo.Logger
 }
 
+// Start serves until Stop is called, then it returns nil
 func (s *Server) Start() error {
-	log.Println("start serving in ", s.Addr)
-	return s.ListenAndServe()
+	s.logger.Info("start serving in ", s.Addr)
+
+	err := s.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// Stop waits for the requests in flight until ctx expires, then closes the remaining connections
+func (s *Server) Stop(ctx context.Context) error {
+	err := s.Shutdown(ctx)
+	if err != nil {
+		s.Close()
+		return err
+	}
+
+	return nil
 }
@@ -0,0 +1,316 @@
+package time_tracker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	accountRepository "timetracker/internal/Account/repository"
+	accountRepMemory "timetracker/internal/Account/repository/memory"
+	accountRep "timetracker/internal/Account/repository/postgres"
+	auditRepository "timetracker/internal/Audit/repository"
+	auditRepMemory "timetracker/internal/Audit/repository/memory"
+	auditRep "timetracker/internal/Audit/repository/postgres"
+	authRepository "timetracker/internal/Auth/repository"
+	authRepMemory "timetracker/internal/Auth/repository/memory"
+	authRepPostgres "timetracker/internal/Auth/repository/postgres"
+	authRep "timetracker/internal/Auth/repository/redis"
+	clientRepository "timetracker/internal/Client/repository"
+	clientRepMemory "timetracker/internal/Client/repository/memory"
+	clientRep "timetracker/internal/Client/repository/postgres"
+	entryRepository "timetracker/internal/Entry/repository"
+	entryRepMemory "timetracker/internal/Entry/repository/memory"
+	entryRep "timetracker/internal/Entry/repository/postgres"
+	friendRepository "timetracker/internal/Friends/repository"
+	friendRepMemory "timetracker/internal/Friends/repository/memory"
+	friendRep "timetracker/internal/Friends/repository/postgres"
+	goalRepository "timetracker/internal/Goal/repository"
+	goalRepMemory "timetracker/internal/Goal/repository/memory"
+	goalRep "timetracker/internal/Goal/repository/postgres"
+	healthUsecase "timetracker/internal/Health/usecase"
+	projectRepository "timetracker/internal/Project/repository"
+	projectRepMemory "timetracker/internal/Project/repository/memory"
+	projectRep "timetracker/internal/Project/repository/postgres"
+	syncRepository "timetracker/internal/Sync/repository"
+	syncRepMemory "timetracker/internal/Sync/repository/memory"
+	syncRep "timetracker/internal/Sync/repository/postgres"
+	tagRepository "timetracker/internal/Tag/repository"
+	tagRepMemory "timetracker/internal/Tag/repository/memory"
+	tagRep "timetracker/internal/Tag/repository/postgres"
+	taskRepository "timetracker/internal/Task/repository"
+	taskRepMemory "timetracker/internal/Task/repository/memory"
+	taskRep "timetracker/internal/Task/repository/postgres"
+	userRepository "timetracker/internal/User/repository"
+	userRepMemory "timetracker/internal/User/repository/memory"
+	userRep "timetracker/internal/User/repository/postgres"
+	"timetracker/internal/cache"
+	"timetracker/internal/metrics"
+	"timetracker/internal/migrations"
+	"timetracker/internal/tracing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"gorm.io/gorm"
+)
+
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
+
+type repositories struct {
+	entry    entryRepository.RepositoryI
+	user     userRepository.RepositoryI
+	tag      tagRepository.RepositoryI
+	goal     goalRepository.RepositoryI
+	project  projectRepository.RepositoryI
+	client   clientRepository.RepositoryI
+	task     taskRepository.RepositoryI
+	session  authRepository.RepositoryI
+	identity authRepository.IdentityRepositoryI
+	friend   friendRepository.RepositoryI
+	audit    auditRepository.RepositoryI
+	export   accountRepository.RepositoryI
+	sync     syncRepository.RepositoryI
+	cache    cache.CacheStorageI
+
+	// checks are the dependencies pinged by /readyz, migrator is nil for the memory storage
+	checks   []healthUsecase.Check
+	migrator *migrations.Migrator
+}
+
+// initStorage builds the repositories of the configured storage, postgres by default,
+// registers the clients to be closed on stop and exports their pool stats.
+func (tt TimeTracker) initStorage(sessionDB string, services *baseServices) (*repositories, error) {
+	var repos *repositories
+	var err error
+
+	switch tt.Storage {
+	case "", StoragePostgres:
+		repos, err = tt.initPostgresStorage(sessionDB, services)
+	case StorageSQLite:
+		repos, err = tt.initSQLiteStorage(services)
+	case StorageMemory:
+		repos, err = tt.initMemoryStorage(services.Logger)
+	default:
+		err = fmt.Errorf("unknown storage %q, expected %s, %s or %s", tt.Storage, StoragePostgres, StorageSQLite, StorageMemory)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	repos.cache = cache.WithMetrics(repos.cache, services.MetricsRegistry.CacheRequests())
+	return repos, nil
+}
+
+func (tt TimeTracker) initPostgresStorage(sessionDB string, services *baseServices) (*repositories, error) {
+	logger, lc, registry := services.Logger, services.Lifecycle, services.MetricsRegistry
+	postgresClient, err := tt.PostgresClient.Init()
+
+	if err != nil {
+		logger.Error("can not connect to Postgres client: %w", err)
+		return nil, err
+	} else {
+		logger.Info("Success conect to postgres")
+	}
+	lc.OnStop("postgres client", closeGorm(postgresClient))
+
+	if err = instrumentGorm(registry, "postgres", semconv.DBSystemPostgreSQL, postgresClient); err != nil {
+		logger.Error("can not instrument postgres client: ", err)
+		return nil, err
+	}
+
+	migrator, err := migrations.NewPostgres(postgresClient)
+	if err == nil {
+		err = checkSchema(migrator)
+	}
+
+	if err != nil {
+		logger.Error("database schema check failed: ", err)
+		return nil, err
+	}
+
+	redisSessionClient, err := tt.RedisSessionClient.Init()
+
+	if err != nil {
+		logger.Error("can not connect to Redis session client: %w", err)
+		return nil, err
+	} else {
+		logger.Info("Success conect to redis")
+	}
+	lc.OnStop("redis session client", closeRedis(redisSessionClient))
+	instrumentRedis(registry, "session", redisSessionClient)
+
+	redisCacheClient, err := tt.RedisProjectStorageClient.Init()
+
+	if err != nil {
+		logger.Error("can not connect to Redis cache client: %w", err)
+		return nil, err
+	} else {
+		logger.Info("Success conect to redis")
+	}
+	lc.OnStop("redis cache client", closeRedis(redisCacheClient))
+	instrumentRedis(registry, "cache", redisCacheClient)
+
+	sessionRepo := authRep.NewAuthRepository(redisSessionClient)
+	if sessionDB == "postgres" {
+		sessionRepo = authRepPostgres.NewAuthRepositoryPostgres(postgresClient)
+	}
+
+	checks := []healthUsecase.Check{
+		{Name: "postgres", Ping: pingGorm(postgresClient)},
+		{Name: "redis session", Ping: pingRedis(redisSessionClient)},
+		{Name: "redis cache", Ping: pingRedis(redisCacheClient)},
+	}
+
+	return &repositories{
+		entry:    entryRep.NewEntryRepository(postgresClient),
+		user:     userRep.NewUserRepository(postgresClient),
+		tag:      tagRep.NewTagRepository(postgresClient),
+		goal:     goalRep.NewGoalRepository(postgresClient),
+		project:  projectRep.NewProjectRepository(postgresClient),
+		client:   clientRep.NewClientRepository(postgresClient),
+		task:     taskRep.NewTaskRepository(postgresClient),
+		session:  sessionRepo,
+		identity: authRepPostgres.NewIdentityRepository(postgresClient),
+		friend:   friendRep.NewFriendRepository(postgresClient),
+		audit:    auditRep.NewAuditRepository(postgresClient),
+		export:   accountRep.NewExportRepository(postgresClient),
+		sync:     syncRep.NewSyncRepository(postgresClient),
+		cache:    cache.NewStorageRedis(redisCacheClient),
+		checks:   checks,
+		migrator: migrator,
+	}, nil
+}
+
+// initSQLiteStorage runs the gorm repositories on a single database file.
+// Sessions live in its cookie table and the project cache in memory, so no redis is needed.
+func (tt TimeTracker) initSQLiteStorage(services *baseServices) (*repositories, error) {
+	logger, lc := services.Logger, services.Lifecycle
+	sqliteClient, err := tt.SQLiteClient.Init()
+
+	if err != nil {
+		logger.Error("can not open SQLite database: ", err)
+		return nil, err
+	} else {
+		logger.Info("Success open sqlite ", tt.SQLiteClient.Path)
+	}
+	lc.OnStop("sqlite client", closeGorm(sqliteClient))
+
+	if err = instrumentGorm(services.MetricsRegistry, "sqlite", semconv.DBSystemSqlite, sqliteClient); err != nil {
+		logger.Error("can not instrument sqlite client: ", err)
+		return nil, err
+	}
+
+	migrator, err := migrations.NewSQLite(sqliteClient)
+	if err == nil {
+		err = checkSchema(migrator)
+	}
+
+	if err != nil {
+		logger.Error("database schema check failed: ", err)
+		return nil, err
+	}
+
+	return &repositories{
+		entry:    entryRep.NewEntryRepository(sqliteClient),
+		user:     userRep.NewUserRepository(sqliteClient),
+		tag:      tagRep.NewTagRepository(sqliteClient),
+		goal:     goalRep.NewGoalRepository(sqliteClient),
+		project:  projectRep.NewProjectRepository(sqliteClient),
+		client:   clientRep.NewClientRepository(sqliteClient),
+		task:     taskRep.NewTaskRepository(sqliteClient),
+		session:  authRepPostgres.NewAuthRepositoryPostgres(sqliteClient),
+		identity: authRepPostgres.NewIdentityRepository(sqliteClient),
+		friend:   friendRep.NewFriendRepository(sqliteClient),
+		audit:    auditRep.NewAuditRepository(sqliteClient),
+		export:   accountRep.NewExportRepository(sqliteClient),
+		sync:     syncRep.NewSyncRepository(sqliteClient),
+		cache:    cache.NewStorageMemory(),
+		checks:   []healthUsecase.Check{{Name: "sqlite", Ping: pingGorm(sqliteClient)}},
+		migrator: migrator,
+	}, nil
+}
+
+// initMemoryStorage keeps everything in the process, the data is lost on exit
+func (tt TimeTracker) initMemoryStorage(logger echo.Logger) (*repositories, error) {
+	db, seededEmails, err := tt.Memory.Init()
+	if err != nil {
+		logger.Error("can not init memory storage: ", err)
+		return nil, err
+	}
+
+	logger.Warn("Using memory storage, all data is lost on exit")
+	if len(seededEmails) > 0 {
+		logger.Info("Seeded users (the first one is an admin): ", strings.Join(seededEmails, ", "))
+	}
+
+	return &repositories{
+		entry:    entryRepMemory.NewEntryRepository(db),
+		user:     userRepMemory.NewUserRepository(db),
+		tag:      tagRepMemory.NewTagRepository(db),
+		goal:     goalRepMemory.NewGoalRepository(db),
+		project:  projectRepMemory.NewProjectRepository(db),
+		client:   clientRepMemory.NewClientRepository(db),
+		task:     taskRepMemory.NewTaskRepository(db),
+		session:  authRepMemory.NewAuthRepository(db),
+		identity: authRepMemory.NewIdentityRepository(db),
+		friend:   friendRepMemory.NewFriendRepository(db),
+		audit:    auditRepMemory.NewAuditRepository(db),
+		export:   accountRepMemory.NewExportRepository(db),
+		sync:     syncRepMemory.NewSyncRepository(db),
+		cache:    cache.NewStorageMemory(),
+	}, nil
+}
+
+func closeGorm(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.Close()
+	}
+}
+
+// instrumentGorm exports the pool stats of db and traces its queries
+func instrumentGorm(registry *metrics.Registry, name string, system attribute.KeyValue, db *gorm.DB) error {
+	if sqlDB, err := db.DB(); err == nil {
+		registry.RegisterDB(name, sqlDB)
+	}
+
+	return db.Use(tracing.NewGormPlugin(system))
+}
+
+// instrumentRedis exports the pool stats of client and traces its commands
+func instrumentRedis(registry *metrics.Registry, name string, client *redis.Client) {
+	registry.RegisterRedis(name, client)
+	client.AddHook(tracing.NewRedisHook(name))
+}
+
+func pingGorm(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+func pingRedis(client *redis.Client) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+func closeRedis(client *redis.Client) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return client.Close()
+	}
+}
@@ -1,32 +1,45 @@
 package time_tracker
 
 import (
+	"context"
 	"fmt"
+	"os/signal"
+	"syscall"
 	"timetracker/cmd/time_tracker/flags"
+	_accountDelivery "timetracker/internal/Account/delivery"
+	accountUsecase "timetracker/internal/Account/usecase"
+	_adminDelivery "timetracker/internal/Admin/delivery"
+	adminUsecase "timetracker/internal/Admin/usecase"
+	_auditDelivery "timetracker/internal/Audit/delivery"
+	auditUsecase "timetracker/internal/Audit/usecase"
 	_authDelivery "timetracker/internal/Auth/delivery"
-	authRepPostgres "timetracker/internal/Auth/repository/postgres"
-	authRep "timetracker/internal/Auth/repository/redis"
 	authUsecase "timetracker/internal/Auth/usecase"
+	_clientDelivery "timetracker/internal/Client/delivery"
+	clientUsecase "timetracker/internal/Client/usecase"
 	_entryDelivery "timetracker/internal/Entry/delivery"
-	entryRep "timetracker/internal/Entry/repository/postgres"
 	entryUsecase "timetracker/internal/Entry/usecase"
 	_friendDelivery "timetracker/internal/Friends/delivery"
-	friendRep "timetracker/internal/Friends/repository/postgres"
 	friendUsecase "timetracker/internal/Friends/usecase"
 	_goalDelivery "timetracker/internal/Goal/delivery"
-	goalRep "timetracker/internal/Goal/repository/postgres"
 	goalUsecase "timetracker/internal/Goal/usecase"
+	_healthDelivery "timetracker/internal/Health/delivery"
+	healthUsecase "timetracker/internal/Health/usecase"
 	_projectDelivery "timetracker/internal/Project/delivery"
-	projectRep "timetracker/internal/Project/repository/postgres"
 	projectUsecase "timetracker/internal/Project/usecase"
+	_syncDelivery "timetracker/internal/Sync/delivery"
+	syncUsecase "timetracker/internal/Sync/usecase"
 	_tagDelivery "timetracker/internal/Tag/delivery"
-	tagRep "timetracker/internal/Tag/repository/postgres"
 	tagUsecase "timetracker/internal/Tag/usecase"
+	_taskDelivery "timetracker/internal/Task/delivery"
+	taskUsecase "timetracker/internal/Task/usecase"
+	_trashDelivery "timetracker/internal/Trash/delivery"
+	trashUsecase "timetracker/internal/Trash/usecase"
 	_userDelivery "timetracker/internal/User/delivery"
-	userRep "timetracker/internal/User/repository/postgres"
 	userUsecase "timetracker/internal/User/usecase"
-	"timetracker/internal/cache"
+	"timetracker/internal/apierror"
+	"timetracker/internal/logger"
 	"timetracker/internal/middleware"
+	"timetracker/internal/tracing"
 
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
@@ -38,91 +51,133 @@ type TimeTracker struct {
 	RedisSessionClient        flags.RedisFlags    `toml:"redis-client"`
 	RedisProjectStorageClient flags.RedisFlags    `toml:"redis-project-storage-client"`
 	Server                    flags.ServerFlags   `toml:"server"`
+	OIDC                      flags.OIDCFlags     `toml:"oidc"`
+	Audit                     flags.AuditFlags    `toml:"audit"`
+	Account                   flags.AccountFlags  `toml:"account"`
+	Trash                     flags.TrashFlags    `toml:"trash"`
+	// Storage is postgres (with redis sessions and cache), sqlite or memory
+	Storage      string            `toml:"storage"`
+	SQLiteClient flags.SQLiteFlags `toml:"sqlite-client"`
+	Memory       flags.MemoryFlags `toml:"memory"`
 }
 
+// Run serves until SIGINT or SIGTERM, then drains the requests in flight and stops
+// the background jobs and the storage clients within the shutdown timeout.
 func (tt TimeTracker) Run(sessionDB string) error {
 	e := echo.New()
+	e.HTTPErrorHandler = apierror.Handler
 	services, err := tt.Init(e)
-
-	logger := services.Logger
-
 	if err != nil {
 		return fmt.Errorf("can not init services: %w", err)
 	}
 
-	postgresClient, err := tt.PostgresClient.Init()
-
-	if err != nil {
-		logger.Error("can not connect to Postgres client: %w", err)
-		return err
-	} else {
-		logger.Info("Success conect to postgres")
-	}
-
-	redisSessionClient, err := tt.RedisSessionClient.Init()
+	log := services.Logger
+	lc := services.Lifecycle
+	metricsRegistry := services.MetricsRegistry
 
+	repos, err := tt.initStorage(sessionDB, services)
 	if err != nil {
-		logger.Error("can not connect to Redis session client: %w", err)
+		lc.Stop(context.Background())
 		return err
-	} else {
-		logger.Info("Success conect to redis")
 	}
 
-	redisCacheClient, err := tt.RedisProjectStorageClient.Init()
-
-	if err != nil {
-		logger.Error("can not connect to Redis cache client: %w", err)
-		return err
-	} else {
-		logger.Info("Success conect to redis")
-	}
-
-	entryRepo := entryRep.NewEntryRepository(postgresClient)
-	userRepo := userRep.NewUserRepository(postgresClient)
-	tagRepo := tagRep.NewTagRepository(postgresClient)
-	goalRepo := goalRep.NewGoalRepository(postgresClient)
-	projectRepo := projectRep.NewProjectRepository(postgresClient)
-	authRepo := authRep.NewAuthRepository(redisSessionClient)
-	authPostgresRepo := authRepPostgres.NewAuthRepositoryPostgres(postgresClient)
-	friendRepo := friendRep.NewFriendRepository(postgresClient)
-	cacheStorage := cache.NewStorageRedis(redisCacheClient)
-
-	entryUC := entryUsecase.New(entryRepo, tagRepo, userRepo)
-	goalUC := goalUsecase.New(goalRepo)
-	projectUC := projectUsecase.New(projectRepo, cacheStorage)
-	tagUC := tagUsecase.New(tagRepo)
-
-	authUC := authUsecase.New(userRepo, authRepo)
-	if sessionDB == "postgres" {
-		authUC = authUsecase.New(userRepo, authPostgresRepo)
-	}
-
-	userUC := userUsecase.New(userRepo)
-	friendUC := friendUsecase.New(friendRepo, userRepo)
+	entryUC := entryUsecase.New(repos.entry, repos.tag, repos.user, repos.project, repos.task, repos.client)
+	goalUC := goalUsecase.New(repos.goal, repos.project)
+	tagUC := tagUsecase.New(repos.tag)
+	authUC := authUsecase.New(repos.user, repos.session)
+	userUC := userUsecase.New(repos.user)
+	adminUC := adminUsecase.New(repos.user, repos.session)
+	auditUC := auditUsecase.New(repos.audit, tt.Audit.Retention)
+	accountUC := accountUsecase.New(repos.export, repos.user, repos.session, repos.entry, repos.tag, repos.project, repos.client, repos.task, repos.goal, repos.friend,
+		tt.Account.DeletionGracePeriod, tt.Account.ExportTTL)
+	friendUC := friendUsecase.New(repos.friend, repos.user)
+	projectUC := projectUsecase.New(repos.project, repos.client, repos.cache, friendUC)
+	clientUC := clientUsecase.New(repos.client, repos.project)
+	taskUC := taskUsecase.New(repos.task, repos.project)
+	syncUC := syncUsecase.New(repos.sync, entryUC, projectUC, tagUC, goalUC, tt.Trash.Retention)
+	trashUC := trashUsecase.New(entryUC, projectUC, tagUC, goalUC, tt.Trash.Retention)
+	healthUC := healthUsecase.New(repos.checks, tt.Server.GetReadinessTimeout(), buildInfo(), schemaVersion(repos.migrator))
 
 	aclMiddleware := middleware.NewAclMiddleware(friendUC)
 
 	_entryDelivery.NewDelivery(e, entryUC, aclMiddleware)
 	_goalDelivery.NewDelivery(e, goalUC, aclMiddleware)
 	_projectDelivery.NewDelivery(e, projectUC, aclMiddleware)
+	_clientDelivery.NewDelivery(e, clientUC)
+	_taskDelivery.NewDelivery(e, taskUC)
 	_tagDelivery.NewDelivery(e, tagUC, aclMiddleware)
-	_authDelivery.NewDelivery(e, authUC)
+	_authDelivery.NewDelivery(e, authUC, tt.Server.SecureCookies)
+
+	if tt.OIDC.Enabled {
+		oidcProvider, err := tt.OIDC.Init()
+		if err != nil {
+			log.Error("can not init OIDC provider: ", err)
+			lc.Stop(context.Background())
+			return err
+		}
+
+		oidcUC := authUsecase.NewOIDC(repos.user, repos.session, repos.identity)
+		_authDelivery.NewOIDCDelivery(e, oidcUC, oidcProvider, tt.OIDC.PostLoginRedirect, tt.Server.SecureCookies)
+	}
 	_userDelivery.NewDelivery(e, userUC, aclMiddleware)
 	_friendDelivery.NewDelivery(e, friendUC, aclMiddleware)
-
-	e.Use(echoMiddleware.LoggerWithConfig(echoMiddleware.LoggerConfig{
-		Format: tt.Logger.LogHttpFormat,
-		Output: logger.Output(),
-	}))
+	_adminDelivery.NewDelivery(e, adminUC, aclMiddleware)
+	_adminDelivery.NewLogLevelDelivery(e, services.Logger.Levels(), aclMiddleware)
+	_auditDelivery.NewDelivery(e, auditUC, aclMiddleware)
+	_accountDelivery.NewDelivery(e, accountUC)
+	_syncDelivery.NewDelivery(e, syncUC)
+	_trashDelivery.NewDelivery(e, trashUC)
+	_healthDelivery.NewDelivery(e, healthUC)
+
+	metricsRegistry.RegisterGauge("active_timers", "Time entries running right now.", func(ctx context.Context) (float64, error) {
+		count, err := entryUC.CountActiveEntries(ctx)
+		return float64(count), err
+	})
+	e.GET("/prometheus", echo.WrapHandler(metricsRegistry.Handler()))
 
 	e.Use(echoMiddleware.Recover())
+	e.Use(metricsRegistry.Middleware)
+	e.Use(tracing.Middleware("/healthz", "/readyz", "/prometheus"))
+	e.Use(echoMiddleware.RequestID())
+	e.Use(logger.Middleware(e, services.Logger))
+	e.Use(middleware.NewAuditMiddleware(auditUC).Inject)
 	authMiddleware := middleware.NewMiddleware(authUC)
 	e.Use(authMiddleware.Auth)
+	e.Use(authMiddleware.CSRF)
+
+	jobsLogger := services.Logger.Named("jobs")
+	lc.Go("audit retention job", every(tt.Audit.PurgeInterval, auditRetentionJob(auditUC, jobsLogger)))
+	lc.Go("account deletion job", every(tt.Account.PurgeInterval, accountDeletionJob(accountUC, auditUC, jobsLogger)))
+	lc.Go("data export job", every(tt.Account.ExportPollInterval, dataExportJob(accountUC, jobsLogger)))
+	lc.Go("trash purge job", every(tt.Trash.PurgeInterval, trashPurgeJob(trashUC, jobsLogger)))
 
 	httpServer := tt.Server.Init(e)
-	server := Server{*httpServer}
-	if err := server.Start(); err != nil {
-		logger.Fatal(err)
+	server := Server{httpServer, log}
+
+	// the server is registered last, so it stops taking requests before anything else is stopped
+	lc.OnStop("http server", server.Stop)
+
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Start()
+	}()
+
+	select {
+	case err = <-serveErr:
+		log.Error("http server failed: ", err)
+	case <-signalCtx.Done():
+		log.Info("shutting down, draining requests for up to ", tt.Server.GetShutdownTimeout())
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), tt.Server.GetShutdownTimeout())
+	defer cancel()
+
+	if stopErr := lc.Stop(shutdownCtx); err == nil {
+		err = stopErr
 	}
-	return nil
+
+	return err
 }
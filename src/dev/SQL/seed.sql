@@ -0,0 +1,6 @@
+-- development data, the schema itself is created by `go run cmd/main.go migrate up`
+INSERT INTO
+	users (name, email, about, role, password)
+VALUES
+	('test', 'test', 'test', 'user', '')
+ON CONFLICT (email) DO NOTHING;
@@ -1,5 +1,5 @@
-// Package docs GENERATED BY SWAG; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
@@ -16,19 +16,63 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth": {
+        "/api/v1/admin/audit": {
             "get": {
-                "description": "check user auth",
+                "description": "paginated audit log, newest first. Acl: audit:read",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
+                ],
+                "summary": "GetAuditRecords",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "actor filter",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "target filter",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "action filter, e.g. auth.signin_failed",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 lower bound, inclusive",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 upper bound, exclusive",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, 50 by default, 500 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Auth",
                 "responses": {
                     "200": {
-                        "description": "success auth",
+                        "description": "success get audit log",
                         "schema": {
                             "allOf": [
                                 {
@@ -38,7 +82,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.User"
+                                            "$ref": "#/definitions/dto.RespAuditPage"
                                         }
                                     }
                                 }
@@ -46,59 +90,45 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "403": {
+                        "description": "permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/entry/create": {
-            "post": {
-                "description": "Create entry",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/admin/loglevel": {
+            "get": {
+                "description": "the root log level and the levels of the packages that override it. Acl: log:level",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "entry"
-                ],
-                "summary": "Create entry",
-                "parameters": [
-                    {
-                        "description": "entry info",
-                        "name": "entry",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ReqCreateUpdateEntry"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "GetLogLevel",
                 "responses": {
                     "200": {
-                        "description": "success update entry",
+                        "description": "success get log levels",
                         "schema": {
                             "allOf": [
                                 {
@@ -108,55 +138,29 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespEntry"
+                                            "$ref": "#/definitions/dto.RespLogLevel"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "bad req",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf or permission denied",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "405": {
-                        "description": "invalid http method",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "422": {
-                        "description": "unprocessable entity",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "internal server error",
+                        "description": "permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
-            }
-        },
-        "/entry/edit": {
-            "post": {
-                "description": "Update an entry",
+            },
+            "put": {
+                "description": "change the root log level, or the level of a package, e.g. http or entry, until restart.\nAn empty level makes the package follow the root level again. Acl: log:level",
                 "consumes": [
                     "application/json"
                 ],
@@ -164,23 +168,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "entry"
+                    "admin"
                 ],
-                "summary": "Update an entry",
+                "summary": "SetLogLevel",
                 "parameters": [
                     {
-                        "description": "entry info",
-                        "name": "entry",
+                        "description": "package and level",
+                        "name": "level",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ReqCreateUpdateEntry"
+                            "$ref": "#/definitions/dto.ReqLogLevel"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success update entry",
+                        "description": "success set log level",
                         "schema": {
                             "allOf": [
                                 {
@@ -190,7 +194,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespEntry"
+                                            "$ref": "#/definitions/dto.RespLogLevel"
                                         }
                                     }
                                 }
@@ -198,69 +202,71 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request, invalid_body",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf or permission denied",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "405": {
-                        "description": "invalid http method",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "422": {
-                        "description": "unprocessable entity",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "internal server error",
+                        "description": "permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/entry/{id}": {
+        "/api/v1/admin/users": {
             "get": {
-                "description": "Get entry by id",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "paginated search of users by name or email. Acl: user:list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "entry"
+                    "admin"
                 ],
-                "summary": "Show a post",
+                "summary": "SearchUsers",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "substring of the name or the email",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "role filter",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "suspended filter",
+                        "name": "suspended",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Entry ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "page size, 20 by default, 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success get entry",
+                        "description": "success get users",
                         "schema": {
                             "allOf": [
                                 {
@@ -270,170 +276,175 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespEntry"
+                                            "$ref": "#/definitions/dto.RespUserPage"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "405": {
-                        "description": "invalid http method",
+                    "403": {
+                        "description": "permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/admin/users/{user_id}": {
             "delete": {
-                "description": "Delete an entry",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "permanently delete the user with all of their data. Acl: user:delete",
                 "tags": [
-                    "entry"
+                    "admin"
                 ],
-                "summary": "Delete an entry",
+                "summary": "DeleteUser",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Entry ID",
-                        "name": "id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "No Content"
+                        "description": "success delete"
                     },
-                    "401": {
-                        "description": "no cookie",
+                    "400": {
+                        "description": "bad_request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "403": {
-                        "description": "invalid csrf",
+                    "401": {
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "404": {
-                        "description": "can't find entry with such id",
+                    "403": {
+                        "description": "permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "405": {
-                        "description": "invalid http method",
+                    "404": {
+                        "description": "not_found: can't find user with such id",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/friends/subscribe/{user_id}": {
+        "/api/v1/admin/users/{user_id}/password-reset": {
             "post": {
-                "description": "subscribe",
+                "description": "drop the password and the sessions of the user and issue a one-time reset token,\nvalid for 24 hours and redeemed with POST /password-reset. Acl: user:password:reset",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "friends"
+                    "admin"
                 ],
-                "summary": "subscribe",
+                "summary": "ForcePasswordReset",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Friend ID",
+                        "description": "User ID",
                         "name": "user_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "success subscribe"
+                    "200": {
+                        "description": "reset token",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespPasswordReset"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf",
+                        "description": "permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "404": {
-                        "description": "subscribe or user doesn't exist",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "405": {
-                        "description": "Method Not Allowed",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "409": {
-                        "description": "subscribe already exists",
+                        "description": "not_found: can't find user with such id",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/friends/unsubscribe/{user_id}": {
-            "delete": {
-                "description": "Unsubscribe",
-                "produces": [
-                    "application/json"
-                ],
+        "/api/v1/admin/users/{user_id}/reactivate": {
+            "post": {
+                "description": "lift the suspension of the account. Acl: user:ban, staff accounts need user:role:change",
                 "tags": [
-                    "friends"
+                    "admin"
                 ],
-                "summary": "Unsubscribe",
+                "summary": "ReactivateUser",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Friend ID",
+                        "description": "User ID",
                         "name": "user_id",
                         "in": "path",
                         "required": true
@@ -441,156 +452,181 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "success unsubscribe, body is empty"
+                        "description": "success reactivate"
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf",
+                        "description": "permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "404": {
-                        "description": "friend/user/friendship doesn't exist",
+                        "description": "not_found: can't find user with such id",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "405": {
-                        "description": "Method Not Allowed",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/goal/create": {
-            "post": {
-                "description": "Create goal",
+        "/api/v1/admin/users/{user_id}/role": {
+            "put": {
+                "description": "change the role of the user. Acl: user:role:change",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "goal"
+                    "admin"
                 ],
-                "summary": "Create goal",
+                "summary": "ChangeRole",
                 "parameters": [
                     {
-                        "description": "goal info",
-                        "name": "goal",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new role",
+                        "name": "role",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ReqCreateUpdateGoal"
+                            "$ref": "#/definitions/dto.ReqChangeRole"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "success update goal",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/pkg.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "$ref": "#/definitions/dto.RespGoal"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
+                    "204": {
+                        "description": "success change role"
                     },
                     "400": {
-                        "description": "bad req",
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf or permission denied",
+                        "description": "permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "405": {
-                        "description": "invalid http method",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "422": {
-                        "description": "unprocessable entity",
+                    "404": {
+                        "description": "not_found: can't find user with such id",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/goal/edit": {
+        "/api/v1/admin/users/{user_id}/suspend": {
             "post": {
-                "description": "Update a goal",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "suspend the account and revoke all of its sessions. Acl: user:ban, staff accounts need user:role:change",
                 "tags": [
-                    "goal"
+                    "admin"
                 ],
-                "summary": "Update a goal",
+                "summary": "SuspendUser",
                 "parameters": [
                     {
-                        "description": "goal info",
-                        "name": "goal",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ReqCreateUpdateGoal"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "success update goal",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "success suspend"
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: can't find user with such id",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users/{user_id}/usage": {
+            "get": {
+                "description": "number of entries, projects, tags, goals and storage used by the user. Acl: user:read:any",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "GetUserUsage",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get usage",
                         "schema": {
                             "allOf": [
                                 {
@@ -600,7 +636,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespGoal"
+                                            "$ref": "#/definitions/dto.RespUserUsage"
                                         }
                                     }
                                 }
@@ -608,69 +644,51 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf or permission denied",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "405": {
-                        "description": "invalid http method",
+                        "description": "permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "422": {
-                        "description": "unprocessable entity",
+                    "404": {
+                        "description": "not_found: can't find user with such id",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/goal/{id}": {
+        "/api/v1/auth/csrf": {
             "get": {
-                "description": "Get goal by id",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get csrf token of the current session. Send it in the X-CSRF-Token header\nwith every POST/PUT/PATCH/DELETE request authenticated by the session cookie.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "goal"
-                ],
-                "summary": "Show a post",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Goal ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "CSRF",
                 "responses": {
                     "200": {
-                        "description": "success get goal",
+                        "description": "csrf token",
                         "schema": {
                             "allOf": [
                                 {
@@ -680,7 +698,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespGoal"
+                                            "$ref": "#/definitions/dto.RespCSRF"
                                         }
                                     }
                                 }
@@ -688,134 +706,120 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "no cookie",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "405": {
-                        "description": "invalid http method",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a goal",
-                "consumes": [
+            }
+        },
+        "/api/v1/auth/logout": {
+            "post": {
+                "description": "user logout",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "goal"
-                ],
-                "summary": "Delete a goal",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Goal ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Logout",
                 "responses": {
                     "204": {
-                        "description": "No Content"
+                        "description": "success logout, body is empty"
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "can't find goal with such id",
+                        "description": "invalid_csrf",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "invalid http method",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/logout": {
+        "/api/v1/auth/password-reset": {
             "post": {
-                "description": "user logout",
-                "produces": [
+                "description": "set a new password with the one-time token issued by an admin (see POST /admin/users/{user_id}/password-reset)",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "auth"
                 ],
-                "summary": "Logout",
-                "responses": {
-                    "204": {
-                        "description": "success logout, body is empty"
-                    },
-                    "401": {
-                        "description": "no cookie",
+                "summary": "ResetPassword",
+                "parameters": [
+                    {
+                        "description": "reset token and new password",
+                        "name": "reset",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/dto.ReqPasswordReset"
                         }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "password changed"
                     },
-                    "403": {
-                        "description": "invalid csrf",
+                    "400": {
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "404": {
+                        "description": "not_found: token is invalid or expired",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/me": {
+        "/api/v1/auth/session": {
             "get": {
-                "description": "get info about me.",
+                "description": "check user auth",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "auth"
                 ],
-                "summary": "get info about me.",
+                "summary": "Auth",
                 "responses": {
                     "200": {
-                        "description": "success get users",
+                        "description": "success auth",
                         "schema": {
                             "allOf": [
                                 {
@@ -825,37 +829,43 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespUser"
+                                            "$ref": "#/definitions/models.User"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "Method Not Allowed",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/me/edit": {
-            "put": {
-                "description": "update user's profile. Acl: user(owner account)",
+        "/api/v1/auth/signin": {
+            "post": {
+                "description": "user sign in",
                 "consumes": [
                     "application/json"
                 ],
@@ -863,84 +873,105 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "auth"
                 ],
-                "summary": "UpdateUser",
+                "summary": "SignIn",
                 "parameters": [
                     {
-                        "description": "user data",
+                        "description": "user info",
                         "name": "user",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ReqUpdateUser"
+                            "$ref": "#/definitions/dto.ReqUserSignIn"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "success update"
+                    "200": {
+                        "description": "success sign in",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespUser"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "invalid_password",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf",
+                        "description": "user_suspended",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "404": {
-                        "description": "can't find user with such id",
+                        "description": "not_found: user doesn't exist",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "Method Not Allowed",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/me/entries": {
-            "get": {
-                "description": "Get user entries or get user entries for a day",
+        "/api/v1/auth/signup": {
+            "post": {
+                "description": "user sign up",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "entry"
+                    "auth"
                 ],
-                "summary": "Get user entries",
+                "summary": "SignUp",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "day for events",
-                        "name": "day",
-                        "in": "query"
+                        "description": "user data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqUserSignUp"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "success get entries",
+                    "201": {
+                        "description": "user created",
                         "schema": {
                             "allOf": [
                                 {
@@ -950,10 +981,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.RespEntry"
-                                            }
+                                            "$ref": "#/definitions/dto.RespUser"
                                         }
                                     }
                                 }
@@ -961,45 +989,59 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "401": {
-                        "description": "no cookie",
+                    "405": {
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "409": {
+                        "description": "email_conflict: email already exists",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/me/friends": {
-            "get": {
-                "description": "get my friends",
+        "/api/v1/clients": {
+            "post": {
+                "description": "Create a client, projects can be done for it",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "friends"
+                    "client"
+                ],
+                "summary": "Create client",
+                "parameters": [
+                    {
+                        "description": "client info",
+                        "name": "client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqCreateClient"
+                        }
+                    }
                 ],
-                "summary": "get my friends",
                 "responses": {
                     "200": {
-                        "description": "success get profile",
+                        "description": "the created client",
                         "schema": {
                             "allOf": [
                                 {
@@ -1009,70 +1051,80 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.RespUser"
-                                            }
+                                            "$ref": "#/definitions/dto.RespClient"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the client"
+                            }
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "404": {
-                        "description": "user doesn't exist",
+                    "403": {
+                        "description": "invalid_csrf",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "Method Not Allowed",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/me/goals": {
+        "/api/v1/clients/{id}": {
             "get": {
-                "description": "Get user goals or get user goals for a day",
+                "description": "Get client by id. Acl: owner",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "goal"
+                    "client"
                 ],
-                "summary": "Get user goals",
+                "summary": "Get client",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "day for events",
-                        "name": "day",
-                        "in": "query"
+                        "description": "ETag of the cached client",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success get goals",
+                        "description": "success get client",
                         "schema": {
                             "allOf": [
                                 {
@@ -1082,115 +1134,145 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.RespGoal"
-                                            }
+                                            "$ref": "#/definitions/dto.RespClient"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the client"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "bad request",
+                    "304": {
+                        "description": "the cached client is up to date"
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "401": {
-                        "description": "no cookie",
+                    "403": {
+                        "description": "permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "Method Not Allowed",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
-            }
-        },
-        "/me/projects": {
-            "get": {
-                "description": "Get user projects or get user projects for a day",
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Delete a client for good, its projects stay without a client. Acl: owner",
                 "tags": [
-                    "project"
+                    "client"
+                ],
+                "summary": "Delete a client",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user projects",
                 "responses": {
-                    "200": {
-                        "description": "success get projects",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/pkg.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.RespProject"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "400": {
-                        "description": "bad request",
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "401": {
-                        "description": "no cookie",
+                    "404": {
+                        "description": "not_found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "Method Not Allowed",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
-            }
-        },
-        "/me/subs": {
-            "get": {
-                "description": "get my subs",
+            },
+            "patch": {
+                "description": "Update a client. Only the fields present in the body are changed. Acl: owner",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "friends"
+                    "client"
+                ],
+                "summary": "Update a client",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the client the changes are based on",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "client info",
+                        "name": "client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqPatchClient"
+                        }
+                    }
                 ],
-                "summary": "get my subs",
                 "responses": {
                     "200": {
-                        "description": "success get profile",
+                        "description": "success update client",
                         "schema": {
                             "allOf": [
                                 {
@@ -1200,143 +1282,92 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.RespUser"
-                                            }
+                                            "$ref": "#/definitions/dto.RespClient"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the updated client"
+                            }
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "user doesn't exist",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "500": {
-                        "description": "internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/me/tag": {
-            "get": {
-                "description": "Get user tags",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "tag"
-                ],
-                "summary": "Get user tags",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "day for events",
-                        "name": "day",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "success get tags",
+                    "404": {
+                        "description": "not_found",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/pkg.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.RespTag"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "400": {
-                        "description": "bad request",
+                    "405": {
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "401": {
-                        "description": "no cookie",
+                    "412": {
+                        "description": "version_mismatch: the client was changed since the If-Match version",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "405": {
-                        "description": "Method Not Allowed",
+                    "428": {
+                        "description": "if_match_required",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/project/create": {
-            "post": {
-                "description": "Create project",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/clients/{id}/projects": {
+            "get": {
+                "description": "Get the projects done for the client. Acl: owner",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "client"
                 ],
-                "summary": "Create project",
+                "summary": "Get client projects",
                 "parameters": [
                     {
-                        "description": "project info",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ReqCreateUpdateProject"
-                        }
+                        "type": "integer",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success update project",
+                        "description": "success get projects",
                         "schema": {
                             "allOf": [
                                 {
@@ -1346,55 +1377,52 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespProject"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespProject"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "bad req",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf or permission denied",
+                        "description": "permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "405": {
-                        "description": "invalid http method",
+                    "404": {
+                        "description": "not_found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "422": {
-                        "description": "unprocessable entity",
+                    "405": {
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/project/edit": {
+        "/api/v1/entries": {
             "post": {
-                "description": "Update an project",
+                "description": "Create entry",
                 "consumes": [
                     "application/json"
                 ],
@@ -1402,23 +1430,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "entry"
                 ],
-                "summary": "Update an project",
+                "summary": "Create entry. Acl: all",
                 "parameters": [
                     {
-                        "description": "project info",
-                        "name": "project",
+                        "description": "entry info",
+                        "name": "entry",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ReqCreateUpdateProject"
+                            "$ref": "#/definitions/dto.ReqCreateUpdateEntry"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success update project",
+                        "description": "success update entry",
                         "schema": {
                             "allOf": [
                                 {
@@ -1428,55 +1456,61 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespProject"
+                                            "$ref": "#/definitions/dto.RespEntry"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the entry"
+                            }
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf or permission denied",
+                        "description": "invalid_csrf or permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "invalid http method",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "422": {
-                        "description": "unprocessable entity",
+                    "409": {
+                        "description": "project_archived or project_deleted: the project takes no new items",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/project/{id}": {
+        "/api/v1/entries/{id}": {
             "get": {
-                "description": "Get project by id",
+                "description": "Get entry by id. Acl: owner, entry:read:any",
                 "consumes": [
                     "application/json"
                 ],
@@ -1484,21 +1518,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "entry"
                 ],
                 "summary": "Show a post",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
+                        "description": "Entry ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached entry",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success get project",
+                        "description": "success get entry",
                         "schema": {
                             "allOf": [
                                 {
@@ -1508,46 +1548,55 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespProject"
+                                            "$ref": "#/definitions/dto.RespEntry"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the entry"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "the cached entry is up to date"
+                    },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "invalid http method",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Delete an project",
+                "description": "Delete an entry, it stays in the trash until it is restored or purged. Acl: owner only",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "entry"
                 ],
-                "summary": "Delete an project",
+                "summary": "Delete an entry",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
+                        "description": "Entry ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1558,41 +1607,39 @@ const docTemplate = `{
                         "description": "No Content"
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf",
+                        "description": "invalid_csrf",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "404": {
-                        "description": "can't find project with such id",
+                        "description": "not_found: can't find entry with such id",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "invalid http method",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
-            }
-        },
-        "/signin": {
-            "post": {
-                "description": "user sign in",
+            },
+            "patch": {
+                "description": "Update an entry. Only the fields present in the body are changed: a null project_id removes the project and an empty tag_list removes all tags. Acl: owner only",
                 "consumes": [
                     "application/json"
                 ],
@@ -1600,23 +1647,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "entry"
                 ],
-                "summary": "SignIn",
+                "summary": "Update an entry",
                 "parameters": [
                     {
-                        "description": "user info",
-                        "name": "user",
+                        "type": "integer",
+                        "description": "Entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the entry the changes are based on, required by the legacy POST /entry/edit too",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "entry info",
+                        "name": "entry",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ReqUserSignIn"
+                            "$ref": "#/definitions/dto.ReqPatchEntry"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success sign in",
+                        "description": "success update entry",
                         "schema": {
                             "allOf": [
                                 {
@@ -1626,73 +1687,92 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespUser"
+                                            "$ref": "#/definitions/dto.RespEntry"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the updated entry"
+                            }
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "invalid password",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "404": {
-                        "description": "user doesn't exist",
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "Method Not Allowed",
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "project_archived or project_deleted: the project takes no new items",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "version_mismatch: the entry was changed since the If-Match version",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "428": {
+                        "description": "if_match_required",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/signup": {
+        "/api/v1/entries/{id}/restore": {
             "post": {
-                "description": "user sign up",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Restore an entry from the trash with its tags. An entry of a deleted project is restored with the project. Acl: owner only",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "entry"
                 ],
-                "summary": "SignUp",
+                "summary": "Restore an entry",
                 "parameters": [
                     {
-                        "description": "user data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ReqUserSignUp"
-                        }
+                        "type": "integer",
+                        "description": "Entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "user created",
+                    "200": {
+                        "description": "the restored entry",
                         "schema": {
                             "allOf": [
                                 {
@@ -1702,125 +1782,61 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespUser"
+                                            "$ref": "#/definitions/dto.RespEntry"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the restored entry"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "bad request",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "405": {
-                        "description": "Method Not Allowed",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "409": {
-                        "description": "email already exists",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/tag/create": {
-            "post": {
-                "description": "Create tag",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "tag"
-                ],
-                "summary": "Create tag",
-                "parameters": [
-                    {
-                        "description": "tag info",
-                        "name": "tag",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ReqCreateUpdateTag"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "success update tag",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/pkg.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "$ref": "#/definitions/dto.RespTag"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "bad req",
+                    "401": {
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "401": {
-                        "description": "no cookie",
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "403": {
-                        "description": "invalid csrf or permission denied",
+                    "404": {
+                        "description": "not_found: no entry with such id in the trash",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "invalid http method",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "422": {
-                        "description": "unprocessable entity",
+                    "409": {
+                        "description": "project_deleted: restore the project first",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/tag/edit": {
+        "/api/v1/goals": {
             "post": {
-                "description": "Update an tag",
+                "description": "Create goal",
                 "consumes": [
                     "application/json"
                 ],
@@ -1828,23 +1844,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tag"
+                    "goal"
                 ],
-                "summary": "Update an tag",
+                "summary": "Create goal",
                 "parameters": [
                     {
-                        "description": "tag info",
-                        "name": "tag",
+                        "description": "goal info",
+                        "name": "goal",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ReqCreateUpdateTag"
+                            "$ref": "#/definitions/dto.ReqCreateUpdateGoal"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success update tag",
+                        "description": "success update goal",
                         "schema": {
                             "allOf": [
                                 {
@@ -1854,55 +1870,61 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespTag"
+                                            "$ref": "#/definitions/dto.RespGoal"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the goal"
+                            }
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf or permission denied",
+                        "description": "invalid_csrf or permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "invalid http method",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "422": {
-                        "description": "unprocessable entity",
+                    "409": {
+                        "description": "project_archived or project_deleted: the project takes no new items",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/tag/{id}": {
+        "/api/v1/goals/{id}": {
             "get": {
-                "description": "Get tag by id",
+                "description": "Get goal by id. Acl: owner, goal:read:any",
                 "consumes": [
                     "application/json"
                 ],
@@ -1910,21 +1932,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tag"
+                    "goal"
                 ],
                 "summary": "Show a post",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tag ID",
+                        "description": "Goal ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached goal",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success get tag",
+                        "description": "success get goal",
                         "schema": {
                             "allOf": [
                                 {
@@ -1934,46 +1962,55 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespTag"
+                                            "$ref": "#/definitions/dto.RespGoal"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the goal"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "the cached goal is up to date"
+                    },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "invalid http method",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Delete an tag",
+                "description": "Delete a goal, it stays in the trash until it is restored or purged",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "tag"
+                    "goal"
                 ],
-                "summary": "Delete an tag",
+                "summary": "Delete a goal. Acl: owner only",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tag ID",
+                        "description": "Goal ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1984,60 +2021,77 @@ const docTemplate = `{
                         "description": "No Content"
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "403": {
-                        "description": "invalid csrf",
+                        "description": "invalid_csrf",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "404": {
-                        "description": "can't find tag with such id",
+                        "description": "not_found: can't find goal with such id",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "invalid http method",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
-            }
-        },
-        "/user/{user_id}/friends": {
-            "get": {
-                "description": "get user friends",
+            },
+            "patch": {
+                "description": "Update a goal. Only the fields present in the body are changed. Acl: owner only",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "friends"
+                    "goal"
                 ],
-                "summary": "get user friends",
+                "summary": "Update a goal",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Goal ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the goal the changes are based on, required by the legacy POST /goal/edit too",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "goal info",
+                        "name": "goal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqPatchGoal"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success get profile",
+                        "description": "success update goal",
                         "schema": {
                             "allOf": [
                                 {
@@ -2047,71 +2101,92 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.RespUser"
-                                            }
+                                            "$ref": "#/definitions/dto.RespGoal"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the updated goal"
+                            }
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "404": {
-                        "description": "user doesn't exist",
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "Method Not Allowed",
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "project_archived or project_deleted: the project takes no new items",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "version_mismatch: the goal was changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "428": {
+                        "description": "if_match_required",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/user/{user_id}/subs": {
-            "get": {
-                "description": "get user subs",
+        "/api/v1/goals/{id}/restore": {
+            "post": {
+                "description": "Restore a goal from the trash. A goal of a deleted project is restored with the project. Acl: owner",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "friends"
+                    "goal"
                 ],
-                "summary": "get user subs",
+                "summary": "Restore a goal",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Goal ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success get profile",
+                        "description": "the restored goal",
                         "schema": {
                             "allOf": [
                                 {
@@ -2121,59 +2196,68 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.RespUser"
-                                            }
+                                            "$ref": "#/definitions/dto.RespGoal"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the restored goal"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "bad request",
+                    "401": {
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
-                    "401": {
-                        "description": "no cookie",
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "404": {
-                        "description": "user doesn't exist",
+                        "description": "not_found: no goal with such id in the trash",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "Method Not Allowed",
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "project_deleted: restore the project first",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
         },
-        "/users": {
+        "/api/v1/me": {
             "get": {
-                "description": "get all users. Acl: admin",
+                "description": "get info about me.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "users"
                 ],
-                "summary": "GetUsers",
+                "summary": "get info about me.",
                 "responses": {
                     "200": {
                         "description": "success get users",
@@ -2186,10 +2270,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.RespUser"
-                                            }
+                                            "$ref": "#/definitions/dto.RespUser"
                                         }
                                     }
                                 }
@@ -2197,48 +2278,51 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "Method Not Allowed",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}": {
-            "get": {
-                "description": "get user's profile",
+            },
+            "delete": {
+                "description": "schedule the deletion of my account and sign out everywhere. The account and all of its data\nare deleted after the grace period, signing in before that cancels the deletion.\nAccounts created through an identity provider have no password, they sign in to the provider\nagain with GET /api/v1/me/reauth first. The confirmation_token cookie it sets is used up here.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "users"
                 ],
-                "summary": "GetProfile",
+                "summary": "DeleteAccount",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "password confirmation",
+                        "name": "confirm",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqDeleteAccount"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "success get user",
+                    "202": {
+                        "description": "deletion scheduled",
                         "schema": {
                             "allOf": [
                                 {
@@ -2248,7 +2332,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/dto.RespUser"
+                                            "$ref": "#/definitions/dto.RespDeleteAccount"
                                         }
                                     }
                                 }
@@ -2256,245 +2340,4883 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "bad request",
+                        "description": "bad_request, invalid_body",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid_password, reauth_required: no fresh sign in to the identity provider",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "update user's profile. Acl: user(owner account), changing the role needs user:role:change",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "UpdateUser",
+                "parameters": [
+                    {
+                        "description": "user data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqUpdateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "success update"
+                    },
+                    "400": {
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "401": {
-                        "description": "no cookie",
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "404": {
-                        "description": "can't find user with such id",
+                        "description": "not_found: can't find user with such id",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "405": {
-                        "description": "Method Not Allowed",
+                        "description": "method_not_allowed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "internal_error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/apierror.Error"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.ReqCreateUpdateEntry": {
-            "type": "object",
-            "required": [
-                "time_end",
-                "time_start"
+        },
+        "/api/v1/me/clients": {
+            "get": {
+                "description": "Get my clients sorted by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "client"
+                ],
+                "summary": "Get my clients",
+                "responses": {
+                    "200": {
+                        "description": "success get clients",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespClient"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/entries": {
+            "get": {
+                "description": "Get my entries or get my entries for a day, of a client",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entry"
+                ],
+                "summary": "Get my entries. Acl: all",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "day for events",
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the entries on the projects of the client",
+                        "name": "client_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get entries",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "permission_denied: the client is not one of the user's",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: can't find client with such id",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/exports": {
+            "post": {
+                "description": "queue an archive of my profile, projects, tags, entries, goals and friend relations.\nPoll GET /me/data-export/{id} until the status is ready, then download it once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "RequestDataExport",
+                "responses": {
+                    "202": {
+                        "description": "export queued",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespDataExport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/exports/{id}": {
+            "get": {
+                "description": "status of my data export: pending, processing, ready, failed or downloaded",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "GetDataExport",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "export status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespDataExport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: can't find export with such id",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/exports/{id}/download": {
+            "get": {
+                "description": "download the zip archive of a ready export. The archive is served only once.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "DownloadDataExport",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "zip archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: export is not ready or already downloaded",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/friends": {
+            "get": {
+                "description": "get my friends",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "get my friends",
+                "responses": {
+                    "200": {
+                        "description": "success get profile",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespUser"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: user doesn't exist",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/goals": {
+            "get": {
+                "description": "Get my goals. Acl: admin,",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goal"
+                ],
+                "summary": "Get my goals",
+                "responses": {
+                    "200": {
+                        "description": "success get goals",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespGoal"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/invitations": {
+            "get": {
+                "description": "Get the invitations to the projects of friends the user didn't answer yet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Get my invitations",
+                "responses": {
+                    "200": {
+                        "description": "the pending invitations",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespProjectMember"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/invitations/{id}": {
+            "delete": {
+                "description": "Decline the invitation to a project",
+                "tags": [
+                    "project"
+                ],
+                "summary": "Decline an invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: no pending invitation to the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/invitations/{id}/accept": {
+            "post": {
+                "description": "Accept the invitation to a project, it shows up in the project listings of the user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Accept an invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the membership",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespProjectMember"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: no pending invitation to the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/projects": {
+            "get": {
+                "description": "Get my projects. The archived ones are only listed with archived=true, without the others.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Get my projects",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "list the archived projects",
+                        "name": "archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get projects",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespProject"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/reauth": {
+            "get": {
+                "description": "sign in to the external identity provider again to confirm the deletion of an account without\na password. The provider asks for the credentials even with a session there. The callback sets\nthe confirmation_token cookie for 5 minutes, DELETE /api/v1/me takes it once.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "OIDCReauth",
+                "responses": {
+                    "302": {
+                        "description": "redirect to the identity provider"
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/report": {
+            "get": {
+                "description": "Sum the hours of my entries by project or by client. The row without a project_id or\na client_id holds the entries without one. since and until are days, both included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entry"
+                ],
+                "summary": "Get my report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project (default) or client",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first day of the report, YYYY-MM-DD",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last day of the report, YYYY-MM-DD",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the entries on the projects of the client",
+                        "name": "client_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the hours of every group",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespReportRow"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "permission_denied: the client is not one of the user's",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: can't find client with such id",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/subscriptions": {
+            "get": {
+                "description": "get my subs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "get my subs",
+                "responses": {
+                    "200": {
+                        "description": "success get profile",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespUser"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: user doesn't exist",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/subscriptions/{user_id}": {
+            "post": {
+                "description": "subscribe",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "subscribe",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Friend ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success subscribe"
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: subscribe or user doesn't exist",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "friend_conflict: subscribe already exists",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Unsubscribe",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "Unsubscribe",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Friend ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "success unsubscribe, body is empty"
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: friend/user/friendship doesn't exist",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/sync": {
+            "get": {
+                "description": "Get my entries, projects, tags and goals changed since the cursor of the last sync, the deleted ones\nare only listed in deleted. Shared projects come too, a project I was removed from is listed in deleted.\nWithout since every object is returned. Ask again with the returned cursor.\nA cursor older than the trash retention may miss purged deletions, sync again without since then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Get changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "cursor of the last sync",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get changes",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespSyncChanges"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request: invalid cursor",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "410": {
+                        "description": "resync_required: the cursor is older than the trash retention",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Apply a batch of changes made offline, in order. Every change gets its own result: applied, conflict\nwith the current object when its version is outdated, or rejected with the error.\nObjects created offline need a client_id, a retried create returns the object of the first one.\nproject_client_id and tag_client_ids reference objects created offline. Data is the body of\nthe create or patch request of the type, delete has no data.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Apply offline changes",
+                "parameters": [
+                    {
+                        "description": "changes in the order they were made",
+                        "name": "mutations",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqSyncMutations"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the result of every change",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespSyncResults"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "invalid_body or validation_failed with the failed fields",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/tags": {
+            "get": {
+                "description": "Get my tags.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Get my tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "day for events",
+                        "name": "day",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get tags",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespTag"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/trash": {
+            "get": {
+                "description": "Get my deleted entries, projects, tags and goals, the last deleted first. They can be restored with\nPOST /api/v1/{entries,projects,tags,goals}/{id}/restore until the purge removes them for good.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Get my trash",
+                "responses": {
+                    "200": {
+                        "description": "success get trash",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespTrash"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects": {
+            "post": {
+                "description": "Create project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Create project",
+                "parameters": [
+                    {
+                        "description": "project info",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqCreateUpdateProject"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success update project",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespProject"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the project"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{id}": {
+            "get": {
+                "description": "Get project by id with the members and the hours each of them logged on it.\nAcl: members and invited users, owner, project:read:any",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Show a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached project",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get project",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespProject"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the project"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "the cached project is up to date"
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an project with its entries and goals, they stay in the trash until they are restored or purged. Acl: owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Delete an project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: can't find project with such id",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update an project. Only the fields present in the body are changed. Acl: owner, editors",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Update an project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the project the changes are based on, required by the legacy POST /project/edit too",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "project info",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqPatchProject"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success update project",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespProject"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the updated project"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "version_mismatch: the project was changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "428": {
+                        "description": "if_match_required",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{id}/archive": {
+            "post": {
+                "description": "Archive a project. It is left out of the project listings and takes no new entries or goals,\nits entries still count in the hours and the exports. Acl: owner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Archive a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the archived project",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespProject"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the archived project"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{id}/members": {
+            "get": {
+                "description": "Get the members and the invited users of the project with the hours each of them logged on it.\nAcl: members and invited users, owner, project:read:any",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Get project members",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get members",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespProjectMember"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Invite a friend to the project as an editor or a viewer. Editors log their own entries\nand goals on the project and change it, viewers only see it. Acl: owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Invite a project member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the invited friend",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqInviteProjectMember"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the pending invitation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespProjectMember"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf, permission_denied or not_friends",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "member_conflict: the user is already a member or invited",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{id}/members/{user_id}": {
+            "delete": {
+                "description": "Remove a member or cancel an invitation, the entries of the member stay on the project.\nA member removes itself to leave the project. Acl: owner, the member itself",
+                "tags": [
+                    "project"
+                ],
+                "summary": "Remove a project member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "bad_request: the owner can't leave the project",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change the role of a member or of a pending invitation. Acl: owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Change the role of a project member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Member ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the new role",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqUpdateProjectMember"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the updated member",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespProjectMember"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{id}/restore": {
+            "post": {
+                "description": "Restore a project from the trash with the entries and goals deleted together with it, its hours count again. Acl: owner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Restore a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the restored project",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespProject"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the restored project"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: no project with such id in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{id}/tasks": {
+            "get": {
+                "description": "Get the tasks of the project with the hours logged on them. Acl: members",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Get project tasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get tasks",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespTask"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: can't find project with such id",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a task in the project, entries can be logged on it. Acl: owner, editor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Create task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "task info",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqCreateTask"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the created task",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespTask"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: can't find project with such id",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "project_archived: the project takes no new items",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/projects/{id}/unarchive": {
+            "post": {
+                "description": "Bring an archived project back to the project listings. Acl: owner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Unarchive a project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the unarchived project",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespProject"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the unarchived project"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags": {
+            "post": {
+                "description": "Create tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Create tag",
+                "parameters": [
+                    {
+                        "description": "tag info",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqCreateUpdateTag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success update tag",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespTag"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the tag"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags/{id}": {
+            "get": {
+                "description": "Get tag by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Show a post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached tag",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get tag",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespTag"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the tag"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "the cached tag is up to date"
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an tag, it stays in the trash until it is restored or purged. Acl: owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Delete an tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: can't find tag with such id",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update an tag. Only the fields present in the body are changed. Acl: owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Update an tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the tag the changes are based on, required by the legacy POST /tag/edit too",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "tag info",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqPatchTag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success update tag",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespTag"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the updated tag"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "version_mismatch: the tag was changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "428": {
+                        "description": "if_match_required",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags/{id}/restore": {
+            "post": {
+                "description": "Restore a tag from the trash, the entries get it back. Acl: owner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Restore a tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "the restored tag",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespTag"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the restored tag"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: no tag with such id in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tasks/{id}": {
+            "get": {
+                "description": "Get task by id with the hours logged on it. Acl: members of the project",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Get task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached task",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get task",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespTask"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the task"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "the cached task is up to date"
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a task for good, its entries stay in the project without a task. Acl: owner, editor",
+                "tags": [
+                    "task"
+                ],
+                "summary": "Delete a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "project_archived: the project takes no changes",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update a task. Only the fields present in the body are changed. Acl: owner, editor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Update a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the task the changes are based on",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "task info",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReqPatchTask"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success update task",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespTask"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of the updated task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request, invalid_body or validation_failed with the failed fields",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "invalid_csrf or permission_denied",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "project_archived: the project takes no changes",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "version_mismatch: the task was changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "428": {
+                        "description": "if_match_required",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "description": "get all users. Acl: user:list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "GetUsers",
+                "responses": {
+                    "200": {
+                        "description": "success get users",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespUser"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}": {
+            "get": {
+                "description": "get user's profile",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "GetProfile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get user",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespUser"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: can't find user with such id",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/entries": {
+            "get": {
+                "description": "Get user entries or get user entries for a day, of a client",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entry"
+                ],
+                "summary": "Get user entries. Acl: friends, entry:read:any",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "day for events",
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the entries on the projects of the client",
+                        "name": "client_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get entries",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "permission_denied: the client is not one of the user's",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: can't find client with such id",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/friends": {
+            "get": {
+                "description": "get user friends",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "get user friends",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get profile",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespUser"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: user doesn't exist",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/goals": {
+            "get": {
+                "description": "Get user goals. Acl: friends, goal:read:any",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goal"
+                ],
+                "summary": "Get user goals",
+                "responses": {
+                    "200": {
+                        "description": "success get goals",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespGoal"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/projects": {
+            "get": {
+                "description": "Get user projects. The archived ones are only listed with archived=true, without the others.\nAcl: friends, project:read:any",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Get user projects",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "list the archived projects",
+                        "name": "archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get projects",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespProject"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/subscriptions": {
+            "get": {
+                "description": "get user subs. Acl: friends:read:any",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "friends"
+                ],
+                "summary": "get user subs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get profile",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespUser"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "not_found: user doesn't exist",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user_id}/tags": {
+            "get": {
+                "description": "Get user tags.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Get user tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "day for events",
+                        "name": "day",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success get tags",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RespTag"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: no cookie",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "405": {
+                        "description": "method_not_allowed",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/callback": {
+            "get": {
+                "description": "finish external login, link or create the account and issue the session cookie.\nA reauth flow sets the confirmation_token cookie instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "OIDCCallback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "state from the login request",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success sign in",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespUser"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "reauth confirmed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespConfirmation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "302": {
+                        "description": "redirect to the frontend after sign in"
+                    },
+                    "400": {
+                        "description": "bad_request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized: identity provider rejected the login, reauth_required: the sign in is not fresh",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "permission_denied: the identity is not linked to the account to confirm",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "email_conflict: email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "start authorization code + PKCE login with the external identity provider",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "OIDCLogin",
+                "responses": {
+                    "302": {
+                        "description": "redirect to the identity provider"
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "the process is up, dependencies are not checked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness",
+                "responses": {
+                    "200": {
+                        "description": "alive",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespHealth"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "pings the database and the redis clients, 503 if any of them fails",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness",
+                "responses": {
+                    "200": {
+                        "description": "ready",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespReadiness"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "some dependency is not ready",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespReadiness"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "build version, commit and applied schema migration",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Version",
+                "responses": {
+                    "200": {
+                        "description": "build info",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/pkg.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/dto.RespVersion"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "internal_error",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apierror.Error": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "not_found"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apierror.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "item is not found"
+                }
+            }
+        },
+        "apierror.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "time_start"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "is required"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
+        },
+        "dto.ReqChangeRole": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqCreateClient": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "contact": {
+                    "type": "string"
+                },
+                "hourly_rate": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqCreateTask": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "done": {
+                    "type": "boolean"
+                },
+                "estimate_hours": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "dto.ReqCreateUpdateEntry": {
+            "type": "object",
+            "required": [
+                "time_end",
+                "time_start"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "tag_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqCreateUpdateGoal": {
+            "type": "object",
+            "required": [
+                "hours_count",
+                "name",
+                "project_id",
+                "time_end",
+                "time_start"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "hours_count": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqCreateUpdateProject": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "about": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_private": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqCreateUpdateTag": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "about": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqDeleteAccount": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqInviteProjectMember": {
+            "type": "object",
+            "required": [
+                "role",
+                "user_id"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "editor",
+                        "viewer"
+                    ]
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReqLogLevel": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "package": {
+                    "description": "Package is empty for the root level",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqPasswordReset": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqPatchClient": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "type": "string"
+                },
+                "hourly_rate": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqPatchEntry": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "tag_list": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqPatchGoal": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "hours_count": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqPatchProject": {
+            "type": "object",
+            "properties": {
+                "about": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_private": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqPatchTag": {
+            "type": "object",
+            "properties": {
+                "about": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqPatchTask": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "boolean"
+                },
+                "estimate_hours": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "dto.ReqSyncMutation": {
+            "type": "object",
+            "required": [
+                "action",
+                "type"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ]
+                },
+                "client_id": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "data": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_client_id": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "tag_client_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "entry",
+                        "project",
+                        "tag",
+                        "goal"
+                    ]
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReqSyncMutations": {
+            "type": "object",
+            "required": [
+                "mutations"
+            ],
+            "properties": {
+                "mutations": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.ReqSyncMutation"
+                    }
+                }
+            }
+        },
+        "dto.ReqUpdateProjectMember": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "editor",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "dto.ReqUpdateUser": {
+            "type": "object",
+            "properties": {
+                "about": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqUserSignIn": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReqUserSignUp": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
             ],
             "properties": {
+                "about": {
+                    "type": "string"
+                },
+                "admin_token": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RespAuditPage": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespAuditRecord"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RespAuditRecord": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "integer"
+                },
+                "target_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RespCSRF": {
+            "type": "object",
+            "properties": {
+                "csrf_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RespClient": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "type": "string"
+                },
+                "hourly_rate": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RespConfirmation": {
+            "type": "object",
+            "properties": {
+                "expire_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RespDataExport": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RespDeleteAccount": {
+            "type": "object",
+            "properties": {
+                "delete_after": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RespDependency": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RespEntry": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt is only set on the items in the trash",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "tag_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RespGoal": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt is only set on the items in the trash",
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
+                "hours_count": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RespHealth": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RespLogLevel": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "packages": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.RespPasswordReset": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RespProject": {
+            "type": "object",
+            "properties": {
+                "about": {
+                    "type": "string"
+                },
+                "archived_at": {
+                    "description": "ArchivedAt is only set on the archived projects",
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is only set on the items in the trash",
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "project_id": {
-                    "type": "integer"
+                "is_private": {
+                    "type": "boolean"
                 },
-                "tag_list": {
+                "members": {
+                    "description": "Members with their hours are only set on the view of a single project",
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/dto.RespProjectMember"
                     }
                 },
-                "time_end": {
+                "name": {
                     "type": "string"
                 },
-                "time_start": {
+                "total_count_hours": {
+                    "type": "number"
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.ReqCreateUpdateGoal": {
+        "dto.RespProjectMember": {
             "type": "object",
-            "required": [
-                "hours_count",
-                "name",
-                "project_id",
-                "time_end",
-                "time_start"
-            ],
             "properties": {
-                "description": {
+                "accepted_at": {
+                    "description": "AcceptedAt is not set while the invitation is pending",
                     "type": "string"
                 },
-                "hours_count": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
-                "id": {
+                "invited_by": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
-                },
                 "project_id": {
                     "type": "integer"
                 },
-                "time_end": {
+                "role": {
                     "type": "string"
                 },
-                "time_start": {
-                    "type": "string"
+                "total_count_hours": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.ReqCreateUpdateProject": {
+        "dto.RespReadiness": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "about": {
-                    "type": "string"
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespDependency"
+                    }
                 },
-                "color": {
+                "status": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.RespReportRow": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "integer"
                 },
-                "id": {
+                "entries_count": {
                     "type": "integer"
                 },
-                "is_private": {
-                    "type": "boolean"
+                "project_id": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "total_count_hours": {
+                    "type": "number"
                 }
             }
         },
-        "dto.ReqCreateUpdateTag": {
+        "dto.RespSyncChanges": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "about": {
-                    "type": "string"
+                "cursor": {
+                    "description": "Cursor is the since of the next sync",
+                    "type": "string",
+                    "example": "1700000000000000"
                 },
-                "color": {
-                    "type": "string"
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespSyncTombstone"
+                    }
                 },
-                "id": {
-                    "type": "integer"
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespEntry"
+                    }
                 },
-                "name": {
-                    "type": "string"
+                "goals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespGoal"
+                    }
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespProject"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespTag"
+                    }
                 }
             }
         },
-        "dto.ReqUpdateUser": {
+        "dto.RespSyncResult": {
             "type": "object",
             "properties": {
-                "about": {
+                "action": {
+                    "type": "string",
+                    "example": "create"
+                },
+                "client_id": {
                     "type": "string"
                 },
-                "role": {
+                "error": {
+                    "$ref": "#/definitions/apierror.Error"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "email": {
-                    "type": "string"
+                "object": {
+                    "description": "Object is the object after the change, or the current one on a conflict",
+                    "type": "object"
                 },
-                "name": {
-                    "type": "string"
+                "status": {
+                    "description": "Status is applied, conflict or rejected",
+                    "type": "string",
+                    "example": "applied"
                 },
-                "password": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "example": "entry"
                 }
             }
         },
-        "dto.ReqUserSignIn": {
+        "dto.RespSyncResults": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespSyncResult"
+                    }
                 }
             }
         },
-        "dto.ReqUserSignUp": {
+        "dto.RespSyncTombstone": {
             "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password"
-            ],
             "properties": {
-                "about": {
+                "deleted_at": {
                     "type": "string"
                 },
-                "role": {
+                "id": {
                     "type": "integer"
                 },
-                "email": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "example": "entry"
                 },
-                "password": {
-                    "type": "string"
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.RespEntry": {
+        "dto.RespTag": {
             "type": "object",
             "properties": {
-                "description": {
+                "about": {
                     "type": "string"
                 },
-                "duration": {
+                "color": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "deleted_at": {
+                    "description": "DeletedAt is only set on the items in the trash",
+                    "type": "string"
                 },
-                "project_id": {
+                "id": {
                     "type": "integer"
                 },
-                "tag_list": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Tag"
-                    }
-                },
-                "time_end": {
+                "name": {
                     "type": "string"
                 },
-                "time_start": {
+                "updated_at": {
                     "type": "string"
                 },
                 "user_id": {
                     "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.RespGoal": {
+        "dto.RespTask": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "done": {
+                    "type": "boolean"
                 },
-                "hours_count": {
+                "estimate_hours": {
                     "type": "number"
                 },
                 "id": {
@@ -2506,86 +7228,129 @@ const docTemplate = `{
                 "project_id": {
                     "type": "integer"
                 },
-                "time_end": {
-                    "type": "string"
+                "total_count_hours": {
+                    "type": "number"
                 },
-                "time_start": {
+                "updated_at": {
                     "type": "string"
                 },
-                "user_id": {
+                "version": {
                     "type": "integer"
                 }
             }
         },
-        "dto.RespProject": {
+        "dto.RespTrash": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespEntry"
+                    }
+                },
+                "goals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespGoal"
+                    }
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespProject"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespTag"
+                    }
+                }
+            }
+        },
+        "dto.RespUser": {
             "type": "object",
             "properties": {
                 "about": {
                     "type": "string"
                 },
-                "color": {
+                "email": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_private": {
-                    "type": "boolean"
-                },
                 "name": {
                     "type": "string"
                 },
-                "user_id": {
+                "role": {
+                    "type": "string"
+                },
+                "suspended": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.RespUserPage": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
                     "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RespUser"
+                    }
                 }
             }
         },
-        "dto.RespTag": {
+        "dto.RespUserUsage": {
             "type": "object",
             "properties": {
-                "about": {
-                    "type": "string"
+                "entries": {
+                    "type": "integer"
                 },
-                "color": {
-                    "type": "string"
+                "goals": {
+                    "type": "integer"
                 },
-                "id": {
+                "projects": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "storage_bytes": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "integer"
                 },
                 "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.RespUser": {
+        "dto.RespVersion": {
             "type": "object",
             "properties": {
-                "about": {
+                "commit": {
                     "type": "string"
                 },
-                "role": {
+                "schema_version": {
                     "type": "integer"
                 },
-                "email": {
+                "service": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
+                "version": {
                     "type": "string"
                 }
             }
         },
-        "echo.HTTPError": {
-            "type": "object",
-            "properties": {
-                "message": {}
-            }
-        },
         "models.Tag": {
             "type": "object",
             "properties": {
@@ -2595,14 +7360,24 @@ const docTemplate = `{
                 "color": {
                     "type": "string"
                 },
+                "deletedAt": {
+                    "description": "DeletedAt is only set on the tombstone of a deleted tag",
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
+                "updatedAt": {
+                    "type": "string"
+                },
                 "userID": {
                     "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -2612,8 +7387,9 @@ const docTemplate = `{
                 "about": {
                     "type": "string"
                 },
-                "role": {
-                    "type": "integer"
+                "delete_after": {
+                    "description": "DeleteAfter is set while the account waits for the hard deletion, signing in cancels it",
+                    "type": "string"
                 },
                 "email": {
                     "type": "string"
@@ -2626,6 +7402,12 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "suspended": {
+                    "type": "boolean"
                 }
             }
         },
@@ -2640,14 +7422,16 @@ const docTemplate = `{
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "",
+	Version:          "1.0",
 	Host:             "",
-	BasePath:         "",
+	BasePath:         "/",
 	Schemes:          []string{},
-	Title:            "",
-	Description:      "",
+	Title:            "timetracker API",
+	Description:      "Versioned routes are under /api/v1. The routes outside of it are deprecated aliases,\ntheir responses have the Deprecation header and a Link to the route that replaces them.\nThe POST /<item>/edit aliases require If-Match like the PATCH routes, without it they get 428 if_match_required.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
@@ -0,0 +1,196 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	accountUsecase "timetracker/internal/Account/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	sessionName      = "session_token"
+	confirmationName = "confirmation_token"
+)
+
+type Delivery struct {
+	AccountUC accountUsecase.UsecaseI
+}
+
+// DeleteAccount godoc
+// @Summary      DeleteAccount
+// @Description  schedule the deletion of my account and sign out everywhere. The account and all of its data
+// @Description  are deleted after the grace period, signing in before that cancels the deletion.
+// @Description  Accounts created through an identity provider have no password, they sign in to the provider
+// @Description  again with GET /api/v1/me/reauth first. The confirmation_token cookie it sets is used up here.
+// @Tags     users
+// @Accept	 application/json
+// @Produce  application/json
+// @Param    confirm body dto.ReqDeleteAccount true "password confirmation"
+// @Success  202 {object} pkg.Response{body=dto.RespDeleteAccount} "deletion scheduled"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body"
+// @Failure 401 {object} apierror.Error "invalid_password, reauth_required: no fresh sign in to the identity provider"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/me [delete]
+func (del *Delivery) DeleteAccount(c echo.Context) error {
+	var req dto.ReqDeleteAccount
+	err := c.Bind(&req)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	userID, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	var confirmation string
+	if cookie, err := c.Cookie(confirmationName); err == nil {
+		confirmation = cookie.Value
+	}
+
+	deleteAfter, err := del.AccountUC.DeleteAccount(c.Request().Context(), userID, req.Password, confirmation)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.AuditUser(c, models.AuditUserDelete, userID, "scheduled after "+deleteAfter.Format(time.RFC3339))
+
+	c.SetCookie(&http.Cookie{Name: sessionName, Path: "/", MaxAge: -1, HttpOnly: true})
+	if confirmation != "" {
+		c.SetCookie(&http.Cookie{Name: confirmationName, Path: "/", MaxAge: -1, HttpOnly: true})
+	}
+	return c.JSON(http.StatusAccepted, pkg.Response{Body: dto.RespDeleteAccount{DeleteAfter: deleteAfter}})
+}
+
+// RequestExport godoc
+// @Summary      RequestDataExport
+// @Description  queue an archive of my profile, projects, tags, entries, goals and friend relations.
+// @Description  Poll GET /me/data-export/{id} until the status is ready, then download it once.
+// @Tags     users
+// @Produce  application/json
+// @Success  202 {object} pkg.Response{body=dto.RespDataExport} "export queued"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/me/exports [post]
+func (del *Delivery) RequestExport(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	export, err := del.AccountUC.RequestExport(c.Request().Context(), userID)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.AuditUser(c, models.AuditDataExport, userID, fmt.Sprintf("export %d requested", export.ID))
+	return c.JSON(http.StatusAccepted, pkg.Response{Body: dto.GetResponseFromModelDataExport(export)})
+}
+
+// GetExport godoc
+// @Summary      GetDataExport
+// @Description  status of my data export: pending, processing, ready, failed or downloaded
+// @Tags     users
+// @Produce  application/json
+// @Param id path int true "Export ID"
+// @Success  200 {object} pkg.Response{body=dto.RespDataExport} "export status"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found: can't find export with such id"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/me/exports/{id} [get]
+func (del *Delivery) GetExport(c echo.Context) error {
+	userID, exportID, err := getUserAndExportID(c)
+	if err != nil {
+		return err
+	}
+
+	export, err := del.AccountUC.GetExport(c.Request().Context(), userID, exportID)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseFromModelDataExport(export)})
+}
+
+// DownloadExport godoc
+// @Summary      DownloadDataExport
+// @Description  download the zip archive of a ready export. The archive is served only once.
+// @Tags     users
+// @Produce  application/zip
+// @Param id path int true "Export ID"
+// @Success  200 {file} file "zip archive"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found: export is not ready or already downloaded"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/me/exports/{id}/download [get]
+func (del *Delivery) DownloadExport(c echo.Context) error {
+	userID, exportID, err := getUserAndExportID(c)
+	if err != nil {
+		return err
+	}
+
+	archive, err := del.AccountUC.DownloadExport(c.Request().Context(), userID, exportID)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.AuditUser(c, models.AuditDataExport, userID, fmt.Sprintf("export %d downloaded", exportID))
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="timetracker-export-%d.zip"`, exportID))
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.Blob(http.StatusOK, "application/zip", archive)
+}
+
+func getUserAndExportID(c echo.Context) (uint64, uint64, error) {
+	userID, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return 0, 0, apierror.From(models.ErrInternalServerError)
+	}
+
+	exportID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return 0, 0, apierror.From(models.ErrBadRequest)
+	}
+
+	return userID, exportID, nil
+}
+
+func NewDelivery(e *echo.Echo, uc accountUsecase.UsecaseI) {
+	handler := &Delivery{
+		AccountUC: uc,
+	}
+
+	v1 := e.Group(middleware.APIv1)
+	v1.DELETE("/me", handler.DeleteAccount)
+	v1.POST("/me/exports", handler.RequestExport)
+	v1.GET("/me/exports/:id", handler.GetExport)
+	v1.GET("/me/exports/:id/download", handler.DownloadExport)
+
+	// the routes from before /api/v1
+	e.POST("/me/delete", handler.DeleteAccount, middleware.Deprecated(middleware.APIv1+"/me"))
+	e.POST("/me/data-export", handler.RequestExport, middleware.Deprecated(middleware.APIv1+"/me/exports"))
+	e.GET("/me/data-export/:id", handler.GetExport, middleware.Deprecated(middleware.APIv1+"/me/exports/:id"))
+	e.GET("/me/data-export/:id/download", handler.DownloadExport, middleware.Deprecated(middleware.APIv1+"/me/exports/:id/download"))
+}
@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"context"
+	"time"
+	"timetracker/internal/Account/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+// copyDataExport never copies the archive, it is only read by TakeExportArchive
+func copyDataExport(e *models.DataExport) *models.DataExport {
+	return &models.DataExport{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Status:    e.Status,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+type exportRepository struct {
+	db *memoryDB.DB
+}
+
+func (er exportRepository) CreateExport(ctx context.Context, export *models.DataExport) error {
+	er.db.Lock()
+	defer er.db.Unlock()
+
+	if _, ok := er.db.Users[export.UserID]; !ok {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table data_export)")
+	}
+
+	row := copyDataExport(export)
+	row.ID = er.db.NextID("data_export")
+	if row.Status == "" {
+		row.Status = models.ExportPending
+	}
+	if row.CreatedAt.IsZero() {
+		row.CreatedAt = time.Now()
+		row.UpdatedAt = row.CreatedAt
+	}
+	er.db.Exports[row.ID] = row
+
+	export.ID = row.ID
+	return nil
+}
+
+func (er exportRepository) GetExport(ctx context.Context, id uint64) (*models.DataExport, error) {
+	er.db.RLock()
+	defer er.db.RUnlock()
+
+	export, ok := er.db.Exports[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	return copyDataExport(export), nil
+}
+
+// ClaimPendingExport moves the oldest pending export to processing, so concurrent
+// workers never build the same archive.
+func (er exportRepository) ClaimPendingExport(ctx context.Context) (*models.DataExport, error) {
+	er.db.Lock()
+	defer er.db.Unlock()
+
+	var oldest *models.DataExport
+	for _, export := range er.db.Exports {
+		if export.Status == models.ExportPending && (oldest == nil || export.ID < oldest.ID) {
+			oldest = export
+		}
+	}
+
+	if oldest == nil {
+		return nil, models.ErrNotFound
+	}
+
+	oldest.Status = models.ExportProcessing
+	oldest.UpdatedAt = time.Now()
+	return copyDataExport(oldest), nil
+}
+
+func (er exportRepository) SetExportReady(ctx context.Context, id uint64, archive []byte) error {
+	return er.setStatus(id, models.ExportReady, archive)
+}
+
+func (er exportRepository) SetExportFailed(ctx context.Context, id uint64) error {
+	return er.setStatus(id, models.ExportFailed, nil)
+}
+
+func (er exportRepository) setStatus(id uint64, status models.ExportStatus, archive []byte) error {
+	er.db.Lock()
+	defer er.db.Unlock()
+
+	export, ok := er.db.Exports[id]
+	if !ok {
+		return nil
+	}
+
+	export.Status = status
+	export.Archive = archive
+	export.UpdatedAt = time.Now()
+	return nil
+}
+
+// TakeExportArchive returns the archive of a ready export and drops it, so it is served only once
+func (er exportRepository) TakeExportArchive(ctx context.Context, id uint64) ([]byte, error) {
+	er.db.Lock()
+	defer er.db.Unlock()
+
+	export, ok := er.db.Exports[id]
+	if !ok || export.Status != models.ExportReady {
+		return nil, models.ErrNotFound
+	}
+
+	archive := export.Archive
+	export.Status = models.ExportDownloaded
+	export.Archive = nil
+	export.UpdatedAt = time.Now()
+	return archive, nil
+}
+
+func (er exportRepository) DeleteExportsBefore(ctx context.Context, before time.Time) (int64, error) {
+	er.db.Lock()
+	defer er.db.Unlock()
+
+	var deleted int64
+	for id, export := range er.db.Exports {
+		if export.CreatedAt.Before(before) {
+			delete(er.db.Exports, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+func NewExportRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &exportRepository{
+		db: db,
+	}
+}
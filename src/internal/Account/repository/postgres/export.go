@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"time"
+	"timetracker/internal/Account/repository"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DataExport struct {
+	ID        uint64    `gorm:"column:id"`
+	UserID    uint64    `gorm:"column:user_id"`
+	Status    string    `gorm:"column:status"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+	Archive   []byte    `gorm:"column:archive"`
+}
+
+func (DataExport) TableName() string {
+	return "data_export"
+}
+
+func toPostgresDataExport(e *models.DataExport) *DataExport {
+	return &DataExport{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Status:    string(e.Status),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+		Archive:   e.Archive,
+	}
+}
+
+func toModelDataExport(e *DataExport) *models.DataExport {
+	return &models.DataExport{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Status:    models.ExportStatus(e.Status),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+		Archive:   e.Archive,
+	}
+}
+
+type exportRepository struct {
+	db *gorm.DB
+}
+
+func (er exportRepository) CreateExport(ctx context.Context, export *models.DataExport) error {
+	postgresExport := toPostgresDataExport(export)
+
+	tx := er.db.WithContext(ctx).Omit("archive").Create(postgresExport)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table data_export)")
+	}
+
+	export.ID = postgresExport.ID
+	return nil
+}
+
+func (er exportRepository) GetExport(ctx context.Context, id uint64) (*models.DataExport, error) {
+	var export DataExport
+
+	tx := er.db.WithContext(ctx).Omit("archive").Where(&DataExport{ID: id}).Take(&export)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table data_export)")
+	}
+
+	return toModelDataExport(&export), nil
+}
+
+// ClaimPendingExport moves the oldest pending export to processing, so concurrent
+// workers never build the same archive.
+func (er exportRepository) ClaimPendingExport(ctx context.Context) (*models.DataExport, error) {
+	var export DataExport
+
+	err := er.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("archive").Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", string(models.ExportPending)).Order("id").Take(&export).Error
+		if err != nil {
+			return err
+		}
+
+		export.Status = string(models.ExportProcessing)
+		export.UpdatedAt = time.Now()
+		return tx.Model(&DataExport{}).Where("id = ?", export.ID).
+			Updates(map[string]interface{}{"status": export.Status, "updated_at": export.UpdatedAt}).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "database error (table data_export)")
+	}
+
+	return toModelDataExport(&export), nil
+}
+
+func (er exportRepository) SetExportReady(ctx context.Context, id uint64, archive []byte) error {
+	tx := er.db.WithContext(ctx).Model(&DataExport{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     string(models.ExportReady),
+		"archive":    archive,
+		"updated_at": time.Now(),
+	})
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table data_export)")
+	}
+
+	return nil
+}
+
+func (er exportRepository) SetExportFailed(ctx context.Context, id uint64) error {
+	tx := er.db.WithContext(ctx).Model(&DataExport{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     string(models.ExportFailed),
+		"updated_at": time.Now(),
+	})
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table data_export)")
+	}
+
+	return nil
+}
+
+// TakeExportArchive returns the archive of a ready export and drops it, so it is served only once.
+// The archive is read and dropped under the same status condition, a concurrent take updates no row.
+func (er exportRepository) TakeExportArchive(ctx context.Context, id uint64) ([]byte, error) {
+	var export DataExport
+	err := er.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ? AND status = ?", id, string(models.ExportReady)).Take(&export).Error
+		if err != nil {
+			return err
+		}
+
+		update := tx.Model(&DataExport{}).Where("id = ? AND status = ?", id, string(models.ExportReady)).
+			Updates(map[string]interface{}{
+				"status":     string(models.ExportDownloaded),
+				"archive":    nil,
+				"updated_at": time.Now(),
+			})
+		if update.Error != nil {
+			return update.Error
+		}
+
+		if update.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "database error (table data_export)")
+	}
+
+	return export.Archive, nil
+}
+
+func (er exportRepository) DeleteExportsBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx := er.db.WithContext(ctx).Where("created_at < ?", before).Delete(&DataExport{})
+
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "database error (table data_export)")
+	}
+
+	return tx.RowsAffected, nil
+}
+
+func NewExportRepository(db *gorm.DB) repository.RepositoryI {
+	return &exportRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"timetracker/models"
+)
+
+type RepositoryI interface {
+	CreateExport(ctx context.Context, export *models.DataExport) error
+	GetExport(ctx context.Context, id uint64) (*models.DataExport, error)
+	ClaimPendingExport(ctx context.Context) (*models.DataExport, error)
+	SetExportReady(ctx context.Context, id uint64, archive []byte) error
+	SetExportFailed(ctx context.Context, id uint64) error
+	TakeExportArchive(ctx context.Context, id uint64) ([]byte, error)
+	DeleteExportsBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"timetracker/models"
+	"timetracker/models/dto"
+)
+
+type archiveFriends struct {
+	Subs    []uint64 `json:"subs"`
+	Friends []uint64 `json:"friends"`
+}
+
+// buildArchive collects the personal data of the user into a zip of json files.
+// The files use the same shapes as the API responses.
+func (u *usecase) buildArchive(ctx context.Context, userID uint64) ([]byte, error) {
+	user, err := u.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := u.projectRepository.GetUserProjects(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*models.Task, 0, 10)
+	for _, project := range projects {
+		projectTasks, err := u.taskRepository.GetProjectTasks(ctx, project.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, projectTasks...)
+	}
+
+	clients, err := u.clientRepository.GetUserClients(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := u.tagRepository.GetUserTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := u.entryRepository.GetUserEntries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		entryTags, err := u.tagRepository.GetEntryTags(ctx, entry.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.TagList = make([]models.Tag, 0, len(entryTags))
+		for _, tag := range entryTags {
+			entry.TagList = append(entry.TagList, *tag)
+		}
+	}
+
+	goals, err := u.goalRepository.GetUserGoals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var friends archiveFriends
+	friends.Subs, err = u.friendRepository.GetUserSubs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	friends.Friends, err = u.friendRepository.GetUserFriends(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		name string
+		body interface{}
+	}{
+		{"profile.json", dto.GetResponseFromModelUser(user)},
+		{"projects.json", dto.GetResponseFromModelProjects(projects)},
+		{"tasks.json", dto.GetResponseFromModelTasks(tasks)},
+		{"clients.json", dto.GetResponseFromModelClients(clients)},
+		{"tags.json", dto.GetResponseFromModelTags(tags)},
+		{"entries.json", dto.GetResponseFromModelEntries(entries)},
+		{"goals.json", dto.GetResponseFromModelGoals(goals)},
+		{"friends.json", friends},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(file.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
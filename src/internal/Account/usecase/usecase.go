@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	accountRep "timetracker/internal/Account/repository"
+	authRep "timetracker/internal/Auth/repository"
+	clientRep "timetracker/internal/Client/repository"
+	entryRep "timetracker/internal/Entry/repository"
+	friendRep "timetracker/internal/Friends/repository"
+	goalRep "timetracker/internal/Goal/repository"
+	projectRep "timetracker/internal/Project/repository"
+	tagRep "timetracker/internal/Tag/repository"
+	taskRep "timetracker/internal/Task/repository"
+	userRep "timetracker/internal/User/repository"
+	"timetracker/internal/tracing"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UsecaseI interface {
+	DeleteAccount(ctx context.Context, userID uint64, password string, confirmation string) (time.Time, error)
+	PurgeDeletedAccounts(ctx context.Context) ([]uint64, error)
+	RequestExport(ctx context.Context, userID uint64) (*models.DataExport, error)
+	GetExport(ctx context.Context, userID uint64, exportID uint64) (*models.DataExport, error)
+	DownloadExport(ctx context.Context, userID uint64, exportID uint64) ([]byte, error)
+	ProcessPendingExport(ctx context.Context) (bool, error)
+	PurgeExpiredExports(ctx context.Context) (int64, error)
+}
+
+type usecase struct {
+	accountRepository accountRep.RepositoryI
+	userRepository    userRep.RepositoryI
+	authRepository    authRep.RepositoryI
+	entryRepository   entryRep.RepositoryI
+	tagRepository     tagRep.RepositoryI
+	projectRepository projectRep.RepositoryI
+	clientRepository  clientRep.RepositoryI
+	taskRepository    taskRep.RepositoryI
+	goalRepository    goalRep.RepositoryI
+	friendRepository  friendRep.RepositoryI
+	gracePeriod       time.Duration
+	exportTTL         time.Duration
+}
+
+// DeleteAccount schedules the hard deletion of the account after the grace period and signs
+// the user out everywhere. Signing in again before the deletion cancels it. Accounts created
+// through an external identity provider have no password, they confirm with the token of a fresh
+// sign in to the provider instead. The token is used up even if the deletion fails later.
+func (u *usecase) DeleteAccount(ctx context.Context, userID uint64, password string, confirmation string) (time.Time, error) {
+	ctx, span := tracing.Start(ctx, "account.Usecase.DeleteAccount")
+	defer span.End()
+
+	user, err := u.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "Error in func account.Usecase.DeleteAccount")
+	}
+
+	if user.Password != "" {
+		err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return time.Time{}, models.ErrInvalidPassword
+		} else if err != nil {
+			return time.Time{}, errors.Wrap(err, "Error in func account.Usecase.DeleteAccount bcrypt error")
+		}
+	} else {
+		if confirmation == "" {
+			return time.Time{}, models.ErrReauthRequired
+		}
+
+		taken, err := u.authRepository.TakeConfirmation(ctx, confirmation)
+		if errors.Is(err, models.ErrNotFound) {
+			return time.Time{}, models.ErrReauthRequired
+		} else if err != nil {
+			return time.Time{}, errors.Wrap(err, "Error in func account.Usecase.DeleteAccount")
+		}
+
+		if taken.UserID != userID {
+			return time.Time{}, models.ErrReauthRequired
+		}
+	}
+
+	deleteAfter := time.Now().Add(u.gracePeriod)
+	err = u.userRepository.ScheduleDeletion(ctx, userID, &deleteAfter)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "Error in func account.Usecase.DeleteAccount")
+	}
+
+	err = u.authRepository.DeleteUserCookies(ctx, userID)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "Error in func account.Usecase.DeleteAccount")
+	}
+
+	return deleteAfter, nil
+}
+
+// PurgeDeletedAccounts hard deletes the accounts whose grace period is over
+// and returns their ids. The owned data is removed by ON DELETE CASCADE.
+func (u *usecase) PurgeDeletedAccounts(ctx context.Context) ([]uint64, error) {
+	ctx, span := tracing.Start(ctx, "account.Usecase.PurgeDeletedAccounts")
+	defer span.End()
+
+	userIDs, err := u.userRepository.GetUsersDueForDeletion(ctx, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func account.Usecase.PurgeDeletedAccounts")
+	}
+
+	deleted := make([]uint64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		err = u.userRepository.DeleteUser(ctx, userID)
+		if err != nil && !errors.Is(err, models.ErrNotFound) {
+			return deleted, errors.Wrap(err, "Error in func account.Usecase.PurgeDeletedAccounts")
+		}
+
+		deleted = append(deleted, userID)
+	}
+
+	return deleted, nil
+}
+
+// RequestExport queues a new export, the archive is built by ProcessPendingExport.
+func (u *usecase) RequestExport(ctx context.Context, userID uint64) (*models.DataExport, error) {
+	ctx, span := tracing.Start(ctx, "account.Usecase.RequestExport")
+	defer span.End()
+
+	now := time.Now()
+	export := &models.DataExport{
+		UserID:    userID,
+		Status:    models.ExportPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := u.accountRepository.CreateExport(ctx, export)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func account.Usecase.RequestExport")
+	}
+
+	return export, nil
+}
+
+// GetExport hides exports of other users behind ErrNotFound.
+func (u *usecase) GetExport(ctx context.Context, userID uint64, exportID uint64) (*models.DataExport, error) {
+	ctx, span := tracing.Start(ctx, "account.Usecase.GetExport")
+	defer span.End()
+
+	export, err := u.accountRepository.GetExport(ctx, exportID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func account.Usecase.GetExport")
+	}
+
+	if export.UserID != userID {
+		return nil, models.ErrNotFound
+	}
+
+	return export, nil
+}
+
+func (u *usecase) DownloadExport(ctx context.Context, userID uint64, exportID uint64) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "account.Usecase.DownloadExport")
+	defer span.End()
+
+	_, err := u.GetExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func account.Usecase.DownloadExport")
+	}
+
+	archive, err := u.accountRepository.TakeExportArchive(ctx, exportID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func account.Usecase.DownloadExport")
+	}
+
+	return archive, nil
+}
+
+// ProcessPendingExport builds the archive of the oldest pending export.
+// It reports false when there was nothing to do.
+func (u *usecase) ProcessPendingExport(ctx context.Context) (bool, error) {
+	ctx, span := tracing.Start(ctx, "account.Usecase.ProcessPendingExport")
+	defer span.End()
+
+	export, err := u.accountRepository.ClaimPendingExport(ctx)
+	if errors.Is(err, models.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrap(err, "Error in func account.Usecase.ProcessPendingExport")
+	}
+
+	archive, err := u.buildArchive(ctx, export.UserID)
+	if err != nil {
+		if failErr := u.accountRepository.SetExportFailed(ctx, export.ID); failErr != nil {
+			return true, errors.Wrap(failErr, "Error in func account.Usecase.ProcessPendingExport")
+		}
+		return true, errors.Wrap(err, "Error in func account.Usecase.ProcessPendingExport")
+	}
+
+	err = u.accountRepository.SetExportReady(ctx, export.ID, archive)
+	if err != nil {
+		return true, errors.Wrap(err, "Error in func account.Usecase.ProcessPendingExport")
+	}
+
+	return true, nil
+}
+
+// PurgeExpiredExports deletes exports that were not downloaded in time together with the
+// bookkeeping of downloaded ones.
+func (u *usecase) PurgeExpiredExports(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "account.Usecase.PurgeExpiredExports")
+	defer span.End()
+
+	deleted, err := u.accountRepository.DeleteExportsBefore(ctx, time.Now().Add(-u.exportTTL))
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in func account.Usecase.PurgeExpiredExports")
+	}
+
+	return deleted, nil
+}
+
+func New(accRep accountRep.RepositoryI, uRep userRep.RepositoryI, aRep authRep.RepositoryI,
+	eRep entryRep.RepositoryI, tRep tagRep.RepositoryI, pRep projectRep.RepositoryI, cRep clientRep.RepositoryI,
+	tsRep taskRep.RepositoryI, gRep goalRep.RepositoryI, fRep friendRep.RepositoryI, gracePeriod time.Duration, exportTTL time.Duration) UsecaseI {
+	return &usecase{
+		accountRepository: accRep,
+		userRepository:    uRep,
+		authRepository:    aRep,
+		entryRepository:   eRep,
+		tagRepository:     tRep,
+		projectRepository: pRep,
+		clientRepository:  cRep,
+		taskRepository:    tsRep,
+		goalRepository:    gRep,
+		friendRepository:  fRep,
+		gracePeriod:       gracePeriod,
+		exportTTL:         exportTTL,
+	}
+}
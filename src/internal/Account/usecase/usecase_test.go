@@ -0,0 +1,239 @@
+package usecase_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"github.com/bxcodec/faker"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+	accountMocks "timetracker/internal/Account/repository/mocks"
+	"timetracker/internal/Account/usecase"
+	authMocks "timetracker/internal/Auth/repository/mocks"
+	clientMocks "timetracker/internal/Client/repository/mocks"
+	entryMocks "timetracker/internal/Entry/repository/mocks"
+	friendMocks "timetracker/internal/Friends/repository/mocks"
+	goalMocks "timetracker/internal/Goal/repository/mocks"
+	projectMocks "timetracker/internal/Project/repository/mocks"
+	tagMocks "timetracker/internal/Tag/repository/mocks"
+	taskMocks "timetracker/internal/Task/repository/mocks"
+	userMocks "timetracker/internal/User/repository/mocks"
+	"timetracker/models"
+)
+
+type repositories struct {
+	account *accountMocks.RepositoryI
+	user    *userMocks.RepositoryI
+	auth    *authMocks.RepositoryI
+	entry   *entryMocks.RepositoryI
+	tag     *tagMocks.RepositoryI
+	project *projectMocks.RepositoryI
+	client  *clientMocks.RepositoryI
+	task    *taskMocks.RepositoryI
+	goal    *goalMocks.RepositoryI
+	friend  *friendMocks.RepositoryI
+}
+
+func newUsecase(t *testing.T) (usecase.UsecaseI, *repositories) {
+	reps := &repositories{
+		account: accountMocks.NewRepositoryI(t),
+		user:    userMocks.NewRepositoryI(t),
+		auth:    authMocks.NewRepositoryI(t),
+		entry:   entryMocks.NewRepositoryI(t),
+		tag:     tagMocks.NewRepositoryI(t),
+		project: projectMocks.NewRepositoryI(t),
+		client:  clientMocks.NewRepositoryI(t),
+		task:    taskMocks.NewRepositoryI(t),
+		goal:    goalMocks.NewRepositoryI(t),
+		friend:  friendMocks.NewRepositoryI(t),
+	}
+
+	useCase := usecase.New(reps.account, reps.user, reps.auth, reps.entry, reps.tag, reps.project,
+		reps.client, reps.task, reps.goal, reps.friend, 24*time.Hour, time.Hour)
+
+	return useCase, reps
+}
+
+type TestCaseDeleteAccount struct {
+	ArgUserID   uint64
+	ArgPassword string
+	ArgConfirm  string
+	Error       error
+}
+
+func TestUsecaseDeleteAccount(t *testing.T) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password"), 8)
+	assert.NoError(t, err)
+
+	user := &models.User{ID: 1, Password: string(hashedPassword)}
+	externalUser := &models.User{ID: 2}
+
+	useCase, reps := newUsecase(t)
+
+	reps.user.On("GetUser", mock.Anything, user.ID).Return(user, nil)
+	reps.user.On("GetUser", mock.Anything, externalUser.ID).Return(externalUser, nil)
+	reps.user.On("GetUser", mock.Anything, uint64(3)).Return(nil, models.ErrNotFound)
+	reps.user.On("ScheduleDeletion", mock.Anything, user.ID, mock.AnythingOfType("*time.Time")).Return(nil)
+	reps.user.On("ScheduleDeletion", mock.Anything, externalUser.ID, mock.AnythingOfType("*time.Time")).Return(nil)
+	reps.auth.On("DeleteUserCookies", mock.Anything, user.ID).Return(nil)
+	reps.auth.On("DeleteUserCookies", mock.Anything, externalUser.ID).Return(nil)
+	reps.auth.On("TakeConfirmation", mock.Anything, "confirmed").Return(&models.Confirmation{UserID: externalUser.ID}, nil)
+	reps.auth.On("TakeConfirmation", mock.Anything, "other_user").Return(&models.Confirmation{UserID: user.ID}, nil)
+	reps.auth.On("TakeConfirmation", mock.Anything, "expired").Return(nil, models.ErrNotFound)
+
+	cases := map[string]TestCaseDeleteAccount{
+		"success": {
+			ArgUserID:   user.ID,
+			ArgPassword: "password",
+			Error:       nil,
+		},
+		"invalid_password": {
+			ArgUserID:   user.ID,
+			ArgPassword: "wrong",
+			Error:       models.ErrInvalidPassword,
+		},
+		"external_account": {
+			ArgUserID:  externalUser.ID,
+			ArgConfirm: "confirmed",
+			Error:      nil,
+		},
+		"external_account_no_confirmation": {
+			ArgUserID: externalUser.ID,
+			Error:     models.ErrReauthRequired,
+		},
+		"external_account_expired_confirmation": {
+			ArgUserID:  externalUser.ID,
+			ArgConfirm: "expired",
+			Error:      models.ErrReauthRequired,
+		},
+		"external_account_other_user_confirmation": {
+			ArgUserID:  externalUser.ID,
+			ArgConfirm: "other_user",
+			Error:      models.ErrReauthRequired,
+		},
+		"not_found": {
+			ArgUserID: 3,
+			Error:     models.ErrNotFound,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			deleteAfter, err := useCase.DeleteAccount(context.Background(), test.ArgUserID, test.ArgPassword, test.ArgConfirm)
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.True(t, deleteAfter.After(time.Now().Add(23*time.Hour)))
+			}
+		})
+	}
+}
+
+func TestUsecasePurgeDeletedAccounts(t *testing.T) {
+	useCase, reps := newUsecase(t)
+
+	reps.user.On("GetUsersDueForDeletion", mock.Anything, mock.AnythingOfType("time.Time")).Return([]uint64{1, 2}, nil)
+	reps.user.On("DeleteUser", mock.Anything, uint64(1)).Return(nil)
+	reps.user.On("DeleteUser", mock.Anything, uint64(2)).Return(models.ErrNotFound)
+
+	deleted, err := useCase.PurgeDeletedAccounts(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, deleted)
+}
+
+type TestCaseGetExport struct {
+	ArgUserID   uint64
+	ArgExportID uint64
+	Error       error
+}
+
+func TestUsecaseDownloadExport(t *testing.T) {
+	export := &models.DataExport{ID: 10, UserID: 1, Status: models.ExportReady}
+
+	useCase, reps := newUsecase(t)
+
+	reps.account.On("GetExport", mock.Anything, export.ID).Return(export, nil)
+	reps.account.On("GetExport", mock.Anything, uint64(11)).Return(nil, models.ErrNotFound)
+	reps.account.On("TakeExportArchive", mock.Anything, export.ID).Return([]byte("zip"), nil).Once()
+	reps.account.On("TakeExportArchive", mock.Anything, export.ID).Return(nil, models.ErrNotFound)
+
+	cases := []struct {
+		name string
+		TestCaseGetExport
+	}{
+		{"success", TestCaseGetExport{ArgUserID: 1, ArgExportID: export.ID, Error: nil}},
+		{"second_download", TestCaseGetExport{ArgUserID: 1, ArgExportID: export.ID, Error: models.ErrNotFound}},
+		{"other_user", TestCaseGetExport{ArgUserID: 2, ArgExportID: export.ID, Error: models.ErrNotFound}},
+		{"not_found", TestCaseGetExport{ArgUserID: 1, ArgExportID: 11, Error: models.ErrNotFound}},
+	}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			archive, err := useCase.DownloadExport(context.Background(), test.ArgUserID, test.ArgExportID)
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, []byte("zip"), archive)
+			}
+		})
+	}
+}
+
+func TestUsecaseProcessPendingExport(t *testing.T) {
+	var user models.User
+	err := faker.FakeData(&user)
+	assert.NoError(t, err)
+
+	mockEntries := make([]*models.Entry, 0, 10)
+	err = faker.FakeData(&mockEntries)
+	assert.NoError(t, err)
+
+	mockTags := make([]*models.Tag, 0, 10)
+	err = faker.FakeData(&mockTags)
+	assert.NoError(t, err)
+
+	export := &models.DataExport{ID: 10, UserID: user.ID, Status: models.ExportProcessing}
+
+	useCase, reps := newUsecase(t)
+
+	reps.account.On("ClaimPendingExport", mock.Anything).Return(export, nil).Once()
+	reps.account.On("ClaimPendingExport", mock.Anything).Return(nil, models.ErrNotFound)
+	reps.user.On("GetUser", mock.Anything, user.ID).Return(&user, nil)
+	reps.project.On("GetUserProjects", mock.Anything, user.ID).Return([]*models.Project{{ID: 4, UserID: &user.ID}}, nil)
+	reps.task.On("GetProjectTasks", mock.Anything, uint64(4)).Return([]*models.Task{{ID: 1, ProjectID: 4, Name: "lab 3 report"}}, nil)
+	reps.client.On("GetUserClients", mock.Anything, user.ID).Return([]*models.Client{}, nil)
+	reps.tag.On("GetUserTags", mock.Anything, user.ID).Return(mockTags, nil)
+	reps.entry.On("GetUserEntries", mock.Anything, user.ID).Return(mockEntries, nil)
+	for _, entry := range mockEntries {
+		reps.tag.On("GetEntryTags", mock.Anything, entry.ID).Return(mockTags, nil)
+	}
+	reps.goal.On("GetUserGoals", mock.Anything, user.ID).Return([]*models.Goal{}, nil)
+	reps.friend.On("GetUserSubs", mock.Anything, user.ID).Return([]uint64{2}, nil)
+	reps.friend.On("GetUserFriends", mock.Anything, user.ID).Return([]uint64{3}, nil)
+
+	var archive []byte
+	reps.account.On("SetExportReady", mock.Anything, export.ID, mock.AnythingOfType("[]uint8")).
+		Run(func(args mock.Arguments) { archive = args.Get(2).([]byte) }).Return(nil)
+
+	processed, err := useCase.ProcessPendingExport(context.Background())
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(zr.File))
+	for _, file := range zr.File {
+		names = append(names, file.Name)
+	}
+	assert.ElementsMatch(t, []string{"profile.json", "projects.json", "tasks.json", "clients.json", "tags.json", "entries.json", "goals.json", "friends.json"}, names)
+
+	processed, err = useCase.ProcessPendingExport(context.Background())
+	require.NoError(t, err)
+	assert.False(t, processed)
+}
@@ -0,0 +1,303 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+
+	adminUsecase "timetracker/internal/Admin/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Delivery struct {
+	AdminUC adminUsecase.UsecaseI
+}
+
+// SearchUsers godoc
+// @Summary      SearchUsers
+// @Description  paginated search of users by name or email. Acl: user:list
+// @Tags     admin
+// @Produce  application/json
+// @Param q query string false "substring of the name or the email"
+// @Param role query string false "role filter"
+// @Param suspended query bool false "suspended filter"
+// @Param limit query int false "page size, 20 by default, 100 at most"
+// @Param offset query int false "page offset"
+// @Success  200 {object} pkg.Response{body=dto.RespUserPage} "success get users"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/admin/users [get]
+func (del *Delivery) SearchUsers(c echo.Context) error {
+	params := &models.UserSearchParams{
+		Query: c.QueryParam("q"),
+		Role:  c.QueryParam("role"),
+	}
+
+	var err error
+	if suspended := c.QueryParam("suspended"); suspended != "" {
+		value, err := strconv.ParseBool(suspended)
+		if err != nil {
+			c.Logger().Error(err)
+			return apierror.From(models.ErrBadRequest)
+		}
+		params.Suspended = &value
+	}
+
+	if limit := c.QueryParam("limit"); limit != "" {
+		params.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			c.Logger().Error(err)
+			return apierror.From(models.ErrBadRequest)
+		}
+	}
+
+	if offset := c.QueryParam("offset"); offset != "" {
+		params.Offset, err = strconv.Atoi(offset)
+		if err != nil {
+			c.Logger().Error(err)
+			return apierror.From(models.ErrBadRequest)
+		}
+	}
+
+	users, total, err := del.AdminUC.SearchUsers(c.Request().Context(), params)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.Audit(c, &models.AuditRecord{Action: models.AuditAdminRead, Details: string(models.PermUserList) + " GET /admin/users"})
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseUserPage(users, total, params)})
+}
+
+// GetUserUsage godoc
+// @Summary      GetUserUsage
+// @Description  number of entries, projects, tags, goals and storage used by the user. Acl: user:read:any
+// @Tags     admin
+// @Produce  application/json
+// @Param user_id path int true "User ID"
+// @Success  200 {object} pkg.Response{body=dto.RespUserUsage} "success get usage"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found: can't find user with such id"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/admin/users/{user_id}/usage [get]
+func (del *Delivery) GetUserUsage(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	usage, err := del.AdminUC.GetUserUsage(c.Request().Context(), id)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.AuditAccess(c, id, models.PermUserReadAny)
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseFromModelUserUsage(usage)})
+}
+
+// SuspendUser godoc
+// @Summary      SuspendUser
+// @Description  suspend the account and revoke all of its sessions. Acl: user:ban, staff accounts need user:role:change
+// @Tags     admin
+// @Param user_id path int true "User ID"
+// @Success  204 "success suspend"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found: can't find user with such id"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/admin/users/{user_id}/suspend [post]
+func (del *Delivery) SuspendUser(c echo.Context) error {
+	actor, id, err := getActorAndUserID(c)
+	if err != nil {
+		return err
+	}
+
+	err = del.AdminUC.SuspendUser(c.Request().Context(), actor, id)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.AuditUser(c, models.AuditUserSuspend, id, "")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ReactivateUser godoc
+// @Summary      ReactivateUser
+// @Description  lift the suspension of the account. Acl: user:ban, staff accounts need user:role:change
+// @Tags     admin
+// @Param user_id path int true "User ID"
+// @Success  204 "success reactivate"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found: can't find user with such id"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/admin/users/{user_id}/reactivate [post]
+func (del *Delivery) ReactivateUser(c echo.Context) error {
+	actor, id, err := getActorAndUserID(c)
+	if err != nil {
+		return err
+	}
+
+	err = del.AdminUC.ReactivateUser(c.Request().Context(), actor, id)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.AuditUser(c, models.AuditUserReactivate, id, "")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ChangeRole godoc
+// @Summary      ChangeRole
+// @Description  change the role of the user. Acl: user:role:change
+// @Tags     admin
+// @Accept	 application/json
+// @Param user_id path int true "User ID"
+// @Param role body dto.ReqChangeRole true "new role"
+// @Success  204 "success change role"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found: can't find user with such id"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/admin/users/{user_id}/role [put]
+func (del *Delivery) ChangeRole(c echo.Context) error {
+	actor, id, err := getActorAndUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ReqChangeRole
+	err = c.Bind(&req)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	if ok, err := pkg.IsRequestValid(&req); !ok {
+		c.Logger().Error(err)
+		return apierror.Validation(err)
+	}
+
+	err = del.AdminUC.ChangeRole(c.Request().Context(), actor, id, req.Role)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.AuditUser(c, models.AuditRoleChange, id, "-> "+req.Role)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ForcePasswordReset godoc
+// @Summary      ForcePasswordReset
+// @Description  drop the password and the sessions of the user and issue a one-time reset token,
+// @Description  valid for 24 hours and redeemed with POST /password-reset. Acl: user:password:reset
+// @Tags     admin
+// @Produce  application/json
+// @Param user_id path int true "User ID"
+// @Success  200 {object} pkg.Response{body=dto.RespPasswordReset} "reset token"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found: can't find user with such id"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/admin/users/{user_id}/password-reset [post]
+func (del *Delivery) ForcePasswordReset(c echo.Context) error {
+	actor, id, err := getActorAndUserID(c)
+	if err != nil {
+		return err
+	}
+
+	reset, err := del.AdminUC.ForcePasswordReset(c.Request().Context(), actor, id)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.AuditUser(c, models.AuditUserPasswordReset, id, "")
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseFromModelPasswordReset(reset)})
+}
+
+// DeleteUser godoc
+// @Summary      DeleteUser
+// @Description  permanently delete the user with all of their data. Acl: user:delete
+// @Tags     admin
+// @Param user_id path int true "User ID"
+// @Success  204 "success delete"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found: can't find user with such id"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/admin/users/{user_id} [delete]
+func (del *Delivery) DeleteUser(c echo.Context) error {
+	actor, id, err := getActorAndUserID(c)
+	if err != nil {
+		return err
+	}
+
+	err = del.AdminUC.DeleteUser(c.Request().Context(), actor, id)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.AuditUser(c, models.AuditUserDelete, id, "")
+	return c.NoContent(http.StatusNoContent)
+}
+
+func getActorAndUserID(c echo.Context) (*models.User, uint64, error) {
+	actor, ok := c.Get("user").(*models.User)
+	if !ok {
+		c.Logger().Error("can't get user from context")
+		return nil, 0, apierror.From(models.ErrInternalServerError)
+	}
+
+	id, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return nil, 0, apierror.From(models.ErrBadRequest)
+	}
+
+	return actor, id, nil
+}
+
+func NewDelivery(e *echo.Echo, uc adminUsecase.UsecaseI, aclM *middleware.AclMiddleware) {
+	handler := &Delivery{
+		AdminUC: uc,
+	}
+
+	v1 := e.Group(middleware.APIv1)
+	v1.GET("/admin/users", handler.SearchUsers, aclM.RequirePermission(models.PermUserList))
+	v1.GET("/admin/users/:user_id/usage", handler.GetUserUsage, aclM.RequirePermission(models.PermUserReadAny))
+	v1.POST("/admin/users/:user_id/suspend", handler.SuspendUser, aclM.RequirePermission(models.PermUserBan))
+	v1.POST("/admin/users/:user_id/reactivate", handler.ReactivateUser, aclM.RequirePermission(models.PermUserBan))
+	v1.PUT("/admin/users/:user_id/role", handler.ChangeRole, aclM.RequirePermission(models.PermUserRoleChange))
+	v1.POST("/admin/users/:user_id/password-reset", handler.ForcePasswordReset, aclM.RequirePermission(models.PermUserPassReset))
+	v1.DELETE("/admin/users/:user_id", handler.DeleteUser, aclM.RequirePermission(models.PermUserDelete))
+
+	// the routes from before /api/v1
+	e.GET("/admin/users", handler.SearchUsers, middleware.Deprecated(middleware.APIv1+"/admin/users"), aclM.RequirePermission(models.PermUserList))
+	e.GET("/admin/users/:user_id/usage", handler.GetUserUsage, middleware.Deprecated(middleware.APIv1+"/admin/users/:user_id/usage"), aclM.RequirePermission(models.PermUserReadAny))
+	e.POST("/admin/users/:user_id/suspend", handler.SuspendUser, middleware.Deprecated(middleware.APIv1+"/admin/users/:user_id/suspend"), aclM.RequirePermission(models.PermUserBan))
+	e.POST("/admin/users/:user_id/reactivate", handler.ReactivateUser, middleware.Deprecated(middleware.APIv1+"/admin/users/:user_id/reactivate"), aclM.RequirePermission(models.PermUserBan))
+	e.PUT("/admin/users/:user_id/role", handler.ChangeRole, middleware.Deprecated(middleware.APIv1+"/admin/users/:user_id/role"), aclM.RequirePermission(models.PermUserRoleChange))
+	e.POST("/admin/users/:user_id/password-reset", handler.ForcePasswordReset, middleware.Deprecated(middleware.APIv1+"/admin/users/:user_id/password-reset"), aclM.RequirePermission(models.PermUserPassReset))
+	e.DELETE("/admin/users/:user_id", handler.DeleteUser, middleware.Deprecated(middleware.APIv1+"/admin/users/:user_id"), aclM.RequirePermission(models.PermUserDelete))
+}
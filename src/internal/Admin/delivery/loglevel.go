@@ -0,0 +1,77 @@
+package delivery
+
+import (
+	"net/http"
+
+	"timetracker/internal/apierror"
+	"timetracker/internal/logger"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LogLevelDelivery struct {
+	Levels *logger.Levels
+}
+
+// GetLogLevel godoc
+// @Summary      GetLogLevel
+// @Description  the root log level and the levels of the packages that override it. Acl: log:level
+// @Tags     admin
+// @Produce  application/json
+// @Success  200 {object} pkg.Response{body=dto.RespLogLevel} "success get log levels"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Router   /api/v1/admin/loglevel [get]
+func (del *LogLevelDelivery) GetLogLevel(c echo.Context) error {
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseLogLevel(del.Levels.Get())})
+}
+
+// SetLogLevel godoc
+// @Summary      SetLogLevel
+// @Description  change the root log level, or the level of a package, e.g. http or entry, until restart.
+// @Description  An empty level makes the package follow the root level again. Acl: log:level
+// @Tags     admin
+// @Accept	 application/json
+// @Produce  application/json
+// @Param level body dto.ReqLogLevel true "package and level"
+// @Success  200 {object} pkg.Response{body=dto.RespLogLevel} "success set log level"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Router   /api/v1/admin/loglevel [put]
+func (del *LogLevelDelivery) SetLogLevel(c echo.Context) error {
+	var req dto.ReqLogLevel
+	err := c.Bind(&req)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	err = del.Levels.Set(req.Package, req.Level)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.New(http.StatusBadRequest, apierror.CodeBadRequest, err.Error())
+	}
+
+	c.Logger().Warnf("log level of package %q set to %q", req.Package, req.Level)
+	middleware.Audit(c, &models.AuditRecord{Action: models.AuditLogLevelChange, Details: req.Package + " -> " + req.Level})
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseLogLevel(del.Levels.Get())})
+}
+
+func NewLogLevelDelivery(e *echo.Echo, levels *logger.Levels, aclM *middleware.AclMiddleware) {
+	handler := &LogLevelDelivery{
+		Levels: levels,
+	}
+
+	v1 := e.Group(middleware.APIv1)
+	v1.GET("/admin/loglevel", handler.GetLogLevel, aclM.RequirePermission(models.PermLogLevel))
+	v1.PUT("/admin/loglevel", handler.SetLogLevel, aclM.RequirePermission(models.PermLogLevel))
+
+	// the routes from before /api/v1
+	e.GET("/admin/loglevel", handler.GetLogLevel, middleware.Deprecated(middleware.APIv1+"/admin/loglevel"), aclM.RequirePermission(models.PermLogLevel))
+	e.PUT("/admin/loglevel", handler.SetLogLevel, middleware.Deprecated(middleware.APIv1+"/admin/loglevel"), aclM.RequirePermission(models.PermLogLevel))
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	authRep "timetracker/internal/Auth/repository"
+	userRep "timetracker/internal/User/repository"
+	"timetracker/internal/policy"
+	"timetracker/internal/tracing"
+	"timetracker/models"
+	"timetracker/pkg"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+
+	passwordResetTTL = 24 * time.Hour
+)
+
+type UsecaseI interface {
+	SearchUsers(ctx context.Context, params *models.UserSearchParams) ([]*models.User, uint64, error)
+	GetUserUsage(ctx context.Context, userID uint64) (*models.UserUsage, error)
+	SuspendUser(ctx context.Context, actor *models.User, userID uint64) error
+	ReactivateUser(ctx context.Context, actor *models.User, userID uint64) error
+	ChangeRole(ctx context.Context, actor *models.User, userID uint64, role string) error
+	ForcePasswordReset(ctx context.Context, actor *models.User, userID uint64) (*models.PasswordReset, error)
+	DeleteUser(ctx context.Context, actor *models.User, userID uint64) error
+}
+
+type usecase struct {
+	userRepository userRep.RepositoryI
+	authRepository authRep.RepositoryI
+}
+
+func (u *usecase) SearchUsers(ctx context.Context, params *models.UserSearchParams) ([]*models.User, uint64, error) {
+	ctx, span := tracing.Start(ctx, "admin.Usecase.SearchUsers")
+	defer span.End()
+
+	if params.Limit <= 0 {
+		params.Limit = DefaultPageLimit
+	} else if params.Limit > MaxPageLimit {
+		params.Limit = MaxPageLimit
+	}
+
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+
+	users, total, err := u.userRepository.SearchUsers(ctx, params)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "Error in func admin.Usecase.SearchUsers")
+	}
+
+	return users, total, nil
+}
+
+func (u *usecase) GetUserUsage(ctx context.Context, userID uint64) (*models.UserUsage, error) {
+	ctx, span := tracing.Start(ctx, "admin.Usecase.GetUserUsage")
+	defer span.End()
+
+	_, err := u.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func admin.Usecase.GetUserUsage")
+	}
+
+	usage, err := u.userRepository.GetUserUsage(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func admin.Usecase.GetUserUsage")
+	}
+
+	return usage, nil
+}
+
+// SuspendUser blocks the account and revokes all of its sessions.
+func (u *usecase) SuspendUser(ctx context.Context, actor *models.User, userID uint64) error {
+	ctx, span := tracing.Start(ctx, "admin.Usecase.SuspendUser")
+	defer span.End()
+
+	_, err := u.getManagedUser(ctx, actor, userID)
+	if err != nil {
+		return errors.Wrap(err, "Error in func admin.Usecase.SuspendUser")
+	}
+
+	err = u.userRepository.SetUserSuspended(ctx, userID, true)
+	if err != nil {
+		return errors.Wrap(err, "Error in func admin.Usecase.SuspendUser")
+	}
+
+	err = u.authRepository.DeleteUserCookies(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "Error in func admin.Usecase.SuspendUser")
+	}
+
+	return nil
+}
+
+func (u *usecase) ReactivateUser(ctx context.Context, actor *models.User, userID uint64) error {
+	ctx, span := tracing.Start(ctx, "admin.Usecase.ReactivateUser")
+	defer span.End()
+
+	_, err := u.getManagedUser(ctx, actor, userID)
+	if err != nil {
+		return errors.Wrap(err, "Error in func admin.Usecase.ReactivateUser")
+	}
+
+	err = u.userRepository.SetUserSuspended(ctx, userID, false)
+	if err != nil {
+		return errors.Wrap(err, "Error in func admin.Usecase.ReactivateUser")
+	}
+
+	return nil
+}
+
+func (u *usecase) ChangeRole(ctx context.Context, actor *models.User, userID uint64, role string) error {
+	ctx, span := tracing.Start(ctx, "admin.Usecase.ChangeRole")
+	defer span.End()
+
+	if _, ok := models.RoleFromString(role); !ok {
+		return models.ErrBadRequest
+	}
+
+	_, err := u.getManagedUser(ctx, actor, userID)
+	if err != nil {
+		return errors.Wrap(err, "Error in func admin.Usecase.ChangeRole")
+	}
+
+	err = u.userRepository.UpdateUser(ctx, &models.User{ID: userID, Role: role})
+	if err != nil {
+		return errors.Wrap(err, "Error in func admin.Usecase.ChangeRole")
+	}
+
+	return nil
+}
+
+// ForcePasswordReset drops the password and the sessions of the user and issues a one-time
+// token to set a new one. The token is returned only here, it has to be handed to the user
+// out of band.
+func (u *usecase) ForcePasswordReset(ctx context.Context, actor *models.User, userID uint64) (*models.PasswordReset, error) {
+	ctx, span := tracing.Start(ctx, "admin.Usecase.ForcePasswordReset")
+	defer span.End()
+
+	_, err := u.getManagedUser(ctx, actor, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func admin.Usecase.ForcePasswordReset")
+	}
+
+	token, err := pkg.RandomToken(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func admin.Usecase.ForcePasswordReset")
+	}
+
+	reset := &models.PasswordReset{
+		UserID:    userID,
+		Token:     token,
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+
+	err = u.userRepository.SetPasswordResetToken(ctx, userID, pkg.HashToken(token), reset.ExpiresAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func admin.Usecase.ForcePasswordReset")
+	}
+
+	err = u.authRepository.DeleteUserCookies(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func admin.Usecase.ForcePasswordReset")
+	}
+
+	return reset, nil
+}
+
+func (u *usecase) DeleteUser(ctx context.Context, actor *models.User, userID uint64) error {
+	ctx, span := tracing.Start(ctx, "admin.Usecase.DeleteUser")
+	defer span.End()
+
+	_, err := u.getManagedUser(ctx, actor, userID)
+	if err != nil {
+		return errors.Wrap(err, "Error in func admin.Usecase.DeleteUser")
+	}
+
+	err = u.authRepository.DeleteUserCookies(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "Error in func admin.Usecase.DeleteUser")
+	}
+
+	err = u.userRepository.DeleteUser(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "Error in func admin.Usecase.DeleteUser")
+	}
+
+	return nil
+}
+
+// getManagedUser loads the target of an admin action. Nobody can manage their own account
+// through the console, and staff accounts can only be managed by users who can change roles.
+func (u *usecase) getManagedUser(ctx context.Context, actor *models.User, userID uint64) (*models.User, error) {
+	if actor.ID == userID {
+		return nil, models.ErrBadRequest
+	}
+
+	user, err := u.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != models.DefaultUser.String() && !policy.HasPermission(actor.Role, models.PermUserRoleChange) {
+		return nil, models.ErrPermissionDenied
+	}
+
+	return user, nil
+}
+
+func New(uRep userRep.RepositoryI, aRep authRep.RepositoryI) UsecaseI {
+	return &usecase{
+		userRepository: uRep,
+		authRepository: aRep,
+	}
+}
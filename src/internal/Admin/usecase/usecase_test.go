@@ -0,0 +1,201 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/bxcodec/faker"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"timetracker/internal/Admin/usecase"
+	authMocks "timetracker/internal/Auth/repository/mocks"
+	userMocks "timetracker/internal/User/repository/mocks"
+	"timetracker/models"
+)
+
+type TestCaseSearchUsers struct {
+	ArgData       *models.UserSearchParams
+	ExpectedLimit int
+	Error         error
+}
+
+type TestCaseManageUser struct {
+	Actor   *models.User
+	ArgData uint64
+	Error   error
+}
+
+func TestUsecaseSearchUsers(t *testing.T) {
+	mockUsers := make([]*models.User, 0, 10)
+	err := faker.FakeData(&mockUsers)
+	assert.NoError(t, err)
+
+	mockUserRepo := userMocks.NewRepositoryI(t)
+	mockAuthRepo := authMocks.NewRepositoryI(t)
+
+	mockUserRepo.On("SearchUsers", mock.Anything, mock.AnythingOfType("*models.UserSearchParams")).Return(mockUsers, uint64(len(mockUsers)), nil)
+
+	useCase := usecase.New(mockUserRepo, mockAuthRepo)
+
+	cases := map[string]TestCaseSearchUsers{
+		"default_limit": {
+			ArgData:       &models.UserSearchParams{Query: "test"},
+			ExpectedLimit: usecase.DefaultPageLimit,
+			Error:         nil,
+		},
+		"max_limit": {
+			ArgData:       &models.UserSearchParams{Limit: 1000},
+			ExpectedLimit: usecase.MaxPageLimit,
+			Error:         nil,
+		},
+		"custom_limit": {
+			ArgData:       &models.UserSearchParams{Limit: 5, Offset: 10},
+			ExpectedLimit: 5,
+			Error:         nil,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			users, total, err := useCase.SearchUsers(context.Background(), test.ArgData)
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, mockUsers, users)
+				assert.Equal(t, uint64(len(mockUsers)), total)
+				assert.Equal(t, test.ExpectedLimit, test.ArgData.Limit)
+			}
+		})
+	}
+}
+
+func TestUsecaseSuspendUser(t *testing.T) {
+	admin := &models.User{ID: 1, Role: models.Admin.String()}
+	moderator := &models.User{ID: 2, Role: models.Moderator.String()}
+	user := &models.User{ID: 3, Role: models.DefaultUser.String()}
+	support := &models.User{ID: 4, Role: models.Support.String()}
+
+	mockUserRepo := userMocks.NewRepositoryI(t)
+	mockAuthRepo := authMocks.NewRepositoryI(t)
+
+	mockUserRepo.On("GetUser", mock.Anything, user.ID).Return(user, nil)
+	mockUserRepo.On("GetUser", mock.Anything, support.ID).Return(support, nil)
+	mockUserRepo.On("GetUser", mock.Anything, uint64(100)).Return(nil, models.ErrNotFound)
+	mockUserRepo.On("SetUserSuspended", mock.Anything, user.ID, true).Return(nil)
+	mockUserRepo.On("SetUserSuspended", mock.Anything, support.ID, true).Return(nil)
+	mockAuthRepo.On("DeleteUserCookies", mock.Anything, user.ID).Return(nil)
+	mockAuthRepo.On("DeleteUserCookies", mock.Anything, support.ID).Return(nil)
+
+	useCase := usecase.New(mockUserRepo, mockAuthRepo)
+
+	cases := map[string]TestCaseManageUser{
+		"success": {
+			Actor:   moderator,
+			ArgData: user.ID,
+			Error:   nil,
+		},
+		"staff_by_admin": {
+			Actor:   admin,
+			ArgData: support.ID,
+			Error:   nil,
+		},
+		"staff_by_moderator": {
+			Actor:   moderator,
+			ArgData: support.ID,
+			Error:   models.ErrPermissionDenied,
+		},
+		"self": {
+			Actor:   moderator,
+			ArgData: moderator.ID,
+			Error:   models.ErrBadRequest,
+		},
+		"not_found": {
+			Actor:   moderator,
+			ArgData: 100,
+			Error:   models.ErrNotFound,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := useCase.SuspendUser(context.Background(), test.Actor, test.ArgData)
+			require.Equal(t, test.Error, errors.Cause(err))
+		})
+	}
+}
+
+func TestUsecaseChangeRole(t *testing.T) {
+	admin := &models.User{ID: 1, Role: models.Admin.String()}
+	user := &models.User{ID: 3, Role: models.DefaultUser.String()}
+
+	mockUserRepo := userMocks.NewRepositoryI(t)
+	mockAuthRepo := authMocks.NewRepositoryI(t)
+
+	mockUserRepo.On("GetUser", mock.Anything, user.ID).Return(user, nil)
+	mockUserRepo.On("UpdateUser", mock.Anything, &models.User{ID: user.ID, Role: models.Moderator.String()}).Return(nil)
+
+	useCase := usecase.New(mockUserRepo, mockAuthRepo)
+
+	err := useCase.ChangeRole(context.Background(), admin, user.ID, models.Moderator.String())
+	require.NoError(t, err)
+
+	err = useCase.ChangeRole(context.Background(), admin, user.ID, "superuser")
+	require.Equal(t, models.ErrBadRequest, errors.Cause(err))
+}
+
+func TestUsecaseForcePasswordReset(t *testing.T) {
+	admin := &models.User{ID: 1, Role: models.Admin.String()}
+	user := &models.User{ID: 3, Role: models.DefaultUser.String()}
+
+	mockUserRepo := userMocks.NewRepositoryI(t)
+	mockAuthRepo := authMocks.NewRepositoryI(t)
+
+	mockUserRepo.On("GetUser", mock.Anything, user.ID).Return(user, nil)
+	mockUserRepo.On("SetPasswordResetToken", mock.Anything, user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+	mockAuthRepo.On("DeleteUserCookies", mock.Anything, user.ID).Return(nil)
+
+	useCase := usecase.New(mockUserRepo, mockAuthRepo)
+
+	reset, err := useCase.ForcePasswordReset(context.Background(), admin, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, reset.UserID)
+	assert.NotEmpty(t, reset.Token)
+
+	storedHash := mockUserRepo.Calls[1].Arguments.String(2)
+	assert.NotEqual(t, reset.Token, storedHash)
+}
+
+func TestUsecaseDeleteUser(t *testing.T) {
+	admin := &models.User{ID: 1, Role: models.Admin.String()}
+	user := &models.User{ID: 3, Role: models.DefaultUser.String()}
+
+	mockUserRepo := userMocks.NewRepositoryI(t)
+	mockAuthRepo := authMocks.NewRepositoryI(t)
+
+	mockUserRepo.On("GetUser", mock.Anything, user.ID).Return(user, nil)
+	mockAuthRepo.On("DeleteUserCookies", mock.Anything, user.ID).Return(nil)
+	mockUserRepo.On("DeleteUser", mock.Anything, user.ID).Return(nil)
+
+	useCase := usecase.New(mockUserRepo, mockAuthRepo)
+
+	cases := map[string]TestCaseManageUser{
+		"success": {
+			Actor:   admin,
+			ArgData: user.ID,
+			Error:   nil,
+		},
+		"self": {
+			Actor:   admin,
+			ArgData: admin.ID,
+			Error:   models.ErrBadRequest,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := useCase.DeleteUser(context.Background(), test.Actor, test.ArgData)
+			require.Equal(t, test.Error, errors.Cause(err))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	auditUsecase "timetracker/internal/Audit/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Delivery struct {
+	AuditUC auditUsecase.UsecaseI
+}
+
+// GetRecords godoc
+// @Summary      GetAuditRecords
+// @Description  paginated audit log, newest first. Acl: audit:read
+// @Tags     admin
+// @Produce  application/json
+// @Param actor_id query int false "actor filter"
+// @Param target_id query int false "target filter"
+// @Param action query string false "action filter, e.g. auth.signin_failed"
+// @Param since query string false "RFC 3339 lower bound, inclusive"
+// @Param until query string false "RFC 3339 upper bound, exclusive"
+// @Param limit query int false "page size, 50 by default, 500 at most"
+// @Param offset query int false "page offset"
+// @Success  200 {object} pkg.Response{body=dto.RespAuditPage} "success get audit log"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/admin/audit [get]
+func (del *Delivery) GetRecords(c echo.Context) error {
+	filter, err := parseFilter(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	records, total, err := del.AuditUC.GetRecords(c.Request().Context(), filter)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseAuditPage(records, total, filter)})
+}
+
+func parseFilter(c echo.Context) (*models.AuditFilter, error) {
+	filter := &models.AuditFilter{
+		Action: models.AuditAction(c.QueryParam("action")),
+	}
+
+	for name, dst := range map[string]**uint64{"actor_id": &filter.ActorID, "target_id": &filter.TargetID} {
+		if value := c.QueryParam(name); value != "" {
+			id, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			*dst = &id
+		}
+	}
+
+	for name, dst := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if value := c.QueryParam(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, err
+			}
+			*dst = t
+		}
+	}
+
+	for name, dst := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if value := c.QueryParam(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, err
+			}
+			*dst = n
+		}
+	}
+
+	return filter, nil
+}
+
+func NewDelivery(e *echo.Echo, uc auditUsecase.UsecaseI, aclM *middleware.AclMiddleware) {
+	handler := &Delivery{
+		AuditUC: uc,
+	}
+
+	v1 := e.Group(middleware.APIv1)
+	v1.GET("/admin/audit", handler.GetRecords, aclM.RequirePermission(models.PermAuditRead))
+
+	// the routes from before /api/v1
+	e.GET("/admin/audit", handler.GetRecords, middleware.Deprecated(middleware.APIv1+"/admin/audit"), aclM.RequirePermission(models.PermAuditRead))
+}
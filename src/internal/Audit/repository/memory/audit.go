@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+	"timetracker/internal/Audit/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+)
+
+func copyAuditRecord(r *models.AuditRecord) *models.AuditRecord {
+	copied := *r
+	copied.ActorID = memoryDB.CopyID(r.ActorID)
+	copied.TargetID = memoryDB.CopyID(r.TargetID)
+	return &copied
+}
+
+type auditRepository struct {
+	db *memoryDB.DB
+}
+
+func (ar auditRepository) CreateRecord(ctx context.Context, record *models.AuditRecord) error {
+	ar.db.Lock()
+	defer ar.db.Unlock()
+
+	row := copyAuditRecord(record)
+	row.ID = ar.db.NextID("audit_log")
+	if row.CreatedAt.IsZero() {
+		row.CreatedAt = time.Now()
+	}
+	ar.db.AuditLog[row.ID] = row
+
+	record.ID = row.ID
+	return nil
+}
+
+func (ar auditRepository) GetRecords(ctx context.Context, filter *models.AuditFilter) ([]*models.AuditRecord, uint64, error) {
+	ar.db.RLock()
+	defer ar.db.RUnlock()
+
+	records := make([]*models.AuditRecord, 0, filter.Limit)
+	for _, record := range ar.db.AuditLog {
+		if matchFilter(record, filter) {
+			records = append(records, copyAuditRecord(record))
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].CreatedAt.Equal(records[j].CreatedAt) {
+			return records[i].CreatedAt.After(records[j].CreatedAt)
+		}
+		return records[i].ID > records[j].ID
+	})
+
+	total := uint64(len(records))
+	if filter.Offset >= len(records) {
+		return []*models.AuditRecord{}, total, nil
+	}
+
+	records = records[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(records) {
+		records = records[:filter.Limit]
+	}
+
+	return records, total, nil
+}
+
+func matchFilter(record *models.AuditRecord, filter *models.AuditFilter) bool {
+	switch {
+	case filter.ActorID != nil && (record.ActorID == nil || *record.ActorID != *filter.ActorID):
+		return false
+	case filter.TargetID != nil && (record.TargetID == nil || *record.TargetID != *filter.TargetID):
+		return false
+	case filter.Action != "" && record.Action != filter.Action:
+		return false
+	case !filter.Since.IsZero() && record.CreatedAt.Before(filter.Since):
+		return false
+	case !filter.Until.IsZero() && !record.CreatedAt.Before(filter.Until):
+		return false
+	}
+
+	return true
+}
+
+func (ar auditRepository) DeleteRecordsBefore(ctx context.Context, before time.Time) (int64, error) {
+	ar.db.Lock()
+	defer ar.db.Unlock()
+
+	var deleted int64
+	for id, record := range ar.db.AuditLog {
+		if record.CreatedAt.Before(before) {
+			delete(ar.db.AuditLog, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+func NewAuditRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &auditRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"time"
+	"timetracker/internal/Audit/repository"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type AuditRecord struct {
+	ID         uint64    `gorm:"column:id"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	ActorID    *uint64   `gorm:"column:actor_id"`
+	Action     string    `gorm:"column:action"`
+	TargetType string    `gorm:"column:target_type"`
+	TargetID   *uint64   `gorm:"column:target_id"`
+	IP         string    `gorm:"column:ip"`
+	RequestID  string    `gorm:"column:request_id"`
+	Details    string    `gorm:"column:details"`
+}
+
+func (AuditRecord) TableName() string {
+	return "audit_log"
+}
+
+func toPostgresAuditRecord(r *models.AuditRecord) *AuditRecord {
+	return &AuditRecord{
+		ID:         r.ID,
+		CreatedAt:  r.CreatedAt,
+		ActorID:    r.ActorID,
+		Action:     string(r.Action),
+		TargetType: r.TargetType,
+		TargetID:   r.TargetID,
+		IP:         r.IP,
+		RequestID:  r.RequestID,
+		Details:    r.Details,
+	}
+}
+
+func toModelAuditRecord(r *AuditRecord) *models.AuditRecord {
+	return &models.AuditRecord{
+		ID:         r.ID,
+		CreatedAt:  r.CreatedAt,
+		ActorID:    r.ActorID,
+		Action:     models.AuditAction(r.Action),
+		TargetType: r.TargetType,
+		TargetID:   r.TargetID,
+		IP:         r.IP,
+		RequestID:  r.RequestID,
+		Details:    r.Details,
+	}
+}
+
+func toModelAuditRecords(records []*AuditRecord) []*models.AuditRecord {
+	out := make([]*models.AuditRecord, len(records))
+
+	for i, r := range records {
+		out[i] = toModelAuditRecord(r)
+	}
+
+	return out
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func (ar auditRepository) CreateRecord(ctx context.Context, record *models.AuditRecord) error {
+	postgresRecord := toPostgresAuditRecord(record)
+
+	tx := ar.db.WithContext(ctx).Create(postgresRecord)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table audit_log)")
+	}
+
+	record.ID = postgresRecord.ID
+	return nil
+}
+
+func (ar auditRepository) GetRecords(ctx context.Context, filter *models.AuditFilter) ([]*models.AuditRecord, uint64, error) {
+	query := ar.db.WithContext(ctx).Model(&AuditRecord{})
+
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+
+	if filter.TargetID != nil {
+		query = query.Where("target_id = ?", *filter.TargetID)
+	}
+
+	if filter.Action != "" {
+		query = query.Where("action = ?", string(filter.Action))
+	}
+
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+
+	var total int64
+	tx := query.Count(&total)
+	if tx.Error != nil {
+		return nil, 0, errors.Wrap(tx.Error, "database error (table audit_log)")
+	}
+
+	records := make([]*AuditRecord, 0, filter.Limit)
+	tx = query.Order("created_at DESC, id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&records)
+	if tx.Error != nil {
+		return nil, 0, errors.Wrap(tx.Error, "database error (table audit_log)")
+	}
+
+	return toModelAuditRecords(records), uint64(total), nil
+}
+
+func (ar auditRepository) DeleteRecordsBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx := ar.db.WithContext(ctx).Where("created_at < ?", before).Delete(&AuditRecord{})
+
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "database error (table audit_log)")
+	}
+
+	return tx.RowsAffected, nil
+}
+
+func NewAuditRepository(db *gorm.DB) repository.RepositoryI {
+	return &auditRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"timetracker/models"
+)
+
+// RepositoryI has no update, records are only removed by the retention purge.
+type RepositoryI interface {
+	CreateRecord(ctx context.Context, record *models.AuditRecord) error
+	GetRecords(ctx context.Context, filter *models.AuditFilter) ([]*models.AuditRecord, uint64, error)
+	DeleteRecordsBefore(ctx context.Context, before time.Time) (int64, error)
+}
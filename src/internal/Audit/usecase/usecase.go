@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	auditRep "timetracker/internal/Audit/repository"
+	"timetracker/internal/tracing"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+type UsecaseI interface {
+	Record(ctx context.Context, record *models.AuditRecord) error
+	GetRecords(ctx context.Context, filter *models.AuditFilter) ([]*models.AuditRecord, uint64, error)
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type usecase struct {
+	auditRepository auditRep.RepositoryI
+	retention       time.Duration
+}
+
+func (u *usecase) Record(ctx context.Context, record *models.AuditRecord) error {
+	ctx, span := tracing.Start(ctx, "audit.Usecase.Record")
+	defer span.End()
+
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	err := u.auditRepository.CreateRecord(ctx, record)
+	if err != nil {
+		return errors.Wrap(err, "Error in func audit.Usecase.Record")
+	}
+
+	return nil
+}
+
+func (u *usecase) GetRecords(ctx context.Context, filter *models.AuditFilter) ([]*models.AuditRecord, uint64, error) {
+	ctx, span := tracing.Start(ctx, "audit.Usecase.GetRecords")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultPageLimit
+	} else if filter.Limit > MaxPageLimit {
+		filter.Limit = MaxPageLimit
+	}
+
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	records, total, err := u.auditRepository.GetRecords(ctx, filter)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "Error in func audit.Usecase.GetRecords")
+	}
+
+	return records, total, nil
+}
+
+// PurgeExpired deletes the records older than the retention period.
+// Zero retention keeps the records forever.
+func (u *usecase) PurgeExpired(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "audit.Usecase.PurgeExpired")
+	defer span.End()
+
+	if u.retention <= 0 {
+		return 0, nil
+	}
+
+	deleted, err := u.auditRepository.DeleteRecordsBefore(ctx, time.Now().Add(-u.retention))
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in func audit.Usecase.PurgeExpired")
+	}
+
+	return deleted, nil
+}
+
+func New(aRep auditRep.RepositoryI, retention time.Duration) UsecaseI {
+	return &usecase{
+		auditRepository: aRep,
+		retention:       retention,
+	}
+}
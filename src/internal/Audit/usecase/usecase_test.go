@@ -0,0 +1,89 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/bxcodec/faker"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	auditMocks "timetracker/internal/Audit/repository/mocks"
+	"timetracker/internal/Audit/usecase"
+	"timetracker/models"
+)
+
+type TestCaseGetRecords struct {
+	ArgData       *models.AuditFilter
+	ExpectedLimit int
+	Error         error
+}
+
+func TestUsecaseRecord(t *testing.T) {
+	var record models.AuditRecord
+	err := faker.FakeData(&record)
+	assert.NoError(t, err)
+	record.CreatedAt = time.Time{}
+
+	mockAuditRepo := auditMocks.NewRepositoryI(t)
+	mockAuditRepo.On("CreateRecord", mock.Anything, &record).Return(nil)
+
+	useCase := usecase.New(mockAuditRepo, time.Hour)
+
+	err = useCase.Record(context.Background(), &record)
+	require.NoError(t, err)
+	assert.False(t, record.CreatedAt.IsZero())
+}
+
+func TestUsecaseGetRecords(t *testing.T) {
+	mockRecords := make([]*models.AuditRecord, 0, 10)
+	err := faker.FakeData(&mockRecords)
+	assert.NoError(t, err)
+
+	mockAuditRepo := auditMocks.NewRepositoryI(t)
+	mockAuditRepo.On("GetRecords", mock.Anything, mock.AnythingOfType("*models.AuditFilter")).Return(mockRecords, uint64(len(mockRecords)), nil)
+
+	useCase := usecase.New(mockAuditRepo, time.Hour)
+
+	cases := map[string]TestCaseGetRecords{
+		"default_limit": {
+			ArgData:       &models.AuditFilter{Action: models.AuditSignIn},
+			ExpectedLimit: usecase.DefaultPageLimit,
+			Error:         nil,
+		},
+		"max_limit": {
+			ArgData:       &models.AuditFilter{Limit: 100000},
+			ExpectedLimit: usecase.MaxPageLimit,
+			Error:         nil,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			records, total, err := useCase.GetRecords(context.Background(), test.ArgData)
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, mockRecords, records)
+				assert.Equal(t, uint64(len(mockRecords)), total)
+				assert.Equal(t, test.ExpectedLimit, test.ArgData.Limit)
+			}
+		})
+	}
+}
+
+func TestUsecasePurgeExpired(t *testing.T) {
+	mockAuditRepo := auditMocks.NewRepositoryI(t)
+	mockAuditRepo.On("DeleteRecordsBefore", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 24*time.Hour
+	})).Return(int64(3), nil)
+
+	deleted, err := usecase.New(mockAuditRepo, 24*time.Hour).PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	deleted, err = usecase.New(mockAuditRepo, 0).PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+}
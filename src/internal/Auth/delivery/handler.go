@@ -3,6 +3,7 @@ package delivery
 import (
 	"net/http"
 	"time"
+	"timetracker/internal/apierror"
 	"timetracker/models"
 	"timetracker/models/dto"
 	"timetracker/pkg"
@@ -10,6 +11,8 @@ import (
 	"github.com/pkg/errors"
 
 	authUsecase "timetracker/internal/Auth/usecase"
+	"timetracker/internal/metrics"
+	"timetracker/internal/middleware"
 
 	"github.com/labstack/echo/v4"
 )
@@ -17,7 +20,8 @@ import (
 const sessionName = "session_token"
 
 type Delivery struct {
-	AuthUC authUsecase.UsecaseI
+	AuthUC        authUsecase.UsecaseI
+	SecureCookies bool
 }
 
 // SignUp godoc
@@ -28,45 +32,46 @@ type Delivery struct {
 // @Produce  application/json
 // @Param    user body dto.ReqUserSignUp true "user data"
 // @Success 201 {object} pkg.Response{body=dto.RespUser} "user created"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 409 {object} echo.HTTPError "nickname already exists"
-// @Failure 409 {object} echo.HTTPError "email already exists"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Router   /signup [post]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 409 {object} apierror.Error "nickname_conflict: nickname already exists"
+// @Failure 409 {object} apierror.Error "email_conflict: email already exists"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/auth/signup [post]
 func (del *Delivery) SignUp(c echo.Context) error {
 	var reqUser dto.ReqUserSignUp
 	err := c.Bind(&reqUser)
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.Bind(err)
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqUser); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.Validation(err)
 	}
 
-	if reqUser.Role == models.Admin.String() {
+	if role, ok := models.RoleFromString(reqUser.Role); reqUser.Role != "" && !ok {
+		c.Logger().Error("unknown role ", reqUser.Role)
+		return apierror.From(models.ErrBadRequest)
+	} else if role != models.DefaultUser {
 		if reqUser.AdminToken != "secret_token" {
 			c.Logger().Error("invalid secret_token")
-			return echo.NewHTTPError(http.StatusBadRequest, "invalid secret_token")
+			return apierror.New(http.StatusBadRequest, apierror.CodeBadRequest, "invalid secret_token")
 		}
 	}
 
 	user := reqUser.ToModelUser()
-	createdCookie, err := del.AuthUC.SignUp(user)
+	createdCookie, err := del.AuthUC.SignUp(c.Request().Context(), user)
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		metrics.CountSignIn(c, metrics.SignUp, false)
+		return apierror.From(err)
 	}
 
-	c.SetCookie(&http.Cookie{
-		Name:     sessionName,
-		Value:    createdCookie.SessionToken,
-		MaxAge:   int(createdCookie.MaxAge),
-		HttpOnly: true,
-	})
+	metrics.CountSignIn(c, metrics.SignUp, true)
+
+	c.SetCookie(newSessionCookie(createdCookie.SessionToken, createdCookie.MaxAge, del.SecureCookies))
 
 	respUser := dto.GetResponseFromModelUser(user)
 
@@ -81,39 +86,43 @@ func (del *Delivery) SignUp(c echo.Context) error {
 // @Produce  application/json
 // @Param    user body dto.ReqUserSignIn true "user info"
 // @Success  200 {object} pkg.Response{body=dto.RespUser} "success sign in"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 404 {object} echo.HTTPError "user doesn't exist"
-// @Failure 401 {object} echo.HTTPError "invalid password"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Router   /signin [post]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 404 {object} apierror.Error "not_found: user doesn't exist"
+// @Failure 401 {object} apierror.Error "invalid_password"
+// @Failure 403 {object} apierror.Error "user_suspended"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/auth/signin [post]
 func (del *Delivery) SignIn(c echo.Context) error {
 	var reqUser dto.ReqUserSignIn
 	err := c.Bind(&reqUser)
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.Bind(err)
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqUser); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.Validation(err)
 	}
 
 	user := reqUser.ToModelUser()
-	gotUser, createdCookie, err := del.AuthUC.SignIn(user)
+	gotUser, createdCookie, err := del.AuthUC.SignIn(c.Request().Context(), user)
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		middleware.Audit(c, &models.AuditRecord{
+			Action:  models.AuditSignInFailed,
+			Details: reqUser.Email + ": " + errors.Cause(err).Error(),
+		})
+		metrics.CountSignIn(c, metrics.SignInPassword, false)
+		return apierror.From(err)
 
 	}
 
-	c.SetCookie(&http.Cookie{
-		Name:     sessionName,
-		Value:    createdCookie.SessionToken,
-		MaxAge:   int(createdCookie.MaxAge),
-		HttpOnly: true,
-	})
+	middleware.Audit(c, &models.AuditRecord{Action: models.AuditSignIn, ActorID: &gotUser.ID})
+	metrics.CountSignIn(c, metrics.SignInPassword, true)
+
+	c.SetCookie(newSessionCookie(createdCookie.SessionToken, createdCookie.MaxAge, del.SecureCookies))
 
 	respUser := dto.GetResponseFromModelUser(gotUser)
 
@@ -126,80 +135,152 @@ func (del *Delivery) SignIn(c echo.Context) error {
 // @Tags     auth
 // @Produce  application/json
 // @Success  204 "success logout, body is empty"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 403 {object} echo.HTTPError "invalid csrf"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Router   /logout [post]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/auth/logout [post]
 func (del *Delivery) Logout(c echo.Context) error {
 	cookie, err := c.Cookie(sessionName)
 	if err == http.ErrNoCookie {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		return apierror.From(models.ErrUnauthorized)
 	}
 
-	err = del.AuthUC.DeleteCookie(cookie.Value)
+	err = del.AuthUC.DeleteCookie(c.Request().Context(), cookie.Value)
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
-	c.SetCookie(&http.Cookie{
-		Name:    sessionName,
-		Value:   "",
-		Expires: time.Now().AddDate(0, 0, -1),
-	})
+	middleware.Audit(c, &models.AuditRecord{Action: models.AuditSignOut})
+
+	expiredCookie := newSessionCookie("", 0, del.SecureCookies)
+	expiredCookie.Expires = time.Now().AddDate(0, 0, -1)
+	c.SetCookie(expiredCookie)
 	return c.NoContent(http.StatusNoContent)
 }
 
+// CSRF godoc
+// @Summary      CSRF
+// @Description  get csrf token of the current session. Send it in the X-CSRF-Token header
+// @Description  with every POST/PUT/PATCH/DELETE request authenticated by the session cookie.
+// @Tags     auth
+// @Produce  application/json
+// @Success  200 {object} pkg.Response{body=dto.RespCSRF} "csrf token"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/auth/csrf [get]
+func (del *Delivery) CSRF(c echo.Context) error {
+	sessionToken, ok := c.Get("session_token").(string)
+	if !ok {
+		c.Logger().Error("csrf token requested without session cookie")
+		return apierror.From(models.ErrUnauthorized)
+	}
+
+	csrfToken, err := del.AuthUC.GetCSRFToken(c.Request().Context(), sessionToken)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.Response().Header().Set(middleware.CSRFHeader, csrfToken)
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.RespCSRF{CSRFToken: csrfToken}})
+}
+
+// ResetPassword godoc
+// @Summary      ResetPassword
+// @Description  set a new password with the one-time token issued by an admin (see POST /admin/users/{user_id}/password-reset)
+// @Tags     auth
+// @Accept	 application/json
+// @Param    reset body dto.ReqPasswordReset true "reset token and new password"
+// @Success  204 "password changed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 404 {object} apierror.Error "not_found: token is invalid or expired"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/auth/password-reset [post]
+func (del *Delivery) ResetPassword(c echo.Context) error {
+	var req dto.ReqPasswordReset
+	err := c.Bind(&req)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	if ok, err := pkg.IsRequestValid(&req); !ok {
+		c.Logger().Error(err)
+		return apierror.Validation(err)
+	}
+
+	userID, err := del.AuthUC.ResetPassword(c.Request().Context(), req.Token, req.Password)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	middleware.Audit(c, &models.AuditRecord{Action: models.AuditPasswordReset, ActorID: &userID})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func newSessionCookie(value string, maxAge time.Duration, secure bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     sessionName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
 // Auth godoc
 // @Summary      Auth
 // @Description  check user auth
 // @Tags     auth
 // @Produce  application/json
 // @Success  200 {object} pkg.Response{body=models.User} "success auth"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /auth [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/auth/session [get]
 func (del *Delivery) Auth(c echo.Context) error {
 	cookie, err := c.Cookie(sessionName)
 	if err == http.ErrNoCookie {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		return apierror.From(models.ErrUnauthorized)
 	}
 
-	gotUser, err := del.AuthUC.Auth(cookie.Value)
+	gotUser, err := del.AuthUC.Auth(c.Request().Context(), cookie.Value)
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	return c.JSON(http.StatusOK, pkg.Response{Body: gotUser})
 }
 
-func handleError(err error) *echo.HTTPError {
-	causeErr := errors.Cause(err)
-	switch {
-	case errors.Is(causeErr, models.ErrNotFound):
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
-	case errors.Is(causeErr, models.ErrBadRequest):
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-	case errors.Is(causeErr, models.ErrPermissionDenied):
-		return echo.NewHTTPError(http.StatusForbidden, models.ErrPermissionDenied.Error())
-	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, causeErr.Error())
-	}
-}
-
-func NewDelivery(e *echo.Echo, uc authUsecase.UsecaseI) {
+func NewDelivery(e *echo.Echo, uc authUsecase.UsecaseI, secureCookies bool) {
 	handler := &Delivery{
-		AuthUC: uc,
+		AuthUC:        uc,
+		SecureCookies: secureCookies,
 	}
 
-	e.POST("/signin", handler.SignIn)
-	e.POST("/signup", handler.SignUp)
-	e.POST("/logout", handler.Logout)
-	e.GET("/auth", handler.Auth)
+	v1 := e.Group(middleware.APIv1)
+	v1.POST("/auth/signin", handler.SignIn)
+	v1.POST("/auth/signup", handler.SignUp)
+	v1.POST("/auth/logout", handler.Logout)
+	v1.GET("/auth/session", handler.Auth)
+	v1.GET("/auth/csrf", handler.CSRF)
+	v1.POST("/auth/password-reset", handler.ResetPassword)
+
+	// the routes from before /api/v1
+	e.POST("/signin", handler.SignIn, middleware.Deprecated(middleware.APIv1+"/auth/signin"))
+	e.POST("/signup", handler.SignUp, middleware.Deprecated(middleware.APIv1+"/auth/signup"))
+	e.POST("/logout", handler.Logout, middleware.Deprecated(middleware.APIv1+"/auth/logout"))
+	e.GET("/auth", handler.Auth, middleware.Deprecated(middleware.APIv1+"/auth/session"))
+	e.GET("/csrf", handler.CSRF, middleware.Deprecated(middleware.APIv1+"/auth/csrf"))
+	e.POST("/password-reset", handler.ResetPassword, middleware.Deprecated(middleware.APIv1+"/auth/password-reset"))
 }
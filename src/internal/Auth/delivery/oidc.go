@@ -0,0 +1,226 @@
+package delivery
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"timetracker/internal/Auth/provider"
+	authUsecase "timetracker/internal/Auth/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/metrics"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	oidcFlowCookieName     = "oidc_flow"
+	oidcFlowPath           = "/auth/oidc"
+	oidcFlowMaxAge         = 10 * time.Minute
+	confirmationCookieName = "confirmation_token"
+)
+
+type OIDCDelivery struct {
+	OIDCUC            authUsecase.OIDCUsecaseI
+	Provider          provider.ProviderI
+	PostLoginRedirect string
+	SecureCookies     bool
+}
+
+// OIDCLogin godoc
+// @Summary      OIDCLogin
+// @Description  start authorization code + PKCE login with the external identity provider
+// @Tags     auth
+// @Success  302 "redirect to the identity provider"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /auth/oidc/login [get]
+func (del *OIDCDelivery) Login(c echo.Context) error {
+	return del.startFlow(c, "")
+}
+
+// OIDCReauth godoc
+// @Summary      OIDCReauth
+// @Description  sign in to the external identity provider again to confirm the deletion of an account without
+// @Description  a password. The provider asks for the credentials even with a session there. The callback sets
+// @Description  the confirmation_token cookie for 5 minutes, DELETE /api/v1/me takes it once.
+// @Tags     auth
+// @Success  302 "redirect to the identity provider"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/me/reauth [get]
+func (del *OIDCDelivery) Reauth(c echo.Context) error {
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	return del.startFlow(c, strconv.FormatUint(userId, 10))
+}
+
+// startFlow keeps the state of the login in the flow cookie. A reauth flow also keeps the user,
+// the callback only confirms it with an identity linked to that user.
+func (del *OIDCDelivery) startFlow(c echo.Context, reauthUserID string) error {
+	state, err := provider.RandomString(32)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	nonce, err := provider.RandomString(32)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	codeVerifier, err := provider.RandomString(32)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	flow := []string{state, nonce, codeVerifier}
+	authURL := del.Provider.AuthCodeURL(state, nonce, provider.S256Challenge(codeVerifier))
+	if reauthUserID != "" {
+		flow = append(flow, reauthUserID)
+		authURL = del.Provider.ReauthCodeURL(state, nonce, provider.S256Challenge(codeVerifier), authUsecase.ReauthMaxAge)
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     oidcFlowCookieName,
+		Value:    strings.Join(flow, "."),
+		Path:     oidcFlowPath,
+		MaxAge:   int(oidcFlowMaxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   del.SecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// OIDCCallback godoc
+// @Summary      OIDCCallback
+// @Description  finish external login, link or create the account and issue the session cookie.
+// @Description  A reauth flow sets the confirmation_token cookie instead.
+// @Tags     auth
+// @Produce  application/json
+// @Param        code    query     string  true  "authorization code"
+// @Param        state   query     string  true  "state from the login request"
+// @Success  200 {object} pkg.Response{body=dto.RespUser} "success sign in"
+// @Success  201 {object} pkg.Response{body=dto.RespConfirmation} "reauth confirmed"
+// @Success  302 "redirect to the frontend after sign in"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 401 {object} apierror.Error "unauthorized: identity provider rejected the login, reauth_required: the sign in is not fresh"
+// @Failure 403 {object} apierror.Error "permission_denied: the identity is not linked to the account to confirm"
+// @Failure 409 {object} apierror.Error "email_conflict: email already exists"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /auth/oidc/callback [get]
+func (del *OIDCDelivery) Callback(c echo.Context) error {
+	if errParam := c.QueryParam("error"); errParam != "" {
+		c.Logger().Error("identity provider error: ", errParam)
+		return apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, errParam)
+	}
+
+	flowCookie, err := c.Cookie(oidcFlowCookieName)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:    oidcFlowCookieName,
+		Value:   "",
+		Path:    oidcFlowPath,
+		Expires: time.Now().AddDate(0, 0, -1),
+	})
+
+	flow := strings.Split(flowCookie.Value, ".")
+	state := c.QueryParam("state")
+	if (len(flow) != 3 && len(flow) != 4) || subtle.ConstantTimeCompare([]byte(flow[0]), []byte(state)) != 1 {
+		c.Logger().Error("oidc state mismatch")
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	identity, err := del.Provider.Exchange(c.Request().Context(), c.QueryParam("code"), flow[2], flow[1])
+	if err != nil {
+		c.Logger().Error(err)
+		metrics.CountSignIn(c, metrics.SignInOIDC, false)
+		return apierror.From(models.ErrUnauthorized)
+	}
+
+	if len(flow) == 4 {
+		return del.confirm(c, flow[3], identity)
+	}
+
+	gotUser, createdCookie, err := del.OIDCUC.SignInExternal(c.Request().Context(), identity)
+	if err != nil {
+		c.Logger().Error(err)
+		middleware.Audit(c, &models.AuditRecord{
+			Action:  models.AuditSignInFailed,
+			Details: identity.Provider + " " + identity.Subject + ": " + errors.Cause(err).Error(),
+		})
+		metrics.CountSignIn(c, metrics.SignInOIDC, false)
+		return apierror.From(err)
+	}
+
+	middleware.Audit(c, &models.AuditRecord{Action: models.AuditSignIn, ActorID: &gotUser.ID, Details: identity.Provider})
+	metrics.CountSignIn(c, metrics.SignInOIDC, true)
+
+	c.SetCookie(newSessionCookie(createdCookie.SessionToken, createdCookie.MaxAge, del.SecureCookies))
+
+	if del.PostLoginRedirect != "" {
+		return c.Redirect(http.StatusFound, del.PostLoginRedirect)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseFromModelUser(gotUser)})
+}
+
+func (del *OIDCDelivery) confirm(c echo.Context, reauthUserID string, identity *models.ExternalIdentity) error {
+	userId, err := strconv.ParseUint(reauthUserID, 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	confirmation, err := del.OIDCUC.Reauthenticate(c.Request().Context(), userId, identity)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     confirmationCookieName,
+		Value:    confirmation.Token,
+		Path:     "/",
+		MaxAge:   int(time.Until(confirmation.ExpireTime).Seconds()),
+		HttpOnly: true,
+		Secure:   del.SecureCookies,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	if del.PostLoginRedirect != "" {
+		return c.Redirect(http.StatusFound, del.PostLoginRedirect)
+	}
+
+	return c.JSON(http.StatusCreated, pkg.Response{Body: dto.RespConfirmation{ExpireTime: confirmation.ExpireTime}})
+}
+
+func NewOIDCDelivery(e *echo.Echo, uc authUsecase.OIDCUsecaseI, p provider.ProviderI, postLoginRedirect string, secureCookies bool) {
+	handler := &OIDCDelivery{
+		OIDCUC:            uc,
+		Provider:          p,
+		PostLoginRedirect: postLoginRedirect,
+		SecureCookies:     secureCookies,
+	}
+
+	e.GET(oidcFlowPath+"/login", handler.Login)
+	e.GET(oidcFlowPath+"/callback", handler.Callback)
+	e.GET(middleware.APIv1+"/me/reauth", handler.Reauth)
+}
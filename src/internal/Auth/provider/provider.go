@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"timetracker/models"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+type ProviderI interface {
+	Name() string
+	AuthCodeURL(state string, nonce string, codeChallenge string) string
+	// ReauthCodeURL makes the user sign in again even with a session at the provider
+	ReauthCodeURL(state string, nonce string, codeChallenge string, maxAge time.Duration) string
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*models.ExternalIdentity, error)
+}
+
+type Config struct {
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type oidcProvider struct {
+	name     string
+	oauth2   oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+type idTokenClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Nonce         string `json:"nonce"`
+	AuthTime      int64  `json:"auth_time"`
+}
+
+// NewOIDCProvider fetches the issuer discovery document, so the IdP has to be reachable at startup.
+func NewOIDCProvider(ctx context.Context, cfg Config) (ProviderI, error) {
+	provider, err := oidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc discovery error")
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"profile", "email"}
+	}
+
+	return &oidcProvider{
+		name: cfg.Name,
+		oauth2: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       append([]string{oidc.ScopeOpenID}, scopes...),
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+	}, nil
+}
+
+func (p *oidcProvider) Name() string {
+	return p.name
+}
+
+func (p *oidcProvider) AuthCodeURL(state string, nonce string, codeChallenge string) string {
+	return p.oauth2.AuthCodeURL(state,
+		oidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+}
+
+func (p *oidcProvider) ReauthCodeURL(state string, nonce string, codeChallenge string, maxAge time.Duration) string {
+	return p.oauth2.AuthCodeURL(state,
+		oidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+		oauth2.SetAuthURLParam("prompt", "login"),
+		oauth2.SetAuthURLParam("max_age", strconv.Itoa(int(maxAge.Seconds()))),
+	)
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*models.ExternalIdentity, error) {
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", codeVerifier))
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc code exchange error")
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("oidc token response has no id_token")
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc id_token verification error")
+	}
+
+	var claims idTokenClaims
+	if err = idToken.Claims(&claims); err != nil {
+		return nil, errors.Wrap(err, "oidc id_token claims error")
+	}
+
+	if claims.Nonce != nonce {
+		return nil, errors.New("oidc id_token nonce mismatch")
+	}
+
+	identity := &models.ExternalIdentity{
+		Provider:      p.name,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}
+
+	if claims.AuthTime != 0 {
+		identity.AuthTime = time.Unix(claims.AuthTime, 0).UTC()
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,163 @@
+package provider_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+	"timetracker/internal/Auth/provider"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID = "timetracker"
+	testCode     = "auth-code"
+)
+
+// mockIdP is a minimal OpenID provider: discovery, jwks and a token endpoint
+// that checks the PKCE verifier against the challenge from the authorize request.
+type mockIdP struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	codeChallenge string
+	nonce         string
+	authTime      time.Time
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &mockIdP{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/jwks", idp.jwks)
+	mux.HandleFunc("/token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (idp *mockIdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"issuer":                                idp.server.URL,
+		"authorization_endpoint":                idp.server.URL + "/authorize",
+		"token_endpoint":                        idp.server.URL + "/token",
+		"jwks_uri":                              idp.server.URL + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (idp *mockIdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "test",
+			"n":   base64.RawURLEncoding.EncodeToString(idp.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(idp.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (idp *mockIdP) token(w http.ResponseWriter, r *http.Request) {
+	if r.FormValue("code") != testCode || provider.S256Challenge(r.FormValue("code_verifier")) != idp.codeChallenge {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idp.sign(),
+	})
+}
+
+func (idp *mockIdP) sign() string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test", "typ": "JWT"})
+	payload, _ := json.Marshal(map[string]interface{}{
+		"iss":            idp.server.URL,
+		"sub":            "user-42",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          idp.nonce,
+		"email":          "user42@example.com",
+		"email_verified": true,
+		"name":           "User 42",
+		"auth_time":      idp.authTime.Unix(),
+	})
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sum := sha256.Sum256([]byte(signingInput))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, sum[:])
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestOIDCProviderCodeFlow(t *testing.T) {
+	idp := newMockIdP(t)
+	idp.authTime = time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	p, err := provider.NewOIDCProvider(context.Background(), provider.Config{
+		Name:        "idp",
+		IssuerURL:   idp.server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost/auth/oidc/callback",
+	})
+	require.NoError(t, err)
+
+	codeVerifier, err := provider.RandomString(32)
+	require.NoError(t, err)
+	idp.nonce = "nonce"
+
+	authURL, err := url.Parse(p.AuthCodeURL("state", idp.nonce, provider.S256Challenge(codeVerifier)))
+	require.NoError(t, err)
+	assert.Equal(t, "S256", authURL.Query().Get("code_challenge_method"))
+	assert.Equal(t, "state", authURL.Query().Get("state"))
+	idp.codeChallenge = authURL.Query().Get("code_challenge")
+
+	t.Run("success", func(t *testing.T) {
+		identity, err := p.Exchange(context.Background(), testCode, codeVerifier, idp.nonce)
+		require.NoError(t, err)
+		assert.Equal(t, "idp", identity.Provider)
+		assert.Equal(t, "user-42", identity.Subject)
+		assert.Equal(t, "user42@example.com", identity.Email)
+		assert.True(t, identity.EmailVerified)
+		assert.True(t, idp.authTime.Equal(identity.AuthTime))
+	})
+
+	t.Run("reauth_url", func(t *testing.T) {
+		reauthURL, err := url.Parse(p.ReauthCodeURL("state", idp.nonce, idp.codeChallenge, 5*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, "login", reauthURL.Query().Get("prompt"))
+		assert.Equal(t, "300", reauthURL.Query().Get("max_age"))
+		assert.Equal(t, idp.codeChallenge, reauthURL.Query().Get("code_challenge"))
+	})
+
+	t.Run("invalid_code_verifier", func(t *testing.T) {
+		_, err := p.Exchange(context.Background(), testCode, "wrong", idp.nonce)
+		assert.Error(t, err)
+	})
+
+	t.Run("nonce_mismatch", func(t *testing.T) {
+		_, err := p.Exchange(context.Background(), testCode, codeVerifier, "other")
+		assert.Error(t, err)
+	})
+}
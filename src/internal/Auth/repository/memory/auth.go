@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"timetracker/internal/Auth/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+)
+
+type authRepository struct {
+	db *memoryDB.DB
+}
+
+func (ar authRepository) CreateCookie(ctx context.Context, cookie *models.Cookie) error {
+	ar.db.Lock()
+	defer ar.db.Unlock()
+
+	ar.db.Sessions[cookie.SessionToken] = &memoryDB.Session{
+		UserID:     cookie.UserID,
+		ExpireTime: time.Now().Add(cookie.MaxAge),
+	}
+
+	return nil
+}
+
+func (ar authRepository) GetUserByCookie(ctx context.Context, value string) (string, error) {
+	ar.db.Lock()
+	defer ar.db.Unlock()
+
+	session, err := ar.getSession(value)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatUint(session.UserID, 10), nil
+}
+
+// getSession drops the session once it is expired, the caller must hold the write lock
+func (ar authRepository) getSession(sessionToken string) (*memoryDB.Session, error) {
+	session, ok := ar.db.Sessions[sessionToken]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	if session.ExpireTime.Before(time.Now()) {
+		delete(ar.db.Sessions, sessionToken)
+		return nil, models.ErrNotFound
+	}
+
+	return session, nil
+}
+
+func (ar authRepository) DeleteCookie(ctx context.Context, value string) error {
+	ar.db.Lock()
+	defer ar.db.Unlock()
+
+	delete(ar.db.Sessions, value)
+	return nil
+}
+
+func (ar authRepository) DeleteUserCookies(ctx context.Context, userID uint64) error {
+	ar.db.Lock()
+	defer ar.db.Unlock()
+
+	for token, session := range ar.db.Sessions {
+		if session.UserID == userID {
+			delete(ar.db.Sessions, token)
+		}
+	}
+
+	return nil
+}
+
+func (ar authRepository) SetCSRFToken(ctx context.Context, sessionToken string, csrfToken string) error {
+	ar.db.Lock()
+	defer ar.db.Unlock()
+
+	session, err := ar.getSession(sessionToken)
+	if err != nil {
+		return err
+	}
+
+	session.CSRFToken = csrfToken
+	return nil
+}
+
+func (ar authRepository) GetCSRFToken(ctx context.Context, sessionToken string) (string, error) {
+	ar.db.Lock()
+	defer ar.db.Unlock()
+
+	session, err := ar.getSession(sessionToken)
+	if err != nil {
+		return "", err
+	}
+
+	if session.CSRFToken == "" {
+		return "", models.ErrNotFound
+	}
+
+	return session.CSRFToken, nil
+}
+
+func (ar authRepository) CreateConfirmation(ctx context.Context, confirmation *models.Confirmation) error {
+	ar.db.Lock()
+	defer ar.db.Unlock()
+
+	stored := *confirmation
+	ar.db.Confirms[confirmation.Token] = &stored
+	return nil
+}
+
+func (ar authRepository) TakeConfirmation(ctx context.Context, token string) (*models.Confirmation, error) {
+	ar.db.Lock()
+	defer ar.db.Unlock()
+
+	confirmation, ok := ar.db.Confirms[token]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	delete(ar.db.Confirms, token)
+	if confirmation.ExpireTime.Before(time.Now()) {
+		return nil, models.ErrNotFound
+	}
+
+	return confirmation, nil
+}
+
+func NewAuthRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &authRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"timetracker/internal/Auth/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+// copyIdentity keeps only the stored fields, like the external_identity table
+func copyIdentity(i *models.ExternalIdentity) *models.ExternalIdentity {
+	return &models.ExternalIdentity{
+		ID:       i.ID,
+		UserID:   i.UserID,
+		Provider: i.Provider,
+		Subject:  i.Subject,
+		Email:    i.Email,
+	}
+}
+
+type identityRepository struct {
+	db *memoryDB.DB
+}
+
+func (ir identityRepository) CreateIdentity(ctx context.Context, identity *models.ExternalIdentity) error {
+	ir.db.Lock()
+	defer ir.db.Unlock()
+
+	if _, ok := ir.db.Users[identity.UserID]; !ok {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table external_identity)")
+	}
+
+	if _, err := ir.findIdentity(identity.Provider, identity.Subject); err == nil {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table external_identity)")
+	}
+
+	row := copyIdentity(identity)
+	row.ID = ir.db.NextID("external_identity")
+	ir.db.Identities[row.ID] = row
+
+	identity.ID = row.ID
+	return nil
+}
+
+func (ir identityRepository) GetIdentity(ctx context.Context, provider string, subject string) (*models.ExternalIdentity, error) {
+	ir.db.RLock()
+	defer ir.db.RUnlock()
+
+	identity, err := ir.findIdentity(provider, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return copyIdentity(identity), nil
+}
+
+func (ir identityRepository) findIdentity(provider string, subject string) (*models.ExternalIdentity, error) {
+	for _, identity := range ir.db.Identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+
+	return nil, models.ErrNotFound
+}
+
+func NewIdentityRepository(db *memoryDB.DB) repository.IdentityRepositoryI {
+	return &identityRepository{
+		db: db,
+	}
+}
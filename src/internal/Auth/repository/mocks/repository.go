@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "timetracker/models"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +15,41 @@ type RepositoryI struct {
 	mock.Mock
 }
 
-// CreateCookie provides a mock function with given fields: cookie
-func (_m *RepositoryI) CreateCookie(cookie *models.Cookie) error {
-	ret := _m.Called(cookie)
+// CreateConfirmation provides a mock function with given fields: ctx, confirmation
+func (_m *RepositoryI) CreateConfirmation(ctx context.Context, confirmation *models.Confirmation) error {
+	ret := _m.Called(ctx, confirmation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Confirmation) error); ok {
+		r0 = rf(ctx, confirmation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateCookie provides a mock function with given fields: ctx, cookie
+func (_m *RepositoryI) CreateCookie(ctx context.Context, cookie *models.Cookie) error {
+	ret := _m.Called(ctx, cookie)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Cookie) error); ok {
+		r0 = rf(ctx, cookie)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCookie provides a mock function with given fields: ctx, value
+func (_m *RepositoryI) DeleteCookie(ctx context.Context, value string) error {
+	ret := _m.Called(ctx, value)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Cookie) error); ok {
-		r0 = rf(cookie)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, value)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,13 +57,13 @@ func (_m *RepositoryI) CreateCookie(cookie *models.Cookie) error {
 	return r0
 }
 
-// DeleteCookie provides a mock function with given fields: value
-func (_m *RepositoryI) DeleteCookie(value string) error {
-	ret := _m.Called(value)
+// DeleteUserCookies provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) DeleteUserCookies(ctx context.Context, userID uint64) error {
+	ret := _m.Called(ctx, userID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(value)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,23 +71,87 @@ func (_m *RepositoryI) DeleteCookie(value string) error {
 	return r0
 }
 
-// GetUserByCookie provides a mock function with given fields: value
-func (_m *RepositoryI) GetUserByCookie(value string) (string, error) {
-	ret := _m.Called(value)
+// GetCSRFToken provides a mock function with given fields: ctx, sessionToken
+func (_m *RepositoryI) GetCSRFToken(ctx context.Context, sessionToken string) (string, error) {
+	ret := _m.Called(ctx, sessionToken)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, sessionToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, sessionToken)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sessionToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByCookie provides a mock function with given fields: ctx, value
+func (_m *RepositoryI) GetUserByCookie(ctx context.Context, value string) (string, error) {
+	ret := _m.Called(ctx, value)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(value)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, value)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(value)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, value)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(value)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCSRFToken provides a mock function with given fields: ctx, sessionToken, csrfToken
+func (_m *RepositoryI) SetCSRFToken(ctx context.Context, sessionToken string, csrfToken string) error {
+	ret := _m.Called(ctx, sessionToken, csrfToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, sessionToken, csrfToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TakeConfirmation provides a mock function with given fields: ctx, token
+func (_m *RepositoryI) TakeConfirmation(ctx context.Context, token string) (*models.Confirmation, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *models.Confirmation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Confirmation, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Confirmation); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Confirmation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"strconv"
 	"time"
 	"timetracker/internal/Auth/repository"
@@ -18,6 +19,7 @@ type Cookie struct {
 	UserID       *uint64   `gorm:"column:user_id"`
 	SessionToken string    `gorm:"column:session_token"`
 	ExpireTime   time.Time `gorm:"column:expire_time"`
+	CSRFToken    string    `gorm:"column:csrf_token"`
 }
 
 func (Cookie) TableName() string {
@@ -45,10 +47,10 @@ func NewAuthRepositoryPostgres(db *gorm.DB) repository.RepositoryI {
 	}
 }
 
-func (ar authRepositoryPostgres) CreateCookie(cookie *models.Cookie) error {
+func (ar authRepositoryPostgres) CreateCookie(ctx context.Context, cookie *models.Cookie) error {
 	postgresCookie := toPostgresCookie(cookie)
 
-	tx := ar.db.Create(postgresCookie)
+	tx := ar.db.WithContext(ctx).Create(postgresCookie)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table cookie)")
@@ -57,16 +59,16 @@ func (ar authRepositoryPostgres) CreateCookie(cookie *models.Cookie) error {
 	return nil
 }
 
-func (ar authRepositoryPostgres) GetUserByCookie(value string) (string, error) {
+func (ar authRepositoryPostgres) GetUserByCookie(ctx context.Context, value string) (string, error) {
 	var postgresCookie Cookie
-	tx := ar.db.Where(&Cookie{SessionToken: value}).Take(&postgresCookie)
+	tx := ar.db.WithContext(ctx).Where(&Cookie{SessionToken: value}).Take(&postgresCookie)
 
 	if tx.Error != nil {
 		return "", errors.Wrap(tx.Error, "database error (table cookie)")
 	}
 
 	if postgresCookie.ExpireTime.Before(time.Now()) {
-		err := ar.DeleteCookie(value)
+		err := ar.DeleteCookie(ctx, value)
 
 		if err != nil {
 			return "", errors.Wrap(tx.Error, "database error (table cookie)")
@@ -79,8 +81,8 @@ func (ar authRepositoryPostgres) GetUserByCookie(value string) (string, error) {
 	return strconv.Itoa(int(*postgresCookie.UserID)), nil
 }
 
-func (ar authRepositoryPostgres) DeleteCookie(value string) error {
-	tx := ar.db.Delete(&Cookie{SessionToken: value})
+func (ar authRepositoryPostgres) DeleteCookie(ctx context.Context, value string) error {
+	tx := ar.db.WithContext(ctx).Delete(&Cookie{SessionToken: value})
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table cookie)")
@@ -88,3 +90,105 @@ func (ar authRepositoryPostgres) DeleteCookie(value string) error {
 
 	return nil
 }
+
+func (ar authRepositoryPostgres) DeleteUserCookies(ctx context.Context, userID uint64) error {
+	tx := ar.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&Cookie{})
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table cookie)")
+	}
+
+	return nil
+}
+
+func (ar authRepositoryPostgres) SetCSRFToken(ctx context.Context, sessionToken string, csrfToken string) error {
+	tx := ar.db.WithContext(ctx).Model(&Cookie{}).Where("session_token = ?", sessionToken).Update("csrf_token", csrfToken)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table cookie)")
+	}
+
+	if tx.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ar authRepositoryPostgres) GetCSRFToken(ctx context.Context, sessionToken string) (string, error) {
+	var postgresCookie Cookie
+	tx := ar.db.WithContext(ctx).Where(&Cookie{SessionToken: sessionToken}).Take(&postgresCookie)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return "", models.ErrNotFound
+	} else if tx.Error != nil {
+		return "", errors.Wrap(tx.Error, "database error (table cookie)")
+	}
+
+	if postgresCookie.CSRFToken == "" {
+		return "", models.ErrNotFound
+	}
+
+	return postgresCookie.CSRFToken, nil
+}
+
+type Confirmation struct {
+	Token      string    `gorm:"column:token;primaryKey"`
+	UserID     uint64    `gorm:"column:user_id"`
+	ExpireTime time.Time `gorm:"column:expire_time"`
+}
+
+func (Confirmation) TableName() string {
+	return "confirmation"
+}
+
+// CreateConfirmation drops the expired confirmations of the user, the unused ones are left
+// behind otherwise
+func (ar authRepositoryPostgres) CreateConfirmation(ctx context.Context, confirmation *models.Confirmation) error {
+	tx := ar.db.WithContext(ctx).Where("user_id = ? AND expire_time < ?", confirmation.UserID, time.Now().UTC()).Delete(&Confirmation{})
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table confirmation)")
+	}
+
+	tx = ar.db.WithContext(ctx).Create(&Confirmation{
+		Token:      confirmation.Token,
+		UserID:     confirmation.UserID,
+		ExpireTime: confirmation.ExpireTime.UTC(),
+	})
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table confirmation)")
+	}
+
+	return nil
+}
+
+// TakeConfirmation deletes the row it read, of two concurrent requests only the one that
+// deleted it gets the confirmation
+func (ar authRepositoryPostgres) TakeConfirmation(ctx context.Context, token string) (*models.Confirmation, error) {
+	var postgresConfirmation Confirmation
+	tx := ar.db.WithContext(ctx).Where("token = ?", token).Take(&postgresConfirmation)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table confirmation)")
+	}
+
+	tx = ar.db.WithContext(ctx).Where("token = ?", token).Delete(&Confirmation{})
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table confirmation)")
+	}
+
+	if tx.RowsAffected == 0 || postgresConfirmation.ExpireTime.Before(time.Now()) {
+		return nil, models.ErrNotFound
+	}
+
+	return &models.Confirmation{
+		Token:      postgresConfirmation.Token,
+		UserID:     postgresConfirmation.UserID,
+		ExpireTime: postgresConfirmation.ExpireTime,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"timetracker/internal/Auth/repository"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ExternalIdentity struct {
+	ID       uint64 `gorm:"column:id"`
+	UserID   uint64 `gorm:"column:user_id"`
+	Provider string `gorm:"column:provider"`
+	Subject  string `gorm:"column:subject"`
+	Email    string `gorm:"column:email"`
+}
+
+func (ExternalIdentity) TableName() string {
+	return "external_identity"
+}
+
+func toPostgresIdentity(i *models.ExternalIdentity) *ExternalIdentity {
+	return &ExternalIdentity{
+		ID:       i.ID,
+		UserID:   i.UserID,
+		Provider: i.Provider,
+		Subject:  i.Subject,
+		Email:    i.Email,
+	}
+}
+
+func toModelIdentity(i *ExternalIdentity) *models.ExternalIdentity {
+	return &models.ExternalIdentity{
+		ID:       i.ID,
+		UserID:   i.UserID,
+		Provider: i.Provider,
+		Subject:  i.Subject,
+		Email:    i.Email,
+	}
+}
+
+type identityRepository struct {
+	db *gorm.DB
+}
+
+func (ir identityRepository) CreateIdentity(ctx context.Context, identity *models.ExternalIdentity) error {
+	postgresIdentity := toPostgresIdentity(identity)
+
+	tx := ir.db.WithContext(ctx).Create(postgresIdentity)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table external_identity)")
+	}
+
+	identity.ID = postgresIdentity.ID
+	return nil
+}
+
+func (ir identityRepository) GetIdentity(ctx context.Context, provider string, subject string) (*models.ExternalIdentity, error) {
+	var identity ExternalIdentity
+
+	tx := ir.db.WithContext(ctx).Where(&ExternalIdentity{Provider: provider, Subject: subject}).Take(&identity)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table external_identity)")
+	}
+
+	return toModelIdentity(&identity), nil
+}
+
+func NewIdentityRepository(db *gorm.DB) repository.IdentityRepositoryI {
+	return &identityRepository{
+		db: db,
+	}
+}
@@ -4,17 +4,35 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
 	"timetracker/internal/Auth/repository"
 	"timetracker/models"
 )
 
+const (
+	csrfKeyPrefix         = "csrf:"
+	userSessionsKeyPrefix = "user_sessions:"
+	confirmationKeyPrefix = "confirmation:"
+)
+
 type authRepository struct {
-	db  *redis.Client
-	ctx context.Context
+	db *redis.Client
+}
+
+func userSessionsKey(userID uint64) string {
+	return userSessionsKeyPrefix + strconv.FormatUint(userID, 10)
 }
 
-func (ar authRepository) CreateCookie(cookie *models.Cookie) error {
-	err := ar.db.Set(ar.ctx, cookie.SessionToken, cookie.UserID, cookie.MaxAge).Err()
+// CreateCookie also indexes the session in the set of the user's sessions, so all of
+// them can be revoked at once. The set lives as long as the newest session.
+func (ar authRepository) CreateCookie(ctx context.Context, cookie *models.Cookie) error {
+	_, err := ar.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, cookie.SessionToken, cookie.UserID, cookie.MaxAge)
+		pipe.SAdd(ctx, userSessionsKey(cookie.UserID), cookie.SessionToken)
+		pipe.Expire(ctx, userSessionsKey(cookie.UserID), cookie.MaxAge)
+		return nil
+	})
 
 	if err != nil {
 		return errors.Wrap(err, "redis error")
@@ -23,8 +41,8 @@ func (ar authRepository) CreateCookie(cookie *models.Cookie) error {
 	return nil
 }
 
-func (ar authRepository) GetUserByCookie(value string) (string, error) {
-	userIdStr, err := ar.db.Get(ar.ctx, value).Result()
+func (ar authRepository) GetUserByCookie(ctx context.Context, value string) (string, error) {
+	userIdStr, err := ar.db.Get(ctx, value).Result()
 
 	if errors.Is(err, redis.Nil) {
 		return "", models.ErrNotFound
@@ -35,9 +53,90 @@ func (ar authRepository) GetUserByCookie(value string) (string, error) {
 	return userIdStr, nil
 }
 
-func (ar authRepository) DeleteCookie(value string) error {
-	err := ar.db.Del(ar.ctx, value).Err()
+func (ar authRepository) DeleteCookie(ctx context.Context, value string) error {
+	userIdStr, err := ar.db.Get(ctx, value).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return errors.Wrap(err, "redis error")
+	}
+
+	_, err = ar.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, value, csrfKey(value))
+		if userIdStr != "" {
+			pipe.SRem(ctx, userSessionsKeyPrefix+userIdStr, value)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "redis error")
+	}
+
+	return nil
+}
+
+func (ar authRepository) DeleteUserCookies(ctx context.Context, userID uint64) error {
+	sessions, err := ar.db.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return errors.Wrap(err, "redis error")
+	}
+
+	keys := make([]string, 0, 2*len(sessions)+1)
+	for _, session := range sessions {
+		keys = append(keys, session, csrfKey(session))
+	}
+	keys = append(keys, userSessionsKey(userID))
+
+	err = ar.db.Del(ctx, keys...).Err()
+	if err != nil {
+		return errors.Wrap(err, "redis error")
+	}
+
+	return nil
+}
+
+func csrfKey(sessionToken string) string {
+	return csrfKeyPrefix + sessionToken
+}
+
+// SetCSRFToken stores the token next to the session so both expire at the same time.
+func (ar authRepository) SetCSRFToken(ctx context.Context, sessionToken string, csrfToken string) error {
+	ttl, err := ar.db.PTTL(ctx, sessionToken).Result()
+	if err != nil {
+		return errors.Wrap(err, "redis error")
+	}
+
+	// go-redis reports -2 for a missing key and -1 for a key without expiration
+	if ttl == -2 {
+		return models.ErrNotFound
+	}
+
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	err = ar.db.Set(ctx, csrfKey(sessionToken), csrfToken, ttl).Err()
+	if err != nil {
+		return errors.Wrap(err, "redis error")
+	}
+
+	return nil
+}
+
+func (ar authRepository) GetCSRFToken(ctx context.Context, sessionToken string) (string, error) {
+	csrfToken, err := ar.db.Get(ctx, csrfKey(sessionToken)).Result()
+
+	if errors.Is(err, redis.Nil) {
+		return "", models.ErrNotFound
+	} else if err != nil {
+		return "", errors.Wrap(err, "redis error")
+	}
+
+	return csrfToken, nil
+}
 
+// CreateConfirmation stores the confirmation until it expires, the sessions don't know about it
+func (ar authRepository) CreateConfirmation(ctx context.Context, confirmation *models.Confirmation) error {
+	err := ar.db.Set(ctx, confirmationKeyPrefix+confirmation.Token, confirmation.UserID, time.Until(confirmation.ExpireTime)).Err()
 	if err != nil {
 		return errors.Wrap(err, "redis error")
 	}
@@ -45,9 +144,33 @@ func (ar authRepository) DeleteCookie(value string) error {
 	return nil
 }
 
+func (ar authRepository) TakeConfirmation(ctx context.Context, token string) (*models.Confirmation, error) {
+	key := confirmationKeyPrefix + token
+
+	var userIdStr *redis.StringCmd
+	var ttl *redis.DurationCmd
+	_, err := ar.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		ttl = pipe.PTTL(ctx, key)
+		userIdStr = pipe.GetDel(ctx, key)
+		return nil
+	})
+
+	if errors.Is(err, redis.Nil) {
+		return nil, models.ErrNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "redis error")
+	}
+
+	userID, err := strconv.ParseUint(userIdStr.Val(), 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "redis error")
+	}
+
+	return &models.Confirmation{Token: token, UserID: userID, ExpireTime: time.Now().Add(ttl.Val())}, nil
+}
+
 func NewAuthRepository(db *redis.Client) repository.RepositoryI {
 	return &authRepository{
-		db:  db,
-		ctx: context.Background(),
+		db: db,
 	}
 }
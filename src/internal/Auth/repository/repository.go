@@ -1,9 +1,23 @@
 package repository
 
-import "timetracker/models"
+import (
+	"context"
+	"timetracker/models"
+)
 
 type RepositoryI interface {
-	CreateCookie(cookie *models.Cookie) error
-	GetUserByCookie(value string) (string, error)
-	DeleteCookie(value string) error
+	CreateCookie(ctx context.Context, cookie *models.Cookie) error
+	GetUserByCookie(ctx context.Context, value string) (string, error)
+	DeleteCookie(ctx context.Context, value string) error
+	DeleteUserCookies(ctx context.Context, userID uint64) error
+	SetCSRFToken(ctx context.Context, sessionToken string, csrfToken string) error
+	GetCSRFToken(ctx context.Context, sessionToken string) (string, error)
+	CreateConfirmation(ctx context.Context, confirmation *models.Confirmation) error
+	// TakeConfirmation returns the confirmation and removes it, ErrNotFound once it is expired or taken
+	TakeConfirmation(ctx context.Context, token string) (*models.Confirmation, error)
+}
+
+type IdentityRepositoryI interface {
+	CreateIdentity(ctx context.Context, identity *models.ExternalIdentity) error
+	GetIdentity(ctx context.Context, provider string, subject string) (*models.ExternalIdentity, error)
 }
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+	authRep "timetracker/internal/Auth/repository"
+	userRep "timetracker/internal/User/repository"
+	"timetracker/internal/tracing"
+	"timetracker/models"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxUserNameLen = 35
+	// ReauthMaxAge is how long ago the user may have signed in to the provider to confirm
+	ReauthMaxAge    = 5 * time.Minute
+	confirmationTTL = 5 * time.Minute
+)
+
+type OIDCUsecaseI interface {
+	SignInExternal(ctx context.Context, identity *models.ExternalIdentity) (*models.User, *models.Cookie, error)
+	Reauthenticate(ctx context.Context, userID uint64, identity *models.ExternalIdentity) (*models.Confirmation, error)
+}
+
+type oidcUsecase struct {
+	authRepository     authRep.RepositoryI
+	identityRepository authRep.IdentityRepositoryI
+	userRepository     userRep.RepositoryI
+}
+
+// SignInExternal logs in the user linked to an external identity.
+// On the first login the identity is linked to the account with the same verified email,
+// or a new account without a password is created just in time.
+func (u oidcUsecase) SignInExternal(ctx context.Context, identity *models.ExternalIdentity) (*models.User, *models.Cookie, error) {
+	ctx, span := tracing.Start(ctx, "auth.Usecase.SignInExternal")
+	defer span.End()
+
+	user, err := u.getLinkedUser(ctx, identity)
+	if errors.Is(err, models.ErrNotFound) {
+		user, err = u.linkUser(ctx, identity)
+	}
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error in func auth.Usecase.SignInExternal")
+	}
+
+	if user.Suspended {
+		return nil, nil, models.ErrUserSuspended
+	}
+
+	err = cancelDeletion(ctx, u.userRepository, user)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error in func auth.Usecase.SignInExternal")
+	}
+
+	user.Password = ""
+
+	cookie := models.Cookie{
+		UserID:       user.ID,
+		SessionToken: uuid.NewString(),
+		MaxAge:       (3600 * 24 * 365) * time.Second,
+	}
+
+	err = u.authRepository.CreateCookie(ctx, &cookie)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error in func auth.Usecase.SignInExternal")
+	}
+
+	return user, &cookie, nil
+}
+
+// Reauthenticate issues a confirmation for a fresh sign in of the user to the provider,
+// accounts without a password confirm their deletion with it. The identity must be linked
+// to the user and signed in within ReauthMaxAge.
+func (u oidcUsecase) Reauthenticate(ctx context.Context, userID uint64, identity *models.ExternalIdentity) (*models.Confirmation, error) {
+	ctx, span := tracing.Start(ctx, "auth.Usecase.Reauthenticate")
+	defer span.End()
+
+	linked, err := u.identityRepository.GetIdentity(ctx, identity.Provider, identity.Subject)
+	if errors.Is(err, models.ErrNotFound) {
+		return nil, models.ErrPermissionDenied
+	} else if err != nil {
+		return nil, errors.Wrap(err, "Error in func auth.Usecase.Reauthenticate")
+	}
+
+	if linked.UserID != userID {
+		return nil, models.ErrPermissionDenied
+	}
+
+	if identity.AuthTime.IsZero() || time.Since(identity.AuthTime) > ReauthMaxAge {
+		return nil, models.ErrReauthRequired
+	}
+
+	confirmation := &models.Confirmation{
+		Token:      uuid.NewString(),
+		UserID:     userID,
+		ExpireTime: time.Now().Add(confirmationTTL),
+	}
+
+	err = u.authRepository.CreateConfirmation(ctx, confirmation)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func auth.Usecase.Reauthenticate")
+	}
+
+	return confirmation, nil
+}
+
+func (u oidcUsecase) getLinkedUser(ctx context.Context, identity *models.ExternalIdentity) (*models.User, error) {
+	linked, err := u.identityRepository.GetIdentity(ctx, identity.Provider, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.userRepository.GetUser(ctx, linked.UserID)
+}
+
+func (u oidcUsecase) linkUser(ctx context.Context, identity *models.ExternalIdentity) (*models.User, error) {
+	if identity.Email == "" {
+		return nil, models.ErrBadRequest
+	}
+
+	user, err := u.userRepository.GetUserByEmail(ctx, identity.Email)
+
+	switch {
+	case err == nil && !identity.EmailVerified:
+		return nil, models.ErrConflictEmail
+	case errors.Is(err, models.ErrNotFound):
+		user = &models.User{
+			Name:  externalUserName(identity),
+			Email: identity.Email,
+			Role:  models.DefaultUser.String(),
+		}
+
+		err = u.userRepository.CreateUser(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	}
+
+	identity.UserID = user.ID
+	err = u.identityRepository.CreateIdentity(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func externalUserName(identity *models.ExternalIdentity) string {
+	name := identity.Name
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	if len([]rune(name)) > maxUserNameLen {
+		name = string([]rune(name)[:maxUserNameLen])
+	}
+
+	return name
+}
+
+func NewOIDC(uRep userRep.RepositoryI, aRep authRep.RepositoryI, iRep authRep.IdentityRepositoryI) OIDCUsecaseI {
+	return &oidcUsecase{
+		userRepository:     uRep,
+		authRepository:     aRep,
+		identityRepository: iRep,
+	}
+}
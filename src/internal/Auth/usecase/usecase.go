@@ -1,11 +1,15 @@
 package usecase
 
 import (
+	"context"
+	"crypto/subtle"
 	"strconv"
 	"time"
 	authRep "timetracker/internal/Auth/repository"
 	userRep "timetracker/internal/User/repository"
+	"timetracker/internal/tracing"
 	"timetracker/models"
+	"timetracker/pkg"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -13,10 +17,13 @@ import (
 )
 
 type UsecaseI interface {
-	Auth(cookie string) (*models.User, error)
-	SignIn(user *models.User) (*models.User, *models.Cookie, error)
-	SignUp(user *models.User) (*models.Cookie, error)
-	DeleteCookie(value string) error
+	Auth(ctx context.Context, cookie string) (*models.User, error)
+	SignIn(ctx context.Context, user *models.User) (*models.User, *models.Cookie, error)
+	SignUp(ctx context.Context, user *models.User) (*models.Cookie, error)
+	DeleteCookie(ctx context.Context, value string) error
+	GetCSRFToken(ctx context.Context, sessionToken string) (string, error)
+	CheckCSRFToken(ctx context.Context, sessionToken string, csrfToken string) error
+	ResetPassword(ctx context.Context, token string, password string) (uint64, error)
 }
 
 type usecase struct {
@@ -24,8 +31,11 @@ type usecase struct {
 	userRepository userRep.RepositoryI
 }
 
-func (u usecase) Auth(cookie string) (*models.User, error) {
-	userIdStr, err := u.authRepository.GetUserByCookie(cookie)
+func (u usecase) Auth(ctx context.Context, cookie string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "auth.Usecase.Auth")
+	defer span.End()
+
+	userIdStr, err := u.authRepository.GetUserByCookie(ctx, cookie)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func auth.Usecase.Auth")
 	}
@@ -35,22 +45,39 @@ func (u usecase) Auth(cookie string) (*models.User, error) {
 		return nil, errors.Wrap(err, "Error in func auth.Usecase.Auth")
 	}
 
-	gotUser, err := u.userRepository.GetUser(userId)
+	gotUser, err := u.userRepository.GetUser(ctx, userId)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func auth.Usecase.Auth")
 	}
+
+	if gotUser.Suspended {
+		return nil, models.ErrUserSuspended
+	}
 	gotUser.Password = ""
 
 	return gotUser, nil
 }
 
-func (u usecase) SignIn(user *models.User) (*models.User, *models.Cookie, error) {
-	repUsr, err := u.userRepository.GetUserByEmail(user.Email)
+func (u usecase) SignIn(ctx context.Context, user *models.User) (*models.User, *models.Cookie, error) {
+	ctx, span := tracing.Start(ctx, "auth.Usecase.SignIn")
+	defer span.End()
+
+	repUsr, err := u.userRepository.GetUserByEmail(ctx, user.Email)
 
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Error in func auth.Usecase.SignIn")
 	}
 
+	if repUsr.Suspended {
+		return nil, nil, models.ErrUserSuspended
+	}
+
+	// accounts created through an external identity provider and accounts waiting
+	// for a forced password reset have no password
+	if repUsr.Password == "" {
+		return nil, nil, models.ErrInvalidPassword
+	}
+
 	err = bcrypt.CompareHashAndPassword([]byte(repUsr.Password), []byte(user.Password))
 	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
 		return nil, nil, models.ErrInvalidPassword
@@ -58,6 +85,11 @@ func (u usecase) SignIn(user *models.User) (*models.User, *models.Cookie, error)
 		return nil, nil, errors.Wrap(err, "Error in func auth.Usecase.SignIn bcrypt error")
 	}
 
+	err = cancelDeletion(ctx, u.userRepository, repUsr)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error in func auth.Usecase.SignIn")
+	}
+
 	repUsr.Password = ""
 
 	cookie := models.Cookie{
@@ -66,7 +98,7 @@ func (u usecase) SignIn(user *models.User) (*models.User, *models.Cookie, error)
 		MaxAge:       (3600 * 24 * 365) * time.Second,
 	}
 
-	err = u.authRepository.CreateCookie(&cookie)
+	err = u.authRepository.CreateCookie(ctx, &cookie)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Error in func auth.Usecase.SignIn")
 	}
@@ -74,8 +106,11 @@ func (u usecase) SignIn(user *models.User) (*models.User, *models.Cookie, error)
 	return repUsr, &cookie, nil
 }
 
-func (u usecase) SignUp(user *models.User) (*models.Cookie, error) {
-	_, err := u.userRepository.GetUserByEmail(user.Email)
+func (u usecase) SignUp(ctx context.Context, user *models.User) (*models.Cookie, error) {
+	ctx, span := tracing.Start(ctx, "auth.Usecase.SignUp")
+	defer span.End()
+
+	_, err := u.userRepository.GetUserByEmail(ctx, user.Email)
 
 	if err != models.ErrNotFound && err != nil {
 		return nil, errors.Wrap(err, "Error in func auth.Usecase.SignUp")
@@ -90,7 +125,7 @@ func (u usecase) SignUp(user *models.User) (*models.Cookie, error) {
 
 	user.Password = string(hashedPassword)
 
-	err = u.userRepository.CreateUser(user)
+	err = u.userRepository.CreateUser(ctx, user)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func auth.Usecase.SignUp")
 	}
@@ -102,7 +137,7 @@ func (u usecase) SignUp(user *models.User) (*models.Cookie, error) {
 		MaxAge:       (3600 * 24 * 365) * time.Second,
 	}
 
-	err = u.authRepository.CreateCookie(&cookie)
+	err = u.authRepository.CreateCookie(ctx, &cookie)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func auth.Usecase.SignUp")
 	}
@@ -110,13 +145,16 @@ func (u usecase) SignUp(user *models.User) (*models.Cookie, error) {
 	return &cookie, nil
 }
 
-func (u usecase) DeleteCookie(value string) error {
-	_, err := u.authRepository.GetUserByCookie(value)
+func (u usecase) DeleteCookie(ctx context.Context, value string) error {
+	ctx, span := tracing.Start(ctx, "auth.Usecase.DeleteCookie")
+	defer span.End()
+
+	_, err := u.authRepository.GetUserByCookie(ctx, value)
 	if err != nil {
 		return errors.Wrap(err, "Error in func auth.Usecase.DeleteCookie")
 	}
 
-	err = u.authRepository.DeleteCookie(value)
+	err = u.authRepository.DeleteCookie(ctx, value)
 	if err != nil {
 		return errors.Wrap(err, "Error in func auth.Usecase.DeleteCookie")
 	}
@@ -124,6 +162,87 @@ func (u usecase) DeleteCookie(value string) error {
 	return nil
 }
 
+// GetCSRFToken returns the synchronizer token of the session and issues one on the first call.
+func (u usecase) GetCSRFToken(ctx context.Context, sessionToken string) (string, error) {
+	ctx, span := tracing.Start(ctx, "auth.Usecase.GetCSRFToken")
+	defer span.End()
+
+	csrfToken, err := u.authRepository.GetCSRFToken(ctx, sessionToken)
+	if err == nil {
+		return csrfToken, nil
+	} else if !errors.Is(err, models.ErrNotFound) {
+		return "", errors.Wrap(err, "Error in func auth.Usecase.GetCSRFToken")
+	}
+
+	csrfToken = uuid.NewString()
+	err = u.authRepository.SetCSRFToken(ctx, sessionToken, csrfToken)
+	if err != nil {
+		return "", errors.Wrap(err, "Error in func auth.Usecase.GetCSRFToken")
+	}
+
+	return csrfToken, nil
+}
+
+func (u usecase) CheckCSRFToken(ctx context.Context, sessionToken string, csrfToken string) error {
+	ctx, span := tracing.Start(ctx, "auth.Usecase.CheckCSRFToken")
+	defer span.End()
+
+	if csrfToken == "" {
+		return models.ErrInvalidCSRF
+	}
+
+	expected, err := u.authRepository.GetCSRFToken(ctx, sessionToken)
+	if errors.Is(err, models.ErrNotFound) {
+		return models.ErrInvalidCSRF
+	} else if err != nil {
+		return errors.Wrap(err, "Error in func auth.Usecase.CheckCSRFToken")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(csrfToken)) != 1 {
+		return models.ErrInvalidCSRF
+	}
+
+	return nil
+}
+
+// ResetPassword redeems a one-time token issued by an admin. Sessions opened before
+// the reset are already revoked when the token is issued.
+func (u usecase) ResetPassword(ctx context.Context, token string, password string) (uint64, error) {
+	ctx, span := tracing.Start(ctx, "auth.Usecase.ResetPassword")
+	defer span.End()
+
+	if token == "" {
+		return 0, models.ErrNotFound
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), 8)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in func auth.Usecase.ResetPassword bcrypt error")
+	}
+
+	userID, err := u.userRepository.ResetPassword(ctx, pkg.HashToken(token), string(hashedPassword))
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in func auth.Usecase.ResetPassword")
+	}
+
+	return userID, nil
+}
+
+// cancelDeletion keeps the account of a user who signs in during the grace period of the deletion.
+func cancelDeletion(ctx context.Context, uRep userRep.RepositoryI, user *models.User) error {
+	if user.DeleteAfter == nil {
+		return nil
+	}
+
+	err := uRep.ScheduleDeletion(ctx, user.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	user.DeleteAfter = nil
+	return nil
+}
+
 func New(uRep userRep.RepositoryI, aRep authRep.RepositoryI) UsecaseI {
 	return &usecase{
 		userRepository: uRep,
@@ -1,6 +1,7 @@
 package usecase_test
 
 import (
+	"context"
 	"github.com/bxcodec/faker"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -9,10 +10,12 @@ import (
 	"golang.org/x/crypto/bcrypt"
 	"strconv"
 	"testing"
+	"time"
 	authMocks "timetracker/internal/Auth/repository/mocks"
 	authUsecase "timetracker/internal/Auth/usecase"
 	userMocks "timetracker/internal/User/repository/mocks"
 	"timetracker/models"
+	"timetracker/pkg"
 )
 
 type TestCaseSignUp struct {
@@ -51,10 +54,10 @@ func TestUsecaseSignUp(t *testing.T) {
 	mockAuthRepo := authMocks.NewRepositoryI(t)
 	mockUserRepo := userMocks.NewRepositoryI(t)
 
-	mockUserRepo.On("GetUserByEmail", mockUserSuccess.Email).Return(&mockUserSuccess, models.ErrNotFound)
-	mockUserRepo.On("CreateUser", &mockUserSuccess).Return(nil)
-	mockAuthRepo.On("CreateCookie", mock.AnythingOfType("*models.Cookie")).Return(nil)
-	mockUserRepo.On("GetUserByEmail", mockUserConflictEmail.Email).Return(&mockUserConflictEmail, models.ErrConflictEmail)
+	mockUserRepo.On("GetUserByEmail", mock.Anything, mockUserSuccess.Email).Return(&mockUserSuccess, models.ErrNotFound)
+	mockUserRepo.On("CreateUser", mock.Anything, &mockUserSuccess).Return(nil)
+	mockAuthRepo.On("CreateCookie", mock.Anything, mock.AnythingOfType("*models.Cookie")).Return(nil)
+	mockUserRepo.On("GetUserByEmail", mock.Anything, mockUserConflictEmail.Email).Return(&mockUserConflictEmail, models.ErrConflictEmail)
 
 	useCase := authUsecase.New(mockUserRepo, mockAuthRepo)
 
@@ -72,7 +75,7 @@ func TestUsecaseSignUp(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			cookie, err := useCase.SignUp(test.ArgData)
+			cookie, err := useCase.SignUp(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
 
 			if err == nil {
@@ -88,6 +91,21 @@ func TestUsecaseSignIn(t *testing.T) {
 	var mockUser models.User
 	err := faker.FakeData(&mockUser)
 	assert.NoError(t, err)
+	mockUser.Suspended = false
+
+	var mockUserPendingDeletion models.User
+	err = faker.FakeData(&mockUserPendingDeletion)
+	assert.NoError(t, err)
+	mockUserPendingDeletion.Suspended = false
+	pendingPassword := mockUserPendingDeletion.Password
+	hashedPendingPassword, err := bcrypt.GenerateFromPassword([]byte(pendingPassword), 8)
+	assert.NoError(t, err)
+	mockUserPendingDeletion.Password = string(hashedPendingPassword)
+
+	var mockUserSuspended models.User
+	err = faker.FakeData(&mockUserSuspended)
+	assert.NoError(t, err)
+	mockUserSuspended.Suspended = true
 
 	var mockUserSignIn models.User
 	mockUserSignIn.Email = mockUser.Email
@@ -107,15 +125,25 @@ func TestUsecaseSignIn(t *testing.T) {
 	mockUserRepo := userMocks.NewRepositoryI(t)
 
 	mockUserFail := mockUser
-	mockUserRepo.On("GetUserByEmail", mockUserSignInInvalidPassword.Email).Return(&mockUserFail, nil)
+	mockUserRepo.On("GetUserByEmail", mock.Anything, mockUserSignInInvalidPassword.Email).Return(&mockUserFail, nil)
 
-	mockUserRepo.On("GetUserByEmail", mockUserSignIn.Email).Return(&mockUser, nil)
-	mockAuthRepo.On("CreateCookie", mock.AnythingOfType("*models.Cookie")).Return(nil)
+	mockUserRepo.On("GetUserByEmail", mock.Anything, mockUserSignIn.Email).Return(&mockUser, nil)
+	mockAuthRepo.On("CreateCookie", mock.Anything, mock.AnythingOfType("*models.Cookie")).Return(nil)
+
+	mockUserRepo.On("GetUserByEmail", mock.Anything, mockUserSuspended.Email).Return(&mockUserSuspended, nil)
+
+	mockUserRepo.On("GetUserByEmail", mock.Anything, mockUserPendingDeletion.Email).Return(&mockUserPendingDeletion, nil)
+	mockUserRepo.On("ScheduleDeletion", mock.Anything, mockUserPendingDeletion.ID, (*time.Time)(nil)).Return(nil)
 
 	useCase := authUsecase.New(mockUserRepo, mockAuthRepo)
 
+	mockUser.DeleteAfter = nil
 	expectedUser := mockUser
 	expectedUser.Password = ""
+
+	expectedPendingUser := mockUserPendingDeletion
+	expectedPendingUser.Password = ""
+	expectedPendingUser.DeleteAfter = nil
 	cases := map[string]TestCaseSignIn{
 		"success": {
 			ArgData:           &mockUserSignIn,
@@ -127,11 +155,20 @@ func TestUsecaseSignIn(t *testing.T) {
 			ArgData: &mockUserSignInInvalidPassword,
 			Error:   models.ErrInvalidPassword,
 		},
+		"suspended": {
+			ArgData: &models.User{Email: mockUserSuspended.Email, Password: mockUserSuspended.Password},
+			Error:   models.ErrUserSuspended,
+		},
+		"cancel_deletion": {
+			ArgData:         &models.User{Email: mockUserPendingDeletion.Email, Password: pendingPassword},
+			ExpectedResUser: &expectedPendingUser,
+			Error:           nil,
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			user, _, err := useCase.SignIn(test.ArgData)
+			user, _, err := useCase.SignIn(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, err)
 
 			if err == nil {
@@ -159,13 +196,13 @@ func TestUsecaseDeleteCookie(t *testing.T) {
 	mockAuthRepo := authMocks.NewRepositoryI(t)
 	mockUserRepo := userMocks.NewRepositoryI(t)
 
-	mockAuthRepo.On("GetUserByCookie", cookie.SessionToken).Return(strconv.Itoa(int(cookie.UserID)), nil)
-	mockAuthRepo.On("DeleteCookie", cookie.SessionToken).Return(nil)
+	mockAuthRepo.On("GetUserByCookie", mock.Anything, cookie.SessionToken).Return(strconv.Itoa(int(cookie.UserID)), nil)
+	mockAuthRepo.On("DeleteCookie", mock.Anything, cookie.SessionToken).Return(nil)
 
-	mockAuthRepo.On("GetUserByCookie", cookieGetFail.SessionToken).Return("", models.ErrNotFound)
+	mockAuthRepo.On("GetUserByCookie", mock.Anything, cookieGetFail.SessionToken).Return("", models.ErrNotFound)
 
-	mockAuthRepo.On("GetUserByCookie", cookieDeleteFail.SessionToken).Return(strconv.Itoa(int(cookieDeleteFail.UserID)), nil)
-	mockAuthRepo.On("DeleteCookie", cookieDeleteFail.SessionToken).Return(models.ErrInternalServerError)
+	mockAuthRepo.On("GetUserByCookie", mock.Anything, cookieDeleteFail.SessionToken).Return(strconv.Itoa(int(cookieDeleteFail.UserID)), nil)
+	mockAuthRepo.On("DeleteCookie", mock.Anything, cookieDeleteFail.SessionToken).Return(models.ErrInternalServerError)
 
 	useCase := authUsecase.New(mockUserRepo, mockAuthRepo)
 
@@ -186,7 +223,7 @@ func TestUsecaseDeleteCookie(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.DeleteCookie(test.ArgData)
+			err := useCase.DeleteCookie(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
 		})
 	}
@@ -195,10 +232,12 @@ func TestUsecaseDeleteCookie(t *testing.T) {
 }
 
 func TestUsecaseAuth(t *testing.T) {
-	var cookie, invalidCookie models.Cookie
+	var cookie, invalidCookie, suspendedCookie models.Cookie
 	err := faker.FakeData(&cookie)
 	assert.NoError(t, err)
 	invalidCookie.SessionToken += "lol"
+	suspendedCookie.SessionToken = cookie.SessionToken + "suspended"
+	suspendedCookie.UserID = cookie.UserID + 1
 
 	mockAuthRepo := authMocks.NewRepositoryI(t)
 	mockUserRepo := userMocks.NewRepositoryI(t)
@@ -208,10 +247,16 @@ func TestUsecaseAuth(t *testing.T) {
 	assert.NoError(t, err)
 
 	user.ID = cookie.UserID
+	user.Suspended = false
+
+	suspendedUser := models.User{ID: suspendedCookie.UserID, Suspended: true}
 
-	mockAuthRepo.On("GetUserByCookie", cookie.SessionToken).Return(strconv.Itoa(int(cookie.UserID)), nil)
-	mockAuthRepo.On("GetUserByCookie", invalidCookie.SessionToken).Return("", models.ErrNotFound)
-	mockUserRepo.On("GetUser", cookie.UserID).Return(&user, nil)
+	mockAuthRepo.On("GetUserByCookie", mock.Anything, cookie.SessionToken).Return(strconv.Itoa(int(cookie.UserID)), nil)
+	mockAuthRepo.On("GetUserByCookie", mock.Anything, invalidCookie.SessionToken).Return("", models.ErrNotFound)
+	mockUserRepo.On("GetUser", mock.Anything, cookie.UserID).Return(&user, nil)
+
+	mockAuthRepo.On("GetUserByCookie", mock.Anything, suspendedCookie.SessionToken).Return(strconv.FormatUint(suspendedCookie.UserID, 10), nil)
+	mockUserRepo.On("GetUser", mock.Anything, suspendedCookie.UserID).Return(&suspendedUser, nil)
 
 	user.Password = ""
 
@@ -228,11 +273,16 @@ func TestUsecaseAuth(t *testing.T) {
 			Expected: nil,
 			Error:    models.ErrNotFound,
 		},
+		"suspended": {
+			ArgData:  suspendedCookie.SessionToken,
+			Expected: nil,
+			Error:    models.ErrUserSuspended,
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			gotUser, err := useCase.Auth(test.ArgData)
+			gotUser, err := useCase.Auth(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
 			if err == nil {
 				assert.Equal(t, test.Expected, gotUser)
@@ -242,3 +292,251 @@ func TestUsecaseAuth(t *testing.T) {
 	mockAuthRepo.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
 }
+
+type TestCaseSignInExternal struct {
+	ArgData         *models.ExternalIdentity
+	ExpectedResUser uint64
+	Error           error
+}
+
+func TestUsecaseSignInExternal(t *testing.T) {
+	var linkedUser, existedUser models.User
+	err := faker.FakeData(&linkedUser)
+	assert.NoError(t, err)
+	err = faker.FakeData(&existedUser)
+	assert.NoError(t, err)
+	linkedUser.Suspended = false
+	existedUser.Suspended = false
+	linkedUser.DeleteAfter = nil
+	existedUser.DeleteAfter = nil
+
+	linked := &models.ExternalIdentity{UserID: linkedUser.ID, Provider: "idp", Subject: "linked"}
+	byEmail := &models.ExternalIdentity{Provider: "idp", Subject: "by_email", Email: existedUser.Email, EmailVerified: true}
+	unverified := &models.ExternalIdentity{Provider: "idp", Subject: "unverified", Email: existedUser.Email}
+	newUser := &models.ExternalIdentity{Provider: "idp", Subject: "new", Email: "new@example.com", EmailVerified: true}
+
+	mockAuthRepo := authMocks.NewRepositoryI(t)
+	mockIdentityRepo := authMocks.NewIdentityRepositoryI(t)
+	mockUserRepo := userMocks.NewRepositoryI(t)
+
+	mockIdentityRepo.On("GetIdentity", mock.Anything, "idp", "linked").Return(linked, nil)
+	mockUserRepo.On("GetUser", mock.Anything, linkedUser.ID).Return(&linkedUser, nil)
+
+	mockIdentityRepo.On("GetIdentity", mock.Anything, "idp", "by_email").Return(nil, models.ErrNotFound)
+	mockIdentityRepo.On("GetIdentity", mock.Anything, "idp", "unverified").Return(nil, models.ErrNotFound)
+	mockUserRepo.On("GetUserByEmail", mock.Anything, existedUser.Email).Return(&existedUser, nil)
+	mockIdentityRepo.On("CreateIdentity", mock.Anything, byEmail).Return(nil)
+
+	mockIdentityRepo.On("GetIdentity", mock.Anything, "idp", "new").Return(nil, models.ErrNotFound)
+	mockUserRepo.On("GetUserByEmail", mock.Anything, newUser.Email).Return(nil, models.ErrNotFound)
+	mockUserRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("*models.User")).Return(nil)
+	mockIdentityRepo.On("CreateIdentity", mock.Anything, newUser).Return(nil)
+
+	mockAuthRepo.On("CreateCookie", mock.Anything, mock.AnythingOfType("*models.Cookie")).Return(nil)
+
+	useCase := authUsecase.NewOIDC(mockUserRepo, mockAuthRepo, mockIdentityRepo)
+
+	cases := map[string]TestCaseSignInExternal{
+		"linked_identity": {
+			ArgData:         linked,
+			ExpectedResUser: linkedUser.ID,
+			Error:           nil,
+		},
+		"link_by_verified_email": {
+			ArgData:         byEmail,
+			ExpectedResUser: existedUser.ID,
+			Error:           nil,
+		},
+		"unverified_email_conflict": {
+			ArgData: unverified,
+			Error:   models.ErrConflictEmail,
+		},
+		"create_user": {
+			ArgData: newUser,
+			Error:   nil,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			user, cookie, err := useCase.SignInExternal(context.Background(), test.ArgData)
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, user.ID, cookie.UserID)
+				assert.Equal(t, user.ID, test.ArgData.UserID)
+				assert.Empty(t, user.Password)
+				if test.ExpectedResUser != 0 {
+					assert.Equal(t, test.ExpectedResUser, user.ID)
+				}
+			}
+		})
+	}
+}
+
+type TestCaseReauthenticate struct {
+	ArgUserID uint64
+	ArgData   *models.ExternalIdentity
+	Error     error
+}
+
+func TestUsecaseReauthenticate(t *testing.T) {
+	linked := &models.ExternalIdentity{UserID: 1, Provider: "idp", Subject: "linked"}
+
+	mockAuthRepo := authMocks.NewRepositoryI(t)
+	mockIdentityRepo := authMocks.NewIdentityRepositoryI(t)
+	mockUserRepo := userMocks.NewRepositoryI(t)
+
+	mockIdentityRepo.On("GetIdentity", mock.Anything, "idp", "linked").Return(linked, nil)
+	mockIdentityRepo.On("GetIdentity", mock.Anything, "idp", "unlinked").Return(nil, models.ErrNotFound)
+	mockAuthRepo.On("CreateConfirmation", mock.Anything, mock.AnythingOfType("*models.Confirmation")).Return(nil)
+
+	useCase := authUsecase.NewOIDC(mockUserRepo, mockAuthRepo, mockIdentityRepo)
+
+	cases := map[string]TestCaseReauthenticate{
+		"success": {
+			ArgUserID: 1,
+			ArgData:   &models.ExternalIdentity{Provider: "idp", Subject: "linked", AuthTime: time.Now().Add(-time.Minute)},
+			Error:     nil,
+		},
+		"stale_sign_in": {
+			ArgUserID: 1,
+			ArgData:   &models.ExternalIdentity{Provider: "idp", Subject: "linked", AuthTime: time.Now().Add(-time.Hour)},
+			Error:     models.ErrReauthRequired,
+		},
+		"no_auth_time": {
+			ArgUserID: 1,
+			ArgData:   &models.ExternalIdentity{Provider: "idp", Subject: "linked"},
+			Error:     models.ErrReauthRequired,
+		},
+		"other_user": {
+			ArgUserID: 2,
+			ArgData:   &models.ExternalIdentity{Provider: "idp", Subject: "linked", AuthTime: time.Now()},
+			Error:     models.ErrPermissionDenied,
+		},
+		"unlinked_identity": {
+			ArgUserID: 1,
+			ArgData:   &models.ExternalIdentity{Provider: "idp", Subject: "unlinked", AuthTime: time.Now()},
+			Error:     models.ErrPermissionDenied,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			confirmation, err := useCase.Reauthenticate(context.Background(), test.ArgUserID, test.ArgData)
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, test.ArgUserID, confirmation.UserID)
+				assert.NotEmpty(t, confirmation.Token)
+				assert.True(t, confirmation.ExpireTime.After(time.Now()))
+			}
+		})
+	}
+}
+
+type TestCaseCheckCSRFToken struct {
+	ArgSession string
+	ArgToken   string
+	Error      error
+}
+
+func TestUsecaseGetCSRFToken(t *testing.T) {
+	mockAuthRepo := authMocks.NewRepositoryI(t)
+	mockUserRepo := userMocks.NewRepositoryI(t)
+
+	mockAuthRepo.On("GetCSRFToken", mock.Anything, "existed").Return("token", nil)
+	mockAuthRepo.On("GetCSRFToken", mock.Anything, "new").Return("", models.ErrNotFound)
+	mockAuthRepo.On("SetCSRFToken", mock.Anything, "new", mock.AnythingOfType("string")).Return(nil)
+
+	useCase := authUsecase.New(mockUserRepo, mockAuthRepo)
+
+	token, err := useCase.GetCSRFToken(context.Background(), "existed")
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+
+	token, err = useCase.GetCSRFToken(context.Background(), "new")
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+}
+
+func TestUsecaseCheckCSRFToken(t *testing.T) {
+	mockAuthRepo := authMocks.NewRepositoryI(t)
+	mockUserRepo := userMocks.NewRepositoryI(t)
+
+	mockAuthRepo.On("GetCSRFToken", mock.Anything, "session").Return("token", nil)
+	mockAuthRepo.On("GetCSRFToken", mock.Anything, "no_token").Return("", models.ErrNotFound)
+
+	useCase := authUsecase.New(mockUserRepo, mockAuthRepo)
+
+	cases := map[string]TestCaseCheckCSRFToken{
+		"success": {
+			ArgSession: "session",
+			ArgToken:   "token",
+			Error:      nil,
+		},
+		"invalid_token": {
+			ArgSession: "session",
+			ArgToken:   "other",
+			Error:      models.ErrInvalidCSRF,
+		},
+		"empty_token": {
+			ArgSession: "session",
+			ArgToken:   "",
+			Error:      models.ErrInvalidCSRF,
+		},
+		"token_not_issued": {
+			ArgSession: "no_token",
+			ArgToken:   "token",
+			Error:      models.ErrInvalidCSRF,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := useCase.CheckCSRFToken(context.Background(), test.ArgSession, test.ArgToken)
+			require.Equal(t, test.Error, errors.Cause(err))
+		})
+	}
+}
+
+type TestCaseResetPassword struct {
+	ArgToken string
+	Error    error
+}
+
+func TestUsecaseResetPassword(t *testing.T) {
+	mockAuthRepo := authMocks.NewRepositoryI(t)
+	mockUserRepo := userMocks.NewRepositoryI(t)
+
+	mockUserRepo.On("ResetPassword", mock.Anything, pkg.HashToken("token"), mock.AnythingOfType("string")).Return(uint64(1), nil)
+	mockUserRepo.On("ResetPassword", mock.Anything, pkg.HashToken("expired"), mock.AnythingOfType("string")).Return(uint64(0), models.ErrNotFound)
+
+	useCase := authUsecase.New(mockUserRepo, mockAuthRepo)
+
+	cases := map[string]TestCaseResetPassword{
+		"success": {
+			ArgToken: "token",
+			Error:    nil,
+		},
+		"expired_token": {
+			ArgToken: "expired",
+			Error:    models.ErrNotFound,
+		},
+		"empty_token": {
+			ArgToken: "",
+			Error:    models.ErrNotFound,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			userID, err := useCase.ResetPassword(context.Background(), test.ArgToken, "new_password")
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, uint64(1), userID)
+			}
+		})
+	}
+}
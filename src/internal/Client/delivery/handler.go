@@ -0,0 +1,293 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+	clientUsecase "timetracker/internal/Client/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Delivery struct {
+	ClientUC clientUsecase.UsecaseI
+}
+
+// CreateClient godoc
+// @Summary      Create client
+// @Description  Create a client, projects can be done for it
+// @Tags     	 client
+// @Accept	 application/json
+// @Produce  application/json
+// @Param    client body dto.ReqCreateClient true "client info"
+// @Success  200 {object} pkg.Response{body=dto.RespClient} "the created client"
+// @Header   200 {string} ETag "version of the client"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Router   /api/v1/clients [post]
+func (delivery *Delivery) CreateClient(c echo.Context) error {
+	var reqClient dto.ReqCreateClient
+	err := c.Bind(&reqClient)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	if ok, err := pkg.IsRequestValid(&reqClient); !ok {
+		c.Logger().Error(err)
+		return apierror.Validation(err)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	client := reqClient.ToModelClient()
+	client.UserID = userId
+	err = delivery.ClientUC.CreateClient(c.Request().Context(), client)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelClient(client))
+	respClient := dto.GetResponseFromModelClient(client)
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respClient})
+}
+
+// GetClient godoc
+// @Summary      Get client
+// @Description  Get client by id. Acl: owner
+// @Tags     	 client
+// @Produce  application/json
+// @Param id  path int  true  "Client ID"
+// @Param    If-None-Match header string false "ETag of the cached client"
+// @Success  200 {object} pkg.Response{body=dto.RespClient} "success get client"
+// @Header   200 {string} ETag "version of the client"
+// @Success  304 "the cached client is up to date"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Router   /api/v1/clients/{id} [get]
+func (delivery *Delivery) GetClient(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	client, err := delivery.ClientUC.GetClient(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	if middleware.NotModified(c, dto.GetETagFromModelClient(client)) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	respClient := dto.GetResponseFromModelClient(client)
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respClient})
+}
+
+// UpdateClient godoc
+// @Summary      Update a client
+// @Description  Update a client. Only the fields present in the body are changed. Acl: owner
+// @Tags     	 client
+// @Accept	 application/json
+// @Produce  application/json
+// @Param    id path int true "Client ID"
+// @Param    If-Match header string true "ETag of the client the changes are based on"
+// @Param    client body dto.ReqPatchClient true "client info"
+// @Success  200 {object} pkg.Response{body=dto.RespClient} "success update client"
+// @Header   200 {string} ETag "version of the updated client"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Failure 412 {object} apierror.Error "version_mismatch: the client was changed since the If-Match version"
+// @Failure 428 {object} apierror.Error "if_match_required"
+// @Router   /api/v1/clients/{id} [patch]
+func (delivery *Delivery) UpdateClient(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	var reqClient dto.ReqPatchClient
+	err = c.Bind(&reqClient)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	if ok, err := pkg.IsRequestValid(&reqClient); !ok {
+		c.Logger().Error(err)
+		return apierror.Validation(err)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	version, err := middleware.IfMatch(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	patch := reqClient.ToModelClientPatch()
+	patch.ID = id
+	patch.UserID = userId
+	patch.Version = version
+	client, err := delivery.ClientUC.UpdateClient(c.Request().Context(), patch)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelClient(client))
+	respClient := dto.GetResponseFromModelClient(client)
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respClient})
+}
+
+// DeleteClient godoc
+// @Summary      Delete a client
+// @Description  Delete a client for good, its projects stay without a client. Acl: owner
+// @Tags     	 client
+// @Param id path int  true  "Client ID"
+// @Success  204
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Router   /api/v1/clients/{id} [delete]
+func (delivery *Delivery) DeleteClient(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	err = delivery.ClientUC.DeleteClient(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetClientProjects godoc
+// @Summary      Get client projects
+// @Description  Get the projects done for the client. Acl: owner
+// @Tags     	 client
+// @Produce  application/json
+// @Param id path int  true  "Client ID"
+// @Success  200 {object} pkg.Response{body=[]dto.RespProject} "success get projects"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Router   /api/v1/clients/{id}/projects [get]
+func (delivery *Delivery) GetClientProjects(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	projects, err := delivery.ClientUC.GetClientProjects(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseFromModelProjects(projects)})
+}
+
+// GetMyClients godoc
+// @Summary      Get my clients
+// @Description  Get my clients sorted by name
+// @Tags     client
+// @Produce  application/json
+// @Success  200 {object} pkg.Response{body=[]dto.RespClient} "success get clients"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/me/clients [get]
+func (delivery *Delivery) GetMyClients(c echo.Context) error {
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	clients, err := delivery.ClientUC.GetUserClients(c.Request().Context(), userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseFromModelClients(clients)})
+}
+
+func NewDelivery(e *echo.Echo, cu clientUsecase.UsecaseI) {
+	handler := &Delivery{
+		ClientUC: cu,
+	}
+
+	v1 := e.Group(middleware.APIv1)
+	v1.POST("/clients", handler.CreateClient)
+	v1.GET("/clients/:id", handler.GetClient)                                   // acl: owner
+	v1.PATCH("/clients/:id", handler.UpdateClient, middleware.RequireIfMatch()) // acl: owner
+	v1.DELETE("/clients/:id", handler.DeleteClient)                             // acl: owner
+	v1.GET("/clients/:id/projects", handler.GetClientProjects)                  // acl: owner
+	v1.GET("/me/clients", handler.GetMyClients)
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+	"timetracker/internal/Client/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+func copyClient(c *models.Client) *models.Client {
+	copied := *c
+	return &copied
+}
+
+type clientRepository struct {
+	db *memoryDB.DB
+}
+
+func (cr clientRepository) CreateClient(ctx context.Context, c *models.Client) error {
+	cr.db.Lock()
+	defer cr.db.Unlock()
+
+	c.Version, c.UpdatedAt = 1, time.Now().UTC()
+
+	if _, ok := cr.db.Users[c.UserID]; !ok {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table client)")
+	}
+
+	client := copyClient(c)
+	client.ID = cr.db.NextID("client")
+	cr.db.Clients[client.ID] = client
+
+	c.ID = client.ID
+	return nil
+}
+
+// UpdateClient writes the editable fields even when they are zero, like the postgres repository
+func (cr clientRepository) UpdateClient(ctx context.Context, c *models.Client) error {
+	cr.db.Lock()
+	defer cr.db.Unlock()
+
+	client, ok := cr.db.Clients[c.ID]
+	if !ok {
+		return models.ErrNotFound
+	}
+
+	if client.Version != c.Version {
+		return models.ErrVersionMismatch
+	}
+
+	client.Name = c.Name
+	client.Contact = c.Contact
+	client.HourlyRate = c.HourlyRate
+	client.Notes = c.Notes
+	client.Version++
+	client.UpdatedAt = time.Now().UTC()
+
+	c.Version, c.UpdatedAt = client.Version, client.UpdatedAt
+	return nil
+}
+
+func (cr clientRepository) GetClient(ctx context.Context, id uint64) (*models.Client, error) {
+	cr.db.RLock()
+	defer cr.db.RUnlock()
+
+	client, ok := cr.db.Clients[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	return copyClient(client), nil
+}
+
+// DeleteClient takes the client off its projects with a new version, like the postgres repository
+func (cr clientRepository) DeleteClient(ctx context.Context, id uint64) error {
+	cr.db.Lock()
+	defer cr.db.Unlock()
+
+	now := time.Now().UTC()
+	for _, project := range cr.db.Projects {
+		if project.ClientID != nil && *project.ClientID == id {
+			project.ClientID = nil
+			project.Version++
+			project.UpdatedAt = now
+		}
+	}
+
+	delete(cr.db.Clients, id)
+	return nil
+}
+
+func (cr clientRepository) GetUserClients(ctx context.Context, userID uint64) ([]*models.Client, error) {
+	cr.db.RLock()
+	defer cr.db.RUnlock()
+
+	clients := make([]*models.Client, 0, 10)
+	for _, client := range cr.db.Clients {
+		if client.UserID == userID {
+			clients = append(clients, copyClient(client))
+		}
+	}
+
+	sort.Slice(clients, func(i, j int) bool {
+		if clients[i].Name != clients[j].Name {
+			return clients[i].Name < clients[j].Name
+		}
+		return clients[i].ID < clients[j].ID
+	})
+
+	return clients, nil
+}
+
+func NewClientRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &clientRepository{
+		db: db,
+	}
+}
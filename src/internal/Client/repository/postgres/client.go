@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"time"
+	"timetracker/internal/Client/repository"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type Client struct {
+	ID         uint64    `gorm:"column:id"`
+	UserID     uint64    `gorm:"column:user_id"`
+	Name       string    `gorm:"column:name"`
+	Contact    string    `gorm:"column:contact"`
+	HourlyRate float64   `gorm:"column:hourly_rate"`
+	Notes      string    `gorm:"column:notes"`
+	Version    uint64    `gorm:"column:version"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+func (Client) TableName() string {
+	return "client"
+}
+
+func toPostgresClient(c *models.Client) *Client {
+	return &Client{
+		ID:         c.ID,
+		UserID:     c.UserID,
+		Name:       c.Name,
+		Contact:    c.Contact,
+		HourlyRate: c.HourlyRate,
+		Notes:      c.Notes,
+		Version:    c.Version,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func toModelClient(c *Client) *models.Client {
+	return &models.Client{
+		ID:         c.ID,
+		UserID:     c.UserID,
+		Name:       c.Name,
+		Contact:    c.Contact,
+		HourlyRate: c.HourlyRate,
+		Notes:      c.Notes,
+		Version:    c.Version,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func toModelClients(clients []*Client) []*models.Client {
+	out := make([]*models.Client, len(clients))
+
+	for i, b := range clients {
+		out[i] = toModelClient(b)
+	}
+
+	return out
+}
+
+type clientRepository struct {
+	db *gorm.DB
+}
+
+func (cr clientRepository) CreateClient(ctx context.Context, c *models.Client) error {
+	c.Version, c.UpdatedAt = 1, time.Now().UTC()
+	postgresClient := toPostgresClient(c)
+
+	tx := cr.db.WithContext(ctx).Create(postgresClient)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table client)")
+	}
+
+	c.ID = postgresClient.ID
+	return nil
+}
+
+// updateColumns are written even when they hold zero values, so a patch can clear them
+var updateColumns = []string{"name", "contact", "hourly_rate", "notes", "version", "updated_at"}
+
+// UpdateClient only writes the row if it still has the version c was read with,
+// then c gets the next version
+func (cr clientRepository) UpdateClient(ctx context.Context, c *models.Client) error {
+	postgresClient := toPostgresClient(c)
+	postgresClient.Version++
+	postgresClient.UpdatedAt = time.Now().UTC()
+
+	tx := cr.db.WithContext(ctx).Where("version = ?", c.Version).Select(updateColumns).Updates(postgresClient)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table client)")
+	}
+
+	if tx.RowsAffected == 0 {
+		return models.ErrVersionMismatch
+	}
+
+	c.Version, c.UpdatedAt = postgresClient.Version, postgresClient.UpdatedAt
+	return nil
+}
+
+func (cr clientRepository) GetClient(ctx context.Context, id uint64) (*models.Client, error) {
+	var client Client
+
+	tx := cr.db.WithContext(ctx).Where("id = ?", id).Take(&client)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table client)")
+	}
+
+	return toModelClient(&client), nil
+}
+
+// DeleteClient removes the client for good. Its projects lose the client with a new
+// version, so the change reaches the synced devices like any other update.
+func (cr clientRepository) DeleteClient(ctx context.Context, id uint64) error {
+	err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table("project").Where("client_id = ?", id).Updates(map[string]interface{}{
+			"client_id":  nil,
+			"updated_at": time.Now().UTC(),
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&Client{}, id).Error
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "database error (table client)")
+	}
+
+	return nil
+}
+
+func (cr clientRepository) GetUserClients(ctx context.Context, userID uint64) ([]*models.Client, error) {
+	clients := make([]*Client, 0, 10)
+
+	tx := cr.db.WithContext(ctx).Where("user_id = ?", userID).Order("name, id").Find(&clients)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table client)")
+	}
+
+	return toModelClients(clients), nil
+}
+
+func NewClientRepository(db *gorm.DB) repository.RepositoryI {
+	return &clientRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"timetracker/models"
+)
+
+type RepositoryI interface {
+	CreateClient(ctx context.Context, c *models.Client) error
+	UpdateClient(ctx context.Context, c *models.Client) error
+	GetClient(ctx context.Context, id uint64) (*models.Client, error)
+	DeleteClient(ctx context.Context, id uint64) error
+	GetUserClients(ctx context.Context, userID uint64) ([]*models.Client, error)
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	clientRep "timetracker/internal/Client/repository"
+	projectRep "timetracker/internal/Project/repository"
+	"timetracker/internal/tracing"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+type UsecaseI interface {
+	CreateClient(ctx context.Context, c *models.Client) error
+	UpdateClient(ctx context.Context, patch *models.ClientPatch) (*models.Client, error)
+	GetClient(ctx context.Context, id uint64, userID uint64) (*models.Client, error)
+	DeleteClient(ctx context.Context, id uint64, userID uint64) error
+	GetUserClients(ctx context.Context, userID uint64) ([]*models.Client, error)
+	GetClientProjects(ctx context.Context, id uint64, userID uint64) ([]*models.Project, error)
+}
+
+type usecase struct {
+	clientRepository  clientRep.RepositoryI
+	projectRepository projectRep.RepositoryI
+}
+
+func New(cRep clientRep.RepositoryI, pRep projectRep.RepositoryI) UsecaseI {
+	return &usecase{
+		clientRepository:  cRep,
+		projectRepository: pRep,
+	}
+}
+
+func (u *usecase) CreateClient(ctx context.Context, c *models.Client) error {
+	ctx, span := tracing.Start(ctx, "client.Usecase.CreateClient")
+	defer span.End()
+
+	err := u.clientRepository.CreateClient(ctx, c)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func client.Usecase.CreateClient")
+	}
+
+	return nil
+}
+
+func (u *usecase) UpdateClient(ctx context.Context, patch *models.ClientPatch) (*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "client.Usecase.UpdateClient")
+	defer span.End()
+
+	client, err := u.GetClient(ctx, patch.ID, patch.UserID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func client.Usecase.UpdateClient")
+	}
+
+	if patch.Version != 0 && patch.Version != client.Version {
+		return nil, models.ErrVersionMismatch
+	}
+
+	patch.Apply(client)
+	err = u.clientRepository.UpdateClient(ctx, client)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func client.Usecase.UpdateClient")
+	}
+
+	return client, nil
+}
+
+// GetClient only returns the clients of the user, they are not shared with anybody
+func (u *usecase) GetClient(ctx context.Context, id uint64, userID uint64) (*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "client.Usecase.GetClient")
+	defer span.End()
+
+	client, err := u.clientRepository.GetClient(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func client.Usecase.GetClient")
+	}
+
+	if client.UserID != userID {
+		return nil, models.ErrPermissionDenied
+	}
+
+	return client, nil
+}
+
+// DeleteClient removes the client, its projects stay without a client
+func (u *usecase) DeleteClient(ctx context.Context, id uint64, userID uint64) error {
+	ctx, span := tracing.Start(ctx, "client.Usecase.DeleteClient")
+	defer span.End()
+
+	_, err := u.GetClient(ctx, id, userID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func client.Usecase.DeleteClient")
+	}
+
+	err = u.clientRepository.DeleteClient(ctx, id)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func client.Usecase.DeleteClient")
+	}
+
+	return nil
+}
+
+func (u *usecase) GetUserClients(ctx context.Context, userID uint64) ([]*models.Client, error) {
+	ctx, span := tracing.Start(ctx, "client.Usecase.GetUserClients")
+	defer span.End()
+
+	clients, err := u.clientRepository.GetUserClients(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func client.Usecase.GetUserClients")
+	}
+
+	return clients, nil
+}
+
+// GetClientProjects returns the projects of the user done for the client
+func (u *usecase) GetClientProjects(ctx context.Context, id uint64, userID uint64) ([]*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "client.Usecase.GetClientProjects")
+	defer span.End()
+
+	_, err := u.GetClient(ctx, id, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func client.Usecase.GetClientProjects")
+	}
+
+	projects, err := u.projectRepository.GetClientProjects(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func client.Usecase.GetClientProjects")
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,175 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	clientMocks "timetracker/internal/Client/repository/mocks"
+	"timetracker/internal/Client/usecase"
+	projectMocks "timetracker/internal/Project/repository/mocks"
+	"timetracker/models"
+
+	"github.com/bxcodec/faker"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type TestCaseGetClient struct {
+	ArgData     []uint64
+	ExpectedRes *models.Client
+	Error       error
+}
+
+type TestCaseUpdateClient struct {
+	ArgData     *models.ClientPatch
+	ExpectedRes *models.Client
+	Error       error
+}
+
+type TestCaseDeleteClient struct {
+	ArgData []uint64
+	Error   error
+}
+
+func TestUsecaseGetClient(t *testing.T) {
+	var mockClient models.Client
+	err := faker.FakeData(&mockClient)
+	assert.NoError(t, err)
+
+	mockClientRepo := clientMocks.NewRepositoryI(t)
+
+	mockClientRepo.On("GetClient", mock.Anything, mockClient.ID).Return(&mockClient, nil)
+	mockClientRepo.On("GetClient", mock.Anything, mockClient.ID+1).Return(nil, models.ErrNotFound)
+
+	useCase := usecase.New(mockClientRepo, nil)
+
+	cases := map[string]TestCaseGetClient{
+		"success": {
+			ArgData:     []uint64{mockClient.ID, mockClient.UserID},
+			ExpectedRes: &mockClient,
+			Error:       nil,
+		},
+		"Client not found": {
+			ArgData: []uint64{mockClient.ID + 1, mockClient.UserID},
+			Error:   models.ErrNotFound,
+		},
+		"Client of another user": {
+			ArgData: []uint64{mockClient.ID, mockClient.UserID + 1},
+			Error:   models.ErrPermissionDenied,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, err := useCase.GetClient(context.Background(), test.ArgData[0], test.ArgData[1])
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, test.ExpectedRes, client)
+			}
+		})
+	}
+}
+
+func TestUsecaseUpdateClient(t *testing.T) {
+	var mockClient models.Client
+	err := faker.FakeData(&mockClient)
+	assert.NoError(t, err)
+
+	contact, rate := "", 45.5
+	patch := &models.ClientPatch{ID: mockClient.ID, UserID: mockClient.UserID, Contact: &contact, HourlyRate: &rate}
+
+	expectedClient := mockClient
+	expectedClient.Contact = ""
+	expectedClient.HourlyRate = rate
+
+	mockClientRepo := clientMocks.NewRepositoryI(t)
+
+	mockClientRepo.On("GetClient", mock.Anything, mockClient.ID).Return(func(context.Context, uint64) *models.Client {
+		client := mockClient
+		return &client
+	}, nil)
+	mockClientRepo.On("UpdateClient", mock.Anything, &expectedClient).Return(nil).Once()
+
+	useCase := usecase.New(mockClientRepo, nil)
+
+	cases := map[string]TestCaseUpdateClient{
+		"success clears fields": {
+			ArgData:     patch,
+			ExpectedRes: &expectedClient,
+			Error:       nil,
+		},
+		"Permission denied": {
+			ArgData: &models.ClientPatch{ID: mockClient.ID, UserID: mockClient.UserID + 1, HourlyRate: &rate},
+			Error:   models.ErrPermissionDenied,
+		},
+		"Version mismatch": {
+			ArgData: &models.ClientPatch{ID: mockClient.ID, UserID: mockClient.UserID, Version: mockClient.Version + 1},
+			Error:   models.ErrVersionMismatch,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, err := useCase.UpdateClient(context.Background(), test.ArgData)
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, test.ExpectedRes, client)
+			}
+		})
+	}
+}
+
+func TestUsecaseDeleteClient(t *testing.T) {
+	var mockClient models.Client
+	err := faker.FakeData(&mockClient)
+	assert.NoError(t, err)
+
+	mockClientRepo := clientMocks.NewRepositoryI(t)
+
+	mockClientRepo.On("GetClient", mock.Anything, mockClient.ID).Return(&mockClient, nil)
+	mockClientRepo.On("DeleteClient", mock.Anything, mockClient.ID).Return(nil).Once()
+
+	useCase := usecase.New(mockClientRepo, nil)
+
+	cases := map[string]TestCaseDeleteClient{
+		"Permission denied": {
+			ArgData: []uint64{mockClient.ID, mockClient.UserID + 1},
+			Error:   models.ErrPermissionDenied,
+		},
+		"success": {
+			ArgData: []uint64{mockClient.ID, mockClient.UserID},
+			Error:   nil,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := useCase.DeleteClient(context.Background(), test.ArgData[0], test.ArgData[1])
+			require.Equal(t, test.Error, errors.Cause(err))
+		})
+	}
+}
+
+func TestUsecaseGetClientProjects(t *testing.T) {
+	userID, otherID := uint64(1), uint64(2)
+	client := &models.Client{ID: 3, UserID: userID}
+	projects := []*models.Project{{ID: 4, UserID: &userID, ClientID: &client.ID}}
+
+	mockClientRepo := clientMocks.NewRepositoryI(t)
+	mockProjectRepo := projectMocks.NewRepositoryI(t)
+
+	mockClientRepo.On("GetClient", mock.Anything, client.ID).Return(client, nil)
+	mockProjectRepo.On("GetClientProjects", mock.Anything, client.ID).Return(projects, nil).Once()
+
+	useCase := usecase.New(mockClientRepo, mockProjectRepo)
+
+	res, err := useCase.GetClientProjects(context.Background(), client.ID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, projects, res)
+
+	_, err = useCase.GetClientProjects(context.Background(), client.ID, otherID)
+	assert.Equal(t, models.ErrPermissionDenied, errors.Cause(err))
+}
@@ -5,34 +5,20 @@ import (
 	"strconv"
 	"time"
 	entryUsecase "timetracker/internal/Entry/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/metrics"
 	"timetracker/internal/middleware"
 	"timetracker/models"
 	"timetracker/models/dto"
 	"timetracker/pkg"
 
 	"github.com/labstack/echo/v4"
-	"github.com/pkg/errors"
 )
 
 type Delivery struct {
 	EntryUC entryUsecase.UsecaseI
 }
 
-func (del *Delivery) ownerOrAdminValidate(c echo.Context, entry *models.Entry) error {
-	user, ok := c.Get("user").(*models.User)
-
-	if !ok {
-		c.Logger().Error("can't get user from context")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
-	}
-
-	if user.Role == models.Admin.String() || *entry.UserID == user.ID {
-		return nil
-	}
-
-	return models.ErrPermissionDenied
-}
-
 // CreateEntry godoc
 // @Summary      Create entry. Acl: all
 // @Description  Create entry
@@ -41,43 +27,46 @@ func (del *Delivery) ownerOrAdminValidate(c echo.Context, entry *models.Entry) e
 // @Produce  application/json
 // @Param    entry body dto.ReqCreateUpdateEntry true "entry info"
 // @Success  200 {object} pkg.Response{body=dto.RespEntry} "success update entry"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 422 {object} echo.HTTPError "unprocessable entity"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 400 {object} echo.HTTPError "bad req"
-// @Failure 403 {object} echo.HTTPError "invalid csrf or permission denied"
-// @Router   /entry/create [post]
+// @Header   200 {string} ETag "version of the entry"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 409 {object} apierror.Error "project_archived or project_deleted: the project takes no new items"
+// @Router   /api/v1/entries [post]
 func (delivery *Delivery) CreateEntry(c echo.Context) error {
 	var reqEntry dto.ReqCreateUpdateEntry
 	err := c.Bind(&reqEntry)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		return apierror.Bind(err)
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqEntry); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.Validation(err)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
 	entry := reqEntry.ToModelEntry()
 	entry.UserID = &userId
-	err = delivery.EntryUC.CreateEntry(entry)
+	err = delivery.EntryUC.CreateEntry(c.Request().Context(), entry)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
+	metrics.CountEntryCreated(c)
+
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelEntry(entry))
 	respEntry := dto.GetResponseFromModelEntry(entry)
 
 	return c.JSON(http.StatusOK, pkg.Response{Body: *respEntry})
@@ -85,35 +74,42 @@ func (delivery *Delivery) CreateEntry(c echo.Context) error {
 
 // GetEntry godoc
 // @Summary      Show a post
-// @Description  Get entry by id. Acl: owner or admin
+// @Description  Get entry by id. Acl: owner, entry:read:any
 // @Tags     	 entry
 // @Accept	 application/json
 // @Produce  application/json
 // @Param id  path int  true  "Entry ID"
+// @Param    If-None-Match header string false "ETag of the cached entry"
 // @Success  200 {object} pkg.Response{body=dto.RespEntry} "success get entry"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /entry/{id} [get]
+// @Header   200 {string} ETag "version of the entry"
+// @Success  304 "the cached entry is up to date"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/entries/{id} [get]
 func (delivery *Delivery) GetEntry(c echo.Context) error {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
 	}
-	entry, err := delivery.EntryUC.GetEntry(id)
+	entry, err := delivery.EntryUC.GetEntry(c.Request().Context(), id)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
-	err = delivery.ownerOrAdminValidate(c, entry)
+	err = middleware.AuthorizeResource(c, *entry.UserID, models.PermEntryReadAny)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
+	}
+
+	if middleware.NotModified(c, dto.GetETagFromModelEntry(entry)) {
+		return c.NoContent(http.StatusNotModified)
 	}
 
 	respEntry := dto.GetResponseFromModelEntry(entry)
@@ -122,49 +118,72 @@ func (delivery *Delivery) GetEntry(c echo.Context) error {
 
 // UpdateEntry godoc
 // @Summary      Update an entry
-// @Description  Update an entry. Acl: owner only
+// @Description  Update an entry. Only the fields present in the body are changed: a null project_id removes the project and an empty tag_list removes all tags. Acl: owner only
 // @Tags     	 entry
 // @Accept	 application/json
 // @Produce  application/json
-// @Param    entry body dto.ReqCreateUpdateEntry true "entry info"
+// @Param    id path int true "Entry ID"
+// @Param    If-Match header string true "ETag of the entry the changes are based on, required by the legacy POST /entry/edit too"
+// @Param    entry body dto.ReqPatchEntry true "entry info"
 // @Success  200 {object} pkg.Response{body=dto.RespEntry} "success update entry"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 422 {object} echo.HTTPError "unprocessable entity"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 403 {object} echo.HTTPError "invalid csrf or permission denied"
-// @Router   /entry/edit [post]
+// @Header   200 {string} ETag "version of the updated entry"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 412 {object} apierror.Error "version_mismatch: the entry was changed since the If-Match version"
+// @Failure 428 {object} apierror.Error "if_match_required"
+// @Failure 409 {object} apierror.Error "project_archived or project_deleted: the project takes no new items"
+// @Router   /api/v1/entries/{id} [patch]
 func (delivery *Delivery) UpdateEntry(c echo.Context) error {
 
-	var reqEntry dto.ReqCreateUpdateEntry
+	var reqEntry dto.ReqPatchEntry
 	err := c.Bind(&reqEntry)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+		return apierror.Bind(err)
+	}
+
+	// the ID is in the path of PATCH /api/v1/entries/:id and in the body of the legacy route
+	if param := c.Param("id"); param != "" {
+		id, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			c.Logger().Error(err)
+			return apierror.From(models.ErrBadRequest)
+		}
+		reqEntry.ID = id
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqEntry); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.Validation(err)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	entry := reqEntry.ToModelEntry()
-	entry.UserID = &userId
-	err = delivery.EntryUC.UpdateEntry(entry)
+	version, err := middleware.IfMatch(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	patch := reqEntry.ToModelEntryPatch()
+	patch.UserID = userId
+	patch.Version = version
+	entry, err := delivery.EntryUC.UpdateEntry(c.Request().Context(), patch)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelEntry(entry))
 	respEntry := dto.GetResponseFromModelEntry(entry)
 
 	return c.JSON(http.StatusOK, pkg.Response{Body: *respEntry})
@@ -172,80 +191,126 @@ func (delivery *Delivery) UpdateEntry(c echo.Context) error {
 
 // DeleteEntry godoc
 // @Summary      Delete an entry
-// @Description  Delete an entry. Acl: owner only
+// @Description  Delete an entry, it stays in the trash until it is restored or purged. Acl: owner only
 // @Tags     	 entry
 // @Accept	 application/json
 // @Param id path int  true  "Entry ID"
 // @Success  204
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 404 {object} echo.HTTPError "can't find entry with such id"
-// @Failure 403 {object} echo.HTTPError "invalid csrf"
-// @Router   /entry/{id} [delete]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found: can't find entry with such id"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Router   /api/v1/entries/{id} [delete]
 func (delivery *Delivery) DeleteEntry(c echo.Context) error {
 
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
+		return apierror.From(models.ErrNotFound)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	err = delivery.EntryUC.DeleteEntry(id, userId)
+	err = delivery.EntryUC.DeleteEntry(c.Request().Context(), id, userId)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// RestoreEntry godoc
+// @Summary      Restore an entry
+// @Description  Restore an entry from the trash with its tags. An entry of a deleted project is restored with the project. Acl: owner only
+// @Tags     	 entry
+// @Produce  application/json
+// @Param id path int  true  "Entry ID"
+// @Success  200 {object} pkg.Response{body=dto.RespEntry} "the restored entry"
+// @Header   200 {string} ETag "version of the restored entry"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found: no entry with such id in the trash"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 409 {object} apierror.Error "project_deleted: restore the project first"
+// @Router   /api/v1/entries/{id}/restore [post]
+func (delivery *Delivery) RestoreEntry(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	entry, err := delivery.EntryUC.RestoreEntry(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelEntry(entry))
+	respEntry := dto.GetResponseFromModelEntry(entry)
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respEntry})
+}
+
 // GetMyEntries godoc
 // @Summary      Get my entries. Acl: all
-// @Description  Get my entries or get my entries for a day
+// @Description  Get my entries or get my entries for a day, of a client
 // @Tags     entry
 // @Produce  application/json
 // @Param        day    query     string  false  "day for events"
+// @Param        client_id    query     int  false  "only the entries on the projects of the client"
 // @Success  200 {object} pkg.Response{body=[]dto.RespEntry} "success get entries"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /me/entries [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied: the client is not one of the user's"
+// @Failure 404 {object} apierror.Error "not_found: can't find client with such id"
+// @Router   /api/v1/me/entries [get]
 func (delivery *Delivery) GetMyEntries(c echo.Context) error {
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
 	day := c.QueryParam("day")
 	var entries []*models.Entry
 	var err error
 
-	if day == "" {
-		entries, err = delivery.EntryUC.GetUserEntries(userId)
+	if c.QueryParam("client_id") != "" {
+		entries, err = delivery.getClientEntries(c, userId, day)
+	} else if day == "" {
+		entries, err = delivery.EntryUC.GetUserEntries(c.Request().Context(), userId)
 	} else {
 		date, err := time.Parse("2006-01-02", day)
 
 		if err != nil {
 			c.Logger().Error("invalid data format, should be YYYY-MM-DD")
-			return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+			return apierror.From(models.ErrInternalServerError)
 		}
 
-		entries, err = delivery.EntryUC.GetUserEntriesForDay(userId, date)
+		entries, err = delivery.EntryUC.GetUserEntriesForDay(c.Request().Context(), userId, date)
 	}
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respEnties := dto.GetResponseFromModelEntries(entries)
@@ -254,44 +319,49 @@ func (delivery *Delivery) GetMyEntries(c echo.Context) error {
 }
 
 // GetUserEntries godoc
-// @Summary      Get user entries. Acl: admin, friends
-// @Description  Get user entries or get user entries for a day
+// @Summary      Get user entries. Acl: friends, entry:read:any
+// @Description  Get user entries or get user entries for a day, of a client
 // @Tags     entry
 // @Produce  application/json
 // @Param        day    query     string  false  "day for events"
+// @Param        client_id    query     int  false  "only the entries on the projects of the client"
 // @Success  200 {object} pkg.Response{body=[]dto.RespEntry} "success get entries"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /user/{user_id}/entries [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied: the client is not one of the user's"
+// @Failure 404 {object} apierror.Error "not_found: can't find client with such id"
+// @Router   /api/v1/users/{user_id}/entries [get]
 func (delivery *Delivery) GetUserEntries(c echo.Context) error {
 	userId, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
 	}
 
 	day := c.QueryParam("day")
 	var entries []*models.Entry
 
-	if day == "" {
-		entries, err = delivery.EntryUC.GetUserEntries(userId)
+	if c.QueryParam("client_id") != "" {
+		entries, err = delivery.getClientEntries(c, userId, day)
+	} else if day == "" {
+		entries, err = delivery.EntryUC.GetUserEntries(c.Request().Context(), userId)
 	} else {
 		date, err := time.Parse("2006-01-02", day)
 
 		if err != nil {
 			c.Logger().Error("invalid data format, should be YYYY-MM-DD")
-			return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+			return apierror.From(models.ErrInternalServerError)
 		}
 
-		entries, err = delivery.EntryUC.GetUserEntriesForDay(userId, date)
+		entries, err = delivery.EntryUC.GetUserEntriesForDay(c.Request().Context(), userId, date)
 	}
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respEnties := dto.GetResponseFromModelEntries(entries)
@@ -299,18 +369,102 @@ func (delivery *Delivery) GetUserEntries(c echo.Context) error {
 	return c.JSON(http.StatusOK, pkg.Response{Body: respEnties})
 }
 
-func handleError(err error) *echo.HTTPError {
-	causeErr := errors.Cause(err)
-	switch {
-	case errors.Is(causeErr, models.ErrNotFound):
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
-	case errors.Is(causeErr, models.ErrBadRequest):
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-	case errors.Is(causeErr, models.ErrPermissionDenied):
-		return echo.NewHTTPError(http.StatusForbidden, models.ErrPermissionDenied.Error())
+// getClientEntries lists the entries of the client_id query parameter, only the ones of the day if there is one
+func (delivery *Delivery) getClientEntries(c echo.Context, userId uint64, day string) ([]*models.Entry, error) {
+	clientID, err := strconv.ParseUint(c.QueryParam("client_id"), 10, 64)
+	if err != nil {
+		return nil, models.ErrBadRequest
+	}
+
+	var since, until time.Time
+	if day != "" {
+		since, err = time.Parse("2006-01-02", day)
+		if err != nil {
+			return nil, models.ErrBadRequest
+		}
+		until = since.AddDate(0, 0, 1)
+	}
+
+	return delivery.EntryUC.GetUserClientEntries(c.Request().Context(), userId, clientID, since, until)
+}
+
+// GetMyReport godoc
+// @Summary      Get my report
+// @Description  Sum the hours of my entries by project or by client. The row without a project_id or
+// @Description  a client_id holds the entries without one. since and until are days, both included.
+// @Tags     entry
+// @Produce  application/json
+// @Param        group_by    query     string  false  "project (default) or client"
+// @Param        since    query     string  false  "first day of the report, YYYY-MM-DD"
+// @Param        until    query     string  false  "last day of the report, YYYY-MM-DD"
+// @Param        client_id    query     int  false  "only the entries on the projects of the client"
+// @Success  200 {object} pkg.Response{body=[]dto.RespReportRow} "the hours of every group"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied: the client is not one of the user's"
+// @Failure 404 {object} apierror.Error "not_found: can't find client with such id"
+// @Router   /api/v1/me/report [get]
+func (delivery *Delivery) GetMyReport(c echo.Context) error {
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	filter, err := parseReportFilter(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	report, err := delivery.EntryUC.GetUserReport(c.Request().Context(), userId, filter)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseFromModelReport(report)})
+}
+
+func parseReportFilter(c echo.Context) (*models.ReportFilter, error) {
+	filter := &models.ReportFilter{GroupBy: models.ReportGroup(c.QueryParam("group_by"))}
+
+	switch filter.GroupBy {
+	case "":
+		filter.GroupBy = models.ReportByProject
+	case models.ReportByProject, models.ReportByClient:
 	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, causeErr.Error())
+		return nil, models.ErrBadRequest
 	}
+
+	if since := c.QueryParam("since"); since != "" {
+		date, err := time.Parse("2006-01-02", since)
+		if err != nil {
+			return nil, models.ErrBadRequest
+		}
+		filter.Since = date
+	}
+
+	if until := c.QueryParam("until"); until != "" {
+		date, err := time.Parse("2006-01-02", until)
+		if err != nil {
+			return nil, models.ErrBadRequest
+		}
+		filter.Until = date.AddDate(0, 0, 1)
+	}
+
+	if param := c.QueryParam("client_id"); param != "" {
+		clientID, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return nil, models.ErrBadRequest
+		}
+		filter.ClientID = &clientID
+	}
+
+	return filter, nil
 }
 
 func NewDelivery(e *echo.Echo, eu entryUsecase.UsecaseI, aclM *middleware.AclMiddleware) {
@@ -318,10 +472,21 @@ func NewDelivery(e *echo.Echo, eu entryUsecase.UsecaseI, aclM *middleware.AclMid
 		EntryUC: eu,
 	}
 
-	e.POST("/entry/create", handler.CreateEntry)
-	e.POST("/entry/edit", handler.UpdateEntry)  // acl: owner
-	e.GET("/entry/:id", handler.GetEntry)       // acl: owner, admin
-	e.DELETE("/entry/:id", handler.DeleteEntry) // acl: owner
-	e.GET("/me/entries", handler.GetMyEntries)
-	e.GET("/user/:user_id/entries", handler.GetUserEntries, aclM.FriendsOrAdminOnly)
+	v1 := e.Group(middleware.APIv1)
+	v1.POST("/entries", handler.CreateEntry)
+	v1.GET("/entries/:id", handler.GetEntry)                                   // acl: owner, admin
+	v1.PATCH("/entries/:id", handler.UpdateEntry, middleware.RequireIfMatch()) // acl: owner
+	v1.DELETE("/entries/:id", handler.DeleteEntry)                             // acl: owner
+	v1.POST("/entries/:id/restore", handler.RestoreEntry)                      // acl: owner
+	v1.GET("/me/entries", handler.GetMyEntries)
+	v1.GET("/me/report", handler.GetMyReport)
+	v1.GET("/users/:user_id/entries", handler.GetUserEntries, aclM.FriendsOrPermission(models.PermEntryReadAny))
+
+	// the routes from before /api/v1
+	e.POST("/entry/create", handler.CreateEntry, middleware.Deprecated(middleware.APIv1+"/entries"))
+	e.POST("/entry/edit", handler.UpdateEntry, middleware.Deprecated(middleware.APIv1+"/entries/:id"), middleware.RequireIfMatch())
+	e.GET("/entry/:id", handler.GetEntry, middleware.Deprecated(middleware.APIv1+"/entries/:id"))
+	e.DELETE("/entry/:id", handler.DeleteEntry, middleware.Deprecated(middleware.APIv1+"/entries/:id"))
+	e.GET("/me/entries", handler.GetMyEntries, middleware.Deprecated(middleware.APIv1+"/me/entries"))
+	e.GET("/user/:user_id/entries", handler.GetUserEntries, middleware.Deprecated(middleware.APIv1+"/users/:user_id/entries"), aclM.FriendsOrPermission(models.PermEntryReadAny))
 }
@@ -0,0 +1,355 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+	"timetracker/internal/Entry/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+	"timetracker/pkg"
+
+	"github.com/pkg/errors"
+)
+
+func copyEntry(e *models.Entry) *models.Entry {
+	return &models.Entry{
+		ID:          e.ID,
+		UserID:      memoryDB.CopyID(e.UserID),
+		ProjectID:   memoryDB.CopyID(e.ProjectID),
+		TaskID:      memoryDB.CopyID(e.TaskID),
+		Description: e.Description,
+		TimeStart:   e.TimeStart,
+		TimeEnd:     e.TimeEnd,
+		Version:     e.Version,
+		UpdatedAt:   e.UpdatedAt,
+		DeletedAt:   memoryDB.CopyTime(e.DeletedAt),
+	}
+}
+
+type entryRepository struct {
+	db *memoryDB.DB
+}
+
+func (er *entryRepository) CreateEntry(ctx context.Context, e *models.Entry) error {
+	er.db.Lock()
+	defer er.db.Unlock()
+
+	e.Version, e.UpdatedAt = 1, time.Now().UTC()
+
+	if err := er.checkReferences(e); err != nil {
+		return err
+	}
+
+	entry := copyEntry(e)
+	entry.ID = er.db.NextID("entry")
+	er.db.Entries[entry.ID] = entry
+	er.db.AddProjectHours(entry, 1)
+
+	e.ID = entry.ID
+	return nil
+}
+
+// UpdateEntry writes the editable fields even when they are zero, like the postgres repository
+func (er *entryRepository) UpdateEntry(ctx context.Context, e *models.Entry) error {
+	er.db.Lock()
+	defer er.db.Unlock()
+
+	entry, ok := er.db.Entries[e.ID]
+	if !ok || entry.DeletedAt != nil {
+		return models.ErrNotFound
+	}
+
+	if entry.Version != e.Version {
+		return models.ErrVersionMismatch
+	}
+
+	if err := er.checkReferences(e); err != nil {
+		return err
+	}
+
+	// like the trigger, the project only changes with the hours of the entry
+	hoursChanged := !memoryDB.SameID(entry.ProjectID, e.ProjectID) ||
+		!entry.TimeStart.Equal(e.TimeStart) || !entry.TimeEnd.Equal(e.TimeEnd)
+	if hoursChanged {
+		er.db.AddProjectHours(entry, -1)
+	}
+
+	entry.ProjectID = memoryDB.CopyID(e.ProjectID)
+	entry.TaskID = memoryDB.CopyID(e.TaskID)
+	entry.Description = e.Description
+	entry.TimeStart = e.TimeStart
+	entry.TimeEnd = e.TimeEnd
+
+	if hoursChanged {
+		er.db.AddProjectHours(entry, 1)
+	}
+
+	entry.Version++
+	entry.UpdatedAt = time.Now().UTC()
+
+	e.Version, e.UpdatedAt = entry.Version, entry.UpdatedAt
+	return nil
+}
+
+func (er *entryRepository) checkReferences(e *models.Entry) error {
+	if e.UserID != nil {
+		if _, ok := er.db.Users[*e.UserID]; !ok {
+			return errors.Wrap(memoryDB.ErrConstraint, "database error (table entry)")
+		}
+	}
+
+	if e.ProjectID != nil {
+		if _, ok := er.db.Projects[*e.ProjectID]; !ok {
+			return errors.Wrap(memoryDB.ErrConstraint, "database error (table entry)")
+		}
+	}
+
+	if e.TaskID != nil {
+		if _, ok := er.db.Tasks[*e.TaskID]; !ok {
+			return errors.Wrap(memoryDB.ErrConstraint, "database error (table entry)")
+		}
+	}
+
+	return nil
+}
+
+func (er *entryRepository) GetEntry(ctx context.Context, id uint64) (*models.Entry, error) {
+	er.db.RLock()
+	defer er.db.RUnlock()
+
+	entry, ok := er.db.Entries[id]
+	if !ok || entry.DeletedAt != nil {
+		return nil, models.ErrNotFound
+	}
+
+	return copyEntry(entry), nil
+}
+
+func (er *entryRepository) DeleteEntry(ctx context.Context, id uint64) error {
+	er.db.Lock()
+	defer er.db.Unlock()
+
+	er.db.SoftDeleteEntry(id, time.Now().UTC())
+	return nil
+}
+
+func (er *entryRepository) GetUserEntries(ctx context.Context, userID uint64) ([]*models.Entry, error) {
+	return er.findEntries(func(entry *models.Entry) bool {
+		return *entry.UserID == userID && entry.DeletedAt == nil
+	}), nil
+}
+
+func (er *entryRepository) GetUserEntriesForDay(ctx context.Context, userID uint64, date time.Time) ([]*models.Entry, error) {
+	todayStart, todayEnd := pkg.GetDayInterval(date)
+
+	return er.findEntries(func(entry *models.Entry) bool {
+		return *entry.UserID == userID && entry.DeletedAt == nil && !entry.TimeStart.Before(todayStart) && !entry.TimeStart.After(todayEnd)
+	}), nil
+}
+
+func (er *entryRepository) GetUserEntryChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Entry, error) {
+	entries := er.findEntries(func(entry *models.Entry) bool {
+		return *entry.UserID == userID && entry.UpdatedAt.After(since) && !entry.UpdatedAt.After(until)
+	})
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].UpdatedAt.Before(entries[j].UpdatedAt)
+	})
+
+	return entries, nil
+}
+
+// clientProject is the project of the entry if it is done for the client and not deleted.
+// The caller must hold the lock.
+func (er *entryRepository) clientProject(entry *models.Entry) *models.Project {
+	if entry.ProjectID == nil {
+		return nil
+	}
+
+	project, ok := er.db.Projects[*entry.ProjectID]
+	if !ok || project.DeletedAt != nil || project.ClientID == nil {
+		return nil
+	}
+
+	return project
+}
+
+// inRange bounds the start of the entry to [since, until), a zero bound doesn't filter
+func inRange(entry *models.Entry, since, until time.Time) bool {
+	return (since.IsZero() || !entry.TimeStart.Before(since)) && (until.IsZero() || entry.TimeStart.Before(until))
+}
+
+func (er *entryRepository) GetUserClientEntries(ctx context.Context, userID uint64, clientID uint64, since, until time.Time) ([]*models.Entry, error) {
+	return er.findEntries(func(entry *models.Entry) bool {
+		if *entry.UserID != userID || entry.DeletedAt != nil || !inRange(entry, since, until) {
+			return false
+		}
+
+		project := er.clientProject(entry)
+		return project != nil && *project.ClientID == clientID
+	}), nil
+}
+
+// GetUserReport groups the entries like the GROUP BY of the postgres repository
+func (er *entryRepository) GetUserReport(ctx context.Context, userID uint64, filter *models.ReportFilter) ([]*models.ReportRow, error) {
+	er.db.RLock()
+	defer er.db.RUnlock()
+
+	rows := map[uint64]*models.ReportRow{}
+	var noGroup *models.ReportRow
+
+	for _, entry := range er.db.Entries {
+		if *entry.UserID != userID || entry.DeletedAt != nil || !inRange(entry, filter.Since, filter.Until) {
+			continue
+		}
+
+		var clientID *uint64
+		if project := er.clientProject(entry); project != nil {
+			clientID = project.ClientID
+		}
+
+		if filter.ClientID != nil && !memoryDB.SameID(clientID, filter.ClientID) {
+			continue
+		}
+
+		groupID := entry.ProjectID
+		if filter.GroupBy == models.ReportByClient {
+			groupID = clientID
+		}
+
+		row := noGroup
+		if groupID != nil {
+			row = rows[*groupID]
+		}
+
+		if row == nil {
+			row = &models.ReportRow{}
+			if filter.GroupBy == models.ReportByClient {
+				row.ClientID = memoryDB.CopyID(groupID)
+			} else {
+				row.ProjectID = memoryDB.CopyID(groupID)
+			}
+
+			if groupID == nil {
+				noGroup = row
+			} else {
+				rows[*groupID] = row
+			}
+		}
+
+		row.TotalCountHours += entry.TimeEnd.Sub(entry.TimeStart).Hours()
+		row.EntriesCount++
+	}
+
+	report := make([]*models.ReportRow, 0, len(rows)+1)
+	if noGroup != nil {
+		report = append(report, noGroup)
+	}
+
+	ids := make([]uint64, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		report = append(report, rows[id])
+	}
+
+	return report, nil
+}
+
+func (er *entryRepository) CountActiveEntries(ctx context.Context, now time.Time) (uint64, error) {
+	er.db.RLock()
+	defer er.db.RUnlock()
+
+	var count uint64
+	for _, entry := range er.db.Entries {
+		if entry.DeletedAt == nil && !entry.TimeStart.After(now) && entry.TimeEnd.After(now) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (er *entryRepository) GetDeletedEntry(ctx context.Context, id uint64) (*models.Entry, error) {
+	er.db.RLock()
+	defer er.db.RUnlock()
+
+	entry, ok := er.db.Entries[id]
+	if !ok || entry.DeletedAt == nil {
+		return nil, models.ErrNotFound
+	}
+
+	return copyEntry(entry), nil
+}
+
+func (er *entryRepository) GetUserDeletedEntries(ctx context.Context, userID uint64) ([]*models.Entry, error) {
+	entries := er.findEntries(func(entry *models.Entry) bool {
+		return *entry.UserID == userID && entry.DeletedAt != nil
+	})
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DeletedAt.After(*entries[j].DeletedAt)
+	})
+
+	return entries, nil
+}
+
+func (er *entryRepository) RestoreEntry(ctx context.Context, id uint64) error {
+	er.db.Lock()
+	defer er.db.Unlock()
+
+	entry, ok := er.db.Entries[id]
+	if !ok || entry.DeletedAt == nil {
+		return models.ErrNotFound
+	}
+
+	if er.db.ProjectDeleted(entry.ProjectID) {
+		return models.ErrProjectDeleted
+	}
+
+	er.db.RestoreEntry(id, time.Now().UTC())
+	return nil
+}
+
+func (er *entryRepository) PurgeDeletedEntries(ctx context.Context, before time.Time) (int64, error) {
+	er.db.Lock()
+	defer er.db.Unlock()
+
+	var purged int64
+	for id, entry := range er.db.Entries {
+		if entry.DeletedAt != nil && entry.DeletedAt.Before(before) {
+			er.db.DeleteEntry(id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+func (er *entryRepository) findEntries(match func(entry *models.Entry) bool) []*models.Entry {
+	er.db.RLock()
+	defer er.db.RUnlock()
+
+	entries := make([]*models.Entry, 0, 10)
+	for _, entry := range er.db.Entries {
+		if match(entry) {
+			entries = append(entries, copyEntry(entry))
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries
+}
+
+func NewEntryRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &entryRepository{
+		db: db,
+	}
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "timetracker/models"
 
 	mock "github.com/stretchr/testify/mock"
@@ -15,13 +17,37 @@ type RepositoryI struct {
 	mock.Mock
 }
 
-// CreateEntry provides a mock function with given fields: e
-func (_m *RepositoryI) CreateEntry(e *models.Entry) error {
-	ret := _m.Called(e)
+// CountActiveEntries provides a mock function with given fields: ctx, now
+func (_m *RepositoryI) CountActiveEntries(ctx context.Context, now time.Time) (uint64, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (uint64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) uint64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateEntry provides a mock function with given fields: ctx, e
+func (_m *RepositoryI) CreateEntry(ctx context.Context, e *models.Entry) error {
+	ret := _m.Called(ctx, e)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Entry) error); ok {
-		r0 = rf(e)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Entry) error); ok {
+		r0 = rf(ctx, e)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -29,13 +55,13 @@ func (_m *RepositoryI) CreateEntry(e *models.Entry) error {
 	return r0
 }
 
-// DeleteEntry provides a mock function with given fields: id
-func (_m *RepositoryI) DeleteEntry(id uint64) error {
-	ret := _m.Called(id)
+// DeleteEntry provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) DeleteEntry(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -43,25 +69,51 @@ func (_m *RepositoryI) DeleteEntry(id uint64) error {
 	return r0
 }
 
-// GetEntry provides a mock function with given fields: id
-func (_m *RepositoryI) GetEntry(id uint64) (*models.Entry, error) {
-	ret := _m.Called(id)
+// GetDeletedEntry provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) GetDeletedEntry(ctx context.Context, id uint64) (*models.Entry, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.Entry, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.Entry); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEntry provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) GetEntry(ctx context.Context, id uint64) (*models.Entry, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 *models.Entry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) (*models.Entry, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.Entry, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) *models.Entry); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.Entry); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Entry)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,25 +121,25 @@ func (_m *RepositoryI) GetEntry(id uint64) (*models.Entry, error) {
 	return r0, r1
 }
 
-// GetUserEntries provides a mock function with given fields: userID
-func (_m *RepositoryI) GetUserEntries(userID uint64) ([]*models.Entry, error) {
-	ret := _m.Called(userID)
+// GetUserClientEntries provides a mock function with given fields: ctx, userID, clientID, since, until
+func (_m *RepositoryI) GetUserClientEntries(ctx context.Context, userID uint64, clientID uint64, since time.Time, until time.Time) ([]*models.Entry, error) {
+	ret := _m.Called(ctx, userID, clientID, since, until)
 
 	var r0 []*models.Entry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) ([]*models.Entry, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, time.Time, time.Time) ([]*models.Entry, error)); ok {
+		return rf(ctx, userID, clientID, since, until)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) []*models.Entry); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, time.Time, time.Time) []*models.Entry); ok {
+		r0 = rf(ctx, userID, clientID, since, until)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Entry)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, clientID, since, until)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -95,25 +147,25 @@ func (_m *RepositoryI) GetUserEntries(userID uint64) ([]*models.Entry, error) {
 	return r0, r1
 }
 
-// GetUserEntriesForDay provides a mock function with given fields: userID, date
-func (_m *RepositoryI) GetUserEntriesForDay(userID uint64, date time.Time) ([]*models.Entry, error) {
-	ret := _m.Called(userID, date)
+// GetUserDeletedEntries provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetUserDeletedEntries(ctx context.Context, userID uint64) ([]*models.Entry, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []*models.Entry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64, time.Time) ([]*models.Entry, error)); ok {
-		return rf(userID, date)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Entry, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(uint64, time.Time) []*models.Entry); ok {
-		r0 = rf(userID, date)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Entry); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Entry)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64, time.Time) error); ok {
-		r1 = rf(userID, date)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -121,13 +173,157 @@ func (_m *RepositoryI) GetUserEntriesForDay(userID uint64, date time.Time) ([]*m
 	return r0, r1
 }
 
-// UpdateEntry provides a mock function with given fields: e
-func (_m *RepositoryI) UpdateEntry(e *models.Entry) error {
-	ret := _m.Called(e)
+// GetUserEntries provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetUserEntries(ctx context.Context, userID uint64) ([]*models.Entry, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Entry, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Entry); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserEntriesForDay provides a mock function with given fields: ctx, userID, date
+func (_m *RepositoryI) GetUserEntriesForDay(ctx context.Context, userID uint64, date time.Time) ([]*models.Entry, error) {
+	ret := _m.Called(ctx, userID, date)
+
+	var r0 []*models.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time) ([]*models.Entry, error)); ok {
+		return rf(ctx, userID, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time) []*models.Entry); ok {
+		r0 = rf(ctx, userID, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, time.Time) error); ok {
+		r1 = rf(ctx, userID, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserEntryChanges provides a mock function with given fields: ctx, userID, since, until
+func (_m *RepositoryI) GetUserEntryChanges(ctx context.Context, userID uint64, since time.Time, until time.Time) ([]*models.Entry, error) {
+	ret := _m.Called(ctx, userID, since, until)
+
+	var r0 []*models.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time, time.Time) ([]*models.Entry, error)); ok {
+		return rf(ctx, userID, since, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time, time.Time) []*models.Entry); ok {
+		r0 = rf(ctx, userID, since, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, since, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserReport provides a mock function with given fields: ctx, userID, filter
+func (_m *RepositoryI) GetUserReport(ctx context.Context, userID uint64, filter *models.ReportFilter) ([]*models.ReportRow, error) {
+	ret := _m.Called(ctx, userID, filter)
+
+	var r0 []*models.ReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *models.ReportFilter) ([]*models.ReportRow, error)); ok {
+		return rf(ctx, userID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *models.ReportFilter) []*models.ReportRow); ok {
+		r0 = rf(ctx, userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, *models.ReportFilter) error); ok {
+		r1 = rf(ctx, userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeletedEntries provides a mock function with given fields: ctx, before
+func (_m *RepositoryI) PurgeDeletedEntries(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreEntry provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) RestoreEntry(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateEntry provides a mock function with given fields: ctx, e
+func (_m *RepositoryI) UpdateEntry(ctx context.Context, e *models.Entry) error {
+	ret := _m.Called(ctx, e)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Entry) error); ok {
-		r0 = rf(e)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Entry) error); ok {
+		r0 = rf(ctx, e)
 	} else {
 		r0 = ret.Error(0)
 	}
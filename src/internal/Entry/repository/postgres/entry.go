@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"time"
 	"timetracker/internal/Entry/repository"
 	"timetracker/models"
@@ -11,12 +12,16 @@ import (
 )
 
 type Entry struct {
-	ID          uint64    `gorm:"column:id"`
-	UserID      *uint64   `gorm:"column:user_id"`
-	ProjectID   *uint64   `gorm:"column:project_id;default:null"`
-	Description string    `gorm:"column:description"`
-	TimeStart   time.Time `gorm:"column:time_start"`
-	TimeEnd     time.Time `gorm:"column:time_end"`
+	ID          uint64         `gorm:"column:id"`
+	UserID      *uint64        `gorm:"column:user_id"`
+	ProjectID   *uint64        `gorm:"column:project_id;default:null"`
+	TaskID      *uint64        `gorm:"column:task_id;default:null"`
+	Description string         `gorm:"column:description"`
+	TimeStart   time.Time      `gorm:"column:time_start"`
+	TimeEnd     time.Time      `gorm:"column:time_end"`
+	Version     uint64         `gorm:"column:version"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at"`
 }
 
 func (Entry) TableName() string {
@@ -28,21 +33,33 @@ func toPostgresEntry(e *models.Entry) *Entry {
 		ID:          e.ID,
 		UserID:      e.UserID,
 		ProjectID:   e.ProjectID,
+		TaskID:      e.TaskID,
 		Description: e.Description,
 		TimeStart:   e.TimeStart,
 		TimeEnd:     e.TimeEnd,
+		Version:     e.Version,
+		UpdatedAt:   e.UpdatedAt,
 	}
 }
 
 func toModelEntry(e *Entry) *models.Entry {
-	return &models.Entry{
+	entry := &models.Entry{
 		ID:          e.ID,
 		UserID:      e.UserID,
 		ProjectID:   e.ProjectID,
+		TaskID:      e.TaskID,
 		Description: e.Description,
 		TimeStart:   e.TimeStart,
 		TimeEnd:     e.TimeEnd,
+		Version:     e.Version,
+		UpdatedAt:   e.UpdatedAt,
 	}
+
+	if e.DeletedAt.Valid {
+		entry.DeletedAt = &e.DeletedAt.Time
+	}
+
+	return entry
 }
 
 func toModelEntries(entries []*Entry) []*models.Entry {
@@ -59,10 +76,11 @@ type entryRepository struct {
 	db *gorm.DB
 }
 
-func (er *entryRepository) CreateEntry(e *models.Entry) error {
+func (er *entryRepository) CreateEntry(ctx context.Context, e *models.Entry) error {
+	e.Version, e.UpdatedAt = 1, time.Now().UTC()
 	postgresEntry := toPostgresEntry(e)
 
-	tx := er.db.Create(postgresEntry)
+	tx := er.db.WithContext(ctx).Create(postgresEntry)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table entry)")
@@ -72,22 +90,34 @@ func (er *entryRepository) CreateEntry(e *models.Entry) error {
 	return nil
 }
 
-func (er *entryRepository) UpdateEntry(e *models.Entry) error {
+// updateColumns are written even when they hold zero values, so a patch can clear them
+var updateColumns = []string{"project_id", "task_id", "description", "time_start", "time_end", "version", "updated_at"}
+
+// UpdateEntry only writes the row if it still has the version e was read with,
+// then e gets the next version
+func (er *entryRepository) UpdateEntry(ctx context.Context, e *models.Entry) error {
 	postgresEntry := toPostgresEntry(e)
+	postgresEntry.Version++
+	postgresEntry.UpdatedAt = time.Now().UTC()
 
-	tx := er.db.Omit("id").Updates(postgresEntry)
+	tx := er.db.WithContext(ctx).Where("version = ?", e.Version).Select(updateColumns).Updates(postgresEntry)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table entry)")
 	}
 
+	if tx.RowsAffected == 0 {
+		return models.ErrVersionMismatch
+	}
+
+	e.Version, e.UpdatedAt = postgresEntry.Version, postgresEntry.UpdatedAt
 	return nil
 }
 
-func (er *entryRepository) GetEntry(id uint64) (*models.Entry, error) {
+func (er *entryRepository) GetEntry(ctx context.Context, id uint64) (*models.Entry, error) {
 	var entry Entry
 
-	tx := er.db.Where("id = ?", id).Take(&entry)
+	tx := er.db.WithContext(ctx).Where("id = ?", id).Take(&entry)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
@@ -98,8 +128,15 @@ func (er *entryRepository) GetEntry(id uint64) (*models.Entry, error) {
 	return toModelEntry(&entry), nil
 }
 
-func (er *entryRepository) DeleteEntry(id uint64) error {
-	tx := er.db.Delete(&Entry{}, id)
+// DeleteEntry leaves a tombstone: the row gets deleted_at and the next version, then the
+// other queries skip it. The tag relations are kept.
+func (er *entryRepository) DeleteEntry(ctx context.Context, id uint64) error {
+	now := time.Now().UTC()
+	tx := er.db.WithContext(ctx).Model(&Entry{ID: id}).Updates(map[string]interface{}{
+		"deleted_at": now,
+		"updated_at": now,
+		"version":    gorm.Expr("version + 1"),
+	})
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table entry)")
@@ -108,10 +145,10 @@ func (er *entryRepository) DeleteEntry(id uint64) error {
 	return nil
 }
 
-func (er *entryRepository) GetUserEntries(userID uint64) ([]*models.Entry, error) {
+func (er *entryRepository) GetUserEntries(ctx context.Context, userID uint64) ([]*models.Entry, error) {
 	entries := make([]*Entry, 0, 10)
 
-	tx := er.db.Where(&Entry{UserID: &userID}).Find(&entries)
+	tx := er.db.WithContext(ctx).Where(&Entry{UserID: &userID}).Find(&entries)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
@@ -122,11 +159,11 @@ func (er *entryRepository) GetUserEntries(userID uint64) ([]*models.Entry, error
 	return toModelEntries(entries), nil
 }
 
-func (er *entryRepository) GetUserEntriesForDay(userID uint64, date time.Time) ([]*models.Entry, error) {
+func (er *entryRepository) GetUserEntriesForDay(ctx context.Context, userID uint64, date time.Time) ([]*models.Entry, error) {
 	entries := make([]*Entry, 0, 10)
 
 	todayStart, todayEnd := pkg.GetDayInterval(date)
-	tx := er.db.Where(&Entry{UserID: &userID}).Where("time_start BETWEEN ? AND ?", todayStart, todayEnd).Find(&entries)
+	tx := er.db.WithContext(ctx).Where(&Entry{UserID: &userID}).Where("time_start BETWEEN ? AND ?", todayStart, todayEnd).Find(&entries)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
@@ -137,6 +174,194 @@ func (er *entryRepository) GetUserEntriesForDay(userID uint64, date time.Time) (
 	return toModelEntries(entries), nil
 }
 
+// GetUserEntryChanges returns the entries of the user changed in (since, until] with the tombstones
+func (er *entryRepository) GetUserEntryChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Entry, error) {
+	entries := make([]*Entry, 0, 10)
+
+	tx := er.db.WithContext(ctx).Unscoped().Where(&Entry{UserID: &userID}).
+		Where("updated_at > ? AND updated_at <= ?", since, until).Order("updated_at").Find(&entries)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table entry)")
+	}
+
+	return toModelEntries(entries), nil
+}
+
+// GetUserClientEntries returns the entries of the user on the projects done for the client
+// started in [since, until), a zero bound doesn't filter. The deleted projects are left out.
+func (er *entryRepository) GetUserClientEntries(ctx context.Context, userID uint64, clientID uint64, since, until time.Time) ([]*models.Entry, error) {
+	entries := make([]*Entry, 0, 10)
+
+	tx := er.db.WithContext(ctx).Select("entry.*").
+		Joins("JOIN project ON project.id = entry.project_id AND project.deleted_at IS NULL").
+		Where("entry.user_id = ? AND project.client_id = ?", userID, clientID)
+
+	if !since.IsZero() {
+		tx = tx.Where("entry.time_start >= ?", since)
+	}
+	if !until.IsZero() {
+		tx = tx.Where("entry.time_start < ?", until)
+	}
+
+	tx = tx.Order("entry.id").Find(&entries)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table entry)")
+	}
+
+	return toModelEntries(entries), nil
+}
+
+// ReportRow is a group of the report, GroupID is the project or the client
+type ReportRow struct {
+	GroupID         *uint64 `gorm:"column:group_id"`
+	TotalCountHours float64 `gorm:"column:total_count_hours"`
+	EntriesCount    uint64  `gorm:"column:entries_count"`
+}
+
+// the hours of the report sum the entries like the update_total_count_hours trigger
+const (
+	postgresReportHours = "SUM(EXTRACT(EPOCH FROM (entry.time_end - entry.time_start))) / 3600"
+	sqliteReportHours   = "SUM(ROUND((julianday(entry.time_end) - julianday(entry.time_start)) * 86400, 3)) / 3600"
+)
+
+// GetUserReport sums the entries of the user by project or by client of the project. The entries
+// without one, or on a deleted project when grouped by client, are in the first row with a nil ID.
+func (er *entryRepository) GetUserReport(ctx context.Context, userID uint64, filter *models.ReportFilter) ([]*models.ReportRow, error) {
+	reportHours := postgresReportHours
+	if er.db.Dialector.Name() == "sqlite" {
+		reportHours = sqliteReportHours
+	}
+
+	group := "entry.project_id"
+	if filter.GroupBy == models.ReportByClient {
+		group = "project.client_id"
+	}
+
+	tx := er.db.WithContext(ctx).Table("entry").
+		Select(group+" AS group_id, "+reportHours+" AS total_count_hours, COUNT(*) AS entries_count").
+		Joins("LEFT JOIN project ON project.id = entry.project_id AND project.deleted_at IS NULL").
+		Where("entry.user_id = ? AND entry.deleted_at IS NULL", userID)
+
+	if !filter.Since.IsZero() {
+		tx = tx.Where("entry.time_start >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		tx = tx.Where("entry.time_start < ?", filter.Until)
+	}
+	if filter.ClientID != nil {
+		tx = tx.Where("project.client_id = ?", *filter.ClientID)
+	}
+
+	rows := make([]*ReportRow, 0, 10)
+	tx = tx.Group(group).Order(group + " IS NOT NULL, " + group).Scan(&rows)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table entry)")
+	}
+
+	report := make([]*models.ReportRow, len(rows))
+	for i, row := range rows {
+		report[i] = &models.ReportRow{TotalCountHours: row.TotalCountHours, EntriesCount: row.EntriesCount}
+		if filter.GroupBy == models.ReportByClient {
+			report[i].ClientID = row.GroupID
+		} else {
+			report[i].ProjectID = row.GroupID
+		}
+	}
+
+	return report, nil
+}
+
+// CountActiveEntries counts the entries of all users running at now
+func (er *entryRepository) CountActiveEntries(ctx context.Context, now time.Time) (uint64, error) {
+	var count int64
+
+	tx := er.db.WithContext(ctx).Model(&Entry{}).Where("time_start <= ? AND time_end > ?", now, now).Count(&count)
+
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "database error (table entry)")
+	}
+
+	return uint64(count), nil
+}
+
+// GetDeletedEntry returns the tombstone of the entry, ErrNotFound if the entry is not deleted
+func (er *entryRepository) GetDeletedEntry(ctx context.Context, id uint64) (*models.Entry, error) {
+	var entry Entry
+
+	tx := er.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Take(&entry)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table entry)")
+	}
+
+	return toModelEntry(&entry), nil
+}
+
+// GetUserDeletedEntries returns the tombstones of the user, the last deleted first
+func (er *entryRepository) GetUserDeletedEntries(ctx context.Context, userID uint64) ([]*models.Entry, error) {
+	entries := make([]*Entry, 0, 10)
+
+	tx := er.db.WithContext(ctx).Unscoped().Where(&Entry{UserID: &userID}).
+		Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Find(&entries)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table entry)")
+	}
+
+	return toModelEntries(entries), nil
+}
+
+// RestoreEntry brings the tombstone back with the next version, the trigger puts its
+// hours back on the project. An entry of a deleted project stays deleted.
+func (er *entryRepository) RestoreEntry(ctx context.Context, id uint64) error {
+	return er.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var deleted int64
+		err := tx.Table("entry").Joins("JOIN project ON project.id = entry.project_id").
+			Where("entry.id = ? AND project.deleted_at IS NOT NULL", id).Count(&deleted).Error
+
+		if err != nil {
+			return errors.Wrap(err, "database error (table entry)")
+		}
+
+		if deleted != 0 {
+			return models.ErrProjectDeleted
+		}
+
+		now := time.Now().UTC()
+		res := tx.Unscoped().Model(&Entry{ID: id}).Where("deleted_at IS NOT NULL").Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": now,
+			"version":    gorm.Expr("version + 1"),
+		})
+
+		if res.Error != nil {
+			return errors.Wrap(res.Error, "database error (table entry)")
+		}
+
+		if res.RowsAffected == 0 {
+			return models.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// PurgeDeletedEntries removes the tombstones deleted before the time for good
+func (er *entryRepository) PurgeDeletedEntries(ctx context.Context, before time.Time) (int64, error) {
+	tx := er.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before.UTC()).Delete(&Entry{})
+
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "database error (table entry)")
+	}
+
+	return tx.RowsAffected, nil
+}
+
 func NewEntryRepository(db *gorm.DB) repository.RepositoryI {
 	return &entryRepository{
 		db: db,
@@ -1,15 +1,24 @@
 package repository
 
 import (
+	"context"
 	"time"
 	"timetracker/models"
 )
 
 type RepositoryI interface {
-	CreateEntry(e *models.Entry) error
-	UpdateEntry(e *models.Entry) error
-	GetEntry(id uint64) (*models.Entry, error)
-	DeleteEntry(id uint64) error
-	GetUserEntries(userID uint64) ([]*models.Entry, error)
-	GetUserEntriesForDay(userID uint64, date time.Time) ([]*models.Entry, error)
+	CreateEntry(ctx context.Context, e *models.Entry) error
+	UpdateEntry(ctx context.Context, e *models.Entry) error
+	GetEntry(ctx context.Context, id uint64) (*models.Entry, error)
+	DeleteEntry(ctx context.Context, id uint64) error
+	GetUserEntries(ctx context.Context, userID uint64) ([]*models.Entry, error)
+	GetUserEntriesForDay(ctx context.Context, userID uint64, date time.Time) ([]*models.Entry, error)
+	GetUserEntryChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Entry, error)
+	GetUserClientEntries(ctx context.Context, userID uint64, clientID uint64, since, until time.Time) ([]*models.Entry, error)
+	GetUserReport(ctx context.Context, userID uint64, filter *models.ReportFilter) ([]*models.ReportRow, error)
+	CountActiveEntries(ctx context.Context, now time.Time) (uint64, error)
+	GetDeletedEntry(ctx context.Context, id uint64) (*models.Entry, error)
+	GetUserDeletedEntries(ctx context.Context, userID uint64) ([]*models.Entry, error)
+	RestoreEntry(ctx context.Context, id uint64) error
+	PurgeDeletedEntries(ctx context.Context, before time.Time) (int64, error)
 }
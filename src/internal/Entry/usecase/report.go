@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"timetracker/internal/tracing"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+// GetUserClientEntries returns the entries of the user on the projects done for the client,
+// started in [since, until). The client must be one of the user's.
+func (u *usecase) GetUserClientEntries(ctx context.Context, userID uint64, clientID uint64, since, until time.Time) ([]*models.Entry, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.GetUserClientEntries")
+	defer span.End()
+
+	err := u.checkClient(ctx, clientID, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.GetUserClientEntries")
+	}
+
+	entries, err := u.entryRepository.GetUserClientEntries(ctx, userID, clientID, since, until)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.GetUserClientEntries")
+	}
+
+	return entries, nil
+}
+
+// GetUserReport sums the hours of the entries of the user by project or by client
+func (u *usecase) GetUserReport(ctx context.Context, userID uint64, filter *models.ReportFilter) ([]*models.ReportRow, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.GetUserReport")
+	defer span.End()
+
+	if filter.ClientID != nil {
+		err := u.checkClient(ctx, *filter.ClientID, userID)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "Error in func entry.Usecase.GetUserReport")
+		}
+	}
+
+	report, err := u.entryRepository.GetUserReport(ctx, userID, filter)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.GetUserReport")
+	}
+
+	return report, nil
+}
+
+// checkClient only lets the user filter by a client of their own
+func (u *usecase) checkClient(ctx context.Context, clientID uint64, userID uint64) error {
+	client, err := u.clientRepository.GetClient(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	if client.UserID != userID {
+		return models.ErrPermissionDenied
+	}
+
+	return nil
+}
@@ -1,47 +1,82 @@
 package usecase
 
 import (
+	"context"
 	"time"
+	clientRep "timetracker/internal/Client/repository"
 	entryRep "timetracker/internal/Entry/repository"
+	projectRep "timetracker/internal/Project/repository"
 	tagRep "timetracker/internal/Tag/repository"
+	taskRep "timetracker/internal/Task/repository"
 	userRep "timetracker/internal/User/repository"
+	"timetracker/internal/policy"
+	"timetracker/internal/tracing"
 	"timetracker/models"
 
 	"github.com/pkg/errors"
 )
 
 type UsecaseI interface {
-	CreateEntry(e *models.Entry) error
-	UpdateEntry(e *models.Entry) error
-	GetEntry(id uint64) (*models.Entry, error)
-	DeleteEntry(id uint64, userID uint64) error
-	GetUserEntries(userID uint64) ([]*models.Entry, error)
-	GetUserEntriesForDay(userID uint64, date time.Time) ([]*models.Entry, error)
+	CreateEntry(ctx context.Context, e *models.Entry) error
+	UpdateEntry(ctx context.Context, patch *models.EntryPatch) (*models.Entry, error)
+	GetEntry(ctx context.Context, id uint64) (*models.Entry, error)
+	DeleteEntry(ctx context.Context, id uint64, userID uint64) error
+	GetUserEntries(ctx context.Context, userID uint64) ([]*models.Entry, error)
+	GetUserEntriesForDay(ctx context.Context, userID uint64, date time.Time) ([]*models.Entry, error)
+	GetUserEntryChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Entry, error)
+	GetUserDeletedEntries(ctx context.Context, userID uint64) ([]*models.Entry, error)
+	RestoreEntry(ctx context.Context, id uint64, userID uint64) (*models.Entry, error)
+	PurgeDeletedEntries(ctx context.Context, before time.Time) (int64, error)
+	CountActiveEntries(ctx context.Context) (uint64, error)
+	GetUserClientEntries(ctx context.Context, userID uint64, clientID uint64, since, until time.Time) ([]*models.Entry, error)
+	GetUserReport(ctx context.Context, userID uint64, filter *models.ReportFilter) ([]*models.ReportRow, error)
 }
 
 type usecase struct {
-	entryRepository entryRep.RepositoryI
-	tagRepository   tagRep.RepositoryI
-	userRepository  userRep.RepositoryI
+	entryRepository   entryRep.RepositoryI
+	tagRepository     tagRep.RepositoryI
+	userRepository    userRep.RepositoryI
+	projectRepository projectRep.RepositoryI
+	taskRepository    taskRep.RepositoryI
+	clientRepository  clientRep.RepositoryI
 }
 
-func New(eRep entryRep.RepositoryI, tRep tagRep.RepositoryI, uRep userRep.RepositoryI) UsecaseI {
+func New(eRep entryRep.RepositoryI, tRep tagRep.RepositoryI, uRep userRep.RepositoryI, pRep projectRep.RepositoryI, tsRep taskRep.RepositoryI,
+	cRep clientRep.RepositoryI) UsecaseI {
 	return &usecase{
-		entryRepository: eRep,
-		tagRepository:   tRep,
-		userRepository:  uRep,
+		entryRepository:   eRep,
+		tagRepository:     tRep,
+		userRepository:    uRep,
+		projectRepository: pRep,
+		taskRepository:    tsRep,
+		clientRepository:  cRep,
 	}
 }
 
-func (u *usecase) CreateEntry(e *models.Entry) error {
-	err := u.entryRepository.CreateEntry(e)
+func (u *usecase) CreateEntry(ctx context.Context, e *models.Entry) error {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.CreateEntry")
+	defer span.End()
+
+	err := u.checkProjectOpen(ctx, e.ProjectID, e.UserID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func entry.Usecase.CreateEntry")
+	}
+
+	err = u.checkTask(ctx, e.TaskID, e.ProjectID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func entry.Usecase.CreateEntry")
+	}
+
+	err = u.entryRepository.CreateEntry(ctx, e)
 
 	if err != nil {
 		return errors.Wrap(err, "Error in func entry.Usecase.CreateEntry")
 	}
 
 	if e.TagList != nil && len(e.TagList) != 0 {
-		err = u.tagRepository.CreateEntryTags(e.ID, e.TagList)
+		err = u.tagRepository.CreateEntryTags(ctx, e.ID, e.TagList)
 
 		if err != nil {
 			return errors.Wrap(err, "Error in func entry.Usecase.CreateEntry")
@@ -51,40 +86,131 @@ func (u *usecase) CreateEntry(e *models.Entry) error {
 	return nil
 }
 
-func (u *usecase) UpdateEntry(e *models.Entry) error {
-	existedEntry, err := u.entryRepository.GetEntry(e.ID)
+func (u *usecase) UpdateEntry(ctx context.Context, patch *models.EntryPatch) (*models.Entry, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.UpdateEntry")
+	defer span.End()
+
+	entry, err := u.entryRepository.GetEntry(ctx, patch.ID)
 
 	if err != nil {
-		return errors.Wrap(err, "Error in func goal.Usecase.Update.UpdateEntry")
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.UpdateEntry")
 	}
 
-	if *existedEntry.UserID != *e.UserID {
-		return models.ErrPermissionDenied
+	if err := policy.AuthorizeOwner(patch.UserID, entry.UserID); err != nil {
+		return nil, err
+	}
+
+	if patch.Version != 0 && patch.Version != entry.Version {
+		return nil, models.ErrVersionMismatch
+	}
+
+	if patch.ProjectID.Set && !sameProject(patch.ProjectID.Value, entry.ProjectID) {
+		err = u.checkProjectOpen(ctx, patch.ProjectID.Value, &patch.UserID)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "Error in func entry.Usecase.UpdateEntry")
+		}
+	}
+
+	patch.Apply(entry)
+
+	// the task stays a task of the project, moving the entry needs a new task or a null one
+	if patch.TaskID.Set || patch.ProjectID.Set {
+		err = u.checkTask(ctx, entry.TaskID, entry.ProjectID)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "Error in func entry.Usecase.UpdateEntry")
+		}
 	}
 
-	err = u.entryRepository.UpdateEntry(e)
+	err = u.entryRepository.UpdateEntry(ctx, entry)
 
 	if err != nil {
-		return errors.Wrap(err, "Error in func entry.Usecase.UpdateEntry")
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.UpdateEntry")
 	}
 
-	if e.TagList != nil && len(e.TagList) != 0 {
-		err = u.tagRepository.UpdateEntryTags(e.ID, e.TagList)
+	if patch.TagList != nil {
+		err = u.tagRepository.UpdateEntryTags(ctx, entry.ID, entry.TagList)
 
 		if err != nil {
-			return errors.Wrap(err, "Error in func entry.Usecase.UpdateEntry")
+			return nil, errors.Wrap(err, "Error in func entry.Usecase.UpdateEntry")
 		}
 	}
 
+	err = u.addTagsToEntry(ctx, entry)
+
 	if err != nil {
-		return errors.Wrap(err, "Error in func entry.Usecase.UpdateEntry")
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.UpdateEntry")
+	}
+
+	return entry, nil
+}
+
+// checkProjectOpen rejects new entries on an archived or a deleted project, and from a user
+// who is not an owner or an editor of the project. A nil id is no project.
+func (u *usecase) checkProjectOpen(ctx context.Context, projectID *uint64, userID *uint64) error {
+	if projectID == nil {
+		return nil
+	}
+
+	project, err := u.projectRepository.GetProject(ctx, *projectID)
+
+	if errors.Is(err, models.ErrNotFound) {
+		if _, err := u.projectRepository.GetDeletedProject(ctx, *projectID); err == nil {
+			return models.ErrProjectDeleted
+		}
+		return models.ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	if project.ArchivedAt != nil {
+		return models.ErrProjectArchived
+	}
+
+	if userID == nil {
+		return models.ErrPermissionDenied
+	}
+
+	member, err := u.projectRepository.GetProjectMember(ctx, project.ID, *userID)
+
+	if errors.Is(err, models.ErrNotFound) {
+		return models.ErrPermissionDenied
+	} else if err != nil {
+		return err
+	}
+
+	if !member.Accepted() || !member.Role.CanLog() {
+		return models.ErrPermissionDenied
 	}
 
 	return nil
 }
 
-func (u *usecase) addAdditionalFieldsToEntry(entry *models.Entry) error {
-	err := u.addTagsToEntry(entry)
+// checkTask lets an entry only be logged on a task of its project. A nil id is no task.
+func (u *usecase) checkTask(ctx context.Context, taskID *uint64, projectID *uint64) error {
+	if taskID == nil {
+		return nil
+	}
+
+	task, err := u.taskRepository.GetTask(ctx, *taskID)
+	if err != nil {
+		return err
+	}
+
+	if projectID == nil || task.ProjectID != *projectID {
+		return models.ErrTaskProject
+	}
+
+	return nil
+}
+
+func sameProject(a, b *uint64) bool {
+	return a == b || a != nil && b != nil && *a == *b
+}
+
+func (u *usecase) addAdditionalFieldsToEntry(ctx context.Context, entry *models.Entry) error {
+	err := u.addTagsToEntry(ctx, entry)
 
 	if err != nil {
 		return errors.Wrap(err, "error while get tags")
@@ -93,8 +219,8 @@ func (u *usecase) addAdditionalFieldsToEntry(entry *models.Entry) error {
 	return nil
 }
 
-func (u *usecase) addTagsToEntry(entry *models.Entry) error {
-	tags, err := u.tagRepository.GetEntryTags(entry.ID)
+func (u *usecase) addTagsToEntry(ctx context.Context, entry *models.Entry) error {
+	tags, err := u.tagRepository.GetEntryTags(ctx, entry.ID)
 
 	if err != nil {
 		return errors.Wrap(err, "Error in func addPostAttachmentsAuthors")
@@ -109,14 +235,17 @@ func (u *usecase) addTagsToEntry(entry *models.Entry) error {
 	return nil
 }
 
-func (u *usecase) GetEntry(id uint64) (*models.Entry, error) {
-	resEntry, err := u.entryRepository.GetEntry(id)
+func (u *usecase) GetEntry(ctx context.Context, id uint64) (*models.Entry, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.GetEntry")
+	defer span.End()
+
+	resEntry, err := u.entryRepository.GetEntry(ctx, id)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "entry.usecase.GetEntry error while get entry info")
 	}
 
-	err = u.addAdditionalFieldsToEntry(resEntry)
+	err = u.addAdditionalFieldsToEntry(ctx, resEntry)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "entry.usecase.GetEntry error while get additional info")
@@ -125,8 +254,11 @@ func (u *usecase) GetEntry(id uint64) (*models.Entry, error) {
 	return resEntry, nil
 }
 
-func (u *usecase) DeleteEntry(id uint64, userId uint64) error {
-	existedEntry, err := u.entryRepository.GetEntry(id)
+func (u *usecase) DeleteEntry(ctx context.Context, id uint64, userId uint64) error {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.DeleteEntry")
+	defer span.End()
+
+	existedEntry, err := u.entryRepository.GetEntry(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -135,34 +267,53 @@ func (u *usecase) DeleteEntry(id uint64, userId uint64) error {
 		return models.ErrNotFound
 	}
 
-	if *existedEntry.UserID != userId {
-		return models.ErrPermissionDenied
+	if err := policy.AuthorizeOwner(userId, existedEntry.UserID); err != nil {
+		return err
 	}
 
-	err = u.entryRepository.DeleteEntry(id) // TODO потом откатывать транзакцию если теги удалились, придумать как
+	// the tombstone keeps its tags, they are removed with the row
+	err = u.entryRepository.DeleteEntry(ctx, id)
 
 	if err != nil {
 		return errors.Wrap(err, "entry.repository delete error")
 	}
 
-	err = u.tagRepository.DeleteEntryTags(id)
+	return nil
+}
+
+func (u *usecase) GetUserEntries(ctx context.Context, userID uint64) ([]*models.Entry, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.GetUserEntries")
+	defer span.End()
+
+	entries, err := u.entryRepository.GetUserEntries(ctx, userID)
 
 	if err != nil {
-		return errors.Wrap(err, "entry.Usecase.tagRepository delete error")
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.GetUserPosts")
 	}
 
-	return nil
+	for idx := range entries {
+		err = u.addAdditionalFieldsToEntry(ctx, entries[idx])
+
+		if err != nil {
+			return nil, errors.Wrap(err, "entry.Usecase.GetUserPosts error while add additional fields")
+		}
+	}
+
+	return entries, nil
 }
 
-func (u *usecase) GetUserEntries(userID uint64) ([]*models.Entry, error) {
-	entries, err := u.entryRepository.GetUserEntries(userID)
+func (u *usecase) GetUserEntriesForDay(ctx context.Context, userID uint64, date time.Time) ([]*models.Entry, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.GetUserEntriesForDay")
+	defer span.End()
+
+	entries, err := u.entryRepository.GetUserEntriesForDay(ctx, userID, date)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func entry.Usecase.GetUserPosts")
 	}
 
 	for idx := range entries {
-		err = u.addAdditionalFieldsToEntry(entries[idx])
+		err = u.addAdditionalFieldsToEntry(ctx, entries[idx])
 
 		if err != nil {
 			return nil, errors.Wrap(err, "entry.Usecase.GetUserPosts error while add additional fields")
@@ -172,20 +323,101 @@ func (u *usecase) GetUserEntries(userID uint64) ([]*models.Entry, error) {
 	return entries, nil
 }
 
-func (u *usecase) GetUserEntriesForDay(userID uint64, date time.Time) ([]*models.Entry, error) {
-	entries, err := u.entryRepository.GetUserEntriesForDay(userID, date)
+// GetUserEntryChanges returns the entries changed in (since, until] with the tombstones of
+// the deleted ones, the tags are only added to the entries that are not deleted
+func (u *usecase) GetUserEntryChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Entry, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.GetUserEntryChanges")
+	defer span.End()
+
+	entries, err := u.entryRepository.GetUserEntryChanges(ctx, userID, since, until)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "Error in func entry.Usecase.GetUserPosts")
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.GetUserEntryChanges")
 	}
 
 	for idx := range entries {
-		err = u.addAdditionalFieldsToEntry(entries[idx])
+		if entries[idx].DeletedAt != nil {
+			continue
+		}
+
+		err = u.addAdditionalFieldsToEntry(ctx, entries[idx])
 
 		if err != nil {
-			return nil, errors.Wrap(err, "entry.Usecase.GetUserPosts error while add additional fields")
+			return nil, errors.Wrap(err, "entry.Usecase.GetUserEntryChanges error while add additional fields")
 		}
 	}
 
 	return entries, nil
 }
+
+// CountActiveEntries counts the timers running right now over all users
+func (u *usecase) CountActiveEntries(ctx context.Context) (uint64, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.CountActiveEntries")
+	defer span.End()
+
+	count, err := u.entryRepository.CountActiveEntries(ctx, time.Now())
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in func entry.Usecase.CountActiveEntries")
+	}
+
+	return count, nil
+}
+
+// GetUserDeletedEntries lists the trash of the user, the entries keep their tags there
+func (u *usecase) GetUserDeletedEntries(ctx context.Context, userID uint64) ([]*models.Entry, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.GetUserDeletedEntries")
+	defer span.End()
+
+	deleted, err := u.entryRepository.GetUserDeletedEntries(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.GetUserDeletedEntries")
+	}
+
+	for idx := range deleted {
+		err = u.addAdditionalFieldsToEntry(ctx, deleted[idx])
+
+		if err != nil {
+			return nil, errors.Wrap(err, "entry.Usecase.GetUserDeletedEntries error while add additional fields")
+		}
+	}
+
+	return deleted, nil
+}
+
+// RestoreEntry gets ErrProjectDeleted for an entry of a deleted project, the project is restored first
+func (u *usecase) RestoreEntry(ctx context.Context, id uint64, userID uint64) (*models.Entry, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.RestoreEntry")
+	defer span.End()
+
+	deleted, err := u.entryRepository.GetDeletedEntry(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.RestoreEntry")
+	}
+
+	if err := policy.AuthorizeOwner(userID, deleted.UserID); err != nil {
+		return nil, err
+	}
+
+	err = u.entryRepository.RestoreEntry(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func entry.Usecase.RestoreEntry")
+	}
+
+	return u.GetEntry(ctx, id)
+}
+
+func (u *usecase) PurgeDeletedEntries(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "entry.Usecase.PurgeDeletedEntries")
+	defer span.End()
+
+	purged, err := u.entryRepository.PurgeDeletedEntries(ctx, before)
+
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in func entry.Usecase.PurgeDeletedEntries")
+	}
+
+	return purged, nil
+}
@@ -1,15 +1,22 @@
 package usecase_test
 
 import (
+	"context"
 	"github.com/bxcodec/faker"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
+	clientMocks "timetracker/internal/Client/repository/mocks"
 	entryMocks "timetracker/internal/Entry/repository/mocks"
 	"timetracker/internal/Entry/usecase"
+	projectMocks "timetracker/internal/Project/repository/mocks"
 	tagMocks "timetracker/internal/Tag/repository/mocks"
+	taskMocks "timetracker/internal/Task/repository/mocks"
 	"timetracker/models"
+	"timetracker/pkg"
 )
 
 type TestCaseGetEntry struct {
@@ -23,11 +30,22 @@ type TestCaseDeleteEntry struct {
 	Error   error
 }
 
+type TestCaseRestoreEntry struct {
+	ArgData []uint64
+	Error   error
+}
+
 type TestCaseCreateUpdateEntry struct {
 	ArgData *models.Entry
 	Error   error
 }
 
+type TestCaseUpdateEntry struct {
+	ArgData     *models.EntryPatch
+	ExpectedRes *models.Entry
+	Error       error
+}
+
 type TestCaseGetUserEntries struct {
 	ArgData     *uint64
 	ExpectedRes []*models.Entry
@@ -52,10 +70,10 @@ func TestUsecaseGetEntry(t *testing.T) {
 		mockExpectedEntry.TagList = append(mockExpectedEntry.TagList, *tag)
 	}
 
-	mockEntryRepo.On("GetEntry", mockEntryRes.ID).Return(&mockEntryRes, nil)
-	mockTagRepo.On("GetEntryTags", mockEntryRes.ID).Return(mockTags, nil)
+	mockEntryRepo.On("GetEntry", mock.Anything, mockEntryRes.ID).Return(&mockEntryRes, nil)
+	mockTagRepo.On("GetEntryTags", mock.Anything, mockEntryRes.ID).Return(mockTags, nil)
 
-	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil)
+	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil, nil, nil, nil)
 
 	cases := map[string]TestCaseGetEntry{
 		"success": {
@@ -67,7 +85,7 @@ func TestUsecaseGetEntry(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			user, err := useCase.GetEntry(test.ArgData)
+			user, err := useCase.GetEntry(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, err)
 
 			if err == nil {
@@ -84,68 +102,152 @@ func TestUsecaseCreateEntry(t *testing.T) {
 	err := faker.FakeData(&mockEntry)
 	assert.NoError(t, err)
 
-	mockEntryRepo := entryMocks.NewRepositoryI(t)
-	mockTagRepo := tagMocks.NewRepositoryI(t)
+	if len(mockEntry.TagList) == 0 {
+		mockEntry.TagList = []models.Tag{{ID: mockEntry.ID}}
+	}
+
+	archivedAt := time.Now().UTC()
+	archivedID, deletedID, viewedID := *mockEntry.ProjectID+1, *mockEntry.ProjectID+2, *mockEntry.ProjectID+3
+	archivedEntry, deletedEntry, viewedEntry := mockEntry, mockEntry, mockEntry
+	archivedEntry.ProjectID, deletedEntry.ProjectID, viewedEntry.ProjectID = &archivedID, &deletedID, &viewedID
 
-	mockEntryRepo.On("CreateEntry", &mockEntry).Return(nil)
-	mockTagRepo.On("CreateEntryTags", mockEntry.ID, mockEntry.TagList).Return(nil)
+	// the task of the entry is one of its project, the other task is of another project
+	otherTaskID := *mockEntry.TaskID + 1
+	otherTaskEntry := mockEntry
+	otherTaskEntry.TaskID = &otherTaskID
 
-	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil)
+	mockEntryRepo := entryMocks.NewRepositoryI(t)
+	mockTagRepo := tagMocks.NewRepositoryI(t)
+	mockProjectRepo := projectMocks.NewRepositoryI(t)
+	mockTaskRepo := taskMocks.NewRepositoryI(t)
+
+	mockProjectRepo.On("GetProject", mock.Anything, *mockEntry.ProjectID).Return(&models.Project{ID: *mockEntry.ProjectID}, nil)
+	mockProjectRepo.On("GetProject", mock.Anything, archivedID).Return(&models.Project{ID: archivedID, ArchivedAt: &archivedAt}, nil)
+	mockProjectRepo.On("GetProject", mock.Anything, deletedID).Return(nil, models.ErrNotFound)
+	mockProjectRepo.On("GetDeletedProject", mock.Anything, deletedID).Return(&models.Project{ID: deletedID}, nil)
+	mockProjectRepo.On("GetProject", mock.Anything, viewedID).Return(&models.Project{ID: viewedID}, nil)
+	// a member of a shared project logs the entries as an editor, a viewer can't
+	mockProjectRepo.On("GetProjectMember", mock.Anything, *mockEntry.ProjectID, *mockEntry.UserID).Return(
+		&models.ProjectMember{Role: models.ProjectEditor, AcceptedAt: &archivedAt}, nil)
+	mockProjectRepo.On("GetProjectMember", mock.Anything, viewedID, *mockEntry.UserID).Return(
+		&models.ProjectMember{Role: models.ProjectViewer, AcceptedAt: &archivedAt}, nil)
+	mockTaskRepo.On("GetTask", mock.Anything, *mockEntry.TaskID).Return(&models.Task{ID: *mockEntry.TaskID, ProjectID: *mockEntry.ProjectID}, nil)
+	mockTaskRepo.On("GetTask", mock.Anything, otherTaskID).Return(&models.Task{ID: otherTaskID, ProjectID: archivedID}, nil)
+	mockEntryRepo.On("CreateEntry", mock.Anything, &mockEntry).Return(nil)
+	mockTagRepo.On("CreateEntryTags", mock.Anything, mockEntry.ID, mockEntry.TagList).Return(nil)
+
+	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil, mockProjectRepo, mockTaskRepo, nil)
 
 	cases := map[string]TestCaseCreateUpdateEntry{
 		"success": {
 			ArgData: &mockEntry,
 			Error:   nil,
 		},
+		"archived project": {
+			ArgData: &archivedEntry,
+			Error:   models.ErrProjectArchived,
+		},
+		"deleted project": {
+			ArgData: &deletedEntry,
+			Error:   models.ErrProjectDeleted,
+		},
+		"viewer of the project": {
+			ArgData: &viewedEntry,
+			Error:   models.ErrPermissionDenied,
+		},
+		"task of another project": {
+			ArgData: &otherTaskEntry,
+			Error:   models.ErrTaskProject,
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.CreateEntry(test.ArgData)
+			err := useCase.CreateEntry(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
 		})
 	}
 	mockEntryRepo.AssertExpectations(t)
 	mockTagRepo.AssertExpectations(t)
+	mockProjectRepo.AssertExpectations(t)
+	mockTaskRepo.AssertExpectations(t)
 }
 
 func TestUsecaseUpdateEntry(t *testing.T) {
-	var mockEntry, invalidMockEntry models.Entry
+	var mockEntry models.Entry
 	err := faker.FakeData(&mockEntry)
 	assert.NoError(t, err)
+	mockEntry.TagList = nil
+
+	description := ""
+	patch := &models.EntryPatch{
+		ID:          mockEntry.ID,
+		UserID:      *mockEntry.UserID,
+		ProjectID:   pkg.Some[*uint64](nil),
+		TaskID:      pkg.Some[*uint64](nil),
+		Description: &description,
+		TagList:     &[]models.Tag{},
+	}
 
-	invalidMockEntry.ID = mockEntry.ID + 1
+	expectedEntry := mockEntry
+	expectedEntry.ProjectID = nil
+	expectedEntry.TaskID = nil
+	expectedEntry.Description = ""
+	expectedEntry.TagList = []models.Tag{}
 
 	mockEntryRepo := entryMocks.NewRepositoryI(t)
 	mockTagRepo := tagMocks.NewRepositoryI(t)
-
-	mockEntryRepo.On("UpdateEntry", &mockEntry).Return(nil)
-	mockTagRepo.On("UpdateEntryTags", mockEntry.ID, mockEntry.TagList).Return(nil)
-
-	mockEntryRepo.On("GetEntry", mockEntry.ID).Return(&mockEntry, nil)
-	mockEntryRepo.On("GetEntry", invalidMockEntry.ID).Return(nil, models.ErrNotFound)
-
-	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil)
-
-	cases := map[string]TestCaseCreateUpdateEntry{
-		"success": {
-			ArgData: &mockEntry,
-			Error:   nil,
+	mockTaskRepo := taskMocks.NewRepositoryI(t)
+
+	mockEntryRepo.On("GetEntry", mock.Anything, mockEntry.ID).Return(func(context.Context, uint64) *models.Entry {
+		entry := mockEntry
+		return &entry
+	}, nil)
+	mockEntryRepo.On("GetEntry", mock.Anything, mockEntry.ID+1).Return(nil, models.ErrNotFound)
+	mockEntryRepo.On("UpdateEntry", mock.Anything, &expectedEntry).Return(nil)
+	mockTagRepo.On("UpdateEntryTags", mock.Anything, mockEntry.ID, []models.Tag{}).Return(nil)
+	mockTagRepo.On("GetEntryTags", mock.Anything, mockEntry.ID).Return([]*models.Tag{}, nil)
+	mockTaskRepo.On("GetTask", mock.Anything, *mockEntry.TaskID).Return(&models.Task{ID: *mockEntry.TaskID, ProjectID: *mockEntry.ProjectID}, nil)
+
+	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil, nil, mockTaskRepo, nil)
+
+	cases := map[string]TestCaseUpdateEntry{
+		"success clears fields and tags": {
+			ArgData:     patch,
+			ExpectedRes: &expectedEntry,
+			Error:       nil,
+		},
+		"task without its project": {
+			ArgData: &models.EntryPatch{ID: mockEntry.ID, UserID: *mockEntry.UserID, ProjectID: pkg.Some[*uint64](nil)},
+			Error:   models.ErrTaskProject,
 		},
 		"Entry not found": {
-			ArgData: &invalidMockEntry,
+			ArgData: &models.EntryPatch{ID: mockEntry.ID + 1, UserID: *mockEntry.UserID},
 			Error:   models.ErrNotFound,
 		},
+		"Permission denied": {
+			ArgData: &models.EntryPatch{ID: mockEntry.ID, UserID: *mockEntry.UserID + 1},
+			Error:   models.ErrPermissionDenied,
+		},
+		"Version mismatch": {
+			ArgData: &models.EntryPatch{ID: mockEntry.ID, UserID: *mockEntry.UserID, Version: mockEntry.Version + 1},
+			Error:   models.ErrVersionMismatch,
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.UpdateEntry(test.ArgData)
+			entry, err := useCase.UpdateEntry(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, test.ExpectedRes, entry)
+			}
 		})
 	}
 	mockEntryRepo.AssertExpectations(t)
 	mockTagRepo.AssertExpectations(t)
+	mockTaskRepo.AssertExpectations(t)
 }
 
 func TestUsecaseDeleteEntry(t *testing.T) {
@@ -154,18 +256,18 @@ func TestUsecaseDeleteEntry(t *testing.T) {
 	assert.NoError(t, err)
 
 	invalidMockEntry.ID += mockEntry.ID + 1
-	*invalidMockEntry.UserID += *mockEntry.UserID + 1
+	invalidUserID := *mockEntry.UserID + 1
+	invalidMockEntry.UserID = &invalidUserID
 
 	mockEntryRepo := entryMocks.NewRepositoryI(t)
 	mockTagRepo := tagMocks.NewRepositoryI(t)
 
-	mockEntryRepo.On("DeleteEntry", mockEntry.ID).Return(nil)
-	mockTagRepo.On("DeleteEntryTags", mockEntry.ID).Return(nil)
+	mockEntryRepo.On("DeleteEntry", mock.Anything, mockEntry.ID).Return(nil)
 
-	mockEntryRepo.On("GetEntry", mockEntry.ID).Return(&mockEntry, nil)
-	mockEntryRepo.On("GetEntry", invalidMockEntry.ID).Return(nil, models.ErrNotFound)
+	mockEntryRepo.On("GetEntry", mock.Anything, mockEntry.ID).Return(&mockEntry, nil)
+	mockEntryRepo.On("GetEntry", mock.Anything, invalidMockEntry.ID).Return(nil, models.ErrNotFound)
 
-	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil)
+	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil, nil, nil, nil)
 
 	cases := map[string]TestCaseDeleteEntry{
 		"success": {
@@ -180,7 +282,7 @@ func TestUsecaseDeleteEntry(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.DeleteEntry(*test.ArgData[0], *test.ArgData[1])
+			err := useCase.DeleteEntry(context.Background(), *test.ArgData[0], *test.ArgData[1])
 			require.Equal(t, test.Error, errors.Cause(err))
 		})
 	}
@@ -212,13 +314,13 @@ func TestUsecaseGetUserEntries(t *testing.T) {
 	mockEntryRepo := entryMocks.NewRepositoryI(t)
 	mockTagRepo := tagMocks.NewRepositoryI(t)
 
-	mockEntryRepo.On("GetUserEntries", mockExpectedEntry[0].UserID).Return(mockExpectedEntry, nil)
+	mockEntryRepo.On("GetUserEntries", mock.Anything, *mockExpectedEntry[0].UserID).Return(mockExpectedEntry, nil)
 
 	for idx := range mockExpectedEntry {
-		mockTagRepo.On("GetEntryTags", mockExpectedEntry[idx].ID).Return(mockTags, nil)
+		mockTagRepo.On("GetEntryTags", mock.Anything, mockExpectedEntry[idx].ID).Return(mockTags, nil)
 	}
 
-	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil)
+	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil, nil, nil, nil)
 
 	cases := map[string]TestCaseGetUserEntries{
 		"success": {
@@ -230,7 +332,7 @@ func TestUsecaseGetUserEntries(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			user, err := useCase.GetUserEntries(*test.ArgData)
+			user, err := useCase.GetUserEntries(context.Background(), *test.ArgData)
 			require.Equal(t, test.Error, err)
 
 			if err == nil {
@@ -241,3 +343,136 @@ func TestUsecaseGetUserEntries(t *testing.T) {
 	mockEntryRepo.AssertExpectations(t)
 	mockTagRepo.AssertExpectations(t)
 }
+
+func TestUsecaseRestoreEntry(t *testing.T) {
+	var mockEntry models.Entry
+	err := faker.FakeData(&mockEntry)
+	assert.NoError(t, err)
+
+	deletedAt := mockEntry.UpdatedAt
+	mockEntry.DeletedAt = nil
+	deletedEntry := mockEntry
+	deletedEntry.DeletedAt = &deletedAt
+	inDeletedProject := deletedEntry
+	inDeletedProject.ID = mockEntry.ID + 1
+
+	mockEntryRepo := entryMocks.NewRepositoryI(t)
+	mockTagRepo := tagMocks.NewRepositoryI(t)
+
+	mockEntryRepo.On("GetDeletedEntry", mock.Anything, mockEntry.ID).Return(&deletedEntry, nil)
+	mockEntryRepo.On("RestoreEntry", mock.Anything, mockEntry.ID).Return(nil).Once()
+	mockEntryRepo.On("GetEntry", mock.Anything, mockEntry.ID).Return(&mockEntry, nil).Once()
+	mockTagRepo.On("GetEntryTags", mock.Anything, mockEntry.ID).Return([]*models.Tag{}, nil).Once()
+
+	mockEntryRepo.On("GetDeletedEntry", mock.Anything, inDeletedProject.ID).Return(&inDeletedProject, nil)
+	mockEntryRepo.On("RestoreEntry", mock.Anything, inDeletedProject.ID).Return(models.ErrProjectDeleted)
+
+	useCase := usecase.New(mockEntryRepo, mockTagRepo, nil, nil, nil, nil)
+
+	cases := map[string]TestCaseRestoreEntry{
+		"success": {
+			ArgData: []uint64{mockEntry.ID, *mockEntry.UserID},
+			Error:   nil,
+		},
+		"other user": {
+			ArgData: []uint64{mockEntry.ID, *mockEntry.UserID + 1},
+			Error:   models.ErrPermissionDenied,
+		},
+		"project is deleted": {
+			ArgData: []uint64{inDeletedProject.ID, *mockEntry.UserID},
+			Error:   models.ErrProjectDeleted,
+		},
+	}
+
+	for _, name := range []string{"success", "other user", "project is deleted"} {
+		test := cases[name]
+		t.Run(name, func(t *testing.T) {
+			entry, err := useCase.RestoreEntry(context.Background(), test.ArgData[0], test.ArgData[1])
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Nil(t, entry.DeletedAt)
+			}
+		})
+	}
+	mockEntryRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestUsecaseGetUserReport(t *testing.T) {
+	userID, clientID, otherClientID := uint64(1), uint64(10), uint64(11)
+	projectID := uint64(2)
+	report := []*models.ReportRow{
+		{TotalCountHours: 0.5, EntriesCount: 1},
+		{ProjectID: &projectID, TotalCountHours: 6, EntriesCount: 2},
+	}
+
+	byProject := &models.ReportFilter{GroupBy: models.ReportByProject}
+	ofClient := &models.ReportFilter{GroupBy: models.ReportByClient, ClientID: &clientID}
+	ofOtherClient := &models.ReportFilter{GroupBy: models.ReportByClient, ClientID: &otherClientID}
+	ofMissingClient := &models.ReportFilter{GroupBy: models.ReportByClient, ClientID: &projectID}
+
+	mockEntryRepo := entryMocks.NewRepositoryI(t)
+	mockClientRepo := clientMocks.NewRepositoryI(t)
+
+	mockEntryRepo.On("GetUserReport", mock.Anything, userID, byProject).Return(report, nil)
+	mockEntryRepo.On("GetUserReport", mock.Anything, userID, ofClient).Return(report[1:], nil)
+	mockClientRepo.On("GetClient", mock.Anything, clientID).Return(&models.Client{ID: clientID, UserID: userID}, nil)
+	mockClientRepo.On("GetClient", mock.Anything, otherClientID).Return(&models.Client{ID: otherClientID, UserID: 2}, nil)
+	mockClientRepo.On("GetClient", mock.Anything, projectID).Return(nil, models.ErrNotFound)
+
+	useCase := usecase.New(mockEntryRepo, nil, nil, nil, nil, mockClientRepo)
+
+	cases := map[string]struct {
+		filter      *models.ReportFilter
+		expectedRes []*models.ReportRow
+		err         error
+	}{
+		"by project": {
+			filter:      byProject,
+			expectedRes: report,
+		},
+		"of the client": {
+			filter:      ofClient,
+			expectedRes: report[1:],
+		},
+		"of a client of another user": {
+			filter: ofOtherClient,
+			err:    models.ErrPermissionDenied,
+		},
+		"of a missing client": {
+			filter: ofMissingClient,
+			err:    models.ErrNotFound,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			res, err := useCase.GetUserReport(context.Background(), userID, test.filter)
+			require.Equal(t, test.err, errors.Cause(err))
+			assert.Equal(t, test.expectedRes, res)
+		})
+	}
+}
+
+func TestUsecaseGetUserClientEntries(t *testing.T) {
+	userID, clientID, otherClientID := uint64(1), uint64(10), uint64(11)
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	entries := []*models.Entry{{ID: 1, UserID: &userID}}
+
+	mockEntryRepo := entryMocks.NewRepositoryI(t)
+	mockClientRepo := clientMocks.NewRepositoryI(t)
+
+	mockClientRepo.On("GetClient", mock.Anything, clientID).Return(&models.Client{ID: clientID, UserID: userID}, nil)
+	mockClientRepo.On("GetClient", mock.Anything, otherClientID).Return(&models.Client{ID: otherClientID, UserID: 2}, nil)
+	mockEntryRepo.On("GetUserClientEntries", mock.Anything, userID, clientID, day, day.AddDate(0, 0, 1)).Return(entries, nil)
+
+	useCase := usecase.New(mockEntryRepo, nil, nil, nil, nil, mockClientRepo)
+
+	res, err := useCase.GetUserClientEntries(context.Background(), userID, clientID, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, entries, res)
+
+	_, err = useCase.GetUserClientEntries(context.Background(), userID, otherClientID, time.Time{}, time.Time{})
+	assert.Equal(t, models.ErrPermissionDenied, errors.Cause(err))
+}
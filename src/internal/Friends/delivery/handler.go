@@ -4,9 +4,8 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/pkg/errors"
-
 	friendUsecase "timetracker/internal/Friends/usecase"
+	"timetracker/internal/apierror"
 	"timetracker/internal/middleware"
 	"timetracker/models"
 	"timetracker/models/dto"
@@ -26,35 +25,35 @@ type Delivery struct {
 // @Produce  application/json
 // @Param user_id path int true "Friend ID"
 // @Success  201 "success subscribe"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 404 {object} echo.HTTPError "subscribe or user doesn't exist"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 409 {object} echo.HTTPError "subscribe already exists"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 403 {object} echo.HTTPError "invalid csrf"
-// @Router   /friends/subscribe/{user_id} [post]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 404 {object} apierror.Error "not_found: subscribe or user doesn't exist"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 409 {object} apierror.Error "friend_conflict: subscribe already exists"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Router   /api/v1/me/subscriptions/{user_id} [post]
 func (delivery *Delivery) Subscribe(c echo.Context) error {
 	friendId, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
 	friendRel := &models.FriendRelation{SubscriberID: &userId, UserID: &friendId}
 
-	err = delivery.FriendsUC.CreateFriendRelation(friendRel)
+	err = delivery.FriendsUC.CreateFriendRelation(c.Request().Context(), friendRel)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	return c.NoContent(http.StatusCreated)
@@ -67,34 +66,34 @@ func (delivery *Delivery) Subscribe(c echo.Context) error {
 // @Produce  application/json
 // @Param user_id path int true "Friend ID"
 // @Success  204 "success unsubscribe, body is empty"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 404 {object} echo.HTTPError "friend/user/friendship doesn't exist"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 403 {object} echo.HTTPError "invalid csrf"
-// @Router   /friends/unsubscribe/{user_id} [delete]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 404 {object} apierror.Error "not_found: friend/user/friendship doesn't exist"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Router   /api/v1/me/subscriptions/{user_id} [delete]
 func (delivery *Delivery) Unsubscribe(c echo.Context) error {
 	friendId, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
 	friendRel := &models.FriendRelation{SubscriberID: &userId, UserID: &friendId}
 
-	err = delivery.FriendsUC.DeleteFriendRelation(friendRel)
+	err = delivery.FriendsUC.DeleteFriendRelation(c.Request().Context(), friendRel)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	return c.NoContent(http.StatusNoContent)
@@ -106,24 +105,24 @@ func (delivery *Delivery) Unsubscribe(c echo.Context) error {
 // @Tags     friends
 // @Produce  application/json
 // @Success  200 {object} pkg.Response{body=[]dto.RespUser} "success get profile"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 404 {object} echo.HTTPError "user doesn't exist"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Router   /me/friends [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 404 {object} apierror.Error "not_found: user doesn't exist"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/me/friends [get]
 func (delivery *Delivery) GetMyFriends(c echo.Context) error {
 	user, ok := c.Get("user").(*models.User)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	friends, err := delivery.FriendsUC.GetUserFriends(user.ID)
+	friends, err := delivery.FriendsUC.GetUserFriends(c.Request().Context(), user.ID)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respFriends := dto.GetResponseFromModelUsers(friends)
@@ -138,25 +137,29 @@ func (delivery *Delivery) GetMyFriends(c echo.Context) error {
 // @Produce  application/json
 // @Param user_id path int true "User ID"
 // @Success  200 {object} pkg.Response{body=[]dto.RespUser} "success get profile"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 404 {object} echo.HTTPError "user doesn't exist"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Router   /user/{user_id}/friends [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 404 {object} apierror.Error "not_found: user doesn't exist"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/users/{user_id}/friends [get]
 func (delivery *Delivery) GetUserFriends(c echo.Context) error {
 	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	if authUserID, ok := c.Get("user_id").(uint64); ok && authUserID != userID {
+		middleware.AuditAccess(c, userID, models.PermFriendsReadAny)
 	}
 
-	friends, err := delivery.FriendsUC.GetUserFriends(userID)
+	friends, err := delivery.FriendsUC.GetUserFriends(c.Request().Context(), userID)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respFriends := dto.GetResponseFromModelUsers(friends)
@@ -170,24 +173,24 @@ func (delivery *Delivery) GetUserFriends(c echo.Context) error {
 // @Tags     friends
 // @Produce  application/json
 // @Success  200 {object} pkg.Response{body=[]dto.RespUser} "success get profile"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 404 {object} echo.HTTPError "user doesn't exist"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Router   /me/subs [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 404 {object} apierror.Error "not_found: user doesn't exist"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/me/subscriptions [get]
 func (delivery *Delivery) GetMySubs(c echo.Context) error {
 	user, ok := c.Get("user").(*models.User)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	subs, err := delivery.FriendsUC.GetUserSubs(user.ID)
+	subs, err := delivery.FriendsUC.GetUserSubs(c.Request().Context(), user.ID)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respFriends := dto.GetResponseFromModelUsers(subs)
@@ -197,30 +200,34 @@ func (delivery *Delivery) GetMySubs(c echo.Context) error {
 
 // GetUserSubs godoc
 // @Summary      get user subs
-// @Description  get user subs. Acl: admin only
+// @Description  get user subs. Acl: friends:read:any
 // @Tags     friends
 // @Produce  application/json
 // @Param user_id path int true "User ID"
 // @Success  200 {object} pkg.Response{body=[]dto.RespUser} "success get profile"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 404 {object} echo.HTTPError "user doesn't exist"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Router   /user/{user_id}/subs [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 404 {object} apierror.Error "not_found: user doesn't exist"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /api/v1/users/{user_id}/subscriptions [get]
 func (delivery *Delivery) GetUserSubs(c echo.Context) error {
 	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	if authUserID, ok := c.Get("user_id").(uint64); ok && authUserID != userID {
+		middleware.AuditAccess(c, userID, models.PermFriendsReadAny)
 	}
 
-	subs, err := delivery.FriendsUC.GetUserSubs(userID)
+	subs, err := delivery.FriendsUC.GetUserSubs(c.Request().Context(), userID)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respFriends := dto.GetResponseFromModelUsers(subs)
@@ -228,29 +235,24 @@ func (delivery *Delivery) GetUserSubs(c echo.Context) error {
 	return c.JSON(http.StatusOK, pkg.Response{Body: respFriends})
 }
 
-func handleError(err error) *echo.HTTPError {
-	causeErr := errors.Cause(err)
-	switch {
-	case errors.Is(causeErr, models.ErrNotFound):
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
-	case errors.Is(causeErr, models.ErrBadRequest):
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-	case errors.Is(causeErr, models.ErrPermissionDenied):
-		return echo.NewHTTPError(http.StatusForbidden, models.ErrPermissionDenied.Error())
-	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, causeErr.Error())
-	}
-}
-
 func NewDelivery(e *echo.Echo, uc friendUsecase.UsecaseI, aclM *middleware.AclMiddleware) {
 	handler := &Delivery{
 		FriendsUC: uc,
 	}
 
-	e.POST("/friends/subscribe/:user_id", handler.Subscribe)
-	e.DELETE("/friends/unsubscribe/:user_id", handler.Unsubscribe)
-	e.GET("/user/:user_id/subs", handler.GetUserSubs, aclM.AdminOnly)
-	e.GET("/user/:user_id/friends", handler.GetUserFriends, aclM.AdminOnly)
-	e.GET("/me/subs", handler.GetMySubs)
-	e.GET("/me/friends", handler.GetMyFriends)
+	v1 := e.Group(middleware.APIv1)
+	v1.POST("/me/subscriptions/:user_id", handler.Subscribe)
+	v1.DELETE("/me/subscriptions/:user_id", handler.Unsubscribe)
+	v1.GET("/me/subscriptions", handler.GetMySubs)
+	v1.GET("/me/friends", handler.GetMyFriends)
+	v1.GET("/users/:user_id/subscriptions", handler.GetUserSubs, aclM.RequirePermission(models.PermFriendsReadAny))
+	v1.GET("/users/:user_id/friends", handler.GetUserFriends, aclM.RequirePermission(models.PermFriendsReadAny))
+
+	// the routes from before /api/v1
+	e.POST("/friends/subscribe/:user_id", handler.Subscribe, middleware.Deprecated(middleware.APIv1+"/me/subscriptions/:user_id"))
+	e.DELETE("/friends/unsubscribe/:user_id", handler.Unsubscribe, middleware.Deprecated(middleware.APIv1+"/me/subscriptions/:user_id"))
+	e.GET("/user/:user_id/subs", handler.GetUserSubs, middleware.Deprecated(middleware.APIv1+"/users/:user_id/subscriptions"), aclM.RequirePermission(models.PermFriendsReadAny))
+	e.GET("/user/:user_id/friends", handler.GetUserFriends, middleware.Deprecated(middleware.APIv1+"/users/:user_id/friends"), aclM.RequirePermission(models.PermFriendsReadAny))
+	e.GET("/me/subs", handler.GetMySubs, middleware.Deprecated(middleware.APIv1+"/me/subscriptions"))
+	e.GET("/me/friends", handler.GetMyFriends, middleware.Deprecated(middleware.APIv1+"/me/friends"))
 }
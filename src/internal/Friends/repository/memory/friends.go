@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"timetracker/internal/Friends/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+func toMemoryFriendRelation(t *models.FriendRelation) memoryDB.FriendRelation {
+	return memoryDB.FriendRelation{
+		SubscriberID: *t.SubscriberID,
+		UserID:       *t.UserID,
+	}
+}
+
+type friendRepository struct {
+	db *memoryDB.DB
+}
+
+func (fr friendRepository) CreateFriendRelation(ctx context.Context, t *models.FriendRelation) error {
+	fr.db.Lock()
+	defer fr.db.Unlock()
+
+	relation := toMemoryFriendRelation(t)
+	_, subscriberExists := fr.db.Users[relation.SubscriberID]
+	_, userExists := fr.db.Users[relation.UserID]
+	_, relationExists := fr.db.Friends[relation]
+	if !subscriberExists || !userExists || relationExists {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table friend_relation)")
+	}
+
+	fr.db.Friends[relation] = struct{}{}
+	return nil
+}
+
+func (fr friendRepository) CheckFriends(ctx context.Context, t *models.FriendRelation) (bool, error) {
+	fr.db.RLock()
+	defer fr.db.RUnlock()
+
+	_, ok := fr.db.Friends[toMemoryFriendRelation(t)]
+	return ok, nil
+}
+
+func (fr friendRepository) DeleteFriendRelation(ctx context.Context, friendRel *models.FriendRelation) error {
+	fr.db.Lock()
+	defer fr.db.Unlock()
+
+	delete(fr.db.Friends, toMemoryFriendRelation(friendRel))
+	return nil
+}
+
+// GetUserSubs returns the subscribers the user isn't subscribed back to
+func (fr friendRepository) GetUserSubs(ctx context.Context, userID uint64) ([]uint64, error) {
+	return fr.findSubscribers(userID, false), nil
+}
+
+// GetUserFriends returns the subscribers the user is subscribed back to
+func (fr friendRepository) GetUserFriends(ctx context.Context, userID uint64) ([]uint64, error) {
+	return fr.findSubscribers(userID, true), nil
+}
+
+func (fr friendRepository) findSubscribers(userID uint64, mutual bool) []uint64 {
+	fr.db.RLock()
+	defer fr.db.RUnlock()
+
+	userIDs := make([]uint64, 0, 10)
+	for relation := range fr.db.Friends {
+		if relation.UserID != userID {
+			continue
+		}
+
+		_, back := fr.db.Friends[memoryDB.FriendRelation{SubscriberID: userID, UserID: relation.SubscriberID}]
+		if back == mutual {
+			userIDs = append(userIDs, relation.SubscriberID)
+		}
+	}
+
+	sort.Slice(userIDs, func(i, j int) bool {
+		return userIDs[i] < userIDs[j]
+	})
+
+	return userIDs
+}
+
+func NewFriendRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &friendRepository{
+		db: db,
+	}
+}
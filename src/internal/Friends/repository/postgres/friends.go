@@ -1,7 +1,7 @@
 package postgres
 
 import (
-	"fmt"
+	"context"
 	"timetracker/internal/Friends/repository"
 	"timetracker/models"
 
@@ -46,10 +46,10 @@ func toModelFriendRelations(friends []*FriendRelation) []*models.FriendRelation
 	return out
 }
 
-func (fr friendRepository) CreateFriendRelation(t *models.FriendRelation) error {
+func (fr friendRepository) CreateFriendRelation(ctx context.Context, t *models.FriendRelation) error {
 	postgresFriend := toPostgresFriendRelation(t)
 
-	tx := fr.db.Create(postgresFriend)
+	tx := fr.db.WithContext(ctx).Create(postgresFriend)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table friend_relation)")
@@ -58,10 +58,9 @@ func (fr friendRepository) CreateFriendRelation(t *models.FriendRelation) error
 	return nil
 }
 
-func (fr friendRepository) CheckFriends(t *models.FriendRelation) (bool, error) {
+func (fr friendRepository) CheckFriends(ctx context.Context, t *models.FriendRelation) (bool, error) {
 	postgresFriend := toPostgresFriendRelation(t)
-	fmt.Println("postgresFriend: ", *postgresFriend.SubscriberID, "   ", *postgresFriend.UserID)
-	tx := fr.db.Where(postgresFriend).Take(&FriendRelation{})
+	tx := fr.db.WithContext(ctx).Where(postgresFriend).Take(&FriendRelation{})
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return false, nil
@@ -72,9 +71,9 @@ func (fr friendRepository) CheckFriends(t *models.FriendRelation) (bool, error)
 	return true, nil
 }
 
-func (fr friendRepository) DeleteFriendRelation(friendRel *models.FriendRelation) error {
+func (fr friendRepository) DeleteFriendRelation(ctx context.Context, friendRel *models.FriendRelation) error {
 	relation := toPostgresFriendRelation(friendRel)
-	tx := fr.db.Where(relation).Delete(&FriendRelation{})
+	tx := fr.db.WithContext(ctx).Where(relation).Delete(&FriendRelation{})
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table friend_relation)")
@@ -83,9 +82,9 @@ func (fr friendRepository) DeleteFriendRelation(friendRel *models.FriendRelation
 	return nil
 }
 
-func (fr friendRepository) GetUserSubs(userID uint64) ([]uint64, error) {
+func (fr friendRepository) GetUserSubs(ctx context.Context, userID uint64) ([]uint64, error) {
 	userIDs := make([]uint64, 0, 10)
-	tx := fr.db.Table(FriendRelation{}.TableName()+" f1").
+	tx := fr.db.WithContext(ctx).Table(FriendRelation{}.TableName()+" f1").
 		Select("f1.subscriber_id").
 		Joins("left join friend_relation f2 on f2.user_id = f1.subscriber_id and f2.subscriber_id = f1.user_id").
 		Where("f1.user_id = ? and f2.user_id is null", userID).Find(&FriendRelation{}).Pluck("subscriber_id", &userIDs)
@@ -97,9 +96,9 @@ func (fr friendRepository) GetUserSubs(userID uint64) ([]uint64, error) {
 	return userIDs, nil
 }
 
-func (fr friendRepository) GetUserFriends(userID uint64) ([]uint64, error) {
+func (fr friendRepository) GetUserFriends(ctx context.Context, userID uint64) ([]uint64, error) {
 	userIDs := make([]uint64, 0, 10)
-	tx := fr.db.Table(FriendRelation{}.TableName()+" f1").
+	tx := fr.db.WithContext(ctx).Table(FriendRelation{}.TableName()+" f1").
 		Select("f1.subscriber_id").
 		Joins("join friend_relation f2 on f2.user_id = f1.subscriber_id and f2.subscriber_id = f1.user_id").
 		Where("f1.user_id = ?", userID).Find(&FriendRelation{}).Pluck("subscriber_id", &userIDs)
@@ -1,11 +1,14 @@
 package repository
 
-import "timetracker/models"
+import (
+	"context"
+	"timetracker/models"
+)
 
 type RepositoryI interface {
-	CreateFriendRelation(t *models.FriendRelation) error
-	DeleteFriendRelation(friendRel *models.FriendRelation) error
-	CheckFriends(t *models.FriendRelation) (bool, error)
-	GetUserSubs(userID uint64) ([]uint64, error)
-	GetUserFriends(userID uint64) ([]uint64, error)
+	CreateFriendRelation(ctx context.Context, t *models.FriendRelation) error
+	DeleteFriendRelation(ctx context.Context, friendRel *models.FriendRelation) error
+	CheckFriends(ctx context.Context, t *models.FriendRelation) (bool, error)
+	GetUserSubs(ctx context.Context, userID uint64) ([]uint64, error)
+	GetUserFriends(ctx context.Context, userID uint64) ([]uint64, error)
 }
@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+	"timetracker/internal/tracing"
 	"timetracker/models"
 
 	friendRep "timetracker/internal/Friends/repository"
@@ -10,11 +12,11 @@ import (
 )
 
 type UsecaseI interface {
-	CreateFriendRelation(friendRel *models.FriendRelation) error
-	DeleteFriendRelation(friendRel *models.FriendRelation) error
-	CheckIsFriends(userID1 uint64, userID2 uint64) (bool, error)
-	GetUserSubs(id uint64) ([]*models.User, error)
-	GetUserFriends(id uint64) ([]*models.User, error)
+	CreateFriendRelation(ctx context.Context, friendRel *models.FriendRelation) error
+	DeleteFriendRelation(ctx context.Context, friendRel *models.FriendRelation) error
+	CheckIsFriends(ctx context.Context, userID1 uint64, userID2 uint64) (bool, error)
+	GetUserSubs(ctx context.Context, id uint64) ([]*models.User, error)
+	GetUserFriends(ctx context.Context, id uint64) ([]*models.User, error)
 }
 
 type usecase struct {
@@ -29,12 +31,15 @@ func New(fRep friendRep.RepositoryI, uRep userRep.RepositoryI) UsecaseI {
 	}
 }
 
-func (uc *usecase) CreateFriendRelation(friendRel *models.FriendRelation) error {
+func (uc *usecase) CreateFriendRelation(ctx context.Context, friendRel *models.FriendRelation) error {
+	ctx, span := tracing.Start(ctx, "friends.Usecase.CreateFriendRelation")
+	defer span.End()
+
 	if friendRel.SubscriberID == friendRel.UserID {
 		return models.ErrBadRequest
 	}
 
-	friendExists, err := uc.friendsRepository.CheckFriends(friendRel)
+	friendExists, err := uc.friendsRepository.CheckFriends(ctx, friendRel)
 	if err != nil {
 		return errors.Wrap(err, "friends repository error")
 	}
@@ -43,7 +48,7 @@ func (uc *usecase) CreateFriendRelation(friendRel *models.FriendRelation) error
 		return models.ErrConflictFriend
 	}
 
-	err = uc.friendsRepository.CreateFriendRelation(friendRel)
+	err = uc.friendsRepository.CreateFriendRelation(ctx, friendRel)
 	if err != nil {
 		return errors.Wrap(err, "friends repository error")
 	}
@@ -51,12 +56,15 @@ func (uc *usecase) CreateFriendRelation(friendRel *models.FriendRelation) error
 	return err
 }
 
-func (uc *usecase) DeleteFriendRelation(friends *models.FriendRelation) error {
+func (uc *usecase) DeleteFriendRelation(ctx context.Context, friends *models.FriendRelation) error {
+	ctx, span := tracing.Start(ctx, "friends.Usecase.DeleteFriendRelation")
+	defer span.End()
+
 	if friends.SubscriberID == friends.UserID {
 		return models.ErrBadRequest
 	}
 
-	friendExists, err := uc.friendsRepository.CheckFriends(friends)
+	friendExists, err := uc.friendsRepository.CheckFriends(ctx, friends)
 	if err != nil {
 		return errors.Wrap(err, "friends repository error")
 	}
@@ -64,7 +72,7 @@ func (uc *usecase) DeleteFriendRelation(friends *models.FriendRelation) error {
 		return models.ErrNotFound
 	}
 
-	err = uc.friendsRepository.DeleteFriendRelation(friends)
+	err = uc.friendsRepository.DeleteFriendRelation(ctx, friends)
 	if err != nil {
 		return errors.Wrap(err, "friends repository error")
 	}
@@ -72,8 +80,11 @@ func (uc *usecase) DeleteFriendRelation(friends *models.FriendRelation) error {
 	return nil
 }
 
-func (uc *usecase) GetUserFriends(id uint64) ([]*models.User, error) {
-	friendsIDs, err := uc.friendsRepository.GetUserFriends(id)
+func (uc *usecase) GetUserFriends(ctx context.Context, id uint64) ([]*models.User, error) {
+	ctx, span := tracing.Start(ctx, "friends.Usecase.GetUserFriends")
+	defer span.End()
+
+	friendsIDs, err := uc.friendsRepository.GetUserFriends(ctx, id)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "friends repository error")
@@ -83,7 +94,7 @@ func (uc *usecase) GetUserFriends(id uint64) ([]*models.User, error) {
 		return nil, nil
 	}
 
-	friends, err := uc.userRepository.GetUsersByIDs(friendsIDs)
+	friends, err := uc.userRepository.GetUsersByIDs(ctx, friendsIDs)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "friends repository error")
@@ -92,8 +103,11 @@ func (uc *usecase) GetUserFriends(id uint64) ([]*models.User, error) {
 	return friends, nil
 }
 
-func (uc *usecase) GetUserSubs(id uint64) ([]*models.User, error) {
-	subIDs, err := uc.friendsRepository.GetUserSubs(id)
+func (uc *usecase) GetUserSubs(ctx context.Context, id uint64) ([]*models.User, error) {
+	ctx, span := tracing.Start(ctx, "friends.Usecase.GetUserSubs")
+	defer span.End()
+
+	subIDs, err := uc.friendsRepository.GetUserSubs(ctx, id)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "friends repository error")
@@ -103,7 +117,7 @@ func (uc *usecase) GetUserSubs(id uint64) ([]*models.User, error) {
 		return nil, nil
 	}
 
-	subs, err := uc.userRepository.GetUsersByIDs(subIDs)
+	subs, err := uc.userRepository.GetUsersByIDs(ctx, subIDs)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "friends repository error")
@@ -112,19 +126,22 @@ func (uc *usecase) GetUserSubs(id uint64) ([]*models.User, error) {
 	return subs, nil
 }
 
-func (uc *usecase) CheckIsFriends(userID1 uint64, userID2 uint64) (bool, error) {
+func (uc *usecase) CheckIsFriends(ctx context.Context, userID1 uint64, userID2 uint64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "friends.Usecase.CheckIsFriends")
+	defer span.End()
+
 	if userID1 == userID2 {
 		return false, models.ErrBadRequest
 	}
 
 	friendRel1 := models.FriendRelation{SubscriberID: &userID1, UserID: &userID2}
-	friendExists1, err := uc.friendsRepository.CheckFriends(&friendRel1)
+	friendExists1, err := uc.friendsRepository.CheckFriends(ctx, &friendRel1)
 	if err != nil {
 		return false, errors.Wrap(err, "friends repository error")
 	}
 
 	friendRel2 := models.FriendRelation{SubscriberID: &userID2, UserID: &userID1}
-	friendExists2, err := uc.friendsRepository.CheckFriends(&friendRel2)
+	friendExists2, err := uc.friendsRepository.CheckFriends(ctx, &friendRel2)
 	if err != nil {
 		return false, errors.Wrap(err, "friends repository error")
 	}
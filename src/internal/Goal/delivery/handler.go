@@ -4,34 +4,19 @@ import (
 	"net/http"
 	"strconv"
 	goalUsecase "timetracker/internal/Goal/usecase"
+	"timetracker/internal/apierror"
 	"timetracker/internal/middleware"
 	"timetracker/models"
 	"timetracker/models/dto"
 	"timetracker/pkg"
 
 	"github.com/labstack/echo/v4"
-	"github.com/pkg/errors"
 )
 
 type Delivery struct {
 	GoalUC goalUsecase.UsecaseI
 }
 
-func (del *Delivery) ownerOrAdminValidate(c echo.Context, goal *models.Goal) error {
-	user, ok := c.Get("user").(*models.User)
-
-	if !ok {
-		c.Logger().Error("can't get user from context")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
-	}
-
-	if user.Role == models.Admin.String() || *goal.UserID == user.ID {
-		return nil
-	}
-
-	return models.ErrPermissionDenied
-}
-
 // CreateGoal godoc
 // @Summary      Create goal
 // @Description  Create goal
@@ -40,14 +25,14 @@ func (del *Delivery) ownerOrAdminValidate(c echo.Context, goal *models.Goal) err
 // @Produce  application/json
 // @Param    goal body dto.ReqCreateUpdateGoal true "goal info"
 // @Success  200 {object} pkg.Response{body=dto.RespGoal} "success update goal"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 422 {object} echo.HTTPError "unprocessable entity"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 400 {object} echo.HTTPError "bad req"
-// @Failure 403 {object} echo.HTTPError "invalid csrf or permission denied"
-// @Router   /goal/create [post]
+// @Header   200 {string} ETag "version of the goal"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 409 {object} apierror.Error "project_archived or project_deleted: the project takes no new items"
+// @Router   /api/v1/goals [post]
 func (delivery *Delivery) CreateGoal(c echo.Context) error {
 
 	var reqGoal dto.ReqCreateUpdateGoal
@@ -55,29 +40,30 @@ func (delivery *Delivery) CreateGoal(c echo.Context) error {
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		return apierror.Bind(err)
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqGoal); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.Validation(err)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
 	goal := reqGoal.ToModelGoal()
 	goal.UserID = &userId
-	err = delivery.GoalUC.CreateGoal(goal)
+	err = delivery.GoalUC.CreateGoal(c.Request().Context(), goal)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelGoal(goal))
 	respGoal := dto.GetResponseFromModelGoal(goal)
 
 	return c.JSON(http.StatusOK, pkg.Response{Body: *respGoal})
@@ -85,35 +71,42 @@ func (delivery *Delivery) CreateGoal(c echo.Context) error {
 
 // GetGoal godoc
 // @Summary      Show a post
-// @Description  Get goal by id. Acl: admin, owner
+// @Description  Get goal by id. Acl: owner, goal:read:any
 // @Tags     	 goal
 // @Accept	 application/json
 // @Produce  application/json
 // @Param id  path int  true  "Goal ID"
+// @Param    If-None-Match header string false "ETag of the cached goal"
 // @Success  200 {object} pkg.Response{body=dto.RespGoal} "success get goal"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /goal/{id} [get]
+// @Header   200 {string} ETag "version of the goal"
+// @Success  304 "the cached goal is up to date"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/goals/{id} [get]
 func (delivery *Delivery) GetGoal(c echo.Context) error {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
 	}
-	goal, err := delivery.GoalUC.GetGoal(id)
+	goal, err := delivery.GoalUC.GetGoal(c.Request().Context(), id)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
-	err = delivery.ownerOrAdminValidate(c, goal)
+	err = middleware.AuthorizeResource(c, *goal.UserID, models.PermGoalReadAny)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
+	}
+
+	if middleware.NotModified(c, dto.GetETagFromModelGoal(goal)) {
+		return c.NoContent(http.StatusNotModified)
 	}
 
 	respGoal := dto.GetResponseFromModelGoal(goal)
@@ -122,49 +115,72 @@ func (delivery *Delivery) GetGoal(c echo.Context) error {
 
 // UpdateGoal godoc
 // @Summary      Update a goal
-// @Description  Update a goal. Acl: owner only
+// @Description  Update a goal. Only the fields present in the body are changed. Acl: owner only
 // @Tags     	 goal
 // @Accept	 application/json
 // @Produce  application/json
-// @Param    goal body dto.ReqCreateUpdateGoal true "goal info"
+// @Param    id path int true "Goal ID"
+// @Param    If-Match header string true "ETag of the goal the changes are based on, required by the legacy POST /goal/edit too"
+// @Param    goal body dto.ReqPatchGoal true "goal info"
 // @Success  200 {object} pkg.Response{body=dto.RespGoal} "success update goal"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 422 {object} echo.HTTPError "unprocessable entity"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 403 {object} echo.HTTPError "invalid csrf or permission denied"
-// @Router   /goal/edit [post]
+// @Header   200 {string} ETag "version of the updated goal"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 412 {object} apierror.Error "version_mismatch: the goal was changed since the If-Match version"
+// @Failure 428 {object} apierror.Error "if_match_required"
+// @Failure 409 {object} apierror.Error "project_archived or project_deleted: the project takes no new items"
+// @Router   /api/v1/goals/{id} [patch]
 func (delivery *Delivery) UpdateGoal(c echo.Context) error {
 
-	var reqGoal dto.ReqCreateUpdateGoal
+	var reqGoal dto.ReqPatchGoal
 	err := c.Bind(&reqGoal)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+		return apierror.Bind(err)
+	}
+
+	// the ID is in the path of PATCH /api/v1/goals/:id and in the body of the legacy route
+	if param := c.Param("id"); param != "" {
+		id, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			c.Logger().Error(err)
+			return apierror.From(models.ErrBadRequest)
+		}
+		reqGoal.ID = id
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqGoal); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.Validation(err)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	goal := reqGoal.ToModelGoal()
-	goal.UserID = &userId
-	err = delivery.GoalUC.UpdateGoal(goal)
+	version, err := middleware.IfMatch(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	patch := reqGoal.ToModelGoalPatch()
+	patch.UserID = userId
+	patch.Version = version
+	goal, err := delivery.GoalUC.UpdateGoal(c.Request().Context(), patch)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelGoal(goal))
 	respGoal := dto.GetResponseFromModelGoal(goal)
 
 	return c.JSON(http.StatusOK, pkg.Response{Body: *respGoal})
@@ -172,64 +188,105 @@ func (delivery *Delivery) UpdateGoal(c echo.Context) error {
 
 // DeleteGoal godoc
 // @Summary      Delete a goal. Acl: owner only
-// @Description  Delete a goal
+// @Description  Delete a goal, it stays in the trash until it is restored or purged
 // @Tags     	 goal
 // @Accept	 application/json
 // @Param id path int  true  "Goal ID"
 // @Success  204
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 404 {object} echo.HTTPError "can't find goal with such id"
-// @Failure 403 {object} echo.HTTPError "invalid csrf"
-// @Router   /goal/{id} [delete]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found: can't find goal with such id"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Router   /api/v1/goals/{id} [delete]
 func (delivery *Delivery) DeleteGoal(c echo.Context) error {
 
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
+		return apierror.From(models.ErrNotFound)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	err = delivery.GoalUC.DeleteGoal(id, userId)
+	err = delivery.GoalUC.DeleteGoal(c.Request().Context(), id, userId)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// RestoreGoal godoc
+// @Summary      Restore a goal
+// @Description  Restore a goal from the trash. A goal of a deleted project is restored with the project. Acl: owner
+// @Tags     	 goal
+// @Produce  application/json
+// @Param id path int  true  "Goal ID"
+// @Success  200 {object} pkg.Response{body=dto.RespGoal} "the restored goal"
+// @Header   200 {string} ETag "version of the restored goal"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found: no goal with such id in the trash"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 409 {object} apierror.Error "project_deleted: restore the project first"
+// @Router   /api/v1/goals/{id}/restore [post]
+func (delivery *Delivery) RestoreGoal(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	goal, err := delivery.GoalUC.RestoreGoal(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelGoal(goal))
+	respGoal := dto.GetResponseFromModelGoal(goal)
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respGoal})
+}
+
 // GetMyGoals godoc
 // @Summary      Get my goals
 // @Description  Get my goals. Acl: admin,
 // @Tags     goal
 // @Produce  application/json
 // @Success  200 {object} pkg.Response{body=[]dto.RespGoal} "success get goals"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /me/goals [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/me/goals [get]
 func (delivery *Delivery) GetMyGoals(c echo.Context) error {
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	goals, err := delivery.GoalUC.GetUserGoals(userId)
+	goals, err := delivery.GoalUC.GetUserGoals(c.Request().Context(), userId)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respEnties := dto.GetResponseFromModelGoals(goals)
@@ -239,27 +296,27 @@ func (delivery *Delivery) GetMyGoals(c echo.Context) error {
 
 // GetUserGoals godoc
 // @Summary      Get user goals
-// @Description  Get user goals. Acl: admin, friends
+// @Description  Get user goals. Acl: friends, goal:read:any
 // @Tags     goal
 // @Produce  application/json
 // @Success  200 {object} pkg.Response{body=[]dto.RespGoal} "success get goals"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /user/{user_id}/goals [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/users/{user_id}/goals [get]
 func (delivery *Delivery) GetUserGoals(c echo.Context) error {
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	goals, err := delivery.GoalUC.GetUserGoals(userId)
+	goals, err := delivery.GoalUC.GetUserGoals(c.Request().Context(), userId)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respEnties := dto.GetResponseFromModelGoals(goals)
@@ -267,29 +324,25 @@ func (delivery *Delivery) GetUserGoals(c echo.Context) error {
 	return c.JSON(http.StatusOK, pkg.Response{Body: respEnties})
 }
 
-func handleError(err error) *echo.HTTPError {
-	causeErr := errors.Cause(err)
-	switch {
-	case errors.Is(causeErr, models.ErrNotFound):
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
-	case errors.Is(causeErr, models.ErrBadRequest):
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-	case errors.Is(causeErr, models.ErrPermissionDenied):
-		return echo.NewHTTPError(http.StatusForbidden, models.ErrPermissionDenied.Error())
-	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, causeErr.Error())
-	}
-}
-
 func NewDelivery(e *echo.Echo, eu goalUsecase.UsecaseI, aclM *middleware.AclMiddleware) {
 	handler := &Delivery{
 		GoalUC: eu,
 	}
 
-	e.POST("/goal/create", handler.CreateGoal)
-	e.POST("/goal/edit", handler.UpdateGoal)
-	e.GET("/goal/:id", handler.GetGoal)
-	e.DELETE("/goal/:id", handler.DeleteGoal)
-	e.GET("/me/goals", handler.GetMyGoals)
-	e.GET("/user/:user_id/goals", handler.GetUserGoals, aclM.FriendsOrAdminOnly)
+	v1 := e.Group(middleware.APIv1)
+	v1.POST("/goals", handler.CreateGoal)
+	v1.GET("/goals/:id", handler.GetGoal)
+	v1.PATCH("/goals/:id", handler.UpdateGoal, middleware.RequireIfMatch())
+	v1.DELETE("/goals/:id", handler.DeleteGoal)
+	v1.POST("/goals/:id/restore", handler.RestoreGoal)
+	v1.GET("/me/goals", handler.GetMyGoals)
+	v1.GET("/users/:user_id/goals", handler.GetUserGoals, aclM.FriendsOrPermission(models.PermGoalReadAny))
+
+	// the routes from before /api/v1
+	e.POST("/goal/create", handler.CreateGoal, middleware.Deprecated(middleware.APIv1+"/goals"))
+	e.POST("/goal/edit", handler.UpdateGoal, middleware.Deprecated(middleware.APIv1+"/goals/:id"), middleware.RequireIfMatch())
+	e.GET("/goal/:id", handler.GetGoal, middleware.Deprecated(middleware.APIv1+"/goals/:id"))
+	e.DELETE("/goal/:id", handler.DeleteGoal, middleware.Deprecated(middleware.APIv1+"/goals/:id"))
+	e.GET("/me/goals", handler.GetMyGoals, middleware.Deprecated(middleware.APIv1+"/me/goals"))
+	e.GET("/user/:user_id/goals", handler.GetUserGoals, middleware.Deprecated(middleware.APIv1+"/users/:user_id/goals"), aclM.FriendsOrPermission(models.PermGoalReadAny))
 }
@@ -0,0 +1,222 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+	"timetracker/internal/Goal/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+func copyGoal(g *models.Goal) *models.Goal {
+	return &models.Goal{
+		ID:          g.ID,
+		UserID:      memoryDB.CopyID(g.UserID),
+		Name:        g.Name,
+		ProjectID:   memoryDB.CopyID(g.ProjectID),
+		Description: g.Description,
+		TimeStart:   g.TimeStart,
+		TimeEnd:     g.TimeEnd,
+		HoursCount:  g.HoursCount,
+		Version:     g.Version,
+		UpdatedAt:   g.UpdatedAt,
+		DeletedAt:   memoryDB.CopyTime(g.DeletedAt),
+	}
+}
+
+type goalRepository struct {
+	db *memoryDB.DB
+}
+
+func (gr goalRepository) CreateGoal(ctx context.Context, g *models.Goal) error {
+	gr.db.Lock()
+	defer gr.db.Unlock()
+
+	g.Version, g.UpdatedAt = 1, time.Now().UTC()
+
+	if err := gr.checkReferences(g); err != nil {
+		return err
+	}
+
+	goal := copyGoal(g)
+	goal.ID = gr.db.NextID("goal")
+	gr.db.Goals[goal.ID] = goal
+
+	g.ID = goal.ID
+	return nil
+}
+
+// UpdateGoal writes the editable fields even when they are zero, like the postgres repository
+func (gr goalRepository) UpdateGoal(ctx context.Context, g *models.Goal) error {
+	gr.db.Lock()
+	defer gr.db.Unlock()
+
+	goal, ok := gr.db.Goals[g.ID]
+	if !ok || goal.DeletedAt != nil {
+		return models.ErrNotFound
+	}
+
+	if goal.Version != g.Version {
+		return models.ErrVersionMismatch
+	}
+
+	if err := gr.checkReferences(g); err != nil {
+		return err
+	}
+
+	goal.Name = g.Name
+	goal.ProjectID = memoryDB.CopyID(g.ProjectID)
+	goal.HoursCount = g.HoursCount
+	goal.Description = g.Description
+	goal.TimeStart = g.TimeStart
+	goal.TimeEnd = g.TimeEnd
+	goal.Version++
+	goal.UpdatedAt = time.Now().UTC()
+
+	g.Version, g.UpdatedAt = goal.Version, goal.UpdatedAt
+	return nil
+}
+
+func (gr goalRepository) checkReferences(g *models.Goal) error {
+	if g.UserID == nil || g.ProjectID == nil {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table goal)")
+	}
+
+	_, userExists := gr.db.Users[*g.UserID]
+	_, projectExists := gr.db.Projects[*g.ProjectID]
+	if !userExists || !projectExists {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table goal)")
+	}
+
+	return nil
+}
+
+func (gr goalRepository) GetGoal(ctx context.Context, id uint64) (*models.Goal, error) {
+	gr.db.RLock()
+	defer gr.db.RUnlock()
+
+	goal, ok := gr.db.Goals[id]
+	if !ok || goal.DeletedAt != nil {
+		return nil, models.ErrNotFound
+	}
+
+	return copyGoal(goal), nil
+}
+
+func (gr goalRepository) DeleteGoal(ctx context.Context, id uint64) error {
+	gr.db.Lock()
+	defer gr.db.Unlock()
+
+	gr.db.SoftDeleteGoal(id, time.Now().UTC())
+	return nil
+}
+
+func (gr goalRepository) GetUserGoals(ctx context.Context, userID uint64) ([]*models.Goal, error) {
+	gr.db.RLock()
+	defer gr.db.RUnlock()
+
+	goals := make([]*models.Goal, 0, 10)
+	for _, goal := range gr.db.Goals {
+		if *goal.UserID == userID && goal.DeletedAt == nil {
+			goals = append(goals, copyGoal(goal))
+		}
+	}
+
+	sort.Slice(goals, func(i, j int) bool {
+		return goals[i].ID < goals[j].ID
+	})
+
+	return goals, nil
+}
+
+func (gr goalRepository) GetUserGoalChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Goal, error) {
+	gr.db.RLock()
+	defer gr.db.RUnlock()
+
+	goals := make([]*models.Goal, 0, 10)
+	for _, goal := range gr.db.Goals {
+		if *goal.UserID == userID && goal.UpdatedAt.After(since) && !goal.UpdatedAt.After(until) {
+			goals = append(goals, copyGoal(goal))
+		}
+	}
+
+	sort.Slice(goals, func(i, j int) bool {
+		return goals[i].UpdatedAt.Before(goals[j].UpdatedAt)
+	})
+
+	return goals, nil
+}
+
+func (gr goalRepository) GetDeletedGoal(ctx context.Context, id uint64) (*models.Goal, error) {
+	gr.db.RLock()
+	defer gr.db.RUnlock()
+
+	goal, ok := gr.db.Goals[id]
+	if !ok || goal.DeletedAt == nil {
+		return nil, models.ErrNotFound
+	}
+
+	return copyGoal(goal), nil
+}
+
+func (gr goalRepository) GetUserDeletedGoals(ctx context.Context, userID uint64) ([]*models.Goal, error) {
+	gr.db.RLock()
+	defer gr.db.RUnlock()
+
+	goals := make([]*models.Goal, 0, 10)
+	for _, goal := range gr.db.Goals {
+		if *goal.UserID == userID && goal.DeletedAt != nil {
+			goals = append(goals, copyGoal(goal))
+		}
+	}
+
+	sort.Slice(goals, func(i, j int) bool {
+		if !goals[i].DeletedAt.Equal(*goals[j].DeletedAt) {
+			return goals[i].DeletedAt.After(*goals[j].DeletedAt)
+		}
+		return goals[i].ID < goals[j].ID
+	})
+
+	return goals, nil
+}
+
+func (gr goalRepository) RestoreGoal(ctx context.Context, id uint64) error {
+	gr.db.Lock()
+	defer gr.db.Unlock()
+
+	goal, ok := gr.db.Goals[id]
+	if !ok || goal.DeletedAt == nil {
+		return models.ErrNotFound
+	}
+
+	if gr.db.ProjectDeleted(goal.ProjectID) {
+		return models.ErrProjectDeleted
+	}
+
+	gr.db.RestoreGoal(id, time.Now().UTC())
+	return nil
+}
+
+func (gr goalRepository) PurgeDeletedGoals(ctx context.Context, before time.Time) (int64, error) {
+	gr.db.Lock()
+	defer gr.db.Unlock()
+
+	var purged int64
+	for id, goal := range gr.db.Goals {
+		if goal.DeletedAt != nil && goal.DeletedAt.Before(before) {
+			delete(gr.db.Goals, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+func NewGoalRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &goalRepository{
+		db: db,
+	}
+}
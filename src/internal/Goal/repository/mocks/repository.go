@@ -3,9 +3,13 @@
 package mocks
 
 import (
+	context "context"
+
 	models "timetracker/models"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // RepositoryI is an autogenerated mock type for the RepositoryI type
@@ -13,13 +17,13 @@ type RepositoryI struct {
 	mock.Mock
 }
 
-// CreateGoal provides a mock function with given fields: g
-func (_m *RepositoryI) CreateGoal(g *models.Goal) error {
-	ret := _m.Called(g)
+// CreateGoal provides a mock function with given fields: ctx, g
+func (_m *RepositoryI) CreateGoal(ctx context.Context, g *models.Goal) error {
+	ret := _m.Called(ctx, g)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Goal) error); ok {
-		r0 = rf(g)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Goal) error); ok {
+		r0 = rf(ctx, g)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,13 +31,13 @@ func (_m *RepositoryI) CreateGoal(g *models.Goal) error {
 	return r0
 }
 
-// DeleteGoal provides a mock function with given fields: id
-func (_m *RepositoryI) DeleteGoal(id uint64) error {
-	ret := _m.Called(id)
+// DeleteGoal provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) DeleteGoal(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,25 +45,51 @@ func (_m *RepositoryI) DeleteGoal(id uint64) error {
 	return r0
 }
 
-// GetGoal provides a mock function with given fields: id
-func (_m *RepositoryI) GetGoal(id uint64) (*models.Goal, error) {
-	ret := _m.Called(id)
+// GetDeletedGoal provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) GetDeletedGoal(ctx context.Context, id uint64) (*models.Goal, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Goal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.Goal, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.Goal); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Goal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGoal provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) GetGoal(ctx context.Context, id uint64) (*models.Goal, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 *models.Goal
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) (*models.Goal, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.Goal, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) *models.Goal); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.Goal); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Goal)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -67,25 +97,25 @@ func (_m *RepositoryI) GetGoal(id uint64) (*models.Goal, error) {
 	return r0, r1
 }
 
-// GetUserGoals provides a mock function with given fields: userID
-func (_m *RepositoryI) GetUserGoals(userID uint64) ([]*models.Goal, error) {
-	ret := _m.Called(userID)
+// GetUserDeletedGoals provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetUserDeletedGoals(ctx context.Context, userID uint64) ([]*models.Goal, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []*models.Goal
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) ([]*models.Goal, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Goal, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) []*models.Goal); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Goal); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Goal)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -93,13 +123,105 @@ func (_m *RepositoryI) GetUserGoals(userID uint64) ([]*models.Goal, error) {
 	return r0, r1
 }
 
-// UpdateGoal provides a mock function with given fields: g
-func (_m *RepositoryI) UpdateGoal(g *models.Goal) error {
-	ret := _m.Called(g)
+// GetUserGoalChanges provides a mock function with given fields: ctx, userID, since, until
+func (_m *RepositoryI) GetUserGoalChanges(ctx context.Context, userID uint64, since time.Time, until time.Time) ([]*models.Goal, error) {
+	ret := _m.Called(ctx, userID, since, until)
+
+	var r0 []*models.Goal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time, time.Time) ([]*models.Goal, error)); ok {
+		return rf(ctx, userID, since, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time, time.Time) []*models.Goal); ok {
+		r0 = rf(ctx, userID, since, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Goal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, since, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserGoals provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetUserGoals(ctx context.Context, userID uint64) ([]*models.Goal, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.Goal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Goal, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Goal); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Goal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeletedGoals provides a mock function with given fields: ctx, before
+func (_m *RepositoryI) PurgeDeletedGoals(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreGoal provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) RestoreGoal(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateGoal provides a mock function with given fields: ctx, g
+func (_m *RepositoryI) UpdateGoal(ctx context.Context, g *models.Goal) error {
+	ret := _m.Called(ctx, g)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Goal) error); ok {
-		r0 = rf(g)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Goal) error); ok {
+		r0 = rf(ctx, g)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
 	"time"
@@ -9,14 +10,17 @@ import (
 )
 
 type Goal struct {
-	ID          uint64    `gorm:"column:id"`
-	UserID      *uint64   `gorm:"column:user_id"`
-	Name        string    `gorm:"column:name"`
-	ProjectID   *uint64   `gorm:"column:project_id"`
-	Description string    `gorm:"column:description"`
-	TimeStart   time.Time `gorm:"column:time_start"`
-	TimeEnd     time.Time `gorm:"column:time_end"`
-	HoursCount  float64   `gorm:"column:hours_count"`
+	ID          uint64         `gorm:"column:id"`
+	UserID      *uint64        `gorm:"column:user_id"`
+	Name        string         `gorm:"column:name"`
+	ProjectID   *uint64        `gorm:"column:project_id"`
+	Description string         `gorm:"column:description"`
+	TimeStart   time.Time      `gorm:"column:time_start"`
+	TimeEnd     time.Time      `gorm:"column:time_end"`
+	HoursCount  float64        `gorm:"column:hours_count"`
+	Version     uint64         `gorm:"column:version"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at"`
 }
 
 func (Goal) TableName() string {
@@ -33,11 +37,13 @@ func toPostgresGoal(g *models.Goal) *Goal {
 		TimeStart:   g.TimeStart,
 		TimeEnd:     g.TimeEnd,
 		HoursCount:  g.HoursCount,
+		Version:     g.Version,
+		UpdatedAt:   g.UpdatedAt,
 	}
 }
 
 func toModelGoal(g *Goal) *models.Goal {
-	return &models.Goal{
+	goal := &models.Goal{
 		ID:          g.ID,
 		UserID:      g.UserID,
 		Name:        g.Name,
@@ -46,7 +52,15 @@ func toModelGoal(g *Goal) *models.Goal {
 		TimeStart:   g.TimeStart,
 		TimeEnd:     g.TimeEnd,
 		HoursCount:  g.HoursCount,
+		Version:     g.Version,
+		UpdatedAt:   g.UpdatedAt,
 	}
+
+	if g.DeletedAt.Valid {
+		goal.DeletedAt = &g.DeletedAt.Time
+	}
+
+	return goal
 }
 
 func toModelGoals(goals []*Goal) []*models.Goal {
@@ -63,10 +77,11 @@ type goalRepository struct {
 	db *gorm.DB
 }
 
-func (gr goalRepository) CreateGoal(g *models.Goal) error {
+func (gr goalRepository) CreateGoal(ctx context.Context, g *models.Goal) error {
+	g.Version, g.UpdatedAt = 1, time.Now().UTC()
 	postgresGoal := toPostgresGoal(g)
 
-	tx := gr.db.Create(postgresGoal)
+	tx := gr.db.WithContext(ctx).Create(postgresGoal)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table goal)")
@@ -76,22 +91,34 @@ func (gr goalRepository) CreateGoal(g *models.Goal) error {
 	return nil
 }
 
-func (gr goalRepository) UpdateGoal(g *models.Goal) error {
+// updateColumns are written even when they hold zero values, so a patch can clear them
+var updateColumns = []string{"name", "project_id", "hours_count", "description", "time_start", "time_end", "version", "updated_at"}
+
+// UpdateGoal only writes the row if it still has the version g was read with,
+// then g gets the next version
+func (gr goalRepository) UpdateGoal(ctx context.Context, g *models.Goal) error {
 	postgresGoal := toPostgresGoal(g)
+	postgresGoal.Version++
+	postgresGoal.UpdatedAt = time.Now().UTC()
 
-	tx := gr.db.Omit("id").Updates(postgresGoal)
+	tx := gr.db.WithContext(ctx).Where("version = ?", g.Version).Select(updateColumns).Updates(postgresGoal)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table goal)")
 	}
 
+	if tx.RowsAffected == 0 {
+		return models.ErrVersionMismatch
+	}
+
+	g.Version, g.UpdatedAt = postgresGoal.Version, postgresGoal.UpdatedAt
 	return nil
 }
 
-func (gr goalRepository) GetGoal(id uint64) (*models.Goal, error) {
+func (gr goalRepository) GetGoal(ctx context.Context, id uint64) (*models.Goal, error) {
 	var goal Goal
 
-	tx := gr.db.Where("id = ?", id).Take(&goal)
+	tx := gr.db.WithContext(ctx).Where("id = ?", id).Take(&goal)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
@@ -102,8 +129,14 @@ func (gr goalRepository) GetGoal(id uint64) (*models.Goal, error) {
 	return toModelGoal(&goal), nil
 }
 
-func (gr goalRepository) DeleteGoal(id uint64) error {
-	tx := gr.db.Delete(&Goal{}, id)
+// DeleteGoal leaves a tombstone like the entries
+func (gr goalRepository) DeleteGoal(ctx context.Context, id uint64) error {
+	now := time.Now().UTC()
+	tx := gr.db.WithContext(ctx).Model(&Goal{ID: id}).Updates(map[string]interface{}{
+		"deleted_at": now,
+		"updated_at": now,
+		"version":    gorm.Expr("version + 1"),
+	})
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table goal)")
@@ -112,19 +145,106 @@ func (gr goalRepository) DeleteGoal(id uint64) error {
 	return nil
 }
 
-func (gr goalRepository) GetUserGoals(userID uint64) ([]*models.Goal, error) {
+func (gr goalRepository) GetUserGoals(ctx context.Context, userID uint64) ([]*models.Goal, error) {
 	goals := make([]*Goal, 0, 10)
 
-	tx := gr.db.Where(&Goal{UserID: &userID}).Find(&goals)
+	tx := gr.db.WithContext(ctx).Where(&Goal{UserID: &userID}).Find(&goals)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table goal)")
+	}
+
+	return toModelGoals(goals), nil
+}
+
+// GetUserGoalChanges returns the goals of the user changed in (since, until] with the tombstones
+func (gr goalRepository) GetUserGoalChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Goal, error) {
+	goals := make([]*Goal, 0, 10)
+
+	tx := gr.db.WithContext(ctx).Unscoped().Where(&Goal{UserID: &userID}).
+		Where("updated_at > ? AND updated_at <= ?", since, until).Order("updated_at").Find(&goals)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table goal)")
+	}
+
+	return toModelGoals(goals), nil
+}
+
+// GetDeletedGoal returns the tombstone of the goal, ErrNotFound if the goal is not deleted
+func (gr goalRepository) GetDeletedGoal(ctx context.Context, id uint64) (*models.Goal, error) {
+	var goal Goal
+
+	tx := gr.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Take(&goal)
+
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
 	} else if tx.Error != nil {
 		return nil, errors.Wrap(tx.Error, "database error (table goal)")
 	}
 
+	return toModelGoal(&goal), nil
+}
+
+// GetUserDeletedGoals returns the tombstones of the user, the last deleted first
+func (gr goalRepository) GetUserDeletedGoals(ctx context.Context, userID uint64) ([]*models.Goal, error) {
+	goals := make([]*Goal, 0, 10)
+
+	tx := gr.db.WithContext(ctx).Unscoped().Where(&Goal{UserID: &userID}).
+		Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Find(&goals)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table goal)")
+	}
+
 	return toModelGoals(goals), nil
 }
 
+// RestoreGoal brings the tombstone back with the next version. A goal of a deleted project stays deleted.
+func (gr goalRepository) RestoreGoal(ctx context.Context, id uint64) error {
+	return gr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var deleted int64
+		err := tx.Table("goal").Joins("JOIN project ON project.id = goal.project_id").
+			Where("goal.id = ? AND project.deleted_at IS NOT NULL", id).Count(&deleted).Error
+
+		if err != nil {
+			return errors.Wrap(err, "database error (table goal)")
+		}
+
+		if deleted != 0 {
+			return models.ErrProjectDeleted
+		}
+
+		res := tx.Unscoped().Model(&Goal{ID: id}).Where("deleted_at IS NOT NULL").Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": time.Now().UTC(),
+			"version":    gorm.Expr("version + 1"),
+		})
+
+		if res.Error != nil {
+			return errors.Wrap(res.Error, "database error (table goal)")
+		}
+
+		if res.RowsAffected == 0 {
+			return models.ErrNotFound
+		}
+
+		return nil
+	})
+}
+
+// PurgeDeletedGoals removes the tombstones deleted before the time for good
+func (gr goalRepository) PurgeDeletedGoals(ctx context.Context, before time.Time) (int64, error) {
+	tx := gr.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before.UTC()).Delete(&Goal{})
+
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "database error (table goal)")
+	}
+
+	return tx.RowsAffected, nil
+}
+
 func NewGoalRepository(db *gorm.DB) repository.RepositoryI {
 	return &goalRepository{
 		db: db,
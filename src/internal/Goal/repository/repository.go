@@ -1,13 +1,20 @@
 package repository
 
 import (
+	"context"
+	"time"
 	"timetracker/models"
 )
 
 type RepositoryI interface {
-	CreateGoal(g *models.Goal) error
-	UpdateGoal(g *models.Goal) error
-	GetGoal(id uint64) (*models.Goal, error)
-	DeleteGoal(id uint64) error
-	GetUserGoals(userID uint64) ([]*models.Goal, error)
+	CreateGoal(ctx context.Context, g *models.Goal) error
+	UpdateGoal(ctx context.Context, g *models.Goal) error
+	GetGoal(ctx context.Context, id uint64) (*models.Goal, error)
+	DeleteGoal(ctx context.Context, id uint64) error
+	GetUserGoals(ctx context.Context, userID uint64) ([]*models.Goal, error)
+	GetUserGoalChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Goal, error)
+	GetDeletedGoal(ctx context.Context, id uint64) (*models.Goal, error)
+	GetUserDeletedGoals(ctx context.Context, userID uint64) ([]*models.Goal, error)
+	RestoreGoal(ctx context.Context, id uint64) error
+	PurgeDeletedGoals(ctx context.Context, before time.Time) (int64, error)
 }
@@ -1,31 +1,51 @@
 package usecase
 
 import (
+	"context"
 	"github.com/pkg/errors"
+	"time"
 	goalRep "timetracker/internal/Goal/repository"
+	projectRep "timetracker/internal/Project/repository"
+	"timetracker/internal/policy"
+	"timetracker/internal/tracing"
 	"timetracker/models"
 )
 
 type UsecaseI interface {
-	CreateGoal(e *models.Goal) error
-	UpdateGoal(e *models.Goal) error
-	GetGoal(id uint64) (*models.Goal, error)
-	DeleteGoal(id uint64, userID uint64) error
-	GetUserGoals(userID uint64) ([]*models.Goal, error)
+	CreateGoal(ctx context.Context, e *models.Goal) error
+	UpdateGoal(ctx context.Context, patch *models.GoalPatch) (*models.Goal, error)
+	GetGoal(ctx context.Context, id uint64) (*models.Goal, error)
+	DeleteGoal(ctx context.Context, id uint64, userID uint64) error
+	GetUserGoals(ctx context.Context, userID uint64) ([]*models.Goal, error)
+	GetUserGoalChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Goal, error)
+	GetUserDeletedGoals(ctx context.Context, userID uint64) ([]*models.Goal, error)
+	RestoreGoal(ctx context.Context, id uint64, userID uint64) (*models.Goal, error)
+	PurgeDeletedGoals(ctx context.Context, before time.Time) (int64, error)
 }
 
 type usecase struct {
-	goalRepository goalRep.RepositoryI
+	goalRepository    goalRep.RepositoryI
+	projectRepository projectRep.RepositoryI
 }
 
-func New(gRep goalRep.RepositoryI) UsecaseI {
+func New(gRep goalRep.RepositoryI, pRep projectRep.RepositoryI) UsecaseI {
 	return &usecase{
-		goalRepository: gRep,
+		goalRepository:    gRep,
+		projectRepository: pRep,
 	}
 }
 
-func (u *usecase) CreateGoal(e *models.Goal) error {
-	err := u.goalRepository.CreateGoal(e)
+func (u *usecase) CreateGoal(ctx context.Context, e *models.Goal) error {
+	ctx, span := tracing.Start(ctx, "goal.Usecase.CreateGoal")
+	defer span.End()
+
+	err := u.checkProjectOpen(ctx, e.ProjectID, e.UserID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func goal.Usecase.CreateGoal")
+	}
+
+	err = u.goalRepository.CreateGoal(ctx, e)
 
 	if err != nil {
 		return errors.Wrap(err, "Error in func goal.Usecase.CreateGoal")
@@ -34,24 +54,88 @@ func (u *usecase) CreateGoal(e *models.Goal) error {
 	return nil
 }
 
-func (u *usecase) UpdateGoal(goal *models.Goal) error {
-	_, err := u.goalRepository.GetGoal(goal.ID)
+func (u *usecase) UpdateGoal(ctx context.Context, patch *models.GoalPatch) (*models.Goal, error) {
+	ctx, span := tracing.Start(ctx, "goal.Usecase.UpdateGoal")
+	defer span.End()
+
+	goal, err := u.goalRepository.GetGoal(ctx, patch.ID)
 
 	if err != nil {
-		return errors.Wrap(err, "Error in func goal.Usecase.Update.GetGoal")
+		return nil, errors.Wrap(err, "Error in func goal.Usecase.Update.GetGoal")
+	}
+
+	if err := policy.AuthorizeOwner(patch.UserID, goal.UserID); err != nil {
+		return nil, err
 	}
 
-	err = u.goalRepository.UpdateGoal(goal)
+	if patch.Version != 0 && patch.Version != goal.Version {
+		return nil, models.ErrVersionMismatch
+	}
+
+	if patch.ProjectID != nil && (goal.ProjectID == nil || *patch.ProjectID != *goal.ProjectID) {
+		err = u.checkProjectOpen(ctx, patch.ProjectID, &patch.UserID)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "Error in func goal.Usecase.UpdateGoal")
+		}
+	}
+
+	patch.Apply(goal)
+	err = u.goalRepository.UpdateGoal(ctx, goal)
 
 	if err != nil {
-		return errors.Wrap(err, "Error in func goal.Usecase.CreateGoal")
+		return nil, errors.Wrap(err, "Error in func goal.Usecase.UpdateGoal")
+	}
+
+	return goal, nil
+}
+
+// checkProjectOpen rejects new goals on an archived or a deleted project, and from a user
+// who is not an owner or an editor of the project. A nil id is no project.
+func (u *usecase) checkProjectOpen(ctx context.Context, projectID *uint64, userID *uint64) error {
+	if projectID == nil {
+		return nil
+	}
+
+	project, err := u.projectRepository.GetProject(ctx, *projectID)
+
+	if errors.Is(err, models.ErrNotFound) {
+		if _, err := u.projectRepository.GetDeletedProject(ctx, *projectID); err == nil {
+			return models.ErrProjectDeleted
+		}
+		return models.ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	if project.ArchivedAt != nil {
+		return models.ErrProjectArchived
+	}
+
+	if userID == nil {
+		return models.ErrPermissionDenied
+	}
+
+	member, err := u.projectRepository.GetProjectMember(ctx, project.ID, *userID)
+
+	if errors.Is(err, models.ErrNotFound) {
+		return models.ErrPermissionDenied
+	} else if err != nil {
+		return err
+	}
+
+	if !member.Accepted() || !member.Role.CanLog() {
+		return models.ErrPermissionDenied
 	}
 
 	return nil
 }
 
-func (u *usecase) GetGoal(id uint64) (*models.Goal, error) {
-	resGoal, err := u.goalRepository.GetGoal(id)
+func (u *usecase) GetGoal(ctx context.Context, id uint64) (*models.Goal, error) {
+	ctx, span := tracing.Start(ctx, "goal.Usecase.GetGoal")
+	defer span.End()
+
+	resGoal, err := u.goalRepository.GetGoal(ctx, id)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "goal.usecase.GetGoal error while get goal info")
@@ -60,8 +144,11 @@ func (u *usecase) GetGoal(id uint64) (*models.Goal, error) {
 	return resGoal, nil
 }
 
-func (u *usecase) DeleteGoal(id uint64, userID uint64) error {
-	existedGoal, err := u.goalRepository.GetGoal(id)
+func (u *usecase) DeleteGoal(ctx context.Context, id uint64, userID uint64) error {
+	ctx, span := tracing.Start(ctx, "goal.Usecase.DeleteGoal")
+	defer span.End()
+
+	existedGoal, err := u.goalRepository.GetGoal(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -70,11 +157,11 @@ func (u *usecase) DeleteGoal(id uint64, userID uint64) error {
 		return errors.New("Goal not found") //TODO models error
 	}
 
-	if *existedGoal.UserID != userID {
-		return errors.New("Permission denied")
+	if err := policy.AuthorizeOwner(userID, existedGoal.UserID); err != nil {
+		return err
 	}
 
-	err = u.goalRepository.DeleteGoal(id)
+	err = u.goalRepository.DeleteGoal(ctx, id)
 
 	if err != nil {
 		return errors.Wrap(err, "Error in func goal.Usecase.DeleteGoal repository")
@@ -83,8 +170,11 @@ func (u *usecase) DeleteGoal(id uint64, userID uint64) error {
 	return nil
 }
 
-func (u *usecase) GetUserGoals(userID uint64) ([]*models.Goal, error) {
-	Goals, err := u.goalRepository.GetUserGoals(userID)
+func (u *usecase) GetUserGoals(ctx context.Context, userID uint64) ([]*models.Goal, error) {
+	ctx, span := tracing.Start(ctx, "goal.Usecase.GetUserGoals")
+	defer span.End()
+
+	Goals, err := u.goalRepository.GetUserGoals(ctx, userID)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func goal.Usecase.GetUserPosts")
@@ -92,3 +182,67 @@ func (u *usecase) GetUserGoals(userID uint64) ([]*models.Goal, error) {
 
 	return Goals, nil
 }
+
+// GetUserGoalChanges returns the goals changed in (since, until] with the tombstones of the deleted ones
+func (u *usecase) GetUserGoalChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Goal, error) {
+	ctx, span := tracing.Start(ctx, "goal.Usecase.GetUserGoalChanges")
+	defer span.End()
+
+	goals, err := u.goalRepository.GetUserGoalChanges(ctx, userID, since, until)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func goal.Usecase.GetUserGoalChanges")
+	}
+
+	return goals, nil
+}
+
+func (u *usecase) GetUserDeletedGoals(ctx context.Context, userID uint64) ([]*models.Goal, error) {
+	ctx, span := tracing.Start(ctx, "goal.Usecase.GetUserDeletedGoals")
+	defer span.End()
+
+	deleted, err := u.goalRepository.GetUserDeletedGoals(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func goal.Usecase.GetUserDeletedGoals")
+	}
+
+	return deleted, nil
+}
+
+// RestoreGoal gets ErrProjectDeleted for a goal of a deleted project, like RestoreEntry
+func (u *usecase) RestoreGoal(ctx context.Context, id uint64, userID uint64) (*models.Goal, error) {
+	ctx, span := tracing.Start(ctx, "goal.Usecase.RestoreGoal")
+	defer span.End()
+
+	deleted, err := u.goalRepository.GetDeletedGoal(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func goal.Usecase.RestoreGoal")
+	}
+
+	if err := policy.AuthorizeOwner(userID, deleted.UserID); err != nil {
+		return nil, err
+	}
+
+	err = u.goalRepository.RestoreGoal(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func goal.Usecase.RestoreGoal")
+	}
+
+	return u.GetGoal(ctx, id)
+}
+
+func (u *usecase) PurgeDeletedGoals(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "goal.Usecase.PurgeDeletedGoals")
+	defer span.End()
+
+	purged, err := u.goalRepository.PurgeDeletedGoals(ctx, before)
+
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in func goal.Usecase.PurgeDeletedGoals")
+	}
+
+	return purged, nil
+}
@@ -1,13 +1,17 @@
 package usecase_test
 
 import (
+	"context"
 	"github.com/bxcodec/faker"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 	goalMocks "timetracker/internal/Goal/repository/mocks"
 	"timetracker/internal/Goal/usecase"
+	projectMocks "timetracker/internal/Project/repository/mocks"
 	"timetracker/models"
 )
 
@@ -22,6 +26,12 @@ type TestCaseDeleteGoal struct {
 	Error   error
 }
 
+type TestCaseUpdateGoal struct {
+	ArgData     *models.GoalPatch
+	ExpectedRes *models.Goal
+	Error       error
+}
+
 type TestCaseCreateUpdateGoal struct {
 	ArgData *models.Goal
 	Error   error
@@ -42,9 +52,9 @@ func TestUsecaseGetGoal(t *testing.T) {
 
 	mockGoalRepo := goalMocks.NewRepositoryI(t)
 
-	mockGoalRepo.On("GetGoal", mockGoalRes.ID).Return(&mockGoalRes, nil)
+	mockGoalRepo.On("GetGoal", mock.Anything, mockGoalRes.ID).Return(&mockGoalRes, nil)
 
-	useCase := usecase.New(mockGoalRepo)
+	useCase := usecase.New(mockGoalRepo, nil)
 
 	cases := map[string]TestCaseGetGoal{
 		"success": {
@@ -56,7 +66,7 @@ func TestUsecaseGetGoal(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			user, err := useCase.GetGoal(test.ArgData)
+			user, err := useCase.GetGoal(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, err)
 
 			if err == nil {
@@ -68,36 +78,56 @@ func TestUsecaseGetGoal(t *testing.T) {
 }
 
 func TestUsecaseUpdateGoal(t *testing.T) {
-	var mockGoal, invalidMockGoal models.Goal
+	var mockGoal models.Goal
 	err := faker.FakeData(&mockGoal)
 	assert.NoError(t, err)
 
-	invalidMockGoal.ID += mockGoal.ID + 1
+	description, hoursCount := "", 12.5
+	patch := &models.GoalPatch{ID: mockGoal.ID, UserID: *mockGoal.UserID, Description: &description, HoursCount: &hoursCount}
 
-	mockGoalRepo := goalMocks.NewRepositoryI(t)
+	expectedGoal := mockGoal
+	expectedGoal.Description = ""
+	expectedGoal.HoursCount = hoursCount
 
-	mockGoalRepo.On("GetGoal", mockGoal.ID).Return(&mockGoal, nil)
-	mockGoalRepo.On("UpdateGoal", &mockGoal).Return(nil)
+	mockGoalRepo := goalMocks.NewRepositoryI(t)
 
-	mockGoalRepo.On("GetGoal", invalidMockGoal.ID).Return(nil, models.ErrNotFound)
+	mockGoalRepo.On("GetGoal", mock.Anything, mockGoal.ID).Return(func(context.Context, uint64) *models.Goal {
+		goal := mockGoal
+		return &goal
+	}, nil)
+	mockGoalRepo.On("UpdateGoal", mock.Anything, &expectedGoal).Return(nil)
+	mockGoalRepo.On("GetGoal", mock.Anything, mockGoal.ID+1).Return(nil, models.ErrNotFound)
 
-	useCase := usecase.New(mockGoalRepo)
+	useCase := usecase.New(mockGoalRepo, nil)
 
-	cases := map[string]TestCaseCreateUpdateGoal{
-		"success": {
-			ArgData: &mockGoal,
-			Error:   nil,
+	cases := map[string]TestCaseUpdateGoal{
+		"success clears fields": {
+			ArgData:     patch,
+			ExpectedRes: &expectedGoal,
+			Error:       nil,
 		},
 		"Goal not found": {
-			ArgData: &invalidMockGoal,
+			ArgData: &models.GoalPatch{ID: mockGoal.ID + 1, UserID: *mockGoal.UserID},
 			Error:   models.ErrNotFound,
 		},
+		"Permission denied": {
+			ArgData: &models.GoalPatch{ID: mockGoal.ID, UserID: *mockGoal.UserID + 1},
+			Error:   models.ErrPermissionDenied,
+		},
+		"Version mismatch": {
+			ArgData: &models.GoalPatch{ID: mockGoal.ID, UserID: *mockGoal.UserID, Version: mockGoal.Version + 1},
+			Error:   models.ErrVersionMismatch,
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.UpdateGoal(test.ArgData)
+			goal, err := useCase.UpdateGoal(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, test.ExpectedRes, goal)
+			}
 		})
 	}
 	mockGoalRepo.AssertExpectations(t)
@@ -108,26 +138,47 @@ func TestUsecaseCreateGoal(t *testing.T) {
 	err := faker.FakeData(&mockGoal)
 	assert.NoError(t, err)
 
+	archivedAt := time.Now().UTC()
+	archivedID, missingID := *mockGoal.ProjectID+1, *mockGoal.ProjectID+2
+	archivedGoal, missingGoal := mockGoal, mockGoal
+	archivedGoal.ProjectID, missingGoal.ProjectID = &archivedID, &missingID
+
 	mockGoalRepo := goalMocks.NewRepositoryI(t)
+	mockProjectRepo := projectMocks.NewRepositoryI(t)
 
-	mockGoalRepo.On("CreateGoal", &mockGoal).Return(nil)
+	mockProjectRepo.On("GetProject", mock.Anything, *mockGoal.ProjectID).Return(&models.Project{ID: *mockGoal.ProjectID}, nil)
+	mockProjectRepo.On("GetProject", mock.Anything, archivedID).Return(&models.Project{ID: archivedID, ArchivedAt: &archivedAt}, nil)
+	mockProjectRepo.On("GetProject", mock.Anything, missingID).Return(nil, models.ErrNotFound)
+	mockProjectRepo.On("GetDeletedProject", mock.Anything, missingID).Return(nil, models.ErrNotFound)
+	mockProjectRepo.On("GetProjectMember", mock.Anything, *mockGoal.ProjectID, *mockGoal.UserID).Return(
+		models.NewProjectOwner(*mockGoal.ProjectID, *mockGoal.UserID, archivedAt), nil)
+	mockGoalRepo.On("CreateGoal", mock.Anything, &mockGoal).Return(nil)
 
-	useCase := usecase.New(mockGoalRepo)
+	useCase := usecase.New(mockGoalRepo, mockProjectRepo)
 
 	cases := map[string]TestCaseCreateUpdateGoal{
 		"success": {
 			ArgData: &mockGoal,
 			Error:   nil,
 		},
+		"archived project": {
+			ArgData: &archivedGoal,
+			Error:   models.ErrProjectArchived,
+		},
+		"project not found": {
+			ArgData: &missingGoal,
+			Error:   models.ErrNotFound,
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.CreateGoal(test.ArgData)
+			err := useCase.CreateGoal(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
 		})
 	}
 	mockGoalRepo.AssertExpectations(t)
+	mockProjectRepo.AssertExpectations(t)
 }
 
 func TestUsecaseDeleteGoal(t *testing.T) {
@@ -136,16 +187,17 @@ func TestUsecaseDeleteGoal(t *testing.T) {
 	assert.NoError(t, err)
 
 	invalidMockGoal.ID += mockGoal.ID + 1
-	*invalidMockGoal.UserID += *mockGoal.UserID + 1
+	invalidUserID := *mockGoal.UserID + 1
+	invalidMockGoal.UserID = &invalidUserID
 
 	mockGoalRepo := goalMocks.NewRepositoryI(t)
 
-	mockGoalRepo.On("GetGoal", mockGoal.ID).Return(&mockGoal, nil)
-	mockGoalRepo.On("DeleteGoal", mockGoal.ID).Return(nil)
+	mockGoalRepo.On("GetGoal", mock.Anything, mockGoal.ID).Return(&mockGoal, nil)
+	mockGoalRepo.On("DeleteGoal", mock.Anything, mockGoal.ID).Return(nil)
 
-	mockGoalRepo.On("GetGoal", invalidMockGoal.ID).Return(nil, models.ErrNotFound)
+	mockGoalRepo.On("GetGoal", mock.Anything, invalidMockGoal.ID).Return(nil, models.ErrNotFound)
 
-	useCase := usecase.New(mockGoalRepo)
+	useCase := usecase.New(mockGoalRepo, nil)
 
 	cases := map[string]TestCaseDeleteGoal{
 		"success": {
@@ -160,7 +212,7 @@ func TestUsecaseDeleteGoal(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.DeleteGoal(test.ArgData[0], test.ArgData[1])
+			err := useCase.DeleteGoal(context.Background(), test.ArgData[0], test.ArgData[1])
 			require.Equal(t, test.Error, errors.Cause(err))
 		})
 	}
@@ -180,9 +232,9 @@ func TestUsecaseGetUserGoals(t *testing.T) {
 
 	mockGoalRepo := goalMocks.NewRepositoryI(t)
 
-	mockGoalRepo.On("GetUserGoals", mockGoalRes[0].UserID).Return(mockGoalRes, nil)
+	mockGoalRepo.On("GetUserGoals", mock.Anything, *mockGoalRes[0].UserID).Return(mockGoalRes, nil)
 
-	useCase := usecase.New(mockGoalRepo)
+	useCase := usecase.New(mockGoalRepo, nil)
 
 	cases := map[string]TestCaseGetUserGoals{
 		"success": {
@@ -194,7 +246,7 @@ func TestUsecaseGetUserGoals(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			user, err := useCase.GetUserGoals(test.ArgData)
+			user, err := useCase.GetUserGoals(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, err)
 
 			if err == nil {
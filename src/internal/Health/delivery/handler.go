@@ -0,0 +1,75 @@
+package delivery
+
+import (
+	"net/http"
+
+	healthUsecase "timetracker/internal/Health/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Delivery struct {
+	HealthUC healthUsecase.UsecaseI
+}
+
+// Live godoc
+// @Summary      Liveness
+// @Description  the process is up, dependencies are not checked
+// @Tags     health
+// @Produce  application/json
+// @Success  200 {object} pkg.Response{body=dto.RespHealth} "alive"
+// @Router   /healthz [get]
+func (del *Delivery) Live(c echo.Context) error {
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.RespHealth{Status: dto.StatusOK}})
+}
+
+// Ready godoc
+// @Summary      Readiness
+// @Description  pings the database and the redis clients, 503 if any of them fails
+// @Tags     health
+// @Produce  application/json
+// @Success  200 {object} pkg.Response{body=dto.RespReadiness} "ready"
+// @Failure  503 {object} pkg.Response{body=dto.RespReadiness} "some dependency is not ready"
+// @Router   /readyz [get]
+func (del *Delivery) Ready(c echo.Context) error {
+	statuses, ready := del.HealthUC.Ready(c.Request().Context())
+
+	code := http.StatusOK
+	if !ready {
+		code = http.StatusServiceUnavailable
+		c.Logger().Warn("not ready")
+	}
+
+	return c.JSON(code, pkg.Response{Body: dto.GetResponseReadiness(statuses, ready)})
+}
+
+// Version godoc
+// @Summary      Version
+// @Description  build version, commit and applied schema migration
+// @Tags     health
+// @Produce  application/json
+// @Success  200 {object} pkg.Response{body=dto.RespVersion} "build info"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Router   /version [get]
+func (del *Delivery) Version(c echo.Context) error {
+	info, err := del.HealthUC.Version(c.Request().Context())
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseVersion(info)})
+}
+
+func NewDelivery(e *echo.Echo, uc healthUsecase.UsecaseI) {
+	handler := &Delivery{
+		HealthUC: uc,
+	}
+
+	e.GET("/healthz", handler.Live)
+	e.GET("/readyz", handler.Ready)
+	e.GET("/version", handler.Version)
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+// Check pings a dependency the application can't serve requests without
+type Check struct {
+	Name string
+	Ping func(ctx context.Context) error
+}
+
+// SchemaVersionFunc reports the applied migration of the database
+type SchemaVersionFunc func(ctx context.Context) (uint64, error)
+
+type UsecaseI interface {
+	Ready(ctx context.Context) ([]*models.DependencyStatus, bool)
+	Version(ctx context.Context) (*models.BuildInfo, error)
+}
+
+type usecase struct {
+	checks        []Check
+	timeout       time.Duration
+	build         models.BuildInfo
+	schemaVersion SchemaVersionFunc
+}
+
+// Ready pings every dependency at once, each one within the timeout.
+// The statuses keep the order of the checks.
+func (u *usecase) Ready(ctx context.Context) ([]*models.DependencyStatus, bool) {
+	statuses := make([]*models.DependencyStatus, len(u.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range u.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			statuses[i] = u.ping(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, status := range statuses {
+		if status.Err != nil {
+			return statuses, false
+		}
+	}
+
+	return statuses, true
+}
+
+func (u *usecase) ping(ctx context.Context, check Check) *models.DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Ping(ctx)
+
+	return &models.DependencyStatus{
+		Name:    check.Name,
+		Latency: time.Since(start),
+		Err:     err,
+	}
+}
+
+func (u *usecase) Version(ctx context.Context) (*models.BuildInfo, error) {
+	info := u.build
+	if u.schemaVersion == nil {
+		return &info, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	version, err := u.schemaVersion(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func health.Usecase.Version")
+	}
+
+	info.SchemaVersion = &version
+	return &info, nil
+}
+
+// New takes a nil schemaVersion for storages without a schema
+func New(checks []Check, timeout time.Duration, build models.BuildInfo, schemaVersion SchemaVersionFunc) UsecaseI {
+	return &usecase{
+		checks:        checks,
+		timeout:       timeout,
+		build:         build,
+		schemaVersion: schemaVersion,
+	}
+}
@@ -0,0 +1,100 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"timetracker/internal/Health/usecase"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestCaseReady struct {
+	Checks        []usecase.Check
+	ExpectedReady bool
+	FailedChecks  []string
+}
+
+var errPing = errors.New("connection refused")
+
+func pingOK(ctx context.Context) error {
+	return nil
+}
+
+func pingFail(ctx context.Context) error {
+	return errPing
+}
+
+func pingStuck(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestUsecaseReady(t *testing.T) {
+	cases := map[string]TestCaseReady{
+		"no dependencies": {
+			ExpectedReady: true,
+		},
+		"success": {
+			Checks:        []usecase.Check{{Name: "postgres", Ping: pingOK}, {Name: "redis", Ping: pingOK}},
+			ExpectedReady: true,
+		},
+		"failed ping": {
+			Checks:        []usecase.Check{{Name: "postgres", Ping: pingOK}, {Name: "redis", Ping: pingFail}},
+			ExpectedReady: false,
+			FailedChecks:  []string{"redis"},
+		},
+		"timeout": {
+			Checks:        []usecase.Check{{Name: "postgres", Ping: pingStuck}, {Name: "redis", Ping: pingOK}},
+			ExpectedReady: false,
+			FailedChecks:  []string{"postgres"},
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			useCase := usecase.New(test.Checks, 20*time.Millisecond, models.BuildInfo{}, nil)
+
+			statuses, ready := useCase.Ready(context.Background())
+			assert.Equal(t, test.ExpectedReady, ready)
+			require.Len(t, statuses, len(test.Checks))
+
+			var failed []string
+			for i, status := range statuses {
+				assert.Equal(t, test.Checks[i].Name, status.Name)
+				if status.Err != nil {
+					failed = append(failed, status.Name)
+				}
+			}
+			assert.Equal(t, test.FailedChecks, failed)
+		})
+	}
+}
+
+func TestUsecaseVersion(t *testing.T) {
+	build := models.BuildInfo{Service: "timetracker", Version: "v1.2.3", Commit: "abc"}
+
+	info, err := usecase.New(nil, time.Second, build, nil).Version(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &build, info)
+
+	schemaVersion := func(ctx context.Context) (uint64, error) {
+		return 7, nil
+	}
+
+	info, err = usecase.New(nil, time.Second, build, schemaVersion).Version(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, info.SchemaVersion)
+	assert.Equal(t, uint64(7), *info.SchemaVersion)
+	assert.Equal(t, "v1.2.3", info.Version)
+
+	failing := func(ctx context.Context) (uint64, error) {
+		return 0, errPing
+	}
+
+	_, err = usecase.New(nil, time.Second, build, failing).Version(context.Background())
+	assert.ErrorIs(t, err, errPing)
+}
@@ -1,37 +1,23 @@
 package delivery
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	projectUsecase "timetracker/internal/Project/usecase"
+	"timetracker/internal/apierror"
 	"timetracker/internal/middleware"
 	"timetracker/models"
 	"timetracker/models/dto"
 	"timetracker/pkg"
 
 	"github.com/labstack/echo/v4"
-	"github.com/pkg/errors"
 )
 
 type Delivery struct {
 	ProjectUC projectUsecase.UsecaseI
 }
 
-func (del *Delivery) ownerOrAdminValidate(c echo.Context, project *models.Project) error {
-	user, ok := c.Get("user").(*models.User)
-
-	if !ok {
-		c.Logger().Error("can't get user from context")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
-	}
-
-	if user.Role == models.Admin.String() || *project.UserID == user.ID {
-		return nil
-	}
-
-	return models.ErrPermissionDenied
-}
-
 // CreateProject godoc
 // @Summary      Create project
 // @Description  Create project
@@ -40,14 +26,13 @@ func (del *Delivery) ownerOrAdminValidate(c echo.Context, project *models.Projec
 // @Produce  application/json
 // @Param    project body dto.ReqCreateUpdateProject true "project info"
 // @Success  200 {object} pkg.Response{body=dto.RespProject} "success update project"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 422 {object} echo.HTTPError "unprocessable entity"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 400 {object} echo.HTTPError "bad req"
-// @Failure 403 {object} echo.HTTPError "invalid csrf or permission denied"
-// @Router   /project/create [post]
+// @Header   200 {string} ETag "version of the project"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Router   /api/v1/projects [post]
 func (delivery *Delivery) CreateProject(c echo.Context) error {
 
 	var reqProject dto.ReqCreateUpdateProject
@@ -55,29 +40,30 @@ func (delivery *Delivery) CreateProject(c echo.Context) error {
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		return apierror.Bind(err)
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqProject); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.Validation(err)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
 	project := reqProject.ToModelProject()
 	project.UserID = &userId
-	err = delivery.ProjectUC.CreateProject(project)
+	err = delivery.ProjectUC.CreateProject(c.Request().Context(), project)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelProject(project))
 	respProject := dto.GetResponseFromModelProject(project)
 
 	return c.JSON(http.StatusOK, pkg.Response{Body: *respProject})
@@ -85,86 +71,132 @@ func (delivery *Delivery) CreateProject(c echo.Context) error {
 
 // GetProject godoc
 // @Summary      Show a post
-// @Description  Get project by id. Acl: admin, owner
+// @Description  Get project by id with the members and the hours each of them logged on it.
+// @Description  Acl: members and invited users, owner, project:read:any
 // @Tags     	 project
 // @Accept	 application/json
 // @Produce  application/json
 // @Param id  path int  true  "Project ID"
+// @Param    If-None-Match header string false "ETag of the cached project"
 // @Success  200 {object} pkg.Response{body=dto.RespProject} "success get project"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /project/{id} [get]
+// @Header   200 {string} ETag "version of the project"
+// @Success  304 "the cached project is up to date"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Router   /api/v1/projects/{id} [get]
 func (delivery *Delivery) GetProject(c echo.Context) error {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
+	}
+	project, err := delivery.ProjectUC.GetProject(c.Request().Context(), id)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
 	}
-	project, err := delivery.ProjectUC.GetProject(id)
+
+	err = delivery.authorizeRead(c, project, userId)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
-	err = delivery.ownerOrAdminValidate(c, project)
+	members, err := delivery.ProjectUC.GetProjectMembers(c.Request().Context(), id)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
-	respProject := dto.GetResponseFromModelProject(project)
+	etag := dto.GetETagFromModelProjectWithMembers(project, members)
+	if middleware.NotModified(c, etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	c.Response().Header().Set(middleware.HeaderETag, etag)
+	respProject := dto.GetResponseFromModelProjectWithMembers(project, members)
 	return c.JSON(http.StatusOK, pkg.Response{Body: *respProject})
 }
 
 // UpdateProject godoc
 // @Summary      Update an project
-// @Description  Update an project. Acl: owner
+// @Description  Update an project. Only the fields present in the body are changed. Acl: owner, editors
 // @Tags     	 project
 // @Accept	 application/json
 // @Produce  application/json
-// @Param    project body dto.ReqCreateUpdateProject true "project info"
+// @Param    id path int true "Project ID"
+// @Param    If-Match header string true "ETag of the project the changes are based on, required by the legacy POST /project/edit too"
+// @Param    project body dto.ReqPatchProject true "project info"
 // @Success  200 {object} pkg.Response{body=dto.RespProject} "success update project"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 422 {object} echo.HTTPError "unprocessable entity"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 403 {object} echo.HTTPError "invalid csrf or permission denied"
-// @Router   /project/edit [post]
+// @Header   200 {string} ETag "version of the updated project"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 412 {object} apierror.Error "version_mismatch: the project was changed since the If-Match version"
+// @Failure 428 {object} apierror.Error "if_match_required"
+// @Router   /api/v1/projects/{id} [patch]
 func (delivery *Delivery) UpdateProject(c echo.Context) error {
 
-	var reqProject dto.ReqCreateUpdateProject
+	var reqProject dto.ReqPatchProject
 	err := c.Bind(&reqProject)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+		return apierror.Bind(err)
+	}
+
+	// the ID is in the path of PATCH /api/v1/projects/:id and in the body of the legacy route
+	if param := c.Param("id"); param != "" {
+		id, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			c.Logger().Error(err)
+			return apierror.From(models.ErrBadRequest)
+		}
+		reqProject.ID = id
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqProject); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.Validation(err)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	project := reqProject.ToModelProject()
-	project.UserID = &userId
-	err = delivery.ProjectUC.UpdateProject(project)
+	version, err := middleware.IfMatch(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	patch := reqProject.ToModelProjectPatch()
+	patch.UserID = userId
+	patch.Version = version
+	project, err := delivery.ProjectUC.UpdateProject(c.Request().Context(), patch)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelProject(project))
 	respProject := dto.GetResponseFromModelProject(project)
 
 	return c.JSON(http.StatusOK, pkg.Response{Body: *respProject})
@@ -172,66 +204,198 @@ func (delivery *Delivery) UpdateProject(c echo.Context) error {
 
 // DeleteProject godoc
 // @Summary      Delete an project
-// @Description  Delete an project. Acl: owner
+// @Description  Delete an project with its entries and goals, they stay in the trash until they are restored or purged. Acl: owner
 // @Tags     	 project
 // @Accept	 application/json
 // @Param id path int  true  "Project ID"
 // @Success  204
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 404 {object} echo.HTTPError "can't find project with such id"
-// @Failure 403 {object} echo.HTTPError "invalid csrf"
-// @Router   /project/{id} [delete]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found: can't find project with such id"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Router   /api/v1/projects/{id} [delete]
 func (delivery *Delivery) DeleteProject(c echo.Context) error {
 
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
+		return apierror.From(models.ErrNotFound)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	err = delivery.ProjectUC.DeleteProject(id, userId)
+	err = delivery.ProjectUC.DeleteProject(c.Request().Context(), id, userId)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// RestoreProject godoc
+// @Summary      Restore a project
+// @Description  Restore a project from the trash with the entries and goals deleted together with it, its hours count again. Acl: owner
+// @Tags     	 project
+// @Produce  application/json
+// @Param id path int  true  "Project ID"
+// @Success  200 {object} pkg.Response{body=dto.RespProject} "the restored project"
+// @Header   200 {string} ETag "version of the restored project"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found: no project with such id in the trash"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Router   /api/v1/projects/{id}/restore [post]
+func (delivery *Delivery) RestoreProject(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	project, err := delivery.ProjectUC.RestoreProject(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelProject(project))
+	respProject := dto.GetResponseFromModelProject(project)
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respProject})
+}
+
+// ArchiveProject godoc
+// @Summary      Archive a project
+// @Description  Archive a project. It is left out of the project listings and takes no new entries or goals,
+// @Description  its entries still count in the hours and the exports. Acl: owner
+// @Tags     	 project
+// @Produce  application/json
+// @Param id path int  true  "Project ID"
+// @Success  200 {object} pkg.Response{body=dto.RespProject} "the archived project"
+// @Header   200 {string} ETag "version of the archived project"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Router   /api/v1/projects/{id}/archive [post]
+func (delivery *Delivery) ArchiveProject(c echo.Context) error {
+	return delivery.setArchived(c, delivery.ProjectUC.ArchiveProject)
+}
+
+// UnarchiveProject godoc
+// @Summary      Unarchive a project
+// @Description  Bring an archived project back to the project listings. Acl: owner
+// @Tags     	 project
+// @Produce  application/json
+// @Param id path int  true  "Project ID"
+// @Success  200 {object} pkg.Response{body=dto.RespProject} "the unarchived project"
+// @Header   200 {string} ETag "version of the unarchived project"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Router   /api/v1/projects/{id}/unarchive [post]
+func (delivery *Delivery) UnarchiveProject(c echo.Context) error {
+	return delivery.setArchived(c, delivery.ProjectUC.UnarchiveProject)
+}
+
+type archiveFunc func(ctx context.Context, id uint64, userID uint64) (*models.Project, error)
+
+func (delivery *Delivery) setArchived(c echo.Context, archive archiveFunc) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	project, err := archive(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelProject(project))
+	respProject := dto.GetResponseFromModelProject(project)
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respProject})
+}
+
+// parseArchived reads the archived query parameter of the listings, false by default
+func parseArchived(c echo.Context) (bool, error) {
+	archived := c.QueryParam("archived")
+	if archived == "" {
+		return false, nil
+	}
+
+	res, err := strconv.ParseBool(archived)
+	if err != nil {
+		return false, models.ErrBadRequest
+	}
+
+	return res, nil
+}
+
 // GetMyProjects godoc
 // @Summary      Get my projects
-// @Description  Get my projects.
+// @Description  Get my projects. The archived ones are only listed with archived=true, without the others.
 // @Tags     project
 // @Produce  application/json
+// @Param    archived query bool false "list the archived projects"
 // @Success  200 {object} pkg.Response{body=[]dto.RespProject} "success get projects"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /me/projects [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/me/projects [get]
 func (delivery *Delivery) GetMyProjects(c echo.Context) error {
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	// projects, err := delivery.ProjectUC.GetUserProjects(userId)
-	projects, err := delivery.ProjectUC.GetUserProjectsWithCache(userId)
+	archived, err := parseArchived(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	var projects []*models.Project
+	if archived {
+		projects, err = delivery.ProjectUC.GetUserArchivedProjects(c.Request().Context(), userId)
+	} else {
+		// projects, err := delivery.ProjectUC.GetUserProjects(c.Request().Context(), userId)
+		projects, err = delivery.ProjectUC.GetUserProjectsWithCache(c.Request().Context(), userId)
+	}
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respProjects := dto.GetResponseFromModelProjects(projects)
@@ -241,28 +405,41 @@ func (delivery *Delivery) GetMyProjects(c echo.Context) error {
 
 // GetUserProjects godoc
 // @Summary      Get user projects
-// @Description  Get user projects. Acl: admin, friends
+// @Description  Get user projects. The archived ones are only listed with archived=true, without the others.
+// @Description  Acl: friends, project:read:any
 // @Tags     project
 // @Produce  application/json
+// @Param    archived query bool false "list the archived projects"
 // @Success  200 {object} pkg.Response{body=[]dto.RespProject} "success get projects"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /user/{user_id}/projects [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/users/{user_id}/projects [get]
 func (delivery *Delivery) GetUserProjects(c echo.Context) error {
 	userId, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
 	}
 
-	projects, err := delivery.ProjectUC.GetUserProjects(userId)
+	archived, err := parseArchived(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	var projects []*models.Project
+	if archived {
+		projects, err = delivery.ProjectUC.GetUserArchivedProjects(c.Request().Context(), userId)
+	} else {
+		projects, err = delivery.ProjectUC.GetUserProjects(c.Request().Context(), userId)
+	}
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respProjects := dto.GetResponseFromModelProjects(projects)
@@ -270,29 +447,35 @@ func (delivery *Delivery) GetUserProjects(c echo.Context) error {
 	return c.JSON(http.StatusOK, pkg.Response{Body: respProjects})
 }
 
-func handleError(err error) *echo.HTTPError {
-	causeErr := errors.Cause(err)
-	switch {
-	case errors.Is(causeErr, models.ErrNotFound):
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
-	case errors.Is(causeErr, models.ErrBadRequest):
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-	case errors.Is(causeErr, models.ErrPermissionDenied):
-		return echo.NewHTTPError(http.StatusForbidden, models.ErrPermissionDenied.Error())
-	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, causeErr.Error())
-	}
-}
 
 func NewDelivery(e *echo.Echo, pu projectUsecase.UsecaseI, aclM *middleware.AclMiddleware) {
 	handler := &Delivery{
 		ProjectUC: pu,
 	}
 
-	e.POST("/project/create", handler.CreateProject)
-	e.POST("/project/edit", handler.UpdateProject) //acl: owner
-	e.GET("/project/:id", handler.GetProject) //acl: owner, admin
-	e.DELETE("/project/:id", handler.DeleteProject) //acl: owner
-	e.GET("/me/projects", handler.GetMyProjects)
-	e.GET("/user/:user_id/projects", handler.GetUserProjects, aclM.FriendsOrAdminOnly)
+	v1 := e.Group(middleware.APIv1)
+	v1.POST("/projects", handler.CreateProject)
+	v1.GET("/projects/:id", handler.GetProject) //acl: members, owner, admin
+	v1.PATCH("/projects/:id", handler.UpdateProject, middleware.RequireIfMatch()) //acl: owner, editors
+	v1.DELETE("/projects/:id", handler.DeleteProject) //acl: owner
+	v1.POST("/projects/:id/restore", handler.RestoreProject) //acl: owner
+	v1.POST("/projects/:id/archive", handler.ArchiveProject) //acl: owner
+	v1.POST("/projects/:id/unarchive", handler.UnarchiveProject) //acl: owner
+	v1.GET("/projects/:id/members", handler.GetProjectMembers) //acl: members
+	v1.POST("/projects/:id/members", handler.InviteProjectMember) //acl: owner
+	v1.PATCH("/projects/:id/members/:user_id", handler.UpdateProjectMember) //acl: owner
+	v1.DELETE("/projects/:id/members/:user_id", handler.DeleteProjectMember) //acl: owner, the member itself
+	v1.GET("/me/invitations", handler.GetMyInvitations)
+	v1.POST("/me/invitations/:id/accept", handler.AcceptInvitation)
+	v1.DELETE("/me/invitations/:id", handler.DeclineInvitation)
+	v1.GET("/me/projects", handler.GetMyProjects)
+	v1.GET("/users/:user_id/projects", handler.GetUserProjects, aclM.FriendsOrPermission(models.PermProjectReadAny))
+
+	// the routes from before /api/v1
+	e.POST("/project/create", handler.CreateProject, middleware.Deprecated(middleware.APIv1+"/projects"))
+	e.POST("/project/edit", handler.UpdateProject, middleware.Deprecated(middleware.APIv1+"/projects/:id"), middleware.RequireIfMatch())
+	e.GET("/project/:id", handler.GetProject, middleware.Deprecated(middleware.APIv1+"/projects/:id"))
+	e.DELETE("/project/:id", handler.DeleteProject, middleware.Deprecated(middleware.APIv1+"/projects/:id"))
+	e.GET("/me/projects", handler.GetMyProjects, middleware.Deprecated(middleware.APIv1+"/me/projects"))
+	e.GET("/user/:user_id/projects", handler.GetUserProjects, middleware.Deprecated(middleware.APIv1+"/users/:user_id/projects"), aclM.FriendsOrPermission(models.PermProjectReadAny))
 }
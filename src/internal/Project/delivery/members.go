@@ -0,0 +1,332 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+	"timetracker/internal/apierror"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+// authorizeRead lets the members and the invited users see the project, the rest goes through the owner acl
+func (delivery *Delivery) authorizeRead(c echo.Context, project *models.Project, userID uint64) error {
+	_, err := delivery.ProjectUC.GetProjectMember(c.Request().Context(), project.ID, userID)
+
+	if errors.Cause(err) == models.ErrNotFound {
+		return middleware.AuthorizeResource(c, *project.UserID, models.PermProjectReadAny)
+	}
+
+	return err
+}
+
+// GetProjectMembers godoc
+// @Summary      Get project members
+// @Description  Get the members and the invited users of the project with the hours each of them logged on it.
+// @Description  Acl: members and invited users, owner, project:read:any
+// @Tags     	 project
+// @Produce  application/json
+// @Param id path int  true  "Project ID"
+// @Success  200 {object} pkg.Response{body=[]dto.RespProjectMember} "success get members"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Router   /api/v1/projects/{id}/members [get]
+func (delivery *Delivery) GetProjectMembers(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	project, err := delivery.ProjectUC.GetProject(c.Request().Context(), id)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	err = delivery.authorizeRead(c, project, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	members, err := delivery.ProjectUC.GetProjectMembers(c.Request().Context(), id)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseFromModelProjectMembers(members)})
+}
+
+// InviteProjectMember godoc
+// @Summary      Invite a project member
+// @Description  Invite a friend to the project as an editor or a viewer. Editors log their own entries
+// @Description  and goals on the project and change it, viewers only see it. Acl: owner
+// @Tags     	 project
+// @Accept	 application/json
+// @Produce  application/json
+// @Param id path int  true  "Project ID"
+// @Param    member body dto.ReqInviteProjectMember true "the invited friend"
+// @Success  200 {object} pkg.Response{body=dto.RespProjectMember} "the pending invitation"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf, permission_denied or not_friends"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Failure 409 {object} apierror.Error "member_conflict: the user is already a member or invited"
+// @Router   /api/v1/projects/{id}/members [post]
+func (delivery *Delivery) InviteProjectMember(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	var reqMember dto.ReqInviteProjectMember
+	err = c.Bind(&reqMember)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	if ok, err := pkg.IsRequestValid(&reqMember); !ok {
+		c.Logger().Error(err)
+		return apierror.Validation(err)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	member := reqMember.ToModelProjectMember(id, userId)
+	err = delivery.ProjectUC.InviteProjectMember(c.Request().Context(), member)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *dto.GetResponseFromModelProjectMember(member)})
+}
+
+// UpdateProjectMember godoc
+// @Summary      Change the role of a project member
+// @Description  Change the role of a member or of a pending invitation. Acl: owner
+// @Tags     	 project
+// @Accept	 application/json
+// @Produce  application/json
+// @Param id path int  true  "Project ID"
+// @Param user_id path int  true  "Member ID"
+// @Param    member body dto.ReqUpdateProjectMember true "the new role"
+// @Success  200 {object} pkg.Response{body=dto.RespProjectMember} "the updated member"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Router   /api/v1/projects/{id}/members/{user_id} [patch]
+func (delivery *Delivery) UpdateProjectMember(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	memberId, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	var reqMember dto.ReqUpdateProjectMember
+	err = c.Bind(&reqMember)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	if ok, err := pkg.IsRequestValid(&reqMember); !ok {
+		c.Logger().Error(err)
+		return apierror.Validation(err)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	member, err := delivery.ProjectUC.UpdateProjectMember(c.Request().Context(), id, userId, memberId, models.ProjectRole(reqMember.Role))
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *dto.GetResponseFromModelProjectMember(member)})
+}
+
+// DeleteProjectMember godoc
+// @Summary      Remove a project member
+// @Description  Remove a member or cancel an invitation, the entries of the member stay on the project.
+// @Description  A member removes itself to leave the project. Acl: owner, the member itself
+// @Tags     	 project
+// @Param id path int  true  "Project ID"
+// @Param user_id path int  true  "Member ID"
+// @Success  204
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request: the owner can't leave the project"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Router   /api/v1/projects/{id}/members/{user_id} [delete]
+func (delivery *Delivery) DeleteProjectMember(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	memberId, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	err = delivery.ProjectUC.DeleteProjectMember(c.Request().Context(), id, userId, memberId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetMyInvitations godoc
+// @Summary      Get my invitations
+// @Description  Get the invitations to the projects of friends the user didn't answer yet
+// @Tags     project
+// @Produce  application/json
+// @Success  200 {object} pkg.Response{body=[]dto.RespProjectMember} "the pending invitations"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/me/invitations [get]
+func (delivery *Delivery) GetMyInvitations(c echo.Context) error {
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	invitations, err := delivery.ProjectUC.GetUserInvitations(c.Request().Context(), userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseFromModelProjectMembers(invitations)})
+}
+
+// AcceptInvitation godoc
+// @Summary      Accept an invitation
+// @Description  Accept the invitation to a project, it shows up in the project listings of the user
+// @Tags     project
+// @Produce  application/json
+// @Param id path int  true  "Project ID"
+// @Success  200 {object} pkg.Response{body=dto.RespProjectMember} "the membership"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Failure 404 {object} apierror.Error "not_found: no pending invitation to the project"
+// @Router   /api/v1/me/invitations/{id}/accept [post]
+func (delivery *Delivery) AcceptInvitation(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	member, err := delivery.ProjectUC.AcceptInvitation(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *dto.GetResponseFromModelProjectMember(member)})
+}
+
+// DeclineInvitation godoc
+// @Summary      Decline an invitation
+// @Description  Decline the invitation to a project
+// @Tags     project
+// @Param id path int  true  "Project ID"
+// @Success  204
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Failure 404 {object} apierror.Error "not_found: no pending invitation to the project"
+// @Router   /api/v1/me/invitations/{id} [delete]
+func (delivery *Delivery) DeclineInvitation(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	err = delivery.ProjectUC.DeclineInvitation(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+func copyProjectMember(m *models.ProjectMember) *models.ProjectMember {
+	return &models.ProjectMember{
+		ProjectID:       m.ProjectID,
+		UserID:          m.UserID,
+		Role:            m.Role,
+		InvitedBy:       memoryDB.CopyID(m.InvitedBy),
+		CreatedAt:       m.CreatedAt,
+		AcceptedAt:      memoryDB.CopyTime(m.AcceptedAt),
+		TotalCountHours: m.TotalCountHours,
+	}
+}
+
+func sortProjectMembers(members []*models.ProjectMember) {
+	sort.Slice(members, func(i, j int) bool {
+		if !members[i].CreatedAt.Equal(members[j].CreatedAt) {
+			return members[i].CreatedAt.Before(members[j].CreatedAt)
+		}
+		return members[i].UserID < members[j].UserID
+	})
+}
+
+// GetMemberProjects returns the projects shared with the user, the ones the user owns are left out
+func (pr projectRepository) GetMemberProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	projects := make([]*models.Project, 0, 10)
+	for key, member := range pr.db.Members {
+		if key.UserID != userID || member.Role == models.ProjectOwner || !member.Accepted() {
+			continue
+		}
+
+		if project, ok := pr.db.Projects[key.ProjectID]; ok && project.DeletedAt == nil {
+			projects = append(projects, copyProject(project))
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].ID < projects[j].ID
+	})
+
+	return projects, nil
+}
+
+func (pr projectRepository) GetProjectMember(ctx context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	member, ok := pr.db.Members[memoryDB.ProjectMemberKey{ProjectID: projectID, UserID: userID}]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	return copyProjectMember(member), nil
+}
+
+// GetProjectMembers returns the members and the invited users with the hours they logged on the project
+func (pr projectRepository) GetProjectMembers(ctx context.Context, projectID uint64) ([]*models.ProjectMember, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	members := make([]*models.ProjectMember, 0, 10)
+	for key, member := range pr.db.Members {
+		if key.ProjectID != projectID {
+			continue
+		}
+
+		res := copyProjectMember(member)
+		for _, entry := range pr.db.Entries {
+			if entry.DeletedAt == nil && *entry.UserID == key.UserID && entry.ProjectID != nil && *entry.ProjectID == projectID {
+				res.TotalCountHours += entry.TimeEnd.Sub(entry.TimeStart).Hours()
+			}
+		}
+		members = append(members, res)
+	}
+
+	sortProjectMembers(members)
+	return members, nil
+}
+
+func (pr projectRepository) CreateProjectMember(ctx context.Context, member *models.ProjectMember) error {
+	pr.db.Lock()
+	defer pr.db.Unlock()
+
+	key := memoryDB.ProjectMemberKey{ProjectID: member.ProjectID, UserID: member.UserID}
+	if _, ok := pr.db.Members[key]; ok {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table project_member)")
+	}
+	if _, ok := pr.db.Projects[member.ProjectID]; !ok {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table project_member)")
+	}
+	if _, ok := pr.db.Users[member.UserID]; !ok {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table project_member)")
+	}
+
+	pr.db.Members[key] = copyProjectMember(member)
+	return nil
+}
+
+// UpdateProjectMember writes the role and the acceptance of the member
+func (pr projectRepository) UpdateProjectMember(ctx context.Context, member *models.ProjectMember) error {
+	pr.db.Lock()
+	defer pr.db.Unlock()
+
+	stored, ok := pr.db.Members[memoryDB.ProjectMemberKey{ProjectID: member.ProjectID, UserID: member.UserID}]
+	if !ok {
+		return models.ErrNotFound
+	}
+
+	stored.Role = member.Role
+	stored.AcceptedAt = memoryDB.CopyTime(member.AcceptedAt)
+	if member.Accepted() {
+		delete(pr.db.Removals, memoryDB.ProjectMemberKey{ProjectID: member.ProjectID, UserID: member.UserID})
+	}
+	return nil
+}
+
+func (pr projectRepository) DeleteProjectMember(ctx context.Context, projectID uint64, userID uint64) error {
+	pr.db.Lock()
+	defer pr.db.Unlock()
+
+	key := memoryDB.ProjectMemberKey{ProjectID: projectID, UserID: userID}
+	member, ok := pr.db.Members[key]
+	if !ok {
+		return models.ErrNotFound
+	}
+
+	if member.Accepted() {
+		pr.db.Removals[key] = time.Now().UTC()
+	}
+	delete(pr.db.Members, key)
+	return nil
+}
+
+// GetUserInvitations returns the pending invitations of the user to the projects that are not deleted
+func (pr projectRepository) GetUserInvitations(ctx context.Context, userID uint64) ([]*models.ProjectMember, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	members := make([]*models.ProjectMember, 0, 10)
+	for key, member := range pr.db.Members {
+		if key.UserID != userID || member.Accepted() {
+			continue
+		}
+
+		if project, ok := pr.db.Projects[key.ProjectID]; ok && project.DeletedAt == nil {
+			members = append(members, copyProjectMember(member))
+		}
+	}
+
+	sortProjectMembers(members)
+	return members, nil
+}
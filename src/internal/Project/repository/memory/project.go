@@ -0,0 +1,263 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+	"timetracker/internal/Project/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+func copyProject(p *models.Project) *models.Project {
+	return &models.Project{
+		ID:              p.ID,
+		UserID:          memoryDB.CopyID(p.UserID),
+		ClientID:        memoryDB.CopyID(p.ClientID),
+		Name:            p.Name,
+		About:           p.About,
+		Color:           p.Color,
+		IsPrivate:       p.IsPrivate,
+		TotalCountHours: p.TotalCountHours,
+		Version:         p.Version,
+		UpdatedAt:       p.UpdatedAt,
+		ArchivedAt:      memoryDB.CopyTime(p.ArchivedAt),
+		DeletedAt:       memoryDB.CopyTime(p.DeletedAt),
+	}
+}
+
+type projectRepository struct {
+	db *memoryDB.DB
+}
+
+// CreateProject makes the user of e the owner member of the project
+func (pr projectRepository) CreateProject(ctx context.Context, e *models.Project) error {
+	pr.db.Lock()
+	defer pr.db.Unlock()
+
+	e.Version, e.UpdatedAt = 1, time.Now().UTC()
+
+	if e.UserID == nil {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table project)")
+	}
+	if _, ok := pr.db.Users[*e.UserID]; !ok {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table project)")
+	}
+	if !pr.clientExists(e.ClientID) {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table project)")
+	}
+
+	project := copyProject(e)
+	project.ID = pr.db.NextID("project")
+	pr.db.Projects[project.ID] = project
+	pr.db.AddProjectOwner(project)
+
+	e.ID = project.ID
+	return nil
+}
+
+// clientExists is the foreign key of project.client_id, the caller must hold the lock
+func (pr projectRepository) clientExists(clientID *uint64) bool {
+	if clientID == nil {
+		return true
+	}
+
+	_, ok := pr.db.Clients[*clientID]
+	return ok
+}
+
+// UpdateProject writes the editable fields even when they are zero, like the postgres repository
+func (pr projectRepository) UpdateProject(ctx context.Context, e *models.Project) error {
+	pr.db.Lock()
+	defer pr.db.Unlock()
+
+	project, ok := pr.db.Projects[e.ID]
+	if !ok || project.DeletedAt != nil {
+		return models.ErrNotFound
+	}
+
+	if project.Version != e.Version {
+		return models.ErrVersionMismatch
+	}
+
+	if !pr.clientExists(e.ClientID) {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table project)")
+	}
+
+	project.ClientID = memoryDB.CopyID(e.ClientID)
+	project.Name = e.Name
+	project.About = e.About
+	project.Color = e.Color
+	project.IsPrivate = e.IsPrivate
+	project.ArchivedAt = memoryDB.CopyTime(e.ArchivedAt)
+	project.Version++
+	project.UpdatedAt = time.Now().UTC()
+
+	e.Version, e.UpdatedAt = project.Version, project.UpdatedAt
+	return nil
+}
+
+func (pr projectRepository) GetProject(ctx context.Context, id uint64) (*models.Project, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	project, ok := pr.db.Projects[id]
+	if !ok || project.DeletedAt != nil {
+		return nil, models.ErrNotFound
+	}
+
+	return copyProject(project), nil
+}
+
+func (pr projectRepository) DeleteProject(ctx context.Context, id uint64) error {
+	pr.db.Lock()
+	defer pr.db.Unlock()
+
+	pr.db.SoftDeleteProject(id, time.Now().UTC())
+	return nil
+}
+
+// GetClientProjects returns the projects done for the client, the deleted ones left out
+func (pr projectRepository) GetClientProjects(ctx context.Context, clientID uint64) ([]*models.Project, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	projects := make([]*models.Project, 0, 10)
+	for _, project := range pr.db.Projects {
+		if project.ClientID != nil && *project.ClientID == clientID && project.DeletedAt == nil {
+			projects = append(projects, copyProject(project))
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].ID < projects[j].ID
+	})
+
+	return projects, nil
+}
+
+func (pr projectRepository) GetUserProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	projects := make([]*models.Project, 0, 10)
+	for _, project := range pr.db.Projects {
+		if *project.UserID == userID && project.DeletedAt == nil {
+			projects = append(projects, copyProject(project))
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].ID < projects[j].ID
+	})
+
+	return projects, nil
+}
+
+func (pr projectRepository) GetUserProjectChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Project, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	changed := func(t time.Time) bool {
+		return t.After(since) && !t.After(until)
+	}
+
+	projects := make([]*models.Project, 0, 10)
+	for key, member := range pr.db.Members {
+		project, ok := pr.db.Projects[key.ProjectID]
+		if key.UserID != userID || !ok || !member.Accepted() {
+			continue
+		}
+
+		if changed(project.UpdatedAt) || changed(*member.AcceptedAt) {
+			projects = append(projects, copyProject(project))
+		}
+	}
+
+	for key, removedAt := range pr.db.Removals {
+		project, ok := pr.db.Projects[key.ProjectID]
+		if key.UserID != userID || !ok || !changed(removedAt) {
+			continue
+		}
+
+		removed := copyProject(project)
+		removed.DeletedAt = memoryDB.CopyTime(&removedAt)
+		removed.UpdatedAt = removedAt
+		projects = append(projects, removed)
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].UpdatedAt.Before(projects[j].UpdatedAt)
+	})
+
+	return projects, nil
+}
+
+func (pr projectRepository) GetDeletedProject(ctx context.Context, id uint64) (*models.Project, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	project, ok := pr.db.Projects[id]
+	if !ok || project.DeletedAt == nil {
+		return nil, models.ErrNotFound
+	}
+
+	return copyProject(project), nil
+}
+
+func (pr projectRepository) GetUserDeletedProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	projects := make([]*models.Project, 0, 10)
+	for _, project := range pr.db.Projects {
+		if *project.UserID == userID && project.DeletedAt != nil {
+			projects = append(projects, copyProject(project))
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		if !projects[i].DeletedAt.Equal(*projects[j].DeletedAt) {
+			return projects[i].DeletedAt.After(*projects[j].DeletedAt)
+		}
+		return projects[i].ID < projects[j].ID
+	})
+
+	return projects, nil
+}
+
+func (pr projectRepository) RestoreProject(ctx context.Context, id uint64) error {
+	pr.db.Lock()
+	defer pr.db.Unlock()
+
+	pr.db.RestoreProject(id, time.Now().UTC())
+	return nil
+}
+
+func (pr projectRepository) PurgeDeletedProjects(ctx context.Context, before time.Time) (int64, error) {
+	pr.db.Lock()
+	defer pr.db.Unlock()
+
+	var purged int64
+	for id, project := range pr.db.Projects {
+		if project.DeletedAt != nil && project.DeletedAt.Before(before) {
+			pr.db.DeleteProject(id)
+			purged++
+		}
+	}
+	for key, removedAt := range pr.db.Removals {
+		if removedAt.Before(before) {
+			delete(pr.db.Removals, key)
+		}
+	}
+
+	return purged, nil
+}
+
+func NewProjectRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &projectRepository{
+		db: db,
+	}
+}
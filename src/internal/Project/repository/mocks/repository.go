@@ -3,9 +3,13 @@
 package mocks
 
 import (
+	context "context"
+
 	models "timetracker/models"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // RepositoryI is an autogenerated mock type for the RepositoryI type
@@ -13,13 +17,27 @@ type RepositoryI struct {
 	mock.Mock
 }
 
-// CreateProject provides a mock function with given fields: e
-func (_m *RepositoryI) CreateProject(e *models.Project) error {
-	ret := _m.Called(e)
+// CreateProject provides a mock function with given fields: ctx, e
+func (_m *RepositoryI) CreateProject(ctx context.Context, e *models.Project) error {
+	ret := _m.Called(ctx, e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Project) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateProjectMember provides a mock function with given fields: ctx, member
+func (_m *RepositoryI) CreateProjectMember(ctx context.Context, member *models.ProjectMember) error {
+	ret := _m.Called(ctx, member)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Project) error); ok {
-		r0 = rf(e)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ProjectMember) error); ok {
+		r0 = rf(ctx, member)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,13 +45,13 @@ func (_m *RepositoryI) CreateProject(e *models.Project) error {
 	return r0
 }
 
-// DeleteProject provides a mock function with given fields: id
-func (_m *RepositoryI) DeleteProject(id uint64) error {
-	ret := _m.Called(id)
+// DeleteProject provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) DeleteProject(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,25 +59,65 @@ func (_m *RepositoryI) DeleteProject(id uint64) error {
 	return r0
 }
 
-// GetProject provides a mock function with given fields: id
-func (_m *RepositoryI) GetProject(id uint64) (*models.Project, error) {
-	ret := _m.Called(id)
+// DeleteProjectMember provides a mock function with given fields: ctx, projectID, userID
+func (_m *RepositoryI) DeleteProjectMember(ctx context.Context, projectID uint64, userID uint64) error {
+	ret := _m.Called(ctx, projectID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) error); ok {
+		r0 = rf(ctx, projectID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetClientProjects provides a mock function with given fields: ctx, clientID
+func (_m *RepositoryI) GetClientProjects(ctx context.Context, clientID uint64) ([]*models.Project, error) {
+	ret := _m.Called(ctx, clientID)
+
+	var r0 []*models.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Project, error)); ok {
+		return rf(ctx, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Project); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeletedProject provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) GetDeletedProject(ctx context.Context, id uint64) (*models.Project, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 *models.Project
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) (*models.Project, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.Project, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) *models.Project); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.Project); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Project)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -67,25 +125,77 @@ func (_m *RepositoryI) GetProject(id uint64) (*models.Project, error) {
 	return r0, r1
 }
 
-// GetUserProjects provides a mock function with given fields: userID
-func (_m *RepositoryI) GetUserProjects(userID uint64) ([]*models.Project, error) {
-	ret := _m.Called(userID)
+// GetMemberProjects provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetMemberProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []*models.Project
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) ([]*models.Project, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Project, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) []*models.Project); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Project); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Project)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProject provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) GetProject(ctx context.Context, id uint64) (*models.Project, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.Project, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.Project); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectMember provides a mock function with given fields: ctx, projectID, userID
+func (_m *RepositoryI) GetProjectMember(ctx context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error) {
+	ret := _m.Called(ctx, projectID, userID)
+
+	var r0 *models.ProjectMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*models.ProjectMember, error)); ok {
+		return rf(ctx, projectID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *models.ProjectMember); ok {
+		r0 = rf(ctx, projectID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProjectMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, projectID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -93,13 +203,197 @@ func (_m *RepositoryI) GetUserProjects(userID uint64) ([]*models.Project, error)
 	return r0, r1
 }
 
-// UpdateProject provides a mock function with given fields: e
-func (_m *RepositoryI) UpdateProject(e *models.Project) error {
-	ret := _m.Called(e)
+// GetProjectMembers provides a mock function with given fields: ctx, projectID
+func (_m *RepositoryI) GetProjectMembers(ctx context.Context, projectID uint64) ([]*models.ProjectMember, error) {
+	ret := _m.Called(ctx, projectID)
+
+	var r0 []*models.ProjectMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.ProjectMember, error)); ok {
+		return rf(ctx, projectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.ProjectMember); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ProjectMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserDeletedProjects provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetUserDeletedProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Project, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Project); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserInvitations provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetUserInvitations(ctx context.Context, userID uint64) ([]*models.ProjectMember, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.ProjectMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.ProjectMember, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.ProjectMember); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ProjectMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserProjectChanges provides a mock function with given fields: ctx, userID, since, until
+func (_m *RepositoryI) GetUserProjectChanges(ctx context.Context, userID uint64, since time.Time, until time.Time) ([]*models.Project, error) {
+	ret := _m.Called(ctx, userID, since, until)
+
+	var r0 []*models.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time, time.Time) ([]*models.Project, error)); ok {
+		return rf(ctx, userID, since, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time, time.Time) []*models.Project); ok {
+		r0 = rf(ctx, userID, since, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, since, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserProjects provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetUserProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Project, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Project); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeletedProjects provides a mock function with given fields: ctx, before
+func (_m *RepositoryI) PurgeDeletedProjects(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreProject provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) RestoreProject(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProject provides a mock function with given fields: ctx, e
+func (_m *RepositoryI) UpdateProject(ctx context.Context, e *models.Project) error {
+	ret := _m.Called(ctx, e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Project) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProjectMember provides a mock function with given fields: ctx, member
+func (_m *RepositoryI) UpdateProjectMember(ctx context.Context, member *models.ProjectMember) error {
+	ret := _m.Called(ctx, member)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Project) error); ok {
-		r0 = rf(e)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ProjectMember) error); ok {
+		r0 = rf(ctx, member)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"time"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ProjectMember struct {
+	ProjectID       uint64     `gorm:"column:project_id;primaryKey"`
+	UserID          uint64     `gorm:"column:user_id;primaryKey"`
+	Role            string     `gorm:"column:role"`
+	InvitedBy       *uint64    `gorm:"column:invited_by"`
+	CreatedAt       time.Time  `gorm:"column:created_at"`
+	AcceptedAt      *time.Time `gorm:"column:accepted_at"`
+	TotalCountHours float64    `gorm:"column:total_count_hours;->;-:migration"`
+}
+
+func (ProjectMember) TableName() string {
+	return "project_member"
+}
+
+// ProjectMemberRemoval is the tombstone of an accepted membership, see GetUserProjectChanges
+type ProjectMemberRemoval struct {
+	ProjectID uint64    `gorm:"column:project_id;primaryKey"`
+	UserID    uint64    `gorm:"column:user_id;primaryKey"`
+	RemovedAt time.Time `gorm:"column:removed_at"`
+}
+
+func (ProjectMemberRemoval) TableName() string {
+	return "project_member_removal"
+}
+
+func toPostgresProjectMember(m *models.ProjectMember) *ProjectMember {
+	return &ProjectMember{
+		ProjectID:  m.ProjectID,
+		UserID:     m.UserID,
+		Role:       string(m.Role),
+		InvitedBy:  m.InvitedBy,
+		CreatedAt:  m.CreatedAt,
+		AcceptedAt: m.AcceptedAt,
+	}
+}
+
+func toModelProjectMember(m *ProjectMember) *models.ProjectMember {
+	return &models.ProjectMember{
+		ProjectID:       m.ProjectID,
+		UserID:          m.UserID,
+		Role:            models.ProjectRole(m.Role),
+		InvitedBy:       m.InvitedBy,
+		CreatedAt:       m.CreatedAt,
+		AcceptedAt:      m.AcceptedAt,
+		TotalCountHours: m.TotalCountHours,
+	}
+}
+
+func toModelProjectMembers(members []*ProjectMember) []*models.ProjectMember {
+	out := make([]*models.ProjectMember, len(members))
+
+	for i, m := range members {
+		out[i] = toModelProjectMember(m)
+	}
+
+	return out
+}
+
+// the hours of the members sum their entries like the update_total_count_hours trigger
+const (
+	postgresMemberHours = "COALESCE(SUM(EXTRACT(EPOCH FROM (entry.time_end - entry.time_start))), 0) / 3600"
+	sqliteMemberHours   = "COALESCE(SUM(ROUND((julianday(entry.time_end) - julianday(entry.time_start)) * 86400, 3)), 0) / 3600"
+)
+
+// GetMemberProjects returns the projects shared with the user, the ones the user owns are left out
+func (pr projectRepository) GetMemberProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	projects := make([]*Project, 0, 10)
+
+	tx := pr.db.WithContext(ctx).Joins("JOIN project_member ON project_member.project_id = project.id").
+		Where("project_member.user_id = ? AND project_member.role <> ? AND project_member.accepted_at IS NOT NULL", userID, models.ProjectOwner).
+		Order("project.id").Find(&projects)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table project_member)")
+	}
+
+	return toModelProjects(projects), nil
+}
+
+func (pr projectRepository) GetProjectMember(ctx context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error) {
+	var member ProjectMember
+
+	tx := pr.db.WithContext(ctx).Where("project_id = ? AND user_id = ?", projectID, userID).Take(&member)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table project_member)")
+	}
+
+	return toModelProjectMember(&member), nil
+}
+
+// GetProjectMembers returns the members and the invited users with the hours they logged on the project
+func (pr projectRepository) GetProjectMembers(ctx context.Context, projectID uint64) ([]*models.ProjectMember, error) {
+	memberHours := postgresMemberHours
+	if pr.db.Dialector.Name() == "sqlite" {
+		memberHours = sqliteMemberHours
+	}
+
+	members := make([]*ProjectMember, 0, 10)
+
+	tx := pr.db.WithContext(ctx).Table("project_member").
+		Select("project_member.project_id, project_member.user_id, project_member.role, project_member.invited_by, "+
+			"project_member.created_at, project_member.accepted_at, "+memberHours+" AS total_count_hours").
+		Joins("LEFT JOIN entry ON entry.project_id = project_member.project_id AND entry.user_id = project_member.user_id AND entry.deleted_at IS NULL").
+		Where("project_member.project_id = ?", projectID).
+		Group("project_member.project_id, project_member.user_id").
+		Order("project_member.created_at, project_member.user_id").
+		Scan(&members)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table project_member)")
+	}
+
+	return toModelProjectMembers(members), nil
+}
+
+func (pr projectRepository) CreateProjectMember(ctx context.Context, member *models.ProjectMember) error {
+	tx := pr.db.WithContext(ctx).Create(toPostgresProjectMember(member))
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table project_member)")
+	}
+
+	return nil
+}
+
+// UpdateProjectMember writes the role and the acceptance of the member, an accepted
+// member drops the tombstone of an earlier membership
+func (pr projectRepository) UpdateProjectMember(ctx context.Context, member *models.ProjectMember) error {
+	err := pr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updated := tx.Model(&ProjectMember{}).
+			Where("project_id = ? AND user_id = ?", member.ProjectID, member.UserID).
+			Updates(map[string]interface{}{
+				"role":        string(member.Role),
+				"accepted_at": member.AcceptedAt,
+			})
+
+		if updated.Error != nil {
+			return updated.Error
+		}
+
+		if updated.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if !member.Accepted() {
+			return nil
+		}
+
+		return tx.Where("project_id = ? AND user_id = ?", member.ProjectID, member.UserID).Delete(&ProjectMemberRemoval{}).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ErrNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "database error (table project_member)")
+	}
+
+	return nil
+}
+
+// DeleteProjectMember leaves a tombstone of an accepted membership, so the removed member
+// syncs the project away. A cancelled invitation leaves none.
+func (pr projectRepository) DeleteProjectMember(ctx context.Context, projectID uint64, userID uint64) error {
+	err := pr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var member ProjectMember
+
+		err := tx.Where("project_id = ? AND user_id = ?", projectID, userID).Take(&member).Error
+		if err != nil {
+			return err
+		}
+
+		if member.AcceptedAt != nil {
+			removal := &ProjectMemberRemoval{ProjectID: projectID, UserID: userID, RemovedAt: time.Now().UTC()}
+			if err = tx.Create(removal).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Where("project_id = ? AND user_id = ?", projectID, userID).Delete(&ProjectMember{}).Error
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ErrNotFound
+	} else if err != nil {
+		return errors.Wrap(err, "database error (table project_member)")
+	}
+
+	return nil
+}
+
+// GetUserInvitations returns the pending invitations of the user to the projects that are not deleted
+func (pr projectRepository) GetUserInvitations(ctx context.Context, userID uint64) ([]*models.ProjectMember, error) {
+	members := make([]*ProjectMember, 0, 10)
+
+	tx := pr.db.WithContext(ctx).Select("project_member.*").Joins("JOIN project ON project.id = project_member.project_id").
+		Where("project_member.user_id = ? AND project_member.accepted_at IS NULL AND project.deleted_at IS NULL", userID).
+		Order("project_member.created_at").Find(&members)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table project_member)")
+	}
+
+	return toModelProjectMembers(members), nil
+}
@@ -1,6 +1,9 @@
 package postgres
 
 import (
+	"context"
+	"sort"
+	"time"
 	"timetracker/internal/Project/repository"
 	"timetracker/models"
 
@@ -9,13 +12,18 @@ import (
 )
 
 type Project struct {
-	ID              uint64  `gorm:"column:id"`
-	UserID          *uint64 `gorm:"column:user_id"`
-	Name            string  `gorm:"column:name"`
-	About           string  `gorm:"column:about"`
-	Color           string  `gorm:"column:color"`
-	IsPrivate       bool    `gorm:"column:is_private"`
-	TotalCountHours float64 `gorm:"column:total_count_hours"`
+	ID              uint64         `gorm:"column:id"`
+	UserID          *uint64        `gorm:"column:user_id"`
+	ClientID        *uint64        `gorm:"column:client_id"`
+	Name            string         `gorm:"column:name"`
+	About           string         `gorm:"column:about"`
+	Color           string         `gorm:"column:color"`
+	IsPrivate       bool           `gorm:"column:is_private"`
+	TotalCountHours float64        `gorm:"column:total_count_hours"`
+	Version         uint64         `gorm:"column:version"`
+	UpdatedAt       time.Time      `gorm:"column:updated_at"`
+	ArchivedAt      *time.Time     `gorm:"column:archived_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"column:deleted_at"`
 }
 
 func (Project) TableName() string {
@@ -26,24 +34,38 @@ func toPostgresProject(p *models.Project) *Project {
 	return &Project{
 		ID:              p.ID,
 		UserID:          p.UserID,
+		ClientID:        p.ClientID,
 		Name:            p.Name,
 		About:           p.About,
 		Color:           p.Color,
 		IsPrivate:       p.IsPrivate,
 		TotalCountHours: p.TotalCountHours,
+		Version:         p.Version,
+		UpdatedAt:       p.UpdatedAt,
+		ArchivedAt:      p.ArchivedAt,
 	}
 }
 
 func toModelProject(p *Project) *models.Project {
-	return &models.Project{
+	project := &models.Project{
 		ID:              p.ID,
 		UserID:          p.UserID,
+		ClientID:        p.ClientID,
 		Name:            p.Name,
 		About:           p.About,
 		Color:           p.Color,
 		IsPrivate:       p.IsPrivate,
 		TotalCountHours: p.TotalCountHours,
+		Version:         p.Version,
+		UpdatedAt:       p.UpdatedAt,
+		ArchivedAt:      p.ArchivedAt,
 	}
+
+	if p.DeletedAt.Valid {
+		project.DeletedAt = &p.DeletedAt.Time
+	}
+
+	return project
 }
 
 func toModelProjects(projects []*Project) []*models.Project {
@@ -60,34 +82,55 @@ type projectRepository struct {
 	db *gorm.DB
 }
 
-func (pr projectRepository) CreateProject(e *models.Project) error {
+// CreateProject makes the user of e the owner member of the project
+func (pr projectRepository) CreateProject(ctx context.Context, e *models.Project) error {
+	e.Version, e.UpdatedAt = 1, time.Now().UTC()
 	postgresProject := toPostgresProject(e)
-	tx := pr.db.Create(postgresProject)
 
-	if tx.Error != nil {
-		return errors.Wrap(tx.Error, "database error (table project)")
+	err := pr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(postgresProject).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(toPostgresProjectMember(models.NewProjectOwner(postgresProject.ID, *e.UserID, e.UpdatedAt))).Error
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "database error (table project)")
 	}
 
 	e.ID = postgresProject.ID
 	return nil
 }
 
-func (pr projectRepository) UpdateProject(e *models.Project) error {
+// updateColumns are written even when they hold zero values, so a patch can clear them
+var updateColumns = []string{"client_id", "name", "about", "color", "is_private", "archived_at", "version", "updated_at"}
+
+// UpdateProject only writes the row if it still has the version e was read with,
+// then e gets the next version
+func (pr projectRepository) UpdateProject(ctx context.Context, e *models.Project) error {
 	postgresProject := toPostgresProject(e)
+	postgresProject.Version++
+	postgresProject.UpdatedAt = time.Now().UTC()
 
-	tx := pr.db.Omit("id").Updates(postgresProject)
+	tx := pr.db.WithContext(ctx).Where("version = ?", e.Version).Select(updateColumns).Updates(postgresProject)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table project)")
 	}
 
+	if tx.RowsAffected == 0 {
+		return models.ErrVersionMismatch
+	}
+
+	e.Version, e.UpdatedAt = postgresProject.Version, postgresProject.UpdatedAt
 	return nil
 }
 
-func (pr projectRepository) GetProject(id uint64) (*models.Project, error) {
+func (pr projectRepository) GetProject(ctx context.Context, id uint64) (*models.Project, error) {
 	var project Project
 
-	tx := pr.db.Where("id = ?", id).Take(&project)
+	tx := pr.db.WithContext(ctx).Where("id = ?", id).Take(&project)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
@@ -98,20 +141,54 @@ func (pr projectRepository) GetProject(id uint64) (*models.Project, error) {
 	return toModelProject(&project), nil
 }
 
-func (pr projectRepository) DeleteProject(id uint64) error {
-	tx := pr.db.Delete(&Project{}, id)
+// DeleteProject leaves a tombstone of the project and of its entries and goals, see
+// DeleteEntry. The trigger of the entries takes their hours off the project.
+func (pr projectRepository) DeleteProject(ctx context.Context, id uint64) error {
+	now := time.Now().UTC()
+	tombstone := map[string]interface{}{
+		"deleted_at": now,
+		"updated_at": now,
+		"version":    gorm.Expr("version + 1"),
+	}
+
+	err := pr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Model(&Project{ID: id}).Updates(tombstone)
+		if deleted.Error != nil || deleted.RowsAffected == 0 {
+			return deleted.Error
+		}
 
-	if tx.Error != nil {
-		return errors.Wrap(tx.Error, "database error (table project)")
+		err := tx.Table("entry").Where("project_id = ? AND deleted_at IS NULL", id).Updates(tombstone).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Table("goal").Where("project_id = ? AND deleted_at IS NULL", id).Updates(tombstone).Error
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "database error (table project)")
 	}
 
 	return nil
 }
 
-func (pr projectRepository) GetUserProjects(userID uint64) ([]*models.Project, error) {
+// GetClientProjects returns the projects done for the client, the deleted ones left out
+func (pr projectRepository) GetClientProjects(ctx context.Context, clientID uint64) ([]*models.Project, error) {
+	projects := make([]*Project, 0, 10)
+
+	tx := pr.db.WithContext(ctx).Where("client_id = ?", clientID).Order("id").Find(&projects)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table project)")
+	}
+
+	return toModelProjects(projects), nil
+}
+
+func (pr projectRepository) GetUserProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
 	projects := make([]*Project, 0, 10)
 
-	tx := pr.db.Where(&Project{UserID: &userID}).Find(&projects)
+	tx := pr.db.WithContext(ctx).Where(&Project{UserID: &userID}).Find(&projects)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
@@ -122,6 +199,142 @@ func (pr projectRepository) GetUserProjects(userID uint64) ([]*models.Project, e
 	return toModelProjects(projects), nil
 }
 
+// projectRemoval is a project with the time the user was removed from it
+type projectRemoval struct {
+	Project
+	RemovedAt time.Time `gorm:"column:removed_at"`
+}
+
+// GetUserProjectChanges returns the projects the user is a member of changed or joined in
+// (since, until] with the tombstones. A project the user was removed from in the range is
+// a tombstone deleted at the removal.
+func (pr projectRepository) GetUserProjectChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Project, error) {
+	projects := make([]*Project, 0, 10)
+
+	tx := pr.db.WithContext(ctx).Unscoped().Select("project.*").
+		Joins("JOIN project_member ON project_member.project_id = project.id").
+		Where("project_member.user_id = ? AND project_member.accepted_at IS NOT NULL", userID).
+		Where("(project.updated_at > ? AND project.updated_at <= ?) OR (project_member.accepted_at > ? AND project_member.accepted_at <= ?)",
+			since, until, since, until).
+		Find(&projects)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table project)")
+	}
+
+	removals := make([]*projectRemoval, 0, 10)
+
+	tx = pr.db.WithContext(ctx).Unscoped().Select("project.*, project_member_removal.removed_at").
+		Joins("JOIN project_member_removal ON project_member_removal.project_id = project.id").
+		Where("project_member_removal.user_id = ? AND project_member_removal.removed_at > ? AND project_member_removal.removed_at <= ?",
+			userID, since, until).
+		Find(&removals)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table project_member_removal)")
+	}
+
+	changes := toModelProjects(projects)
+	for _, removal := range removals {
+		project := toModelProject(&removal.Project)
+		project.UpdatedAt, project.DeletedAt = removal.RemovedAt, &removal.RemovedAt
+		changes = append(changes, project)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].UpdatedAt.Before(changes[j].UpdatedAt)
+	})
+
+	return changes, nil
+}
+
+// GetDeletedProject returns the tombstone of the project, ErrNotFound if the project is not deleted
+func (pr projectRepository) GetDeletedProject(ctx context.Context, id uint64) (*models.Project, error) {
+	var project Project
+
+	tx := pr.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Take(&project)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table project)")
+	}
+
+	return toModelProject(&project), nil
+}
+
+// GetUserDeletedProjects returns the tombstones of the user, the last deleted first
+func (pr projectRepository) GetUserDeletedProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	projects := make([]*Project, 0, 10)
+
+	tx := pr.db.WithContext(ctx).Unscoped().Where(&Project{UserID: &userID}).
+		Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Find(&projects)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table project)")
+	}
+
+	return toModelProjects(projects), nil
+}
+
+// RestoreProject brings the tombstone back with the entries and goals deleted together with it,
+// the ones deleted before stay in the trash. The trigger of the entries puts their hours back.
+func (pr projectRepository) RestoreProject(ctx context.Context, id uint64) error {
+	now := time.Now().UTC()
+	restored := map[string]interface{}{
+		"deleted_at": nil,
+		"updated_at": now,
+		"version":    gorm.Expr("version + 1"),
+	}
+	// compared in the database, deleted_at doesn't make a round trip through the driver
+	deletedTogether := "project_id = ? AND deleted_at = (SELECT deleted_at FROM project WHERE id = ?)"
+
+	err := pr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var deleted int64
+		err := tx.Unscoped().Model(&Project{}).Where("id = ? AND deleted_at IS NOT NULL", id).Count(&deleted).Error
+		if err != nil || deleted == 0 {
+			return err
+		}
+
+		err = tx.Table("entry").Where(deletedTogether, id, id).Updates(restored).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Table("goal").Where(deletedTogether, id, id).Updates(restored).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&Project{ID: id}).Updates(restored).Error
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "database error (table project)")
+	}
+
+	return nil
+}
+
+// PurgeDeletedProjects removes the tombstones deleted before the time for good,
+// their entries and goals go with them. The membership tombstones of the time go too,
+// they are not counted.
+func (pr projectRepository) PurgeDeletedProjects(ctx context.Context, before time.Time) (int64, error) {
+	tx := pr.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before.UTC()).Delete(&Project{})
+
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "database error (table project)")
+	}
+
+	removals := pr.db.WithContext(ctx).Where("removed_at < ?", before.UTC()).Delete(&ProjectMemberRemoval{})
+
+	if removals.Error != nil {
+		return 0, errors.Wrap(removals.Error, "database error (table project_member_removal)")
+	}
+
+	return tx.RowsAffected, nil
+}
+
 func NewProjectRepository(db *gorm.DB) repository.RepositoryI {
 	return &projectRepository{
 		db: db,
@@ -1,13 +1,28 @@
 package repository
 
 import (
+	"context"
+	"time"
 	"timetracker/models"
 )
 
 type RepositoryI interface {
-	CreateProject(e *models.Project) error
-	UpdateProject(e *models.Project) error
-	GetProject(id uint64) (*models.Project, error)
-	DeleteProject(id uint64) error
-	GetUserProjects(userID uint64) ([]*models.Project, error)
+	CreateProject(ctx context.Context, e *models.Project) error
+	UpdateProject(ctx context.Context, e *models.Project) error
+	GetProject(ctx context.Context, id uint64) (*models.Project, error)
+	DeleteProject(ctx context.Context, id uint64) error
+	GetUserProjects(ctx context.Context, userID uint64) ([]*models.Project, error)
+	GetUserProjectChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Project, error)
+	GetDeletedProject(ctx context.Context, id uint64) (*models.Project, error)
+	GetUserDeletedProjects(ctx context.Context, userID uint64) ([]*models.Project, error)
+	RestoreProject(ctx context.Context, id uint64) error
+	PurgeDeletedProjects(ctx context.Context, before time.Time) (int64, error)
+	GetClientProjects(ctx context.Context, clientID uint64) ([]*models.Project, error)
+	GetMemberProjects(ctx context.Context, userID uint64) ([]*models.Project, error)
+	GetProjectMember(ctx context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error)
+	GetProjectMembers(ctx context.Context, projectID uint64) ([]*models.ProjectMember, error)
+	CreateProjectMember(ctx context.Context, member *models.ProjectMember) error
+	UpdateProjectMember(ctx context.Context, member *models.ProjectMember) error
+	DeleteProjectMember(ctx context.Context, projectID uint64, userID uint64) error
+	GetUserInvitations(ctx context.Context, userID uint64) ([]*models.ProjectMember, error)
 }
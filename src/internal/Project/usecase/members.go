@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"timetracker/internal/tracing"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+// memberRole returns the role of the user on the project. A user who is not a member,
+// or didn't accept the invitation yet, gets ErrPermissionDenied.
+func (u *usecase) memberRole(ctx context.Context, projectID uint64, userID uint64) (models.ProjectRole, error) {
+	member, err := u.projectRepository.GetProjectMember(ctx, projectID, userID)
+
+	if errors.Is(err, models.ErrNotFound) {
+		return "", models.ErrPermissionDenied
+	} else if err != nil {
+		return "", err
+	}
+
+	if !member.Accepted() {
+		return "", models.ErrPermissionDenied
+	}
+
+	return member.Role, nil
+}
+
+func (u *usecase) requireOwner(ctx context.Context, projectID uint64, userID uint64) error {
+	role, err := u.memberRole(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	if role != models.ProjectOwner {
+		return models.ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// dropCachedProjects drops the cached projects of every member, the project changed in their listings.
+// The cache holds the shared projects too, so the owner alone is not enough.
+func (u *usecase) dropCachedProjects(ctx context.Context, projectID uint64) error {
+	members, err := u.projectRepository.GetProjectMembers(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	return u.dropMembersCache(ctx, members)
+}
+
+// dropMembersCache is dropCachedProjects with the members read before the project changed
+func (u *usecase) dropMembersCache(ctx context.Context, members []*models.ProjectMember) error {
+	for _, member := range members {
+		err := u.redisStorage.Delete(ctx, strconv.FormatUint(member.UserID, 10))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetProjectMember returns the member of the project or the pending invitation of the user
+func (u *usecase) GetProjectMember(ctx context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.GetProjectMember")
+	defer span.End()
+
+	member, err := u.projectRepository.GetProjectMember(ctx, projectID, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.GetProjectMember")
+	}
+
+	return member, nil
+}
+
+// GetProjectMembers returns the members and the invited users with the hours they logged on the project
+func (u *usecase) GetProjectMembers(ctx context.Context, projectID uint64) ([]*models.ProjectMember, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.GetProjectMembers")
+	defer span.End()
+
+	members, err := u.projectRepository.GetProjectMembers(ctx, projectID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.GetProjectMembers")
+	}
+
+	return members, nil
+}
+
+// InviteProjectMember invites a friend of the owner to the project as an editor or a viewer,
+// member.InvitedBy is the owner
+func (u *usecase) InviteProjectMember(ctx context.Context, member *models.ProjectMember) error {
+	ctx, span := tracing.Start(ctx, "project.Usecase.InviteProjectMember")
+	defer span.End()
+
+	if member.InvitedBy == nil || member.Role == models.ProjectOwner || *member.InvitedBy == member.UserID {
+		return models.ErrBadRequest
+	}
+
+	_, err := u.projectRepository.GetProject(ctx, member.ProjectID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.InviteProjectMember")
+	}
+
+	err = u.requireOwner(ctx, member.ProjectID, *member.InvitedBy)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.InviteProjectMember")
+	}
+
+	isFriends, err := u.friendUC.CheckIsFriends(ctx, *member.InvitedBy, member.UserID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.InviteProjectMember")
+	}
+
+	if !isFriends {
+		return models.ErrNotFriends
+	}
+
+	_, err = u.projectRepository.GetProjectMember(ctx, member.ProjectID, member.UserID)
+
+	if err == nil {
+		return models.ErrConflictMember
+	} else if !errors.Is(err, models.ErrNotFound) {
+		return errors.Wrap(err, "Error in func project.Usecase.InviteProjectMember")
+	}
+
+	member.CreatedAt, member.AcceptedAt = time.Now().UTC(), nil
+	err = u.projectRepository.CreateProjectMember(ctx, member)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.InviteProjectMember")
+	}
+
+	return nil
+}
+
+// UpdateProjectMember changes the role of a member or of an invitation, the owner keeps the project
+func (u *usecase) UpdateProjectMember(ctx context.Context, projectID uint64, ownerID uint64, memberID uint64, role models.ProjectRole) (*models.ProjectMember, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.UpdateProjectMember")
+	defer span.End()
+
+	err := u.requireOwner(ctx, projectID, ownerID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.UpdateProjectMember")
+	}
+
+	member, err := u.projectRepository.GetProjectMember(ctx, projectID, memberID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.UpdateProjectMember")
+	}
+
+	if member.Role == models.ProjectOwner || role == models.ProjectOwner {
+		return nil, models.ErrBadRequest
+	}
+
+	member.Role = role
+	err = u.projectRepository.UpdateProjectMember(ctx, member)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.UpdateProjectMember")
+	}
+
+	return member, nil
+}
+
+// DeleteProjectMember removes a member or cancels an invitation. The owner removes anybody but
+// the owner, a member can only remove itself to leave the project.
+func (u *usecase) DeleteProjectMember(ctx context.Context, projectID uint64, userID uint64, memberID uint64) error {
+	ctx, span := tracing.Start(ctx, "project.Usecase.DeleteProjectMember")
+	defer span.End()
+
+	if userID != memberID {
+		err := u.requireOwner(ctx, projectID, userID)
+
+		if err != nil {
+			return errors.Wrap(err, "Error in func project.Usecase.DeleteProjectMember")
+		}
+	}
+
+	member, err := u.projectRepository.GetProjectMember(ctx, projectID, memberID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.DeleteProjectMember")
+	}
+
+	if member.Role == models.ProjectOwner {
+		return models.ErrBadRequest
+	}
+
+	err = u.projectRepository.DeleteProjectMember(ctx, projectID, memberID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.DeleteProjectMember")
+	}
+
+	err = u.redisStorage.Delete(ctx, strconv.FormatUint(memberID, 10))
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.DeleteProjectMember")
+	}
+
+	return nil
+}
+
+// GetUserInvitations returns the invitations the user didn't answer yet
+func (u *usecase) GetUserInvitations(ctx context.Context, userID uint64) ([]*models.ProjectMember, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.GetUserInvitations")
+	defer span.End()
+
+	invitations, err := u.projectRepository.GetUserInvitations(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.GetUserInvitations")
+	}
+
+	return invitations, nil
+}
+
+// AcceptInvitation makes the invited user a member, the project shows up in the listings of the user
+func (u *usecase) AcceptInvitation(ctx context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.AcceptInvitation")
+	defer span.End()
+
+	member, err := u.pendingInvitation(ctx, projectID, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.AcceptInvitation")
+	}
+
+	now := time.Now().UTC()
+	member.AcceptedAt = &now
+	err = u.projectRepository.UpdateProjectMember(ctx, member)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.AcceptInvitation")
+	}
+
+	err = u.redisStorage.Delete(ctx, strconv.FormatUint(userID, 10))
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.AcceptInvitation")
+	}
+
+	return member, nil
+}
+
+// DeclineInvitation removes the invitation of the user
+func (u *usecase) DeclineInvitation(ctx context.Context, projectID uint64, userID uint64) error {
+	ctx, span := tracing.Start(ctx, "project.Usecase.DeclineInvitation")
+	defer span.End()
+
+	_, err := u.pendingInvitation(ctx, projectID, userID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.DeclineInvitation")
+	}
+
+	err = u.projectRepository.DeleteProjectMember(ctx, projectID, userID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.DeclineInvitation")
+	}
+
+	return nil
+}
+
+// pendingInvitation returns ErrNotFound for an accepted one or an invitation to a deleted project
+func (u *usecase) pendingInvitation(ctx context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error) {
+	member, err := u.projectRepository.GetProjectMember(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Accepted() {
+		return nil, models.ErrNotFound
+	}
+
+	_, err = u.projectRepository.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
@@ -1,39 +1,72 @@
 package usecase
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
+	clientRep "timetracker/internal/Client/repository"
+	friendUsecase "timetracker/internal/Friends/usecase"
 	projectRep "timetracker/internal/Project/repository"
 	"timetracker/internal/cache"
+	"timetracker/internal/tracing"
 	"timetracker/models"
 
 	"github.com/pkg/errors"
 )
 
 type UsecaseI interface {
-	CreateProject(e *models.Project) error
-	UpdateProject(e *models.Project) error
-	GetProject(id uint64) (*models.Project, error)
-	DeleteProject(id uint64, userID uint64) error
-	GetUserProjects(userID uint64) ([]*models.Project, error)
-	GetUserProjectsWithCache(userID uint64) ([]*models.Project, error)
+	CreateProject(ctx context.Context, e *models.Project) error
+	UpdateProject(ctx context.Context, patch *models.ProjectPatch) (*models.Project, error)
+	GetProject(ctx context.Context, id uint64) (*models.Project, error)
+	DeleteProject(ctx context.Context, id uint64, userID uint64) error
+	GetUserProjects(ctx context.Context, userID uint64) ([]*models.Project, error)
+	GetUserArchivedProjects(ctx context.Context, userID uint64) ([]*models.Project, error)
+	ArchiveProject(ctx context.Context, id uint64, userID uint64) (*models.Project, error)
+	UnarchiveProject(ctx context.Context, id uint64, userID uint64) (*models.Project, error)
+	GetUserProjectChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Project, error)
+	GetUserDeletedProjects(ctx context.Context, userID uint64) ([]*models.Project, error)
+	RestoreProject(ctx context.Context, id uint64, userID uint64) (*models.Project, error)
+	PurgeDeletedProjects(ctx context.Context, before time.Time) (int64, error)
+	GetUserProjectsWithCache(ctx context.Context, userID uint64) ([]*models.Project, error)
+	GetProjectMember(ctx context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error)
+	GetProjectMembers(ctx context.Context, projectID uint64) ([]*models.ProjectMember, error)
+	InviteProjectMember(ctx context.Context, member *models.ProjectMember) error
+	UpdateProjectMember(ctx context.Context, projectID uint64, ownerID uint64, memberID uint64, role models.ProjectRole) (*models.ProjectMember, error)
+	DeleteProjectMember(ctx context.Context, projectID uint64, userID uint64, memberID uint64) error
+	GetUserInvitations(ctx context.Context, userID uint64) ([]*models.ProjectMember, error)
+	AcceptInvitation(ctx context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error)
+	DeclineInvitation(ctx context.Context, projectID uint64, userID uint64) error
 }
 
 type usecase struct {
 	projectRepository projectRep.RepositoryI
+	clientRepository  clientRep.RepositoryI
 	redisStorage      cache.CacheStorageI
+	friendUC          friendUsecase.UsecaseI
 }
 
-func New(pRep projectRep.RepositoryI, rS cache.CacheStorageI) UsecaseI {
+func New(pRep projectRep.RepositoryI, cRep clientRep.RepositoryI, rS cache.CacheStorageI, fUC friendUsecase.UsecaseI) UsecaseI {
 	return &usecase{
 		projectRepository: pRep,
+		clientRepository:  cRep,
 		redisStorage:      rS,
+		friendUC:          fUC,
 	}
 }
 
-func (u *usecase) CreateProject(e *models.Project) error {
-	err := u.projectRepository.CreateProject(e)
+func (u *usecase) CreateProject(ctx context.Context, e *models.Project) error {
+	ctx, span := tracing.Start(ctx, "project.Usecase.CreateProject")
+	defer span.End()
+
+	err := u.checkClient(ctx, e.ClientID, *e.UserID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.CreateProject")
+	}
+
+	err = u.projectRepository.CreateProject(ctx, e)
 
 	if err != nil {
 		return errors.Wrap(err, "Error in func project.Usecase.CreateProject")
@@ -42,24 +75,80 @@ func (u *usecase) CreateProject(e *models.Project) error {
 	return nil
 }
 
-func (u *usecase) UpdateProject(p *models.Project) error {
-	_, err := u.projectRepository.GetProject(p.ID)
+// UpdateProject lets the owner and the editors change the project, the cached projects
+// of every member are dropped so the listings show the change.
+func (u *usecase) UpdateProject(ctx context.Context, patch *models.ProjectPatch) (*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.UpdateProject")
+	defer span.End()
+
+	project, err := u.projectRepository.GetProject(ctx, patch.ID)
 
 	if err != nil {
-		return errors.Wrap(err, "Error in func project.Usecase.Update.GetProject")
+		return nil, errors.Wrap(err, "Error in func project.Usecase.Update.GetProject")
 	}
 
-	err = u.projectRepository.UpdateProject(p)
+	role, err := u.memberRole(ctx, patch.ID, patch.UserID)
 
 	if err != nil {
-		return errors.Wrap(err, "Error in func project.Usecase.Update")
+		return nil, errors.Wrap(err, "Error in func project.Usecase.Update")
+	}
+
+	if !role.CanEdit() {
+		return nil, models.ErrPermissionDenied
+	}
+
+	if patch.Version != 0 && patch.Version != project.Version {
+		return nil, models.ErrVersionMismatch
+	}
+
+	// the client is one of the owner, an editor can't see the others
+	if patch.ClientID.Set {
+		err = u.checkClient(ctx, patch.ClientID.Value, *project.UserID)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "Error in func project.Usecase.Update")
+		}
+	}
+
+	patch.Apply(project)
+	err = u.projectRepository.UpdateProject(ctx, project)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.Update")
+	}
+
+	err = u.dropCachedProjects(ctx, project.ID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.Update")
+	}
+
+	return project, nil
+}
+
+// checkClient lets a project only be done for a client of its owner, nil is no client
+func (u *usecase) checkClient(ctx context.Context, clientID *uint64, ownerID uint64) error {
+	if clientID == nil {
+		return nil
+	}
+
+	client, err := u.clientRepository.GetClient(ctx, *clientID)
+	if err != nil {
+		return err
+	}
+
+	if client.UserID != ownerID {
+		return models.ErrPermissionDenied
 	}
 
 	return nil
 }
 
-func (u *usecase) GetProject(id uint64) (*models.Project, error) {
-	resProject, err := u.projectRepository.GetProject(id)
+func (u *usecase) GetProject(ctx context.Context, id uint64) (*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.GetProject")
+	defer span.End()
+
+	resProject, err := u.projectRepository.GetProject(ctx, id)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "project.usecase.GetProject error while get project info")
@@ -68,32 +157,50 @@ func (u *usecase) GetProject(id uint64) (*models.Project, error) {
 	return resProject, nil
 }
 
-func (u *usecase) DeleteProject(id uint64, userID uint64) error {
-	existedProject, err := u.projectRepository.GetProject(id)
+// DeleteProject is for the owner only. The members are read before the delete, their
+// cached projects are dropped after it.
+func (u *usecase) DeleteProject(ctx context.Context, id uint64, userID uint64) error {
+	ctx, span := tracing.Start(ctx, "project.Usecase.DeleteProject")
+	defer span.End()
+
+	_, err := u.projectRepository.GetProject(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if existedProject == nil {
-		return errors.New("Project not found") //TODO models error
+	err = u.requireOwner(ctx, id, userID)
+	if err != nil {
+		return err
 	}
 
-	if *existedProject.UserID != userID {
-		return errors.New("Permission denied")
+	members, err := u.projectRepository.GetProjectMembers(ctx, id)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.DeleteProject")
 	}
 
-	err = u.projectRepository.DeleteProject(id)
+	err = u.projectRepository.DeleteProject(ctx, id)
 
 	if err != nil {
 		return errors.Wrap(err, "Error in func project.Usecase.DeleteProject repository")
 	}
 
+	err = u.dropMembersCache(ctx, members)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func project.Usecase.DeleteProject")
+	}
+
 	return nil
 }
 
-func (u *usecase) GetUserProjectsWithCache(userID uint64) ([]*models.Project, error) {
+// GetUserProjectsWithCache returns the projects of the user with the ones shared with the user
+func (u *usecase) GetUserProjectsWithCache(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.GetUserProjectsWithCache")
+	defer span.End()
+
 	strUserID := strconv.FormatUint(userID, 10)
-	dataFromCache, err := u.redisStorage.Get(strUserID)
+	dataFromCache, err := u.redisStorage.Get(ctx, strUserID)
 	if err != nil && err != models.ErrNotFound {
 		return nil, errors.Wrap(err, "Error in func project.Usecase.GetUserProjectsWithCache")
 	}
@@ -104,17 +211,23 @@ func (u *usecase) GetUserProjectsWithCache(userID uint64) ([]*models.Project, er
 		if err != nil {
 			return nil, errors.Wrap(err, "Error in func project.Usecase.GetUserProjectsWithCache")
 		}
-		fmt.Println("from cache")
 		return res, nil
 	}
 
-	projects, err := u.projectRepository.GetUserProjects(userID)
+	projects, err := u.projectRepository.GetUserProjects(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.GetUserProjectsWithCache")
+	}
+
+	shared, err := u.projectRepository.GetMemberProjects(ctx, userID)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func project.Usecase.GetUserProjectsWithCache")
 	}
 
-	err = u.redisStorage.Set(strUserID, projects)
+	projects = filterArchived(append(projects, shared...), false)
+	err = u.redisStorage.Set(ctx, strUserID, projects)
 	if err != nil {
 		return nil, fmt.Errorf("can not set data to redis cache")
 	}
@@ -122,12 +235,179 @@ func (u *usecase) GetUserProjectsWithCache(userID uint64) ([]*models.Project, er
 	return projects, nil
 }
 
-func (u *usecase) GetUserProjects(userID uint64) ([]*models.Project, error) {
-	projects, err := u.projectRepository.GetUserProjects(userID)
+func (u *usecase) GetUserProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.GetUserProjects")
+	defer span.End()
+
+	projects, err := u.projectRepository.GetUserProjects(ctx, userID)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func project.Usecase.GetUserPosts")
 	}
 
+	return filterArchived(projects, false), nil
+}
+
+// GetUserArchivedProjects returns the archived projects the user owns, the listings leave them out
+func (u *usecase) GetUserArchivedProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.GetUserArchivedProjects")
+	defer span.End()
+
+	projects, err := u.projectRepository.GetUserProjects(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.GetUserArchivedProjects")
+	}
+
+	return filterArchived(projects, true), nil
+}
+
+// filterArchived keeps the archived projects or the rest of them
+func filterArchived(projects []*models.Project, archived bool) []*models.Project {
+	filtered := make([]*models.Project, 0, len(projects))
+	for _, project := range projects {
+		if (project.ArchivedAt != nil) == archived {
+			filtered = append(filtered, project)
+		}
+	}
+
+	return filtered
+}
+
+// ArchiveProject hides the project of the user from the listings, its entries still count
+func (u *usecase) ArchiveProject(ctx context.Context, id uint64, userID uint64) (*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.ArchiveProject")
+	defer span.End()
+
+	now := time.Now().UTC()
+	project, err := u.setArchivedAt(ctx, id, userID, &now)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.ArchiveProject")
+	}
+
+	return project, nil
+}
+
+// UnarchiveProject brings the archived project of the user back to the listings
+func (u *usecase) UnarchiveProject(ctx context.Context, id uint64, userID uint64) (*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.UnarchiveProject")
+	defer span.End()
+
+	project, err := u.setArchivedAt(ctx, id, userID, nil)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.UnarchiveProject")
+	}
+
+	return project, nil
+}
+
+// setArchivedAt leaves a project already in the state as it is. The cached
+// projects of the members are dropped, they are read again on the next request.
+func (u *usecase) setArchivedAt(ctx context.Context, id uint64, userID uint64, archivedAt *time.Time) (*models.Project, error) {
+	project, err := u.projectRepository.GetProject(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.requireOwner(ctx, id, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if (project.ArchivedAt != nil) == (archivedAt != nil) {
+		return project, nil
+	}
+
+	project.ArchivedAt = archivedAt
+	err = u.projectRepository.UpdateProject(ctx, project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.dropCachedProjects(ctx, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// GetUserProjectChanges returns the own and shared projects changed in (since, until] with the tombstones
+// of the deleted ones and of the ones the user was removed from
+func (u *usecase) GetUserProjectChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.GetUserProjectChanges")
+	defer span.End()
+
+	projects, err := u.projectRepository.GetUserProjectChanges(ctx, userID, since, until)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.GetUserProjectChanges")
+	}
+
 	return projects, nil
 }
+
+func (u *usecase) GetUserDeletedProjects(ctx context.Context, userID uint64) ([]*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.GetUserDeletedProjects")
+	defer span.End()
+
+	deleted, err := u.projectRepository.GetUserDeletedProjects(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.GetUserDeletedProjects")
+	}
+
+	return deleted, nil
+}
+
+// RestoreProject is for the owner only, the entries and goals deleted with the project come back too.
+// The project shows up again in the cached projects of the members.
+func (u *usecase) RestoreProject(ctx context.Context, id uint64, userID uint64) (*models.Project, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.RestoreProject")
+	defer span.End()
+
+	deleted, err := u.projectRepository.GetDeletedProject(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.RestoreProject")
+	}
+
+	err = u.requireOwner(ctx, deleted.ID, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.RestoreProject")
+	}
+
+	err = u.projectRepository.RestoreProject(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.RestoreProject")
+	}
+
+	err = u.dropCachedProjects(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func project.Usecase.RestoreProject")
+	}
+
+	return u.GetProject(ctx, id)
+}
+
+func (u *usecase) PurgeDeletedProjects(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "project.Usecase.PurgeDeletedProjects")
+	defer span.End()
+
+	purged, err := u.projectRepository.PurgeDeletedProjects(ctx, before)
+
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in func project.Usecase.PurgeDeletedProjects")
+	}
+
+	return purged, nil
+}
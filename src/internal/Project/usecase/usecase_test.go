@@ -1,14 +1,23 @@
 package usecase_test
 
 import (
+	"context"
 	"github.com/bxcodec/faker"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"strconv"
 	"testing"
+	"time"
+	clientMocks "timetracker/internal/Client/repository/mocks"
+	friendMocks "timetracker/internal/Friends/repository/mocks"
+	friendUsecase "timetracker/internal/Friends/usecase"
 	goalMocks "timetracker/internal/Project/repository/mocks"
 	"timetracker/internal/Project/usecase"
+	"timetracker/internal/cache"
 	"timetracker/models"
+	"timetracker/pkg"
 )
 
 type TestCaseGetProject struct {
@@ -22,6 +31,18 @@ type TestCaseDeleteProject struct {
 	Error   error
 }
 
+type TestCaseRestoreProject struct {
+	ArgData     []uint64
+	ExpectedRes *models.Project
+	Error       error
+}
+
+type TestCaseUpdateProject struct {
+	ArgData     *models.ProjectPatch
+	ExpectedRes *models.Project
+	Error       error
+}
+
 type TestCaseCreateUpdateProject struct {
 	ArgData *models.Project
 	Error   error
@@ -33,6 +54,20 @@ type TestCaseGetUserProjects struct {
 	Error       error
 }
 
+// onMembers mocks the accepted members of every project, the other users are no members
+func onMembers(repo *goalMocks.RepositoryI, roles map[uint64]models.ProjectRole) {
+	repo.On("GetProjectMember", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error) {
+			role, ok := roles[userID]
+			if !ok {
+				return nil, models.ErrNotFound
+			}
+
+			acceptedAt := time.Now().UTC()
+			return &models.ProjectMember{ProjectID: projectID, UserID: userID, Role: role, AcceptedAt: &acceptedAt}, nil
+		})
+}
+
 func TestUsecaseGetProject(t *testing.T) {
 	var mockProjectRes models.Project
 	err := faker.FakeData(&mockProjectRes)
@@ -42,9 +77,9 @@ func TestUsecaseGetProject(t *testing.T) {
 
 	mockProjectRepo := goalMocks.NewRepositoryI(t)
 
-	mockProjectRepo.On("GetProject", mockProjectRes.ID).Return(&mockProjectRes, nil)
+	mockProjectRepo.On("GetProject", mock.Anything, mockProjectRes.ID).Return(&mockProjectRes, nil)
 
-	useCase := usecase.New(mockProjectRepo, nil)
+	useCase := usecase.New(mockProjectRepo, nil, nil, nil)
 
 	cases := map[string]TestCaseGetProject{
 		"success": {
@@ -56,7 +91,7 @@ func TestUsecaseGetProject(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			user, err := useCase.GetProject(test.ArgData)
+			user, err := useCase.GetProject(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, err)
 
 			if err == nil {
@@ -68,36 +103,97 @@ func TestUsecaseGetProject(t *testing.T) {
 }
 
 func TestUsecaseUpdateProject(t *testing.T) {
-	var mockProject, invalidMockProject models.Project
+	var mockProject models.Project
 	err := faker.FakeData(&mockProject)
 	assert.NoError(t, err)
+	mockProject.IsPrivate = true
 
-	invalidMockProject.ID += mockProject.ID + 1
-
-	mockProjectRepo := goalMocks.NewRepositoryI(t)
+	about, isPrivate := "", false
+	patch := &models.ProjectPatch{ID: mockProject.ID, UserID: *mockProject.UserID, About: &about, IsPrivate: &isPrivate}
 
-	mockProjectRepo.On("GetProject", mockProject.ID).Return(&mockProject, nil)
-	mockProjectRepo.On("UpdateProject", &mockProject).Return(nil)
+	expectedProject := mockProject
+	expectedProject.About = ""
+	expectedProject.IsPrivate = false
 
-	mockProjectRepo.On("GetProject", invalidMockProject.ID).Return(nil, models.ErrNotFound)
-
-	useCase := usecase.New(mockProjectRepo, nil)
+	mockProjectRepo := goalMocks.NewRepositoryI(t)
 
-	cases := map[string]TestCaseCreateUpdateProject{
-		"success": {
-			ArgData: &mockProject,
-			Error:   nil,
+	mockProjectRepo.On("GetProject", mock.Anything, mockProject.ID).Return(func(context.Context, uint64) *models.Project {
+		project := mockProject
+		return &project
+	}, nil)
+	mockProjectRepo.On("UpdateProject", mock.Anything, &expectedProject).Return(nil)
+	mockProjectRepo.On("GetProject", mock.Anything, mockProject.ID+1).Return(nil, models.ErrNotFound)
+	editorID, viewerID := *mockProject.UserID+2, *mockProject.UserID+3
+	onMembers(mockProjectRepo, map[uint64]models.ProjectRole{
+		*mockProject.UserID: models.ProjectOwner,
+		editorID:            models.ProjectEditor,
+		viewerID:            models.ProjectViewer,
+	})
+
+	editorPatch := *patch
+	editorPatch.UserID = editorID
+
+	// the rename shows up in the cached listings of every member
+	mockProjectRepo.On("GetProjectMembers", mock.Anything, mockProject.ID).Return([]*models.ProjectMember{
+		models.NewProjectOwner(mockProject.ID, *mockProject.UserID, time.Now().UTC()),
+		{ProjectID: mockProject.ID, UserID: editorID, Role: models.ProjectEditor},
+	}, nil)
+	storage := cache.NewStorageMemory()
+	editorKey := strconv.FormatUint(editorID, 10)
+
+	otherClient := &models.Client{ID: 1, UserID: *mockProject.UserID + 1}
+	mockClientRepo := clientMocks.NewRepositoryI(t)
+	mockClientRepo.On("GetClient", mock.Anything, otherClient.ID).Return(otherClient, nil)
+
+	useCase := usecase.New(mockProjectRepo, mockClientRepo, storage, nil)
+
+	cases := map[string]TestCaseUpdateProject{
+		"success clears fields": {
+			ArgData:     patch,
+			ExpectedRes: &expectedProject,
+			Error:       nil,
 		},
 		"Project not found": {
-			ArgData: &invalidMockProject,
+			ArgData: &models.ProjectPatch{ID: mockProject.ID + 1, UserID: *mockProject.UserID},
 			Error:   models.ErrNotFound,
 		},
+		"Permission denied": {
+			ArgData: &models.ProjectPatch{ID: mockProject.ID, UserID: *mockProject.UserID + 1},
+			Error:   models.ErrPermissionDenied,
+		},
+		"editor updates": {
+			ArgData:     &editorPatch,
+			ExpectedRes: &expectedProject,
+			Error:       nil,
+		},
+		"viewer can't update": {
+			ArgData: &models.ProjectPatch{ID: mockProject.ID, UserID: viewerID},
+			Error:   models.ErrPermissionDenied,
+		},
+		"client of another user": {
+			ArgData: &models.ProjectPatch{ID: mockProject.ID, UserID: *mockProject.UserID, ClientID: pkg.Some(&otherClient.ID)},
+			Error:   models.ErrPermissionDenied,
+		},
+		"Version mismatch": {
+			ArgData: &models.ProjectPatch{ID: mockProject.ID, UserID: *mockProject.UserID, Version: mockProject.Version + 1},
+			Error:   models.ErrVersionMismatch,
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.UpdateProject(test.ArgData)
+			require.NoError(t, storage.Set(context.Background(), editorKey, []*models.Project{&mockProject}))
+
+			project, err := useCase.UpdateProject(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
+
+			_, cacheErr := storage.Get(context.Background(), editorKey)
+			if err == nil {
+				assert.Equal(t, test.ExpectedRes, project)
+				assert.ErrorIs(t, cacheErr, models.ErrNotFound)
+			} else {
+				assert.NoError(t, cacheErr)
+			}
 		})
 	}
 	mockProjectRepo.AssertExpectations(t)
@@ -108,22 +204,40 @@ func TestUsecaseCreateProject(t *testing.T) {
 	err := faker.FakeData(&mockProject)
 	assert.NoError(t, err)
 
+	ownClient := &models.Client{ID: *mockProject.ClientID, UserID: *mockProject.UserID}
+	otherClient := &models.Client{ID: ownClient.ID + 1, UserID: ownClient.UserID + 1}
+
+	withoutClient, withOtherClient := mockProject, mockProject
+	withoutClient.ClientID, withOtherClient.ClientID = nil, &otherClient.ID
+
 	mockProjectRepo := goalMocks.NewRepositoryI(t)
+	mockClientRepo := clientMocks.NewRepositoryI(t)
 
-	mockProjectRepo.On("CreateProject", &mockProject).Return(nil)
+	mockProjectRepo.On("CreateProject", mock.Anything, &mockProject).Return(nil)
+	mockProjectRepo.On("CreateProject", mock.Anything, &withoutClient).Return(nil)
+	mockClientRepo.On("GetClient", mock.Anything, ownClient.ID).Return(ownClient, nil)
+	mockClientRepo.On("GetClient", mock.Anything, otherClient.ID).Return(otherClient, nil)
 
-	useCase := usecase.New(mockProjectRepo, nil)
+	useCase := usecase.New(mockProjectRepo, mockClientRepo, nil, nil)
 
 	cases := map[string]TestCaseCreateUpdateProject{
 		"success": {
 			ArgData: &mockProject,
 			Error:   nil,
 		},
+		"without a client": {
+			ArgData: &withoutClient,
+			Error:   nil,
+		},
+		"client of another user": {
+			ArgData: &withOtherClient,
+			Error:   models.ErrPermissionDenied,
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.CreateProject(test.ArgData)
+			err := useCase.CreateProject(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
 		})
 	}
@@ -136,16 +250,31 @@ func TestUsecaseDeleteProject(t *testing.T) {
 	assert.NoError(t, err)
 
 	invalidMockProject.ID += mockProject.ID + 1
-	*invalidMockProject.UserID += *mockProject.UserID + 1
+	invalidUserID := *mockProject.UserID + 1
+	invalidMockProject.UserID = &invalidUserID
 
 	mockProjectRepo := goalMocks.NewRepositoryI(t)
 
-	mockProjectRepo.On("GetProject", mockProject.ID).Return(&mockProject, nil)
-	mockProjectRepo.On("DeleteProject", mockProject.ID).Return(nil)
+	mockProjectRepo.On("GetProject", mock.Anything, mockProject.ID).Return(&mockProject, nil)
+	memberID := *mockProject.UserID + 2
+	mockProjectRepo.On("GetProjectMembers", mock.Anything, mockProject.ID).Return([]*models.ProjectMember{
+		models.NewProjectOwner(mockProject.ID, *mockProject.UserID, time.Now().UTC()),
+		{ProjectID: mockProject.ID, UserID: memberID, Role: models.ProjectViewer},
+	}, nil).Once()
+	mockProjectRepo.On("DeleteProject", mock.Anything, mockProject.ID).Return(nil)
+
+	mockProjectRepo.On("GetProject", mock.Anything, invalidMockProject.ID).Return(nil, models.ErrNotFound)
+	onMembers(mockProjectRepo, map[uint64]models.ProjectRole{
+		*mockProject.UserID: models.ProjectOwner,
+		invalidUserID:       models.ProjectEditor,
+	})
 
-	mockProjectRepo.On("GetProject", invalidMockProject.ID).Return(nil, models.ErrNotFound)
+	storage := cache.NewStorageMemory()
+	ownerKey, memberKey := strconv.FormatUint(*mockProject.UserID, 10), strconv.FormatUint(memberID, 10)
+	require.NoError(t, storage.Set(context.Background(), ownerKey, []*models.Project{&mockProject}))
+	require.NoError(t, storage.Set(context.Background(), memberKey, []*models.Project{&mockProject}))
 
-	useCase := usecase.New(mockProjectRepo, nil)
+	useCase := usecase.New(mockProjectRepo, nil, storage, nil)
 
 	cases := map[string]TestCaseDeleteProject{
 		"success": {
@@ -156,15 +285,25 @@ func TestUsecaseDeleteProject(t *testing.T) {
 			ArgData: []*uint64{&invalidMockProject.ID, invalidMockProject.UserID},
 			Error:   models.ErrNotFound,
 		},
+		"editor can't delete": {
+			ArgData: []*uint64{&mockProject.ID, &invalidUserID},
+			Error:   models.ErrPermissionDenied,
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.DeleteProject(*test.ArgData[0], *test.ArgData[1])
+			err := useCase.DeleteProject(context.Background(), *test.ArgData[0], *test.ArgData[1])
 			require.Equal(t, test.Error, errors.Cause(err))
 		})
 	}
 	mockProjectRepo.AssertExpectations(t)
+
+	// the deleted project is gone from the cached listings of the owner and of the members
+	_, err = storage.Get(context.Background(), ownerKey)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	_, err = storage.Get(context.Background(), memberKey)
+	assert.ErrorIs(t, err, models.ErrNotFound)
 }
 
 func TestUsecaseGetUserProjects(t *testing.T) {
@@ -173,6 +312,7 @@ func TestUsecaseGetUserProjects(t *testing.T) {
 
 	for idx := range mockProjectRes {
 		mockProjectRes[idx].UserID = mockProjectRes[0].UserID
+		mockProjectRes[idx].ArchivedAt = nil
 	}
 	assert.NoError(t, err)
 
@@ -180,9 +320,9 @@ func TestUsecaseGetUserProjects(t *testing.T) {
 
 	mockProjectRepo := goalMocks.NewRepositoryI(t)
 
-	mockProjectRepo.On("GetUserProjects", mockProjectRes[0].UserID).Return(mockProjectRes, nil)
+	mockProjectRepo.On("GetUserProjects", mock.Anything, *mockProjectRes[0].UserID).Return(mockProjectRes, nil)
 
-	useCase := usecase.New(mockProjectRepo, nil)
+	useCase := usecase.New(mockProjectRepo, nil, nil, nil)
 
 	cases := map[string]TestCaseGetUserProjects{
 		"success": {
@@ -194,7 +334,7 @@ func TestUsecaseGetUserProjects(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			user, err := useCase.GetUserProjects(*test.ArgData)
+			user, err := useCase.GetUserProjects(context.Background(), *test.ArgData)
 			require.Equal(t, test.Error, err)
 
 			if err == nil {
@@ -204,3 +344,207 @@ func TestUsecaseGetUserProjects(t *testing.T) {
 	}
 	mockProjectRepo.AssertExpectations(t)
 }
+
+func TestUsecaseRestoreProject(t *testing.T) {
+	var mockProject models.Project
+	err := faker.FakeData(&mockProject)
+	assert.NoError(t, err)
+
+	deletedAt := mockProject.UpdatedAt
+	deletedProject := mockProject
+	deletedProject.DeletedAt = &deletedAt
+	restoredProject := mockProject
+	restoredProject.DeletedAt = nil
+	notDeletedID := mockProject.ID + 1
+
+	mockProjectRepo := goalMocks.NewRepositoryI(t)
+
+	mockProjectRepo.On("GetDeletedProject", mock.Anything, mockProject.ID).Return(&deletedProject, nil)
+	mockProjectRepo.On("GetDeletedProject", mock.Anything, notDeletedID).Return(nil, models.ErrNotFound)
+	mockProjectRepo.On("RestoreProject", mock.Anything, mockProject.ID).Return(nil).Once()
+	mockProjectRepo.On("GetProject", mock.Anything, mockProject.ID).Return(&restoredProject, nil).Once()
+	mockProjectRepo.On("GetProjectMembers", mock.Anything, mockProject.ID).Return(
+		[]*models.ProjectMember{models.NewProjectOwner(mockProject.ID, *mockProject.UserID, time.Now().UTC())}, nil).Once()
+	onMembers(mockProjectRepo, map[uint64]models.ProjectRole{*mockProject.UserID: models.ProjectOwner})
+
+	storage := cache.NewStorageMemory()
+	ownerKey := strconv.FormatUint(*mockProject.UserID, 10)
+	require.NoError(t, storage.Set(context.Background(), ownerKey, []*models.Project{}))
+
+	useCase := usecase.New(mockProjectRepo, nil, storage, nil)
+
+	cases := map[string]TestCaseRestoreProject{
+		"success": {
+			ArgData:     []uint64{mockProject.ID, *mockProject.UserID},
+			ExpectedRes: &restoredProject,
+			Error:       nil,
+		},
+		"not deleted": {
+			ArgData: []uint64{notDeletedID, *mockProject.UserID},
+			Error:   models.ErrNotFound,
+		},
+		"other user": {
+			ArgData: []uint64{mockProject.ID, *mockProject.UserID + 1},
+			Error:   models.ErrPermissionDenied,
+		},
+	}
+
+	for _, name := range []string{"success", "not deleted", "other user"} {
+		test := cases[name]
+		t.Run(name, func(t *testing.T) {
+			project, err := useCase.RestoreProject(context.Background(), test.ArgData[0], test.ArgData[1])
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, test.ExpectedRes, project)
+			}
+		})
+	}
+	mockProjectRepo.AssertExpectations(t)
+
+	// the restored project shows up in the listing again
+	_, err = storage.Get(context.Background(), ownerKey)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
+
+func TestUsecaseArchiveProject(t *testing.T) {
+	userID := uint64(1)
+	archivedAt := time.Now().UTC()
+	project := &models.Project{ID: 2, UserID: &userID, Version: 1}
+	archived := &models.Project{ID: 3, UserID: &userID, Version: 1, ArchivedAt: &archivedAt}
+
+	mockProjectRepo := goalMocks.NewRepositoryI(t)
+	storage := cache.NewStorageMemory()
+
+	mockProjectRepo.On("GetProject", mock.Anything, project.ID).Return(func(context.Context, uint64) *models.Project {
+		copied := *project
+		return &copied
+	}, nil)
+	mockProjectRepo.On("GetProject", mock.Anything, archived.ID).Return(func(context.Context, uint64) *models.Project {
+		copied := *archived
+		return &copied
+	}, nil)
+	mockProjectRepo.On("UpdateProject", mock.Anything, mock.MatchedBy(func(p *models.Project) bool {
+		return p.ID == project.ID && p.ArchivedAt != nil
+	})).Return(nil).Once()
+	mockProjectRepo.On("GetUserProjects", mock.Anything, userID).Return([]*models.Project{project, archived}, nil)
+	mockProjectRepo.On("GetMemberProjects", mock.Anything, userID).Return([]*models.Project{}, nil)
+	mockProjectRepo.On("GetProjectMembers", mock.Anything, project.ID).Return([]*models.ProjectMember{models.NewProjectOwner(project.ID, userID, archivedAt)}, nil)
+	onMembers(mockProjectRepo, map[uint64]models.ProjectRole{userID: models.ProjectOwner})
+
+	useCase := usecase.New(mockProjectRepo, nil, storage, nil)
+
+	// the cached listing is dropped by the archive
+	projects, err := useCase.GetUserProjectsWithCache(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Project{project}, projects)
+
+	res, err := useCase.ArchiveProject(context.Background(), project.ID, userID)
+	require.NoError(t, err)
+	assert.NotNil(t, res.ArchivedAt)
+
+	_, err = storage.Get(context.Background(), "1")
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	// already archived, nothing to write
+	res, err = useCase.ArchiveProject(context.Background(), archived.ID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, archived, res)
+
+	_, err = useCase.ArchiveProject(context.Background(), project.ID, userID+1)
+	assert.Equal(t, models.ErrPermissionDenied, errors.Cause(err))
+
+	projects, err = useCase.GetUserArchivedProjects(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Project{archived}, projects)
+}
+
+func TestUsecaseInviteProjectMember(t *testing.T) {
+	ownerID, friendID, strangerID, editorID := uint64(1), uint64(2), uint64(3), uint64(4)
+	project := &models.Project{ID: 5, UserID: &ownerID}
+
+	mockProjectRepo := goalMocks.NewRepositoryI(t)
+	mockFriendRepo := friendMocks.NewRepositoryI(t)
+
+	mockProjectRepo.On("GetProject", mock.Anything, project.ID).Return(project, nil)
+	onMembers(mockProjectRepo, map[uint64]models.ProjectRole{ownerID: models.ProjectOwner, editorID: models.ProjectEditor})
+	mockProjectRepo.On("CreateProjectMember", mock.Anything, mock.MatchedBy(func(m *models.ProjectMember) bool {
+		return m.UserID == friendID && m.Role == models.ProjectViewer && !m.Accepted()
+	})).Return(nil).Once()
+	// friends are subscribed to each other
+	mockFriendRepo.On("CheckFriends", mock.Anything, mock.Anything).Return(func(_ context.Context, rel *models.FriendRelation) (bool, error) {
+		return *rel.SubscriberID != strangerID && *rel.UserID != strangerID, nil
+	})
+
+	useCase := usecase.New(mockProjectRepo, nil, nil, friendUsecase.New(mockFriendRepo, nil))
+
+	invite := func(invitedBy uint64, userID uint64, role models.ProjectRole) *models.ProjectMember {
+		return &models.ProjectMember{ProjectID: project.ID, UserID: userID, Role: role, InvitedBy: &invitedBy}
+	}
+
+	cases := map[string]struct {
+		member *models.ProjectMember
+		err    error
+	}{
+		"success":          {invite(ownerID, friendID, models.ProjectViewer), nil},
+		"not a friend":     {invite(ownerID, strangerID, models.ProjectViewer), models.ErrNotFriends},
+		"already a member": {invite(ownerID, editorID, models.ProjectViewer), models.ErrConflictMember},
+		"not the owner":    {invite(editorID, friendID, models.ProjectViewer), models.ErrPermissionDenied},
+		"second owner":     {invite(ownerID, friendID, models.ProjectOwner), models.ErrBadRequest},
+		"self":             {invite(ownerID, ownerID, models.ProjectEditor), models.ErrBadRequest},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := useCase.InviteProjectMember(context.Background(), test.member)
+			require.Equal(t, test.err, errors.Cause(err))
+		})
+	}
+}
+
+func TestUsecaseProjectInvitation(t *testing.T) {
+	projectID, ownerID, invitedID, memberID := uint64(5), uint64(1), uint64(2), uint64(3)
+	createdAt := time.Now().UTC()
+
+	mockProjectRepo := goalMocks.NewRepositoryI(t)
+	storage := cache.NewStorageMemory()
+
+	mockProjectRepo.On("GetProject", mock.Anything, projectID).Return(&models.Project{ID: projectID, UserID: &ownerID}, nil)
+	mockProjectRepo.On("GetProjectMember", mock.Anything, projectID, invitedID).Return(func(context.Context, uint64, uint64) *models.ProjectMember {
+		return &models.ProjectMember{ProjectID: projectID, UserID: invitedID, Role: models.ProjectEditor, CreatedAt: createdAt}
+	}, nil)
+	mockProjectRepo.On("GetProjectMember", mock.Anything, projectID, memberID).Return(&models.ProjectMember{
+		ProjectID: projectID, UserID: memberID, Role: models.ProjectViewer, AcceptedAt: &createdAt}, nil)
+	mockProjectRepo.On("GetProjectMember", mock.Anything, projectID, ownerID).Return(models.NewProjectOwner(projectID, ownerID, createdAt), nil)
+	mockProjectRepo.On("UpdateProjectMember", mock.Anything, mock.MatchedBy(func(m *models.ProjectMember) bool {
+		return m.UserID == invitedID && m.Accepted()
+	})).Return(nil).Once()
+	mockProjectRepo.On("DeleteProjectMember", mock.Anything, projectID, memberID).Return(nil).Once()
+
+	useCase := usecase.New(mockProjectRepo, nil, storage, nil)
+	ctx := context.Background()
+
+	require.NoError(t, storage.Set(ctx, "2", []*models.Project{}))
+	member, err := useCase.AcceptInvitation(ctx, projectID, invitedID)
+	require.NoError(t, err)
+	assert.True(t, member.Accepted())
+
+	// the shared project shows up in the listing of the member
+	_, err = storage.Get(ctx, "2")
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	// only a pending invitation can be answered
+	err = useCase.DeclineInvitation(ctx, projectID, memberID)
+	assert.Equal(t, models.ErrNotFound, errors.Cause(err))
+
+	// a member can't remove another one, but can leave
+	err = useCase.DeleteProjectMember(ctx, projectID, memberID, ownerID)
+	assert.Equal(t, models.ErrPermissionDenied, errors.Cause(err))
+
+	err = useCase.DeleteProjectMember(ctx, projectID, memberID, memberID)
+	require.NoError(t, err)
+
+	// the owner can't leave its project
+	err = useCase.DeleteProjectMember(ctx, projectID, ownerID, ownerID)
+	assert.Equal(t, models.ErrBadRequest, errors.Cause(err))
+}
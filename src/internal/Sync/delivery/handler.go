@@ -0,0 +1,114 @@
+package delivery
+
+import (
+	"net/http"
+	syncUsecase "timetracker/internal/Sync/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Delivery struct {
+	SyncUC syncUsecase.UsecaseI
+}
+
+// GetChanges godoc
+// @Summary      Get changes
+// @Description  Get my entries, projects, tags and goals changed since the cursor of the last sync, the deleted ones
+// @Description  are only listed in deleted. Shared projects come too, a project I was removed from is listed in deleted.
+// @Description  Without since every object is returned. Ask again with the returned cursor.
+// @Description  A cursor older than the trash retention may miss purged deletions, sync again without since then.
+// @Tags     sync
+// @Produce  application/json
+// @Param    since query string false "cursor of the last sync"
+// @Success  200 {object} pkg.Response{body=dto.RespSyncChanges} "success get changes"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request: invalid cursor"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 410 {object} apierror.Error "resync_required: the cursor is older than the trash retention"
+// @Router   /api/v1/me/sync [get]
+func (delivery *Delivery) GetChanges(c echo.Context) error {
+	since, err := dto.ParseSyncCursor(c.QueryParam("since"))
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	changes, err := delivery.SyncUC.GetChanges(c.Request().Context(), userId, since)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *dto.GetResponseFromModelSyncChanges(changes)})
+}
+
+// ApplyMutations godoc
+// @Summary      Apply offline changes
+// @Description  Apply a batch of changes made offline, in order. Every change gets its own result: applied, conflict
+// @Description  with the current object when its version is outdated, or rejected with the error.
+// @Description  Objects created offline need a client_id, a retried create returns the object of the first one.
+// @Description  project_client_id and tag_client_ids reference objects created offline. Data is the body of
+// @Description  the create or patch request of the type, delete has no data.
+// @Tags     sync
+// @Accept	 application/json
+// @Produce  application/json
+// @Param    mutations body dto.ReqSyncMutations true "changes in the order they were made"
+// @Success  200 {object} pkg.Response{body=dto.RespSyncResults} "the result of every change"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Router   /api/v1/me/sync [post]
+func (delivery *Delivery) ApplyMutations(c echo.Context) error {
+	var req dto.ReqSyncMutations
+	err := c.Bind(&req)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	if ok, err := pkg.IsRequestValid(&req); !ok {
+		c.Logger().Error(err)
+		return apierror.Validation(err)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	results := delivery.SyncUC.ApplyMutations(c.Request().Context(), userId, req.ToModelSyncMutations())
+	for _, result := range results {
+		if result.Err != nil && apierror.Status(result.Err) == http.StatusInternalServerError {
+			c.Logger().Error(result.Err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *dto.GetResponseFromModelSyncResults(results)})
+}
+
+func NewDelivery(e *echo.Echo, su syncUsecase.UsecaseI) {
+	handler := &Delivery{
+		SyncUC: su,
+	}
+
+	v1 := e.Group(middleware.APIv1)
+	v1.GET("/me/sync", handler.GetChanges)
+	v1.POST("/me/sync", handler.ApplyMutations)
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"timetracker/internal/Sync/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+type syncRepository struct {
+	db *memoryDB.DB
+}
+
+func (sr syncRepository) CreateClientID(ctx context.Context, c *models.SyncClientID) error {
+	sr.db.Lock()
+	defer sr.db.Unlock()
+
+	key := memoryDB.SyncClientKey{UserID: c.UserID, ClientID: c.ClientID}
+	_, userExists := sr.db.Users[c.UserID]
+	_, duplicate := sr.db.ClientIDs[key]
+	if !userExists || duplicate {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table sync_client_id)")
+	}
+
+	copied := *c
+	sr.db.ClientIDs[key] = &copied
+	return nil
+}
+
+func (sr syncRepository) GetClientID(ctx context.Context, userID uint64, clientID string) (*models.SyncClientID, error) {
+	sr.db.RLock()
+	defer sr.db.RUnlock()
+
+	c, ok := sr.db.ClientIDs[memoryDB.SyncClientKey{UserID: userID, ClientID: clientID}]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	copied := *c
+	return &copied, nil
+}
+
+func NewSyncRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &syncRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"timetracker/internal/Sync/repository"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type SyncClientID struct {
+	UserID     uint64 `gorm:"column:user_id"`
+	ClientID   string `gorm:"column:client_id"`
+	ObjectType string `gorm:"column:object_type"`
+	ObjectID   uint64 `gorm:"column:object_id"`
+}
+
+func (SyncClientID) TableName() string {
+	return "sync_client_id"
+}
+
+func toPostgresSyncClientID(c *models.SyncClientID) *SyncClientID {
+	return &SyncClientID{
+		UserID:     c.UserID,
+		ClientID:   c.ClientID,
+		ObjectType: string(c.Type),
+		ObjectID:   c.ObjectID,
+	}
+}
+
+func toModelSyncClientID(c *SyncClientID) *models.SyncClientID {
+	return &models.SyncClientID{
+		UserID:   c.UserID,
+		ClientID: c.ClientID,
+		Type:     models.SyncObject(c.ObjectType),
+		ObjectID: c.ObjectID,
+	}
+}
+
+type syncRepository struct {
+	db *gorm.DB
+}
+
+func (sr syncRepository) CreateClientID(ctx context.Context, c *models.SyncClientID) error {
+	tx := sr.db.WithContext(ctx).Create(toPostgresSyncClientID(c))
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table sync_client_id)")
+	}
+
+	return nil
+}
+
+func (sr syncRepository) GetClientID(ctx context.Context, userID uint64, clientID string) (*models.SyncClientID, error) {
+	var c SyncClientID
+
+	tx := sr.db.WithContext(ctx).Where("user_id = ? AND client_id = ?", userID, clientID).Take(&c)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table sync_client_id)")
+	}
+
+	return toModelSyncClientID(&c), nil
+}
+
+func NewSyncRepository(db *gorm.DB) repository.RepositoryI {
+	return &syncRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"timetracker/models"
+)
+
+type RepositoryI interface {
+	CreateClientID(ctx context.Context, c *models.SyncClientID) error
+	GetClientID(ctx context.Context, userID uint64, clientID string) (*models.SyncClientID, error)
+}
@@ -0,0 +1,401 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	entryUsecase "timetracker/internal/Entry/usecase"
+	goalUsecase "timetracker/internal/Goal/usecase"
+	projectUsecase "timetracker/internal/Project/usecase"
+	syncRep "timetracker/internal/Sync/repository"
+	tagUsecase "timetracker/internal/Tag/usecase"
+	"timetracker/internal/policy"
+	"timetracker/internal/tracing"
+	"timetracker/models"
+	"timetracker/pkg"
+
+	"github.com/pkg/errors"
+)
+
+type UsecaseI interface {
+	GetChanges(ctx context.Context, userID uint64, since time.Time) (*models.SyncChanges, error)
+	ApplyMutations(ctx context.Context, userID uint64, mutations []*models.SyncMutation) []*models.SyncResult
+}
+
+// syncLag keeps the cursor behind the writes that may not be committed yet,
+// their updated_at is taken before the commit
+const syncLag = time.Second
+
+type usecase struct {
+	syncRepository syncRep.RepositoryI
+	entryUsecase   entryUsecase.UsecaseI
+	projectUsecase projectUsecase.UsecaseI
+	tagUsecase     tagUsecase.UsecaseI
+	goalUsecase    goalUsecase.UsecaseI
+	retention      time.Duration
+}
+
+// New takes the trash retention: the tombstones older than it are purged, so a cursor
+// older than it may miss deletions. Zero keeps the tombstones forever.
+func New(sRep syncRep.RepositoryI, eUC entryUsecase.UsecaseI, pUC projectUsecase.UsecaseI, tUC tagUsecase.UsecaseI, gUC goalUsecase.UsecaseI, retention time.Duration) UsecaseI {
+	return &usecase{
+		syncRepository: sRep,
+		entryUsecase:   eUC,
+		projectUsecase: pUC,
+		tagUsecase:     tUC,
+		goalUsecase:    gUC,
+		retention:      retention,
+	}
+}
+
+// GetChanges returns the objects of the user changed after since. A zero since is the first
+// sync of the client, it gets every object and no tombstones. A since older than the trash
+// retention gets ErrResyncRequired, the client starts over with a first sync.
+func (u *usecase) GetChanges(ctx context.Context, userID uint64, since time.Time) (*models.SyncChanges, error) {
+	ctx, span := tracing.Start(ctx, "sync.Usecase.GetChanges")
+	defer span.End()
+
+	since = since.UTC()
+	now := time.Now().UTC()
+	if u.retention > 0 && !since.IsZero() && since.Before(now.Add(-u.retention)) {
+		return nil, models.ErrResyncRequired
+	}
+
+	until := now.Add(-syncLag).Truncate(time.Microsecond)
+	changes := &models.SyncChanges{
+		Cursor:   until,
+		Entries:  make([]*models.Entry, 0, 10),
+		Projects: make([]*models.Project, 0, 10),
+		Tags:     make([]*models.Tag, 0, 10),
+		Goals:    make([]*models.Goal, 0, 10),
+		Deleted:  make([]models.SyncTombstone, 0, 10),
+	}
+
+	if !since.Before(until) {
+		changes.Cursor = since
+		return changes, nil
+	}
+
+	deleted := func(t models.SyncObject, id, version uint64, deletedAt *time.Time) bool {
+		if deletedAt == nil {
+			return false
+		}
+
+		if !since.IsZero() {
+			changes.Deleted = append(changes.Deleted, models.SyncTombstone{Type: t, ID: id, Version: version, DeletedAt: *deletedAt})
+		}
+		return true
+	}
+
+	entries, err := u.entryUsecase.GetUserEntryChanges(ctx, userID, since, until)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func sync.Usecase.GetChanges")
+	}
+
+	for _, entry := range entries {
+		if !deleted(models.SyncEntry, entry.ID, entry.Version, entry.DeletedAt) {
+			changes.Entries = append(changes.Entries, entry)
+		}
+	}
+
+	projects, err := u.projectUsecase.GetUserProjectChanges(ctx, userID, since, until)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func sync.Usecase.GetChanges")
+	}
+
+	for _, project := range projects {
+		if !deleted(models.SyncProject, project.ID, project.Version, project.DeletedAt) {
+			changes.Projects = append(changes.Projects, project)
+		}
+	}
+
+	tags, err := u.tagUsecase.GetUserTagChanges(ctx, userID, since, until)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func sync.Usecase.GetChanges")
+	}
+
+	for _, tag := range tags {
+		if !deleted(models.SyncTag, tag.ID, tag.Version, tag.DeletedAt) {
+			changes.Tags = append(changes.Tags, tag)
+		}
+	}
+
+	goals, err := u.goalUsecase.GetUserGoalChanges(ctx, userID, since, until)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func sync.Usecase.GetChanges")
+	}
+
+	for _, goal := range goals {
+		if !deleted(models.SyncGoal, goal.ID, goal.Version, goal.DeletedAt) {
+			changes.Goals = append(changes.Goals, goal)
+		}
+	}
+
+	return changes, nil
+}
+
+// ApplyMutations applies the mutations in order, each one on its own: a rejected or
+// conflicting mutation doesn't stop the next ones
+func (u *usecase) ApplyMutations(ctx context.Context, userID uint64, mutations []*models.SyncMutation) []*models.SyncResult {
+	ctx, span := tracing.Start(ctx, "sync.Usecase.ApplyMutations")
+	defer span.End()
+
+	results := make([]*models.SyncResult, 0, len(mutations))
+	for _, mutation := range mutations {
+		results = append(results, u.applyMutation(ctx, userID, mutation))
+	}
+
+	return results
+}
+
+func (u *usecase) applyMutation(ctx context.Context, userID uint64, m *models.SyncMutation) *models.SyncResult {
+	result := &models.SyncResult{ClientID: m.ClientID, Type: m.Type, Action: m.Action}
+
+	err := m.Err
+	if err == nil {
+		err = u.resolveClientIDs(ctx, userID, m)
+	}
+
+	if err == nil {
+		switch m.Action {
+		case models.SyncCreate:
+			err = u.create(ctx, userID, m, result)
+		case models.SyncUpdate:
+			err = u.update(ctx, userID, m, result)
+		case models.SyncDelete:
+			err = u.delete(ctx, userID, m)
+		default:
+			err = models.ErrBadRequest
+		}
+	}
+
+	result.ID = m.ID
+	switch {
+	case err == nil:
+		result.Status = models.SyncApplied
+	case errors.Is(err, models.ErrVersionMismatch):
+		// the client merges its change into the current object and tries again
+		result.Status, result.Err = models.SyncConflict, err
+		_, _, _ = u.getObject(ctx, m.Type, m.ID, result)
+	default:
+		result.Status, result.Err = models.SyncRejected, err
+	}
+
+	return result
+}
+
+// resolveClientIDs sets the ids of the objects the mutation only knows by their client ids
+func (u *usecase) resolveClientIDs(ctx context.Context, userID uint64, m *models.SyncMutation) error {
+	if m.ID == 0 && m.Action != models.SyncCreate {
+		id, err := u.objectID(ctx, userID, m.ClientID, m.Type)
+		if err != nil {
+			return err
+		}
+		m.ID = id
+	}
+
+	if m.ProjectClientID != "" {
+		projectID, err := u.objectID(ctx, userID, m.ProjectClientID, models.SyncProject)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case m.Entry != nil:
+			m.Entry.ProjectID = &projectID
+		case m.EntryPatch != nil:
+			m.EntryPatch.ProjectID = pkg.Some(&projectID)
+		case m.Goal != nil:
+			m.Goal.ProjectID = &projectID
+		case m.GoalPatch != nil:
+			m.GoalPatch.ProjectID = &projectID
+		}
+	}
+
+	if len(m.TagClientIDs) != 0 {
+		tags := make([]models.Tag, 0, len(m.TagClientIDs))
+		for _, clientID := range m.TagClientIDs {
+			tagID, err := u.objectID(ctx, userID, clientID, models.SyncTag)
+			if err != nil {
+				return err
+			}
+			tags = append(tags, models.Tag{ID: tagID})
+		}
+
+		switch {
+		case m.Entry != nil:
+			m.Entry.TagList = append(m.Entry.TagList, tags...)
+		case m.EntryPatch != nil:
+			if m.EntryPatch.TagList != nil {
+				tags = append(*m.EntryPatch.TagList, tags...)
+			}
+			m.EntryPatch.TagList = &tags
+		}
+	}
+
+	return nil
+}
+
+func (u *usecase) objectID(ctx context.Context, userID uint64, clientID string, t models.SyncObject) (uint64, error) {
+	if clientID == "" {
+		return 0, models.ErrBadRequest
+	}
+
+	created, err := u.syncRepository.GetClientID(ctx, userID, clientID)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in func sync.Usecase.objectID")
+	}
+
+	if created.Type != t {
+		return 0, models.ErrNotFound
+	}
+
+	return created.ObjectID, nil
+}
+
+// create makes the object once, a retried batch gets the object made by the first one
+func (u *usecase) create(ctx context.Context, userID uint64, m *models.SyncMutation, result *models.SyncResult) error {
+	if m.ClientID == "" {
+		return models.ErrClientIDRequired
+	}
+
+	created, err := u.syncRepository.GetClientID(ctx, userID, m.ClientID)
+	if err == nil {
+		if created.Type != m.Type {
+			return models.ErrConflictClientID
+		}
+
+		m.ID = created.ObjectID
+		_, _, err = u.getObject(ctx, m.Type, m.ID, result)
+		if errors.Is(err, models.ErrNotFound) {
+			return nil
+		}
+		return err
+	} else if !errors.Is(err, models.ErrNotFound) {
+		return errors.Wrap(err, "Error in func sync.Usecase.create")
+	}
+
+	switch {
+	case m.Type == models.SyncEntry && m.Entry != nil:
+		m.Entry.UserID = &userID
+		err = u.entryUsecase.CreateEntry(ctx, m.Entry)
+		m.ID = m.Entry.ID
+	case m.Type == models.SyncProject && m.Project != nil:
+		m.Project.UserID = &userID
+		err = u.projectUsecase.CreateProject(ctx, m.Project)
+		m.ID = m.Project.ID
+	case m.Type == models.SyncTag && m.Tag != nil:
+		m.Tag.UserID = userID
+		err = u.tagUsecase.CreateTag(ctx, m.Tag)
+		m.ID = m.Tag.ID
+	case m.Type == models.SyncGoal && m.Goal != nil:
+		m.Goal.UserID = &userID
+		err = u.goalUsecase.CreateGoal(ctx, m.Goal)
+		m.ID = m.Goal.ID
+	default:
+		return models.ErrBadRequest
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = u.syncRepository.CreateClientID(ctx, &models.SyncClientID{UserID: userID, ClientID: m.ClientID, Type: m.Type, ObjectID: m.ID})
+	if err != nil {
+		return errors.Wrap(err, "Error in func sync.Usecase.create")
+	}
+
+	// the result has the object as it is stored, e.g. the entry with its tags
+	_, _, err = u.getObject(ctx, m.Type, m.ID, result)
+	return err
+}
+
+func (u *usecase) update(ctx context.Context, userID uint64, m *models.SyncMutation, result *models.SyncResult) error {
+	var err error
+
+	switch {
+	case m.Type == models.SyncEntry && m.EntryPatch != nil:
+		m.EntryPatch.ID, m.EntryPatch.UserID, m.EntryPatch.Version = m.ID, userID, m.Version
+		result.Entry, err = u.entryUsecase.UpdateEntry(ctx, m.EntryPatch)
+	case m.Type == models.SyncProject && m.ProjectPatch != nil:
+		m.ProjectPatch.ID, m.ProjectPatch.UserID, m.ProjectPatch.Version = m.ID, userID, m.Version
+		result.Project, err = u.projectUsecase.UpdateProject(ctx, m.ProjectPatch)
+	case m.Type == models.SyncTag && m.TagPatch != nil:
+		m.TagPatch.ID, m.TagPatch.UserID, m.TagPatch.Version = m.ID, userID, m.Version
+		result.Tag, err = u.tagUsecase.UpdateTag(ctx, m.TagPatch)
+	case m.Type == models.SyncGoal && m.GoalPatch != nil:
+		m.GoalPatch.ID, m.GoalPatch.UserID, m.GoalPatch.Version = m.ID, userID, m.Version
+		result.Goal, err = u.goalUsecase.UpdateGoal(ctx, m.GoalPatch)
+	default:
+		return models.ErrBadRequest
+	}
+
+	return err
+}
+
+// delete only deletes the version the client has seen, like update. An object that is
+// already deleted, e.g. by the first try of a retried batch, counts as deleted.
+func (u *usecase) delete(ctx context.Context, userID uint64, m *models.SyncMutation) error {
+	owner, version, err := u.getObject(ctx, m.Type, m.ID, &models.SyncResult{})
+	if errors.Is(err, models.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err := policy.AuthorizeOwner(userID, &owner); err != nil {
+		return err
+	}
+
+	if m.Version != 0 && m.Version != version {
+		return models.ErrVersionMismatch
+	}
+
+	switch m.Type {
+	case models.SyncEntry:
+		return u.entryUsecase.DeleteEntry(ctx, m.ID, userID)
+	case models.SyncProject:
+		return u.projectUsecase.DeleteProject(ctx, m.ID, userID)
+	case models.SyncTag:
+		return u.tagUsecase.DeleteTag(ctx, m.ID, userID)
+	case models.SyncGoal:
+		return u.goalUsecase.DeleteGoal(ctx, m.ID, userID)
+	}
+
+	return models.ErrBadRequest
+}
+
+// getObject sets the current object of the result and returns its owner and version
+func (u *usecase) getObject(ctx context.Context, t models.SyncObject, id uint64, result *models.SyncResult) (uint64, uint64, error) {
+	switch t {
+	case models.SyncEntry:
+		entry, err := u.entryUsecase.GetEntry(ctx, id)
+		if err != nil {
+			return 0, 0, err
+		}
+		result.Entry = entry
+		return *entry.UserID, entry.Version, nil
+	case models.SyncProject:
+		project, err := u.projectUsecase.GetProject(ctx, id)
+		if err != nil {
+			return 0, 0, err
+		}
+		result.Project = project
+		return *project.UserID, project.Version, nil
+	case models.SyncTag:
+		tag, err := u.tagUsecase.GetTag(ctx, id)
+		if err != nil {
+			return 0, 0, err
+		}
+		result.Tag = tag
+		return tag.UserID, tag.Version, nil
+	case models.SyncGoal:
+		goal, err := u.goalUsecase.GetGoal(ctx, id)
+		if err != nil {
+			return 0, 0, err
+		}
+		result.Goal = goal
+		return *goal.UserID, goal.Version, nil
+	}
+
+	return 0, 0, models.ErrBadRequest
+}
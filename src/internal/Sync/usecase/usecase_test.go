@@ -0,0 +1,184 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	entryMocks "timetracker/internal/Entry/repository/mocks"
+	entryUsecase "timetracker/internal/Entry/usecase"
+	goalMocks "timetracker/internal/Goal/repository/mocks"
+	goalUsecase "timetracker/internal/Goal/usecase"
+	projectMocks "timetracker/internal/Project/repository/mocks"
+	projectUsecase "timetracker/internal/Project/usecase"
+	syncMocks "timetracker/internal/Sync/repository/mocks"
+	"timetracker/internal/Sync/usecase"
+	tagMocks "timetracker/internal/Tag/repository/mocks"
+	tagUsecase "timetracker/internal/Tag/usecase"
+	taskMocks "timetracker/internal/Task/repository/mocks"
+	userMocks "timetracker/internal/User/repository/mocks"
+	"timetracker/models"
+)
+
+type syncMocksSet struct {
+	sync    *syncMocks.RepositoryI
+	entry   *entryMocks.RepositoryI
+	project *projectMocks.RepositoryI
+	tag     *tagMocks.RepositoryI
+	goal    *goalMocks.RepositoryI
+}
+
+const syncRetention = 30 * 24 * time.Hour
+
+func newSyncUsecase(t *testing.T) (usecase.UsecaseI, syncMocksSet) {
+	m := syncMocksSet{
+		sync:    syncMocks.NewRepositoryI(t),
+		entry:   entryMocks.NewRepositoryI(t),
+		project: projectMocks.NewRepositoryI(t),
+		tag:     tagMocks.NewRepositoryI(t),
+		goal:    goalMocks.NewRepositoryI(t),
+	}
+
+	useCase := usecase.New(m.sync,
+		entryUsecase.New(m.entry, m.tag, userMocks.NewRepositoryI(t), m.project, taskMocks.NewRepositoryI(t), nil),
+		projectUsecase.New(m.project, nil, nil, nil),
+		tagUsecase.New(m.tag),
+		goalUsecase.New(m.goal, m.project),
+		syncRetention)
+
+	return useCase, m
+}
+
+func TestUsecaseGetChanges(t *testing.T) {
+	userID := uint64(1)
+	deletedAt := time.Now().UTC().Add(-time.Hour)
+	tag := &models.Tag{ID: 2, UserID: userID, Name: "tag", Version: 1}
+	goal := &models.Goal{ID: 3, UserID: &userID, Version: 2, DeletedAt: &deletedAt}
+
+	cases := map[string]struct {
+		since         time.Time
+		expectedTombs []models.SyncTombstone
+	}{
+		"first sync": {
+			since:         time.Time{},
+			expectedTombs: []models.SyncTombstone{},
+		},
+		"next sync": {
+			since:         deletedAt.Add(-time.Hour),
+			expectedTombs: []models.SyncTombstone{{Type: models.SyncGoal, ID: 3, Version: 2, DeletedAt: deletedAt}},
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			useCase, m := newSyncUsecase(t)
+			m.entry.On("GetUserEntryChanges", mock.Anything, userID, test.since, mock.Anything).Return([]*models.Entry{}, nil)
+			m.project.On("GetUserProjectChanges", mock.Anything, userID, test.since, mock.Anything).Return([]*models.Project{}, nil)
+			m.tag.On("GetUserTagChanges", mock.Anything, userID, test.since, mock.Anything).Return([]*models.Tag{tag}, nil)
+			m.goal.On("GetUserGoalChanges", mock.Anything, userID, test.since, mock.Anything).Return([]*models.Goal{goal}, nil)
+
+			changes, err := useCase.GetChanges(context.Background(), userID, test.since)
+			require.NoError(t, err)
+
+			assert.True(t, changes.Cursor.After(test.since))
+			assert.Equal(t, []*models.Tag{tag}, changes.Tags)
+			assert.Empty(t, changes.Goals)
+			assert.Equal(t, test.expectedTombs, changes.Deleted)
+		})
+	}
+}
+
+func TestUsecaseGetChangesUpToDate(t *testing.T) {
+	useCase, _ := newSyncUsecase(t)
+	since := time.Now().UTC()
+
+	changes, err := useCase.GetChanges(context.Background(), 1, since)
+	require.NoError(t, err)
+
+	assert.Equal(t, since, changes.Cursor)
+	assert.Empty(t, changes.Entries)
+	assert.Empty(t, changes.Deleted)
+}
+
+func TestUsecaseGetChangesExpiredCursor(t *testing.T) {
+	useCase, _ := newSyncUsecase(t)
+	since := time.Now().UTC().Add(-syncRetention - time.Hour)
+
+	_, err := useCase.GetChanges(context.Background(), 1, since)
+	require.Equal(t, models.ErrResyncRequired, err)
+}
+
+func TestUsecaseApplyMutationsCreate(t *testing.T) {
+	userID := uint64(1)
+	useCase, m := newSyncUsecase(t)
+
+	stored := &models.Tag{ID: 7, UserID: userID, Name: "offline", Version: 1}
+	m.sync.On("GetClientID", mock.Anything, userID, "tag-1").Return(nil, models.ErrNotFound).Once()
+	m.tag.On("CreateTag", mock.Anything, mock.AnythingOfType("*models.Tag")).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Tag).ID = stored.ID
+	}).Return(nil).Once()
+	m.sync.On("CreateClientID", mock.Anything, &models.SyncClientID{UserID: userID, ClientID: "tag-1", Type: models.SyncTag, ObjectID: stored.ID}).Return(nil).Once()
+	m.tag.On("GetTag", mock.Anything, stored.ID).Return(stored, nil)
+	// the retried batch finds the tag made by the first one
+	m.sync.On("GetClientID", mock.Anything, userID, "tag-1").Return(&models.SyncClientID{UserID: userID, ClientID: "tag-1", Type: models.SyncTag, ObjectID: stored.ID}, nil)
+
+	mutation := func() *models.SyncMutation {
+		return &models.SyncMutation{ClientID: "tag-1", Type: models.SyncTag, Action: models.SyncCreate, Tag: &models.Tag{Name: "offline"}}
+	}
+
+	for _, name := range []string{"create", "replay"} {
+		t.Run(name, func(t *testing.T) {
+			results := useCase.ApplyMutations(context.Background(), userID, []*models.SyncMutation{mutation()})
+			require.Len(t, results, 1)
+
+			assert.Equal(t, models.SyncApplied, results[0].Status)
+			assert.Equal(t, stored.ID, results[0].ID)
+			assert.Equal(t, stored, results[0].Tag)
+		})
+	}
+}
+
+func TestUsecaseApplyMutations(t *testing.T) {
+	userID := uint64(1)
+	current := &models.Goal{ID: 3, UserID: &userID, Version: 4}
+
+	useCase, m := newSyncUsecase(t)
+	m.goal.On("GetGoal", mock.Anything, current.ID).Return(current, nil)
+	m.goal.On("GetGoal", mock.Anything, uint64(5)).Return(nil, models.ErrNotFound)
+	m.sync.On("GetClientID", mock.Anything, userID, "tag-1").Return(&models.SyncClientID{UserID: userID, ClientID: "tag-1", Type: models.SyncTag, ObjectID: 7}, nil)
+
+	mutations := []*models.SyncMutation{
+		{Type: models.SyncTag, Action: models.SyncCreate, Tag: &models.Tag{Name: "tag"}},
+		{Type: models.SyncGoal, Action: models.SyncUpdate, ID: current.ID, Version: 2, GoalPatch: &models.GoalPatch{}},
+		{Type: models.SyncGoal, Action: models.SyncDelete, ID: current.ID, Version: 2},
+		{Type: models.SyncGoal, Action: models.SyncDelete, ID: 5},
+		{Type: models.SyncEntry, Action: models.SyncCreate, ClientID: "tag-1", Entry: &models.Entry{}},
+		{Type: models.SyncTag, Action: models.SyncUpdate, ID: 7, Err: models.ErrBadRequest},
+	}
+
+	expected := []struct {
+		status models.SyncStatus
+		err    error
+	}{
+		{models.SyncRejected, models.ErrClientIDRequired},
+		{models.SyncConflict, models.ErrVersionMismatch},
+		{models.SyncConflict, models.ErrVersionMismatch},
+		{models.SyncApplied, nil},
+		{models.SyncRejected, models.ErrConflictClientID},
+		{models.SyncRejected, models.ErrBadRequest},
+	}
+
+	results := useCase.ApplyMutations(context.Background(), userID, mutations)
+	require.Len(t, results, len(expected))
+
+	for idx, result := range results {
+		assert.Equal(t, expected[idx].status, result.Status, idx)
+		assert.ErrorIs(t, result.Err, expected[idx].err, idx)
+	}
+
+	// a conflict returns the current object to merge the change into
+	assert.Equal(t, current, results[1].Goal)
+	assert.Equal(t, current, results[2].Goal)
+}
@@ -4,34 +4,19 @@ import (
 	"net/http"
 	"strconv"
 	tagUsecase "timetracker/internal/Tag/usecase"
+	"timetracker/internal/apierror"
 	"timetracker/internal/middleware"
 	"timetracker/models"
 	"timetracker/models/dto"
 	"timetracker/pkg"
 
 	"github.com/labstack/echo/v4"
-	"github.com/pkg/errors"
 )
 
 type Delivery struct {
 	TagUC tagUsecase.UsecaseI
 }
 
-func (del *Delivery) ownerOrAdminValidate(c echo.Context, tag *models.Tag) error {
-	user, ok := c.Get("user").(*models.User)
-
-	if !ok {
-		c.Logger().Error("can't get user from context")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
-	}
-
-	if user.Role == models.Admin.String() || tag.UserID == user.ID {
-		return nil
-	}
-
-	return models.ErrPermissionDenied
-}
-
 // CreateTag godoc
 // @Summary      Create tag
 // @Description  Create tag
@@ -40,14 +25,13 @@ func (del *Delivery) ownerOrAdminValidate(c echo.Context, tag *models.Tag) error
 // @Produce  application/json
 // @Param    tag body dto.ReqCreateUpdateTag true "tag info"
 // @Success  200 {object} pkg.Response{body=dto.RespTag} "success update tag"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 422 {object} echo.HTTPError "unprocessable entity"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 400 {object} echo.HTTPError "bad req"
-// @Failure 403 {object} echo.HTTPError "invalid csrf or permission denied"
-// @Router   /tag/create [post]
+// @Header   200 {string} ETag "version of the tag"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Router   /api/v1/tags [post]
 func (delivery *Delivery) CreateTag(c echo.Context) error {
 
 	var reqTag dto.ReqCreateUpdateTag
@@ -55,29 +39,30 @@ func (delivery *Delivery) CreateTag(c echo.Context) error {
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		return apierror.Bind(err)
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqTag); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.Validation(err)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
 	tag := reqTag.ToModelTag()
 	tag.UserID = userId
-	err = delivery.TagUC.CreateTag(tag)
+	err = delivery.TagUC.CreateTag(c.Request().Context(), tag)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelTag(tag))
 	respTag := dto.GetResponseFromModelTag(tag)
 
 	return c.JSON(http.StatusOK, pkg.Response{Body: *respTag})
@@ -90,30 +75,37 @@ func (delivery *Delivery) CreateTag(c echo.Context) error {
 // @Accept	 application/json
 // @Produce  application/json
 // @Param id  path int  true  "Tag ID"
+// @Param    If-None-Match header string false "ETag of the cached tag"
 // @Success  200 {object} pkg.Response{body=dto.RespTag} "success get tag"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /tag/{id} [get]
+// @Header   200 {string} ETag "version of the tag"
+// @Success  304 "the cached tag is up to date"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/tags/{id} [get]
 func (delivery *Delivery) GetTag(c echo.Context) error {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
 	}
-	tag, err := delivery.TagUC.GetTag(id)
+	tag, err := delivery.TagUC.GetTag(c.Request().Context(), id)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
-	err = delivery.ownerOrAdminValidate(c, tag)
+	err = middleware.AuthorizeResource(c, tag.UserID, models.PermTagReadAny)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
+	}
+
+	if middleware.NotModified(c, dto.GetETagFromModelTag(tag)) {
+		return c.NoContent(http.StatusNotModified)
 	}
 
 	respTag := dto.GetResponseFromModelTag(tag)
@@ -122,49 +114,71 @@ func (delivery *Delivery) GetTag(c echo.Context) error {
 
 // UpdateTag godoc
 // @Summary      Update an tag
-// @Description  Update an tag. Acl: owner
+// @Description  Update an tag. Only the fields present in the body are changed. Acl: owner
 // @Tags     	 tag
 // @Accept	 application/json
 // @Produce  application/json
-// @Param    tag body dto.ReqCreateUpdateTag true "tag info"
+// @Param    id path int true "Tag ID"
+// @Param    If-Match header string true "ETag of the tag the changes are based on, required by the legacy POST /tag/edit too"
+// @Param    tag body dto.ReqPatchTag true "tag info"
 // @Success  200 {object} pkg.Response{body=dto.RespTag} "success update tag"
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 422 {object} echo.HTTPError "unprocessable entity"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 403 {object} echo.HTTPError "invalid csrf or permission denied"
-// @Router   /tag/edit [post]
+// @Header   200 {string} ETag "version of the updated tag"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 412 {object} apierror.Error "version_mismatch: the tag was changed since the If-Match version"
+// @Failure 428 {object} apierror.Error "if_match_required"
+// @Router   /api/v1/tags/{id} [patch]
 func (delivery *Delivery) UpdateTag(c echo.Context) error {
 
-	var reqTag dto.ReqCreateUpdateTag
+	var reqTag dto.ReqPatchTag
 	err := c.Bind(&reqTag)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return c.JSON(http.StatusUnprocessableEntity, err.Error())
+		return apierror.Bind(err)
+	}
+
+	// the ID is in the path of PATCH /api/v1/tags/:id and in the body of the legacy route
+	if param := c.Param("id"); param != "" {
+		id, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			c.Logger().Error(err)
+			return apierror.From(models.ErrBadRequest)
+		}
+		reqTag.ID = id
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqTag); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.Validation(err)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	tag := reqTag.ToModelTag()
-	tag.UserID = userId
-	err = delivery.TagUC.UpdateTag(tag)
+	version, err := middleware.IfMatch(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	patch := reqTag.ToModelTagPatch()
+	patch.UserID = userId
+	patch.Version = version
+	tag, err := delivery.TagUC.UpdateTag(c.Request().Context(), patch)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelTag(tag))
 	respTag := dto.GetResponseFromModelTag(tag)
 
 	return c.JSON(http.StatusOK, pkg.Response{Body: *respTag})
@@ -172,41 +186,81 @@ func (delivery *Delivery) UpdateTag(c echo.Context) error {
 
 // DeleteTag godoc
 // @Summary      Delete an tag
-// @Description  Delete an tag. Acl: owner
+// @Description  Delete an tag, it stays in the trash until it is restored or purged. Acl: owner
 // @Tags     	 tag
 // @Accept	 application/json
 // @Param id path int  true  "Tag ID"
 // @Success  204
-// @Failure 405 {object} echo.HTTPError "invalid http method"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 404 {object} echo.HTTPError "can't find tag with such id"
-// @Failure 403 {object} echo.HTTPError "invalid csrf"
-// @Router   /tag/{id} [delete]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found: can't find tag with such id"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Router   /api/v1/tags/{id} [delete]
 func (delivery *Delivery) DeleteTag(c echo.Context) error {
 
 	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
+		return apierror.From(models.ErrNotFound)
 	}
 
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	err = delivery.TagUC.DeleteTag(id, userId)
+	err = delivery.TagUC.DeleteTag(c.Request().Context(), id, userId)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// RestoreTag godoc
+// @Summary      Restore a tag
+// @Description  Restore a tag from the trash, the entries get it back. Acl: owner
+// @Tags     	 tag
+// @Produce  application/json
+// @Param id path int  true  "Tag ID"
+// @Success  200 {object} pkg.Response{body=dto.RespTag} "the restored tag"
+// @Header   200 {string} ETag "version of the restored tag"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found: no tag with such id in the trash"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Router   /api/v1/tags/{id}/restore [post]
+func (delivery *Delivery) RestoreTag(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	tag, err := delivery.TagUC.RestoreTag(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelTag(tag))
+	respTag := dto.GetResponseFromModelTag(tag)
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respTag})
+}
+
 // GetMyTags godoc
 // @Summary      Get my tags
 // @Description  Get my tags.
@@ -214,23 +268,23 @@ func (delivery *Delivery) DeleteTag(c echo.Context) error {
 // @Produce  application/json
 // @Param        day    query     string  false  "day for events"
 // @Success  200 {object} pkg.Response{body=[]dto.RespTag} "success get tags"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /me/tags [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/me/tags [get]
 func (delivery *Delivery) GetMyTags(c echo.Context) error {
 	userId, ok := c.Get("user_id").(uint64)
 	if !ok {
 		c.Logger().Error("can't parse context user_id")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
-	tags, err := delivery.TagUC.GetUserTags(userId)
+	tags, err := delivery.TagUC.GetUserTags(c.Request().Context(), userId)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respTags := dto.GetResponseFromModelTags(tags)
@@ -245,24 +299,24 @@ func (delivery *Delivery) GetMyTags(c echo.Context) error {
 // @Produce  application/json
 // @Param        day    query     string  false  "day for events"
 // @Success  200 {object} pkg.Response{body=[]dto.RespTag} "success get tags"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /user/{user_id}/tags [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/users/{user_id}/tags [get]
 func (delivery *Delivery) GetUserTags(c echo.Context) error {
 	userId, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
 	}
 
-	tags, err := delivery.TagUC.GetUserTags(userId)
+	tags, err := delivery.TagUC.GetUserTags(c.Request().Context(), userId)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respTags := dto.GetResponseFromModelTags(tags)
@@ -270,29 +324,25 @@ func (delivery *Delivery) GetUserTags(c echo.Context) error {
 	return c.JSON(http.StatusOK, pkg.Response{Body: respTags})
 }
 
-func handleError(err error) *echo.HTTPError {
-	causeErr := errors.Cause(err)
-	switch {
-	case errors.Is(causeErr, models.ErrNotFound):
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
-	case errors.Is(causeErr, models.ErrBadRequest):
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-	case errors.Is(causeErr, models.ErrPermissionDenied):
-		return echo.NewHTTPError(http.StatusForbidden, models.ErrPermissionDenied.Error())
-	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, causeErr.Error())
-	}
-}
-
 func NewDelivery(e *echo.Echo, eu tagUsecase.UsecaseI, aclM *middleware.AclMiddleware) {
 	handler := &Delivery{
 		TagUC: eu,
 	}
 
-	e.POST("/tag/create", handler.CreateTag)
-	e.POST("/tag/edit", handler.UpdateTag)  // acl: owner
-	e.GET("/tag/:id", handler.GetTag)       // acl: owner, admin
-	e.DELETE("/tag/:id", handler.DeleteTag) // acl: owner
-	e.GET("/me/tags", handler.GetMyTags)
-	e.GET("/user/:user_id/tags", handler.GetUserTags, aclM.FriendsOrAdminOnly)
+	v1 := e.Group(middleware.APIv1)
+	v1.POST("/tags", handler.CreateTag)
+	v1.GET("/tags/:id", handler.GetTag)                                   // acl: owner, admin
+	v1.PATCH("/tags/:id", handler.UpdateTag, middleware.RequireIfMatch()) // acl: owner
+	v1.DELETE("/tags/:id", handler.DeleteTag)                             // acl: owner
+	v1.POST("/tags/:id/restore", handler.RestoreTag)                      // acl: owner
+	v1.GET("/me/tags", handler.GetMyTags)
+	v1.GET("/users/:user_id/tags", handler.GetUserTags, aclM.FriendsOrPermission(models.PermTagReadAny))
+
+	// the routes from before /api/v1
+	e.POST("/tag/create", handler.CreateTag, middleware.Deprecated(middleware.APIv1+"/tags"))
+	e.POST("/tag/edit", handler.UpdateTag, middleware.Deprecated(middleware.APIv1+"/tags/:id"), middleware.RequireIfMatch())
+	e.GET("/tag/:id", handler.GetTag, middleware.Deprecated(middleware.APIv1+"/tags/:id"))
+	e.DELETE("/tag/:id", handler.DeleteTag, middleware.Deprecated(middleware.APIv1+"/tags/:id"))
+	e.GET("/me/tags", handler.GetMyTags, middleware.Deprecated(middleware.APIv1+"/me/tags"))
+	e.GET("/user/:user_id/tags", handler.GetUserTags, middleware.Deprecated(middleware.APIv1+"/users/:user_id/tags"), aclM.FriendsOrPermission(models.PermTagReadAny))
 }
@@ -0,0 +1,258 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+	"timetracker/internal/Tag/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+func copyTag(t *models.Tag) *models.Tag {
+	copied := *t
+	copied.DeletedAt = memoryDB.CopyTime(t.DeletedAt)
+	return &copied
+}
+
+func sortTags(tags []*models.Tag) {
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].ID < tags[j].ID
+	})
+}
+
+type tagRepository struct {
+	db *memoryDB.DB
+}
+
+func (tr tagRepository) CreateTag(ctx context.Context, t *models.Tag) error {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	t.Version, t.UpdatedAt = 1, time.Now().UTC()
+
+	if _, ok := tr.db.Users[t.UserID]; !ok {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table tag)")
+	}
+
+	tag := copyTag(t)
+	tag.ID = tr.db.NextID("tag")
+	tr.db.Tags[tag.ID] = tag
+
+	t.ID = tag.ID
+	return nil
+}
+
+// UpdateTag writes the editable fields even when they are zero, like the postgres repository
+func (tr tagRepository) UpdateTag(ctx context.Context, t *models.Tag) error {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	tag, ok := tr.db.Tags[t.ID]
+	if !ok || tag.DeletedAt != nil {
+		return models.ErrNotFound
+	}
+
+	if tag.Version != t.Version {
+		return models.ErrVersionMismatch
+	}
+
+	tag.Name = t.Name
+	tag.About = t.About
+	tag.Color = t.Color
+	tag.Version++
+	tag.UpdatedAt = time.Now().UTC()
+
+	t.Version, t.UpdatedAt = tag.Version, tag.UpdatedAt
+	return nil
+}
+
+func (tr tagRepository) GetTag(ctx context.Context, id uint64) (*models.Tag, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	tag, ok := tr.db.Tags[id]
+	if !ok || tag.DeletedAt != nil {
+		return nil, models.ErrNotFound
+	}
+
+	return copyTag(tag), nil
+}
+
+func (tr tagRepository) DeleteTag(ctx context.Context, id uint64) error {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	tr.db.SoftDeleteTag(id, time.Now().UTC())
+	return nil
+}
+
+func (tr tagRepository) GetUserTags(ctx context.Context, userID uint64) ([]*models.Tag, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	tags := make([]*models.Tag, 0, 10)
+	for _, tag := range tr.db.Tags {
+		if tag.UserID == userID && tag.DeletedAt == nil {
+			tags = append(tags, copyTag(tag))
+		}
+	}
+
+	sortTags(tags)
+	return tags, nil
+}
+
+func (tr tagRepository) GetUserTagChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Tag, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	tags := make([]*models.Tag, 0, 10)
+	for _, tag := range tr.db.Tags {
+		if tag.UserID == userID && tag.UpdatedAt.After(since) && !tag.UpdatedAt.After(until) {
+			tags = append(tags, copyTag(tag))
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].UpdatedAt.Before(tags[j].UpdatedAt)
+	})
+
+	return tags, nil
+}
+
+func (tr tagRepository) GetDeletedTag(ctx context.Context, id uint64) (*models.Tag, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	tag, ok := tr.db.Tags[id]
+	if !ok || tag.DeletedAt == nil {
+		return nil, models.ErrNotFound
+	}
+
+	return copyTag(tag), nil
+}
+
+func (tr tagRepository) GetUserDeletedTags(ctx context.Context, userID uint64) ([]*models.Tag, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	tags := make([]*models.Tag, 0, 10)
+	for _, tag := range tr.db.Tags {
+		if tag.UserID == userID && tag.DeletedAt != nil {
+			tags = append(tags, copyTag(tag))
+		}
+	}
+
+	sortTags(tags)
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].DeletedAt.After(*tags[j].DeletedAt)
+	})
+
+	return tags, nil
+}
+
+func (tr tagRepository) RestoreTag(ctx context.Context, id uint64) error {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	tr.db.RestoreTag(id, time.Now().UTC())
+	return nil
+}
+
+func (tr tagRepository) PurgeDeletedTags(ctx context.Context, before time.Time) (int64, error) {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	var purged int64
+	for id, tag := range tr.db.Tags {
+		if tag.DeletedAt != nil && tag.DeletedAt.Before(before) {
+			tr.db.DeleteTag(id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+func (tr tagRepository) GetEntryTags(ctx context.Context, entryID uint64) ([]*models.Tag, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	tags := make([]*models.Tag, 0, 10)
+	for tagID := range tr.db.TagEntries[entryID] {
+		if tag := tr.db.Tags[tagID]; tag.DeletedAt == nil {
+			tags = append(tags, copyTag(tag))
+		}
+	}
+
+	sortTags(tags)
+	return tags, nil
+}
+
+func (tr tagRepository) CreateEntryTags(ctx context.Context, entryID uint64, tagList []models.Tag) error {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	return tr.createEntryTags(entryID, tagList)
+}
+
+// createEntryTags inserts all relations or none of them, the caller must hold the write lock
+func (tr tagRepository) createEntryTags(entryID uint64, tagList []models.Tag) error {
+	if _, ok := tr.db.Entries[entryID]; !ok {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table tag)")
+	}
+
+	tagIDs := tr.db.TagEntries[entryID]
+	inserted := make(map[uint64]struct{}, len(tagList))
+	for idx := range tagList {
+		_, tagExists := tr.db.Tags[tagList[idx].ID]
+		_, relationExists := tagIDs[tagList[idx].ID]
+		_, duplicate := inserted[tagList[idx].ID]
+		if !tagExists || relationExists || duplicate {
+			return errors.Wrap(memoryDB.ErrConstraint, "database error (table tag)")
+		}
+		inserted[tagList[idx].ID] = struct{}{}
+	}
+
+	if tagIDs == nil {
+		tagIDs = map[uint64]struct{}{}
+		tr.db.TagEntries[entryID] = tagIDs
+	}
+
+	for idx := range tagList {
+		tagIDs[tagList[idx].ID] = struct{}{}
+	}
+
+	return nil
+}
+
+func (tr tagRepository) UpdateEntryTags(ctx context.Context, entryID uint64, tagList []models.Tag) error {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	previous := tr.db.TagEntries[entryID]
+	delete(tr.db.TagEntries, entryID)
+
+	err := tr.createEntryTags(entryID, tagList)
+	if err != nil && previous != nil {
+		tr.db.TagEntries[entryID] = previous
+	}
+
+	return err
+}
+
+func (tr tagRepository) DeleteEntryTags(ctx context.Context, entryID uint64) error {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	delete(tr.db.TagEntries, entryID)
+	return nil
+}
+
+func NewTagRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &tagRepository{
+		db: db,
+	}
+}
@@ -3,9 +3,13 @@
 package mocks
 
 import (
+	context "context"
+
 	models "timetracker/models"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // RepositoryI is an autogenerated mock type for the RepositoryI type
@@ -13,13 +17,13 @@ type RepositoryI struct {
 	mock.Mock
 }
 
-// CreateEntryTags provides a mock function with given fields: entryID, tagList
-func (_m *RepositoryI) CreateEntryTags(entryID uint64, tagList []models.Tag) error {
-	ret := _m.Called(entryID, tagList)
+// CreateEntryTags provides a mock function with given fields: ctx, entryID, tagList
+func (_m *RepositoryI) CreateEntryTags(ctx context.Context, entryID uint64, tagList []models.Tag) error {
+	ret := _m.Called(ctx, entryID, tagList)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64, []models.Tag) error); ok {
-		r0 = rf(entryID, tagList)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []models.Tag) error); ok {
+		r0 = rf(ctx, entryID, tagList)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,13 +31,13 @@ func (_m *RepositoryI) CreateEntryTags(entryID uint64, tagList []models.Tag) err
 	return r0
 }
 
-// CreateTag provides a mock function with given fields: e
-func (_m *RepositoryI) CreateTag(e *models.Tag) error {
-	ret := _m.Called(e)
+// CreateTag provides a mock function with given fields: ctx, e
+func (_m *RepositoryI) CreateTag(ctx context.Context, e *models.Tag) error {
+	ret := _m.Called(ctx, e)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Tag) error); ok {
-		r0 = rf(e)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Tag) error); ok {
+		r0 = rf(ctx, e)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,13 +45,13 @@ func (_m *RepositoryI) CreateTag(e *models.Tag) error {
 	return r0
 }
 
-// DeleteEntryTags provides a mock function with given fields: entryID
-func (_m *RepositoryI) DeleteEntryTags(entryID uint64) error {
-	ret := _m.Called(entryID)
+// DeleteEntryTags provides a mock function with given fields: ctx, entryID
+func (_m *RepositoryI) DeleteEntryTags(ctx context.Context, entryID uint64) error {
+	ret := _m.Called(ctx, entryID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64) error); ok {
-		r0 = rf(entryID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, entryID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -55,13 +59,13 @@ func (_m *RepositoryI) DeleteEntryTags(entryID uint64) error {
 	return r0
 }
 
-// DeleteTag provides a mock function with given fields: id
-func (_m *RepositoryI) DeleteTag(id uint64) error {
-	ret := _m.Called(id)
+// DeleteTag provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) DeleteTag(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -69,25 +73,51 @@ func (_m *RepositoryI) DeleteTag(id uint64) error {
 	return r0
 }
 
-// GetEntryTags provides a mock function with given fields: entryID
-func (_m *RepositoryI) GetEntryTags(entryID uint64) ([]*models.Tag, error) {
-	ret := _m.Called(entryID)
+// GetDeletedTag provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) GetDeletedTag(ctx context.Context, id uint64) (*models.Tag, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.Tag, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.Tag); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEntryTags provides a mock function with given fields: ctx, entryID
+func (_m *RepositoryI) GetEntryTags(ctx context.Context, entryID uint64) ([]*models.Tag, error) {
+	ret := _m.Called(ctx, entryID)
 
 	var r0 []*models.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) ([]*models.Tag, error)); ok {
-		return rf(entryID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Tag, error)); ok {
+		return rf(ctx, entryID)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) []*models.Tag); ok {
-		r0 = rf(entryID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Tag); ok {
+		r0 = rf(ctx, entryID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(entryID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, entryID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -95,25 +125,25 @@ func (_m *RepositoryI) GetEntryTags(entryID uint64) ([]*models.Tag, error) {
 	return r0, r1
 }
 
-// GetTag provides a mock function with given fields: id
-func (_m *RepositoryI) GetTag(id uint64) (*models.Tag, error) {
-	ret := _m.Called(id)
+// GetTag provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) GetTag(ctx context.Context, id uint64) (*models.Tag, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 *models.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) (*models.Tag, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.Tag, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) *models.Tag); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.Tag); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -121,25 +151,25 @@ func (_m *RepositoryI) GetTag(id uint64) (*models.Tag, error) {
 	return r0, r1
 }
 
-// GetUserTags provides a mock function with given fields: userID
-func (_m *RepositoryI) GetUserTags(userID uint64) ([]*models.Tag, error) {
-	ret := _m.Called(userID)
+// GetUserDeletedTags provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetUserDeletedTags(ctx context.Context, userID uint64) ([]*models.Tag, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 []*models.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) ([]*models.Tag, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Tag, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) []*models.Tag); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Tag); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -147,13 +177,105 @@ func (_m *RepositoryI) GetUserTags(userID uint64) ([]*models.Tag, error) {
 	return r0, r1
 }
 
-// UpdateEntryTags provides a mock function with given fields: entryID, tagList
-func (_m *RepositoryI) UpdateEntryTags(entryID uint64, tagList []models.Tag) error {
-	ret := _m.Called(entryID, tagList)
+// GetUserTagChanges provides a mock function with given fields: ctx, userID, since, until
+func (_m *RepositoryI) GetUserTagChanges(ctx context.Context, userID uint64, since time.Time, until time.Time) ([]*models.Tag, error) {
+	ret := _m.Called(ctx, userID, since, until)
+
+	var r0 []*models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time, time.Time) ([]*models.Tag, error)); ok {
+		return rf(ctx, userID, since, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time, time.Time) []*models.Tag); ok {
+		r0 = rf(ctx, userID, since, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, since, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserTags provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetUserTags(ctx context.Context, userID uint64) ([]*models.Tag, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]*models.Tag, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []*models.Tag); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeletedTags provides a mock function with given fields: ctx, before
+func (_m *RepositoryI) PurgeDeletedTags(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreTag provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) RestoreTag(ctx context.Context, id uint64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateEntryTags provides a mock function with given fields: ctx, entryID, tagList
+func (_m *RepositoryI) UpdateEntryTags(ctx context.Context, entryID uint64, tagList []models.Tag) error {
+	ret := _m.Called(ctx, entryID, tagList)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64, []models.Tag) error); ok {
-		r0 = rf(entryID, tagList)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []models.Tag) error); ok {
+		r0 = rf(ctx, entryID, tagList)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -161,13 +283,13 @@ func (_m *RepositoryI) UpdateEntryTags(entryID uint64, tagList []models.Tag) err
 	return r0
 }
 
-// UpdateTag provides a mock function with given fields: e
-func (_m *RepositoryI) UpdateTag(e *models.Tag) error {
-	ret := _m.Called(e)
+// UpdateTag provides a mock function with given fields: ctx, e
+func (_m *RepositoryI) UpdateTag(ctx context.Context, e *models.Tag) error {
+	ret := _m.Called(ctx, e)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Tag) error); ok {
-		r0 = rf(e)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Tag) error); ok {
+		r0 = rf(ctx, e)
 	} else {
 		r0 = ret.Error(0)
 	}
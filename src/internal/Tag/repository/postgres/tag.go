@@ -1,18 +1,23 @@
 package postgres
 
 import (
+	"context"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"time"
 	"timetracker/internal/Tag/repository"
 	"timetracker/models"
 )
 
 type Tag struct {
-	ID     uint64 `gorm:"column:id"`
-	UserID uint64 `gorm:"column:user_id"`
-	Name   string `gorm:"column:name"`
-	About  string `gorm:"column:about"`
-	Color  string `gorm:"column:color"`
+	ID        uint64         `gorm:"column:id"`
+	UserID    uint64         `gorm:"column:user_id"`
+	Name      string         `gorm:"column:name"`
+	About     string         `gorm:"column:about"`
+	Color     string         `gorm:"column:color"`
+	Version   uint64         `gorm:"column:version"`
+	UpdatedAt time.Time      `gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at"`
 }
 
 type TagEntryRelation struct {
@@ -30,22 +35,32 @@ func (Tag) TableName() string {
 
 func toPostgresTag(t *models.Tag) *Tag {
 	return &Tag{
-		ID:     t.ID,
-		UserID: t.UserID,
-		Name:   t.Name,
-		About:  t.About,
-		Color:  t.Color,
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Name:      t.Name,
+		About:     t.About,
+		Color:     t.Color,
+		Version:   t.Version,
+		UpdatedAt: t.UpdatedAt,
 	}
 }
 
 func toModelTag(t *Tag) *models.Tag {
-	return &models.Tag{
-		ID:     t.ID,
-		UserID: t.UserID,
-		Name:   t.Name,
-		About:  t.About,
-		Color:  t.Color,
+	tag := &models.Tag{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Name:      t.Name,
+		About:     t.About,
+		Color:     t.Color,
+		Version:   t.Version,
+		UpdatedAt: t.UpdatedAt,
 	}
+
+	if t.DeletedAt.Valid {
+		tag.DeletedAt = &t.DeletedAt.Time
+	}
+
+	return tag
 }
 
 func toModelTags(tags []*Tag) []*models.Tag {
@@ -62,10 +77,11 @@ type tagRepository struct {
 	db *gorm.DB
 }
 
-func (tr tagRepository) CreateTag(t *models.Tag) error {
+func (tr tagRepository) CreateTag(ctx context.Context, t *models.Tag) error {
+	t.Version, t.UpdatedAt = 1, time.Now().UTC()
 	postgresTag := toPostgresTag(t)
 
-	tx := tr.db.Create(postgresTag)
+	tx := tr.db.WithContext(ctx).Create(postgresTag)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table tag)")
@@ -75,22 +91,34 @@ func (tr tagRepository) CreateTag(t *models.Tag) error {
 	return nil
 }
 
-func (tr tagRepository) UpdateTag(t *models.Tag) error {
+// updateColumns are written even when they hold zero values, so a patch can clear them
+var updateColumns = []string{"name", "about", "color", "version", "updated_at"}
+
+// UpdateTag only writes the row if it still has the version t was read with,
+// then t gets the next version
+func (tr tagRepository) UpdateTag(ctx context.Context, t *models.Tag) error {
 	postgresTag := toPostgresTag(t)
+	postgresTag.Version++
+	postgresTag.UpdatedAt = time.Now().UTC()
 
-	tx := tr.db.Omit("id").Updates(postgresTag)
+	tx := tr.db.WithContext(ctx).Where("version = ?", t.Version).Select(updateColumns).Updates(postgresTag)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table tag)")
 	}
 
+	if tx.RowsAffected == 0 {
+		return models.ErrVersionMismatch
+	}
+
+	t.Version, t.UpdatedAt = postgresTag.Version, postgresTag.UpdatedAt
 	return nil
 }
 
-func (tr tagRepository) GetTag(id uint64) (*models.Tag, error) {
+func (tr tagRepository) GetTag(ctx context.Context, id uint64) (*models.Tag, error) {
 	var tag Tag
 
-	tx := tr.db.Where("id = ?", id).Take(&tag)
+	tx := tr.db.WithContext(ctx).Where("id = ?", id).Take(&tag)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
@@ -101,8 +129,15 @@ func (tr tagRepository) GetTag(id uint64) (*models.Tag, error) {
 	return toModelTag(&tag), nil
 }
 
-func (tr tagRepository) DeleteTag(id uint64) error {
-	tx := tr.db.Delete(&Tag{}, id)
+// DeleteTag leaves a tombstone like the entries, the relations of a deleted tag are
+// kept but GetEntryTags skips it
+func (tr tagRepository) DeleteTag(ctx context.Context, id uint64) error {
+	now := time.Now().UTC()
+	tx := tr.db.WithContext(ctx).Model(&Tag{ID: id}).Updates(map[string]interface{}{
+		"deleted_at": now,
+		"updated_at": now,
+		"version":    gorm.Expr("version + 1"),
+	})
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table tag)")
@@ -111,10 +146,10 @@ func (tr tagRepository) DeleteTag(id uint64) error {
 	return nil
 }
 
-func (tr tagRepository) GetUserTags(ustrID uint64) ([]*models.Tag, error) {
+func (tr tagRepository) GetUserTags(ctx context.Context, ustrID uint64) ([]*models.Tag, error) {
 	entries := make([]*Tag, 0, 10)
 
-	tx := tr.db.Where(&Tag{UserID: ustrID}).Find(&entries)
+	tx := tr.db.WithContext(ctx).Where(&Tag{UserID: ustrID}).Find(&entries)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
@@ -125,9 +160,9 @@ func (tr tagRepository) GetUserTags(ustrID uint64) ([]*models.Tag, error) {
 	return toModelTags(entries), nil
 }
 
-func (tr tagRepository) GetEntryTags(entryID uint64) ([]*models.Tag, error) {
+func (tr tagRepository) GetEntryTags(ctx context.Context, entryID uint64) ([]*models.Tag, error) {
 	tagEntryRels := make([]*TagEntryRelation, 0, 10)
-	tx := tr.db.Where(&TagEntryRelation{EntryID: entryID}).Find(&tagEntryRels)
+	tx := tr.db.WithContext(ctx).Where(&TagEntryRelation{EntryID: entryID}).Find(&tagEntryRels)
 
 	if tx.Error != nil {
 		return nil, errors.Wrap(tx.Error, "database error (table tag)")
@@ -137,10 +172,10 @@ func (tr tagRepository) GetEntryTags(entryID uint64) ([]*models.Tag, error) {
 
 	for idx := range tagEntryRels {
 		var tag Tag
-		tx := tr.db.Where(&Tag{ID: tagEntryRels[idx].TagID}).Take(&tag)
+		tx := tr.db.WithContext(ctx).Where(&Tag{ID: tagEntryRels[idx].TagID}).Take(&tag)
 
 		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
-			return nil, models.ErrNotFound
+			continue
 		} else if tx.Error != nil {
 			return nil, errors.Wrap(tx.Error, "database error (table tag)")
 		}
@@ -151,14 +186,83 @@ func (tr tagRepository) GetEntryTags(entryID uint64) ([]*models.Tag, error) {
 	return toModelTags(tags), nil
 }
 
-func (tr tagRepository) CreateEntryTags(entryID uint64, tagList []models.Tag) error {
+// GetUserTagChanges returns the tags of the user changed in (since, until] with the tombstones
+func (tr tagRepository) GetUserTagChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Tag, error) {
+	tags := make([]*Tag, 0, 10)
+
+	tx := tr.db.WithContext(ctx).Unscoped().Where(&Tag{UserID: userID}).
+		Where("updated_at > ? AND updated_at <= ?", since, until).Order("updated_at").Find(&tags)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table tag)")
+	}
+
+	return toModelTags(tags), nil
+}
+
+// GetDeletedTag returns the tombstone of the tag, ErrNotFound if the tag is not deleted
+func (tr tagRepository) GetDeletedTag(ctx context.Context, id uint64) (*models.Tag, error) {
+	var tag Tag
+
+	tx := tr.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Take(&tag)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table tag)")
+	}
+
+	return toModelTag(&tag), nil
+}
+
+// GetUserDeletedTags returns the tombstones of the user, the last deleted first
+func (tr tagRepository) GetUserDeletedTags(ctx context.Context, userID uint64) ([]*models.Tag, error) {
+	tags := make([]*Tag, 0, 10)
+
+	tx := tr.db.WithContext(ctx).Unscoped().Where(&Tag{UserID: userID}).
+		Where("deleted_at IS NOT NULL").Order("deleted_at DESC, id").Find(&tags)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table tag)")
+	}
+
+	return toModelTags(tags), nil
+}
+
+// RestoreTag brings the tombstone back with the next version, the entries kept their relations to it
+func (tr tagRepository) RestoreTag(ctx context.Context, id uint64) error {
+	tx := tr.db.WithContext(ctx).Unscoped().Model(&Tag{ID: id}).Where("deleted_at IS NOT NULL").Updates(map[string]interface{}{
+		"deleted_at": nil,
+		"updated_at": time.Now().UTC(),
+		"version":    gorm.Expr("version + 1"),
+	})
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table tag)")
+	}
+
+	return nil
+}
+
+// PurgeDeletedTags removes the tombstones deleted before the time for good
+func (tr tagRepository) PurgeDeletedTags(ctx context.Context, before time.Time) (int64, error) {
+	tx := tr.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before.UTC()).Delete(&Tag{})
+
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "database error (table tag)")
+	}
+
+	return tx.RowsAffected, nil
+}
+
+func (tr tagRepository) CreateEntryTags(ctx context.Context, entryID uint64, tagList []models.Tag) error {
 	tagEntryRels := make([]*TagEntryRelation, 0, len(tagList))
 
 	for idx := range tagList {
 		tagEntryRels = append(tagEntryRels, &TagEntryRelation{EntryID: entryID, TagID: tagList[idx].ID})
 	}
 
-	tx := tr.db.Create(tagEntryRels)
+	tx := tr.db.WithContext(ctx).Create(tagEntryRels)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table tag)")
@@ -167,20 +271,21 @@ func (tr tagRepository) CreateEntryTags(entryID uint64, tagList []models.Tag) er
 	return nil
 }
 
-func (tr tagRepository) UpdateEntryTags(entryID uint64, tagList []models.Tag) error {
-	err := tr.DeleteEntryTags(entryID)
+func (tr tagRepository) UpdateEntryTags(ctx context.Context, entryID uint64, tagList []models.Tag) error {
+	err := tr.DeleteEntryTags(ctx, entryID)
 
-	if err != nil {
+	// an empty list only removes the tags, gorm can't create an empty slice
+	if err != nil || len(tagList) == 0 {
 		return err
 	}
 
-	err = tr.CreateEntryTags(entryID, tagList)
+	err = tr.CreateEntryTags(ctx, entryID, tagList)
 
 	return err
 }
 
-func (tr tagRepository) DeleteEntryTags(entryID uint64) error {
-	tx := tr.db.Where("entry_id = ?", entryID).Delete(&TagEntryRelation{})
+func (tr tagRepository) DeleteEntryTags(ctx context.Context, entryID uint64) error {
+	tx := tr.db.WithContext(ctx).Where("entry_id = ?", entryID).Delete(&TagEntryRelation{})
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table tags)")
@@ -1,17 +1,24 @@
 package repository
 
 import (
+	"context"
+	"time"
 	"timetracker/models"
 )
 
 type RepositoryI interface {
-	CreateTag(e *models.Tag) error
-	UpdateTag(e *models.Tag) error
-	GetTag(id uint64) (*models.Tag, error)
-	DeleteTag(id uint64) error
-	GetUserTags(userID uint64) ([]*models.Tag, error)
-	GetEntryTags(entryID uint64) ([]*models.Tag, error)
-	CreateEntryTags(entryID uint64, tagList []models.Tag) error
-	UpdateEntryTags(entryID uint64, tagList []models.Tag) error
-	DeleteEntryTags(entryID uint64) error
+	CreateTag(ctx context.Context, e *models.Tag) error
+	UpdateTag(ctx context.Context, e *models.Tag) error
+	GetTag(ctx context.Context, id uint64) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id uint64) error
+	GetUserTags(ctx context.Context, userID uint64) ([]*models.Tag, error)
+	GetUserTagChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Tag, error)
+	GetDeletedTag(ctx context.Context, id uint64) (*models.Tag, error)
+	GetUserDeletedTags(ctx context.Context, userID uint64) ([]*models.Tag, error)
+	RestoreTag(ctx context.Context, id uint64) error
+	PurgeDeletedTags(ctx context.Context, before time.Time) (int64, error)
+	GetEntryTags(ctx context.Context, entryID uint64) ([]*models.Tag, error)
+	CreateEntryTags(ctx context.Context, entryID uint64, tagList []models.Tag) error
+	UpdateEntryTags(ctx context.Context, entryID uint64, tagList []models.Tag) error
+	DeleteEntryTags(ctx context.Context, entryID uint64) error
 }
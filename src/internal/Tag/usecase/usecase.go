@@ -1,25 +1,36 @@
 package usecase
 
 import (
+	"context"
 	"github.com/pkg/errors"
+	"time"
 	tagRep "timetracker/internal/Tag/repository"
+	"timetracker/internal/policy"
+	"timetracker/internal/tracing"
 	"timetracker/models"
 )
 
 type UsecaseI interface {
-	CreateTag(t *models.Tag) error
-	UpdateTag(t *models.Tag) error
-	GetTag(id uint64) (*models.Tag, error)
-	DeleteTag(id uint64, userID uint64) error
-	GetUserTags(userID uint64) ([]*models.Tag, error)
+	CreateTag(ctx context.Context, t *models.Tag) error
+	UpdateTag(ctx context.Context, patch *models.TagPatch) (*models.Tag, error)
+	GetTag(ctx context.Context, id uint64) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id uint64, userID uint64) error
+	GetUserTags(ctx context.Context, userID uint64) ([]*models.Tag, error)
+	GetUserTagChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Tag, error)
+	GetUserDeletedTags(ctx context.Context, userID uint64) ([]*models.Tag, error)
+	RestoreTag(ctx context.Context, id uint64, userID uint64) (*models.Tag, error)
+	PurgeDeletedTags(ctx context.Context, before time.Time) (int64, error)
 }
 
 type usecase struct {
 	tagRepository tagRep.RepositoryI
 }
 
-func (u *usecase) CreateTag(t *models.Tag) error {
-	err := u.tagRepository.CreateTag(t)
+func (u *usecase) CreateTag(ctx context.Context, t *models.Tag) error {
+	ctx, span := tracing.Start(ctx, "tag.Usecase.CreateTag")
+	defer span.End()
+
+	err := u.tagRepository.CreateTag(ctx, t)
 
 	if err != nil {
 		return errors.Wrap(err, "Error in func Tag.Usecase.CreateTag")
@@ -28,24 +39,39 @@ func (u *usecase) CreateTag(t *models.Tag) error {
 	return nil
 }
 
-func (u *usecase) UpdateTag(t *models.Tag) error {
-	_, err := u.tagRepository.GetTag(t.ID)
+func (u *usecase) UpdateTag(ctx context.Context, patch *models.TagPatch) (*models.Tag, error) {
+	ctx, span := tracing.Start(ctx, "tag.Usecase.UpdateTag")
+	defer span.End()
+
+	tag, err := u.tagRepository.GetTag(ctx, patch.ID)
 
 	if err != nil {
-		return errors.Wrap(err, "Error in func Tag.Usecase.CreateTag")
+		return nil, errors.Wrap(err, "Error in func Tag.Usecase.UpdateTag")
+	}
+
+	if err := policy.AuthorizeOwner(patch.UserID, &tag.UserID); err != nil {
+		return nil, err
+	}
+
+	if patch.Version != 0 && patch.Version != tag.Version {
+		return nil, models.ErrVersionMismatch
 	}
 
-	err = u.tagRepository.UpdateTag(t)
+	patch.Apply(tag)
+	err = u.tagRepository.UpdateTag(ctx, tag)
 
 	if err != nil {
-		return errors.Wrap(err, "Error in func Tag.Usecase.CreateTag")
+		return nil, errors.Wrap(err, "Error in func Tag.Usecase.UpdateTag")
 	}
 
-	return nil
+	return tag, nil
 }
 
-func (u *usecase) GetTag(id uint64) (*models.Tag, error) {
-	resTag, err := u.tagRepository.GetTag(id)
+func (u *usecase) GetTag(ctx context.Context, id uint64) (*models.Tag, error) {
+	ctx, span := tracing.Start(ctx, "tag.Usecase.GetTag")
+	defer span.End()
+
+	resTag, err := u.tagRepository.GetTag(ctx, id)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "Tag.usecase.GetTag error while get Tag info")
@@ -54,8 +80,11 @@ func (u *usecase) GetTag(id uint64) (*models.Tag, error) {
 	return resTag, nil
 }
 
-func (u *usecase) DeleteTag(id uint64, userID uint64) error {
-	existedTag, err := u.tagRepository.GetTag(id)
+func (u *usecase) DeleteTag(ctx context.Context, id uint64, userID uint64) error {
+	ctx, span := tracing.Start(ctx, "tag.Usecase.DeleteTag")
+	defer span.End()
+
+	existedTag, err := u.tagRepository.GetTag(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -64,11 +93,11 @@ func (u *usecase) DeleteTag(id uint64, userID uint64) error {
 		return errors.New("Tag not found") //TODO models error
 	}
 
-	if existedTag.UserID != userID {
-		return errors.New("Permission denied")
+	if err := policy.AuthorizeOwner(userID, &existedTag.UserID); err != nil {
+		return err
 	}
 
-	err = u.tagRepository.DeleteTag(id)
+	err = u.tagRepository.DeleteTag(ctx, id)
 
 	if err != nil {
 		return errors.Wrap(err, "Tag.repository delete error")
@@ -77,8 +106,11 @@ func (u *usecase) DeleteTag(id uint64, userID uint64) error {
 	return nil
 }
 
-func (u *usecase) GetUserTags(userID uint64) ([]*models.Tag, error) {
-	entries, err := u.tagRepository.GetUserTags(userID)
+func (u *usecase) GetUserTags(ctx context.Context, userID uint64) ([]*models.Tag, error) {
+	ctx, span := tracing.Start(ctx, "tag.Usecase.GetUserTags")
+	defer span.End()
+
+	entries, err := u.tagRepository.GetUserTags(ctx, userID)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func Tag.Usecase.GetUserPosts")
@@ -92,3 +124,66 @@ func New(tRep tagRep.RepositoryI) UsecaseI {
 		tagRepository: tRep,
 	}
 }
+
+// GetUserTagChanges returns the tags changed in (since, until] with the tombstones of the deleted ones
+func (u *usecase) GetUserTagChanges(ctx context.Context, userID uint64, since, until time.Time) ([]*models.Tag, error) {
+	ctx, span := tracing.Start(ctx, "tag.Usecase.GetUserTagChanges")
+	defer span.End()
+
+	tags, err := u.tagRepository.GetUserTagChanges(ctx, userID, since, until)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func tag.Usecase.GetUserTagChanges")
+	}
+
+	return tags, nil
+}
+
+func (u *usecase) GetUserDeletedTags(ctx context.Context, userID uint64) ([]*models.Tag, error) {
+	ctx, span := tracing.Start(ctx, "tag.Usecase.GetUserDeletedTags")
+	defer span.End()
+
+	deleted, err := u.tagRepository.GetUserDeletedTags(ctx, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func tag.Usecase.GetUserDeletedTags")
+	}
+
+	return deleted, nil
+}
+
+func (u *usecase) RestoreTag(ctx context.Context, id uint64, userID uint64) (*models.Tag, error) {
+	ctx, span := tracing.Start(ctx, "tag.Usecase.RestoreTag")
+	defer span.End()
+
+	deleted, err := u.tagRepository.GetDeletedTag(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func tag.Usecase.RestoreTag")
+	}
+
+	if err := policy.AuthorizeOwner(userID, &deleted.UserID); err != nil {
+		return nil, err
+	}
+
+	err = u.tagRepository.RestoreTag(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func tag.Usecase.RestoreTag")
+	}
+
+	return u.GetTag(ctx, id)
+}
+
+func (u *usecase) PurgeDeletedTags(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "tag.Usecase.PurgeDeletedTags")
+	defer span.End()
+
+	purged, err := u.tagRepository.PurgeDeletedTags(ctx, before)
+
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in func tag.Usecase.PurgeDeletedTags")
+	}
+
+	return purged, nil
+}
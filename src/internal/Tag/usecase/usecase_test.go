@@ -1,9 +1,11 @@
 package usecase_test
 
 import (
+	"context"
 	"github.com/bxcodec/faker"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"testing"
 	tagMocks "timetracker/internal/Tag/repository/mocks"
@@ -22,6 +24,12 @@ type TestCaseDeleteTag struct {
 	Error   error
 }
 
+type TestCaseUpdateTag struct {
+	ArgData     *models.TagPatch
+	ExpectedRes *models.Tag
+	Error       error
+}
+
 type TestCaseCreateUpdateTag struct {
 	ArgData *models.Tag
 	Error   error
@@ -42,7 +50,7 @@ func TestUsecaseGetTag(t *testing.T) {
 
 	mockTagRepo := tagMocks.NewRepositoryI(t)
 
-	mockTagRepo.On("GetTag", mockTagRes.ID).Return(&mockTagRes, nil)
+	mockTagRepo.On("GetTag", mock.Anything, mockTagRes.ID).Return(&mockTagRes, nil)
 
 	useCase := usecase.New(mockTagRepo)
 
@@ -56,7 +64,7 @@ func TestUsecaseGetTag(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			user, err := useCase.GetTag(test.ArgData)
+			user, err := useCase.GetTag(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, err)
 
 			if err == nil {
@@ -68,36 +76,56 @@ func TestUsecaseGetTag(t *testing.T) {
 }
 
 func TestUsecaseUpdateTag(t *testing.T) {
-	var mockTag, invalidMockTag models.Tag
+	var mockTag models.Tag
 	err := faker.FakeData(&mockTag)
 	assert.NoError(t, err)
 
-	invalidMockTag.ID += mockTag.ID + 1
+	name, color := "work", ""
+	patch := &models.TagPatch{ID: mockTag.ID, UserID: mockTag.UserID, Name: &name, Color: &color}
 
-	mockTagRepo := tagMocks.NewRepositoryI(t)
+	expectedTag := mockTag
+	expectedTag.Name = name
+	expectedTag.Color = ""
 
-	mockTagRepo.On("GetTag", mockTag.ID).Return(&mockTag, nil)
-	mockTagRepo.On("UpdateTag", &mockTag).Return(nil)
+	mockTagRepo := tagMocks.NewRepositoryI(t)
 
-	mockTagRepo.On("GetTag", invalidMockTag.ID).Return(nil, models.ErrNotFound)
+	mockTagRepo.On("GetTag", mock.Anything, mockTag.ID).Return(func(context.Context, uint64) *models.Tag {
+		tag := mockTag
+		return &tag
+	}, nil)
+	mockTagRepo.On("UpdateTag", mock.Anything, &expectedTag).Return(nil)
+	mockTagRepo.On("GetTag", mock.Anything, mockTag.ID+1).Return(nil, models.ErrNotFound)
 
 	useCase := usecase.New(mockTagRepo)
 
-	cases := map[string]TestCaseCreateUpdateTag{
-		"success": {
-			ArgData: &mockTag,
-			Error:   nil,
+	cases := map[string]TestCaseUpdateTag{
+		"success clears fields": {
+			ArgData:     patch,
+			ExpectedRes: &expectedTag,
+			Error:       nil,
 		},
 		"Tag not found": {
-			ArgData: &invalidMockTag,
+			ArgData: &models.TagPatch{ID: mockTag.ID + 1, UserID: mockTag.UserID},
 			Error:   models.ErrNotFound,
 		},
+		"Permission denied": {
+			ArgData: &models.TagPatch{ID: mockTag.ID, UserID: mockTag.UserID + 1},
+			Error:   models.ErrPermissionDenied,
+		},
+		"Version mismatch": {
+			ArgData: &models.TagPatch{ID: mockTag.ID, UserID: mockTag.UserID, Version: mockTag.Version + 1},
+			Error:   models.ErrVersionMismatch,
+		},
 	}
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.UpdateTag(test.ArgData)
+			tag, err := useCase.UpdateTag(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, test.ExpectedRes, tag)
+			}
 		})
 	}
 	mockTagRepo.AssertExpectations(t)
@@ -110,7 +138,7 @@ func TestUsecaseCreateTag(t *testing.T) {
 
 	mockTagRepo := tagMocks.NewRepositoryI(t)
 
-	mockTagRepo.On("CreateTag", &mockTag).Return(nil)
+	mockTagRepo.On("CreateTag", mock.Anything, &mockTag).Return(nil)
 
 	useCase := usecase.New(mockTagRepo)
 
@@ -123,7 +151,7 @@ func TestUsecaseCreateTag(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.CreateTag(test.ArgData)
+			err := useCase.CreateTag(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
 		})
 	}
@@ -140,10 +168,10 @@ func TestUsecaseDeleteTag(t *testing.T) {
 
 	mockTagRepo := tagMocks.NewRepositoryI(t)
 
-	mockTagRepo.On("GetTag", mockTag.ID).Return(&mockTag, nil)
-	mockTagRepo.On("DeleteTag", mockTag.ID).Return(nil)
+	mockTagRepo.On("GetTag", mock.Anything, mockTag.ID).Return(&mockTag, nil)
+	mockTagRepo.On("DeleteTag", mock.Anything, mockTag.ID).Return(nil)
 
-	mockTagRepo.On("GetTag", invalidMockTag.ID).Return(nil, models.ErrNotFound)
+	mockTagRepo.On("GetTag", mock.Anything, invalidMockTag.ID).Return(nil, models.ErrNotFound)
 
 	useCase := usecase.New(mockTagRepo)
 
@@ -160,7 +188,7 @@ func TestUsecaseDeleteTag(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.DeleteTag(test.ArgData[0], test.ArgData[1])
+			err := useCase.DeleteTag(context.Background(), test.ArgData[0], test.ArgData[1])
 			require.Equal(t, test.Error, errors.Cause(err))
 		})
 	}
@@ -180,7 +208,7 @@ func TestUsecaseGetUserTags(t *testing.T) {
 
 	mockTagRepo := tagMocks.NewRepositoryI(t)
 
-	mockTagRepo.On("GetUserTags", mockTagRes[0].UserID).Return(mockTagRes, nil)
+	mockTagRepo.On("GetUserTags", mock.Anything, mockTagRes[0].UserID).Return(mockTagRes, nil)
 
 	useCase := usecase.New(mockTagRepo)
 
@@ -194,7 +222,7 @@ func TestUsecaseGetUserTags(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			user, err := useCase.GetUserTags(test.ArgData)
+			user, err := useCase.GetUserTags(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, err)
 
 			if err == nil {
@@ -0,0 +1,275 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+	taskUsecase "timetracker/internal/Task/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Delivery struct {
+	TaskUC taskUsecase.UsecaseI
+}
+
+// CreateTask godoc
+// @Summary      Create task
+// @Description  Create a task in the project, entries can be logged on it. Acl: owner, editor
+// @Tags     	 task
+// @Accept	 application/json
+// @Produce  application/json
+// @Param    id path int true "Project ID"
+// @Param    task body dto.ReqCreateTask true "task info"
+// @Success  200 {object} pkg.Response{body=dto.RespTask} "the created task"
+// @Header   200 {string} ETag "version of the task"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 404 {object} apierror.Error "not_found: can't find project with such id"
+// @Failure 409 {object} apierror.Error "project_archived: the project takes no new items"
+// @Router   /api/v1/projects/{id}/tasks [post]
+func (delivery *Delivery) CreateTask(c echo.Context) error {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	var reqTask dto.ReqCreateTask
+	err = c.Bind(&reqTask)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	if ok, err := pkg.IsRequestValid(&reqTask); !ok {
+		c.Logger().Error(err)
+		return apierror.Validation(err)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	task := reqTask.ToModelTask(projectID)
+	err = delivery.TaskUC.CreateTask(c.Request().Context(), task, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelTask(task))
+	respTask := dto.GetResponseFromModelTask(task)
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respTask})
+}
+
+// GetProjectTasks godoc
+// @Summary      Get project tasks
+// @Description  Get the tasks of the project with the hours logged on them. Acl: members
+// @Tags     	 task
+// @Produce  application/json
+// @Param id path int  true  "Project ID"
+// @Success  200 {object} pkg.Response{body=[]dto.RespTask} "success get tasks"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found: can't find project with such id"
+// @Router   /api/v1/projects/{id}/tasks [get]
+func (delivery *Delivery) GetProjectTasks(c echo.Context) error {
+	projectID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	tasks, err := delivery.TaskUC.GetProjectTasks(c.Request().Context(), projectID, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: dto.GetResponseFromModelTasks(tasks)})
+}
+
+// GetTask godoc
+// @Summary      Get task
+// @Description  Get task by id with the hours logged on it. Acl: members of the project
+// @Tags     	 task
+// @Produce  application/json
+// @Param id  path int  true  "Task ID"
+// @Param    If-None-Match header string false "ETag of the cached task"
+// @Success  200 {object} pkg.Response{body=dto.RespTask} "success get task"
+// @Header   200 {string} ETag "version of the task"
+// @Success  304 "the cached task is up to date"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "permission_denied"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Router   /api/v1/tasks/{id} [get]
+func (delivery *Delivery) GetTask(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	task, err := delivery.TaskUC.GetTask(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	if middleware.NotModified(c, dto.GetETagFromModelTask(task)) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	respTask := dto.GetResponseFromModelTask(task)
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respTask})
+}
+
+// UpdateTask godoc
+// @Summary      Update a task
+// @Description  Update a task. Only the fields present in the body are changed. Acl: owner, editor
+// @Tags     	 task
+// @Accept	 application/json
+// @Produce  application/json
+// @Param    id path int true "Task ID"
+// @Param    If-Match header string true "ETag of the task the changes are based on"
+// @Param    task body dto.ReqPatchTask true "task info"
+// @Success  200 {object} pkg.Response{body=dto.RespTask} "success update task"
+// @Header   200 {string} ETag "version of the updated task"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Failure 409 {object} apierror.Error "project_archived: the project takes no changes"
+// @Failure 412 {object} apierror.Error "version_mismatch: the task was changed since the If-Match version"
+// @Failure 428 {object} apierror.Error "if_match_required"
+// @Router   /api/v1/tasks/{id} [patch]
+func (delivery *Delivery) UpdateTask(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrBadRequest)
+	}
+
+	var reqTask dto.ReqPatchTask
+	err = c.Bind(&reqTask)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.Bind(err)
+	}
+
+	if ok, err := pkg.IsRequestValid(&reqTask); !ok {
+		c.Logger().Error(err)
+		return apierror.Validation(err)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	version, err := middleware.IfMatch(c)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	patch := reqTask.ToModelTaskPatch()
+	patch.ID = id
+	patch.UserID = userId
+	patch.Version = version
+	task, err := delivery.TaskUC.UpdateTask(c.Request().Context(), patch)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	c.Response().Header().Set(middleware.HeaderETag, dto.GetETagFromModelTask(task))
+	respTask := dto.GetResponseFromModelTask(task)
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *respTask})
+}
+
+// DeleteTask godoc
+// @Summary      Delete a task
+// @Description  Delete a task for good, its entries stay in the project without a task. Acl: owner, editor
+// @Tags     	 task
+// @Param id path int  true  "Task ID"
+// @Success  204
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 404 {object} apierror.Error "not_found"
+// @Failure 403 {object} apierror.Error "invalid_csrf or permission_denied"
+// @Failure 409 {object} apierror.Error "project_archived: the project takes no changes"
+// @Router   /api/v1/tasks/{id} [delete]
+func (delivery *Delivery) DeleteTask(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(models.ErrNotFound)
+	}
+
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	err = delivery.TaskUC.DeleteTask(c.Request().Context(), id, userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func NewDelivery(e *echo.Echo, tu taskUsecase.UsecaseI) {
+	handler := &Delivery{
+		TaskUC: tu,
+	}
+
+	v1 := e.Group(middleware.APIv1)
+	v1.POST("/projects/:id/tasks", handler.CreateTask)                      // acl: owner, editor
+	v1.GET("/projects/:id/tasks", handler.GetProjectTasks)                  // acl: members
+	v1.GET("/tasks/:id", handler.GetTask)                                   // acl: members
+	v1.PATCH("/tasks/:id", handler.UpdateTask, middleware.RequireIfMatch()) // acl: owner, editor
+	v1.DELETE("/tasks/:id", handler.DeleteTask)                             // acl: owner, editor
+}
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+	"timetracker/internal/Task/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+type taskRepository struct {
+	db *memoryDB.DB
+}
+
+// copyTask sums the hours of the entries logged on the task like the postgres repository,
+// the caller must hold the lock
+func (tr taskRepository) copyTask(t *models.Task) *models.Task {
+	copied := *t
+	for _, entry := range tr.db.Entries {
+		if entry.DeletedAt == nil && entry.TaskID != nil && *entry.TaskID == t.ID {
+			copied.TotalCountHours += entry.TimeEnd.Sub(entry.TimeStart).Hours()
+		}
+	}
+
+	return &copied
+}
+
+func (tr taskRepository) CreateTask(ctx context.Context, t *models.Task) error {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	t.Version, t.UpdatedAt = 1, time.Now().UTC()
+
+	if _, ok := tr.db.Projects[t.ProjectID]; !ok {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table task)")
+	}
+
+	task := *t
+	task.ID = tr.db.NextID("task")
+	task.TotalCountHours = 0
+	tr.db.Tasks[task.ID] = &task
+
+	t.ID = task.ID
+	return nil
+}
+
+// UpdateTask writes the editable fields even when they are zero, like the postgres repository
+func (tr taskRepository) UpdateTask(ctx context.Context, t *models.Task) error {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	task, ok := tr.db.Tasks[t.ID]
+	if !ok {
+		return models.ErrNotFound
+	}
+
+	if task.Version != t.Version {
+		return models.ErrVersionMismatch
+	}
+
+	task.Name = t.Name
+	task.EstimateHours = t.EstimateHours
+	task.Done = t.Done
+	task.Version++
+	task.UpdatedAt = time.Now().UTC()
+
+	t.Version, t.UpdatedAt = task.Version, task.UpdatedAt
+	return nil
+}
+
+func (tr taskRepository) GetTask(ctx context.Context, id uint64) (*models.Task, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	task, ok := tr.db.Tasks[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	return tr.copyTask(task), nil
+}
+
+// DeleteTask takes the task off its entries with a new version, like the postgres repository
+func (tr taskRepository) DeleteTask(ctx context.Context, id uint64) error {
+	tr.db.Lock()
+	defer tr.db.Unlock()
+
+	tr.db.DeleteTask(id, time.Now().UTC())
+	return nil
+}
+
+func (tr taskRepository) GetProjectTasks(ctx context.Context, projectID uint64) ([]*models.Task, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	tasks := make([]*models.Task, 0, 10)
+	for _, task := range tr.db.Tasks {
+		if task.ProjectID == projectID {
+			tasks = append(tasks, tr.copyTask(task))
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+
+	return tasks, nil
+}
+
+func NewTaskRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &taskRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"time"
+	"timetracker/internal/Task/repository"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type Task struct {
+	ID              uint64    `gorm:"column:id"`
+	ProjectID       uint64    `gorm:"column:project_id"`
+	Name            string    `gorm:"column:name"`
+	EstimateHours   float64   `gorm:"column:estimate_hours"`
+	Done            bool      `gorm:"column:done"`
+	TotalCountHours float64   `gorm:"column:total_count_hours;->;-:migration"`
+	Version         uint64    `gorm:"column:version"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (Task) TableName() string {
+	return "task"
+}
+
+func toPostgresTask(t *models.Task) *Task {
+	return &Task{
+		ID:            t.ID,
+		ProjectID:     t.ProjectID,
+		Name:          t.Name,
+		EstimateHours: t.EstimateHours,
+		Done:          t.Done,
+		Version:       t.Version,
+		UpdatedAt:     t.UpdatedAt,
+	}
+}
+
+func toModelTask(t *Task) *models.Task {
+	return &models.Task{
+		ID:              t.ID,
+		ProjectID:       t.ProjectID,
+		Name:            t.Name,
+		EstimateHours:   t.EstimateHours,
+		Done:            t.Done,
+		TotalCountHours: t.TotalCountHours,
+		Version:         t.Version,
+		UpdatedAt:       t.UpdatedAt,
+	}
+}
+
+func toModelTasks(tasks []*Task) []*models.Task {
+	out := make([]*models.Task, len(tasks))
+
+	for i, b := range tasks {
+		out[i] = toModelTask(b)
+	}
+
+	return out
+}
+
+// the hours of the tasks sum their entries like the hours of the project members
+const (
+	postgresTaskHours = "COALESCE(SUM(EXTRACT(EPOCH FROM (entry.time_end - entry.time_start))), 0) / 3600"
+	sqliteTaskHours   = "COALESCE(SUM(ROUND((julianday(entry.time_end) - julianday(entry.time_start)) * 86400, 3)), 0) / 3600"
+)
+
+type taskRepository struct {
+	db *gorm.DB
+}
+
+// withHours selects the tasks with the hours of their entries, the tombstones don't count
+func (tr taskRepository) withHours(ctx context.Context) *gorm.DB {
+	taskHours := postgresTaskHours
+	if tr.db.Dialector.Name() == "sqlite" {
+		taskHours = sqliteTaskHours
+	}
+
+	return tr.db.WithContext(ctx).Model(&Task{}).
+		Select("task.*, " + taskHours + " AS total_count_hours").
+		Joins("LEFT JOIN entry ON entry.task_id = task.id AND entry.deleted_at IS NULL").
+		Group("task.id")
+}
+
+func (tr taskRepository) CreateTask(ctx context.Context, t *models.Task) error {
+	t.Version, t.UpdatedAt = 1, time.Now().UTC()
+	postgresTask := toPostgresTask(t)
+
+	tx := tr.db.WithContext(ctx).Create(postgresTask)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table task)")
+	}
+
+	t.ID = postgresTask.ID
+	return nil
+}
+
+// updateColumns are written even when they hold zero values, so a patch can clear them
+var updateColumns = []string{"name", "estimate_hours", "done", "version", "updated_at"}
+
+// UpdateTask only writes the row if it still has the version t was read with,
+// then t gets the next version
+func (tr taskRepository) UpdateTask(ctx context.Context, t *models.Task) error {
+	postgresTask := toPostgresTask(t)
+	postgresTask.Version++
+	postgresTask.UpdatedAt = time.Now().UTC()
+
+	tx := tr.db.WithContext(ctx).Where("version = ?", t.Version).Select(updateColumns).Updates(postgresTask)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table task)")
+	}
+
+	if tx.RowsAffected == 0 {
+		return models.ErrVersionMismatch
+	}
+
+	t.Version, t.UpdatedAt = postgresTask.Version, postgresTask.UpdatedAt
+	return nil
+}
+
+func (tr taskRepository) GetTask(ctx context.Context, id uint64) (*models.Task, error) {
+	var task Task
+
+	tx := tr.withHours(ctx).Where("task.id = ?", id).Take(&task)
+
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return nil, models.ErrNotFound
+	} else if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table task)")
+	}
+
+	return toModelTask(&task), nil
+}
+
+// DeleteTask removes the task for good. Its entries lose the task with a new
+// version, so the change reaches the synced devices like any other update.
+func (tr taskRepository) DeleteTask(ctx context.Context, id uint64) error {
+	err := tr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table("entry").Where("task_id = ?", id).Updates(map[string]interface{}{
+			"task_id":    nil,
+			"updated_at": time.Now().UTC(),
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&Task{}, id).Error
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "database error (table task)")
+	}
+
+	return nil
+}
+
+func (tr taskRepository) GetProjectTasks(ctx context.Context, projectID uint64) ([]*models.Task, error) {
+	tasks := make([]*Task, 0, 10)
+
+	tx := tr.withHours(ctx).Where("task.project_id = ?", projectID).Order("task.id").Find(&tasks)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table task)")
+	}
+
+	return toModelTasks(tasks), nil
+}
+
+func NewTaskRepository(db *gorm.DB) repository.RepositoryI {
+	return &taskRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"timetracker/models"
+)
+
+type RepositoryI interface {
+	CreateTask(ctx context.Context, t *models.Task) error
+	UpdateTask(ctx context.Context, t *models.Task) error
+	GetTask(ctx context.Context, id uint64) (*models.Task, error)
+	DeleteTask(ctx context.Context, id uint64) error
+	GetProjectTasks(ctx context.Context, projectID uint64) ([]*models.Task, error)
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	projectRep "timetracker/internal/Project/repository"
+	taskRep "timetracker/internal/Task/repository"
+	"timetracker/internal/tracing"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+type UsecaseI interface {
+	CreateTask(ctx context.Context, t *models.Task, userID uint64) error
+	UpdateTask(ctx context.Context, patch *models.TaskPatch) (*models.Task, error)
+	GetTask(ctx context.Context, id uint64, userID uint64) (*models.Task, error)
+	DeleteTask(ctx context.Context, id uint64, userID uint64) error
+	GetProjectTasks(ctx context.Context, projectID uint64, userID uint64) ([]*models.Task, error)
+}
+
+type usecase struct {
+	taskRepository    taskRep.RepositoryI
+	projectRepository projectRep.RepositoryI
+}
+
+func New(tRep taskRep.RepositoryI, pRep projectRep.RepositoryI) UsecaseI {
+	return &usecase{
+		taskRepository:    tRep,
+		projectRepository: pRep,
+	}
+}
+
+// checkMember lets the members of the project see its tasks. A user who is not a member,
+// or didn't accept the invitation yet, gets ErrPermissionDenied.
+func (u *usecase) checkMember(ctx context.Context, projectID uint64, userID uint64) (*models.Project, models.ProjectRole, error) {
+	project, err := u.projectRepository.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	member, err := u.projectRepository.GetProjectMember(ctx, projectID, userID)
+
+	if errors.Is(err, models.ErrNotFound) {
+		return nil, "", models.ErrPermissionDenied
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	if !member.Accepted() {
+		return nil, "", models.ErrPermissionDenied
+	}
+
+	return project, member.Role, nil
+}
+
+// checkEditor lets the owner and the editors change the tasks while the project is not archived
+func (u *usecase) checkEditor(ctx context.Context, projectID uint64, userID uint64) error {
+	project, role, err := u.checkMember(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !role.CanEdit() {
+		return models.ErrPermissionDenied
+	}
+
+	if project.ArchivedAt != nil {
+		return models.ErrProjectArchived
+	}
+
+	return nil
+}
+
+func (u *usecase) CreateTask(ctx context.Context, t *models.Task, userID uint64) error {
+	ctx, span := tracing.Start(ctx, "task.Usecase.CreateTask")
+	defer span.End()
+
+	err := u.checkEditor(ctx, t.ProjectID, userID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func task.Usecase.CreateTask")
+	}
+
+	err = u.taskRepository.CreateTask(ctx, t)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func task.Usecase.CreateTask")
+	}
+
+	return nil
+}
+
+func (u *usecase) UpdateTask(ctx context.Context, patch *models.TaskPatch) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "task.Usecase.UpdateTask")
+	defer span.End()
+
+	task, err := u.taskRepository.GetTask(ctx, patch.ID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func task.Usecase.UpdateTask")
+	}
+
+	err = u.checkEditor(ctx, task.ProjectID, patch.UserID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func task.Usecase.UpdateTask")
+	}
+
+	if patch.Version != 0 && patch.Version != task.Version {
+		return nil, models.ErrVersionMismatch
+	}
+
+	patch.Apply(task)
+	err = u.taskRepository.UpdateTask(ctx, task)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func task.Usecase.UpdateTask")
+	}
+
+	return task, nil
+}
+
+// GetTask returns the task to a member of its project
+func (u *usecase) GetTask(ctx context.Context, id uint64, userID uint64) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "task.Usecase.GetTask")
+	defer span.End()
+
+	task, err := u.taskRepository.GetTask(ctx, id)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func task.Usecase.GetTask")
+	}
+
+	_, _, err = u.checkMember(ctx, task.ProjectID, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func task.Usecase.GetTask")
+	}
+
+	return task, nil
+}
+
+// DeleteTask removes the task, its entries stay in the project without a task
+func (u *usecase) DeleteTask(ctx context.Context, id uint64, userID uint64) error {
+	ctx, span := tracing.Start(ctx, "task.Usecase.DeleteTask")
+	defer span.End()
+
+	task, err := u.taskRepository.GetTask(ctx, id)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func task.Usecase.DeleteTask")
+	}
+
+	err = u.checkEditor(ctx, task.ProjectID, userID)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func task.Usecase.DeleteTask")
+	}
+
+	err = u.taskRepository.DeleteTask(ctx, id)
+
+	if err != nil {
+		return errors.Wrap(err, "Error in func task.Usecase.DeleteTask")
+	}
+
+	return nil
+}
+
+func (u *usecase) GetProjectTasks(ctx context.Context, projectID uint64, userID uint64) ([]*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "task.Usecase.GetProjectTasks")
+	defer span.End()
+
+	_, _, err := u.checkMember(ctx, projectID, userID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func task.Usecase.GetProjectTasks")
+	}
+
+	tasks, err := u.taskRepository.GetProjectTasks(ctx, projectID)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func task.Usecase.GetProjectTasks")
+	}
+
+	return tasks, nil
+}
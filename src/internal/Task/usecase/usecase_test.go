@@ -0,0 +1,227 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	projectMocks "timetracker/internal/Project/repository/mocks"
+	taskMocks "timetracker/internal/Task/repository/mocks"
+	"timetracker/internal/Task/usecase"
+	"timetracker/models"
+
+	"github.com/bxcodec/faker"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type TestCaseCreateTask struct {
+	ArgData *models.Task
+	UserID  uint64
+	Error   error
+}
+
+type TestCaseGetTask struct {
+	ArgData     []uint64
+	ExpectedRes *models.Task
+	Error       error
+}
+
+type TestCaseUpdateTask struct {
+	ArgData     *models.TaskPatch
+	ExpectedRes *models.Task
+	Error       error
+}
+
+// onMembers mocks the members of every project, a member without AcceptedAt is only
+// invited and the other users are no members
+func onMembers(repo *projectMocks.RepositoryI, members map[uint64]*models.ProjectMember) {
+	repo.On("GetProjectMember", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, projectID uint64, userID uint64) (*models.ProjectMember, error) {
+			member, ok := members[userID]
+			if !ok {
+				return nil, models.ErrNotFound
+			}
+
+			return &models.ProjectMember{ProjectID: projectID, UserID: userID, Role: member.Role, AcceptedAt: member.AcceptedAt}, nil
+		})
+}
+
+// the owner, an editor, a viewer and an invited editor of the project
+func projectMembers(ownerID uint64) map[uint64]*models.ProjectMember {
+	acceptedAt := time.Now().UTC()
+
+	return map[uint64]*models.ProjectMember{
+		ownerID:     {Role: models.ProjectOwner, AcceptedAt: &acceptedAt},
+		ownerID + 1: {Role: models.ProjectEditor, AcceptedAt: &acceptedAt},
+		ownerID + 2: {Role: models.ProjectViewer, AcceptedAt: &acceptedAt},
+		ownerID + 3: {Role: models.ProjectEditor},
+	}
+}
+
+func TestUsecaseCreateTask(t *testing.T) {
+	var mockTask models.Task
+	err := faker.FakeData(&mockTask)
+	assert.NoError(t, err)
+
+	var ownerID uint64 = 7
+	archivedTask := mockTask
+	archivedTask.ProjectID = mockTask.ProjectID + 1
+
+	archivedAt := time.Now().UTC()
+	mockTaskRepo := taskMocks.NewRepositoryI(t)
+	mockProjectRepo := projectMocks.NewRepositoryI(t)
+
+	mockProjectRepo.On("GetProject", mock.Anything, mockTask.ProjectID).Return(&models.Project{ID: mockTask.ProjectID}, nil)
+	mockProjectRepo.On("GetProject", mock.Anything, archivedTask.ProjectID).Return(&models.Project{ID: archivedTask.ProjectID, ArchivedAt: &archivedAt}, nil)
+	onMembers(mockProjectRepo, projectMembers(ownerID))
+	mockTaskRepo.On("CreateTask", mock.Anything, &mockTask).Return(nil).Twice()
+
+	useCase := usecase.New(mockTaskRepo, mockProjectRepo)
+
+	cases := map[string]TestCaseCreateTask{
+		"owner": {
+			ArgData: &mockTask,
+			UserID:  ownerID,
+			Error:   nil,
+		},
+		"editor": {
+			ArgData: &mockTask,
+			UserID:  ownerID + 1,
+			Error:   nil,
+		},
+		"viewer": {
+			ArgData: &mockTask,
+			UserID:  ownerID + 2,
+			Error:   models.ErrPermissionDenied,
+		},
+		"invited user": {
+			ArgData: &mockTask,
+			UserID:  ownerID + 3,
+			Error:   models.ErrPermissionDenied,
+		},
+		"not a member": {
+			ArgData: &mockTask,
+			UserID:  ownerID + 4,
+			Error:   models.ErrPermissionDenied,
+		},
+		"archived project": {
+			ArgData: &archivedTask,
+			UserID:  ownerID,
+			Error:   models.ErrProjectArchived,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := useCase.CreateTask(context.Background(), test.ArgData, test.UserID)
+			require.Equal(t, test.Error, errors.Cause(err))
+		})
+	}
+}
+
+func TestUsecaseGetTask(t *testing.T) {
+	var mockTask models.Task
+	err := faker.FakeData(&mockTask)
+	assert.NoError(t, err)
+
+	var ownerID uint64 = 7
+	mockTaskRepo := taskMocks.NewRepositoryI(t)
+	mockProjectRepo := projectMocks.NewRepositoryI(t)
+
+	mockProjectRepo.On("GetProject", mock.Anything, mockTask.ProjectID).Return(&models.Project{ID: mockTask.ProjectID}, nil)
+	onMembers(mockProjectRepo, projectMembers(ownerID))
+
+	mockTaskRepo.On("GetTask", mock.Anything, mockTask.ID).Return(&mockTask, nil)
+	mockTaskRepo.On("GetTask", mock.Anything, mockTask.ID+1).Return(nil, models.ErrNotFound)
+
+	useCase := usecase.New(mockTaskRepo, mockProjectRepo)
+
+	cases := map[string]TestCaseGetTask{
+		"viewer": {
+			ArgData:     []uint64{mockTask.ID, ownerID + 2},
+			ExpectedRes: &mockTask,
+			Error:       nil,
+		},
+		"Task not found": {
+			ArgData: []uint64{mockTask.ID + 1, ownerID},
+			Error:   models.ErrNotFound,
+		},
+		"invited user": {
+			ArgData: []uint64{mockTask.ID, ownerID + 3},
+			Error:   models.ErrPermissionDenied,
+		},
+		"not a member": {
+			ArgData: []uint64{mockTask.ID, ownerID + 4},
+			Error:   models.ErrPermissionDenied,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			task, err := useCase.GetTask(context.Background(), test.ArgData[0], test.ArgData[1])
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, test.ExpectedRes, task)
+			}
+		})
+	}
+}
+
+func TestUsecaseUpdateTask(t *testing.T) {
+	var mockTask models.Task
+	err := faker.FakeData(&mockTask)
+	assert.NoError(t, err)
+	mockTask.Done = false
+
+	var ownerID uint64 = 7
+	estimate, done := 0.0, true
+	patch := &models.TaskPatch{ID: mockTask.ID, UserID: ownerID + 1, EstimateHours: &estimate, Done: &done}
+
+	expectedTask := mockTask
+	expectedTask.EstimateHours = 0
+	expectedTask.Done = true
+
+	mockTaskRepo := taskMocks.NewRepositoryI(t)
+	mockProjectRepo := projectMocks.NewRepositoryI(t)
+
+	mockProjectRepo.On("GetProject", mock.Anything, mockTask.ProjectID).Return(&models.Project{ID: mockTask.ProjectID}, nil)
+	onMembers(mockProjectRepo, projectMembers(ownerID))
+
+	mockTaskRepo.On("GetTask", mock.Anything, mockTask.ID).Return(func(context.Context, uint64) *models.Task {
+		task := mockTask
+		return &task
+	}, nil)
+	mockTaskRepo.On("UpdateTask", mock.Anything, &expectedTask).Return(nil).Once()
+
+	useCase := usecase.New(mockTaskRepo, mockProjectRepo)
+
+	cases := map[string]TestCaseUpdateTask{
+		"editor clears the estimate and finishes the task": {
+			ArgData:     patch,
+			ExpectedRes: &expectedTask,
+			Error:       nil,
+		},
+		"viewer": {
+			ArgData: &models.TaskPatch{ID: mockTask.ID, UserID: ownerID + 2, Done: &done},
+			Error:   models.ErrPermissionDenied,
+		},
+		"Version mismatch": {
+			ArgData: &models.TaskPatch{ID: mockTask.ID, UserID: ownerID, Version: mockTask.Version + 1},
+			Error:   models.ErrVersionMismatch,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			task, err := useCase.UpdateTask(context.Background(), test.ArgData)
+			require.Equal(t, test.Error, errors.Cause(err))
+
+			if err == nil {
+				assert.Equal(t, test.ExpectedRes, task)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package delivery
+
+import (
+	"net/http"
+	trashUsecase "timetracker/internal/Trash/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+	"timetracker/models/dto"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Delivery struct {
+	TrashUC trashUsecase.UsecaseI
+}
+
+// GetMyTrash godoc
+// @Summary      Get my trash
+// @Description  Get my deleted entries, projects, tags and goals, the last deleted first. They can be restored with
+// @Description  POST /api/v1/{entries,projects,tags,goals}/{id}/restore until the purge removes them for good.
+// @Tags     trash
+// @Produce  application/json
+// @Success  200 {object} pkg.Response{body=dto.RespTrash} "success get trash"
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/me/trash [get]
+func (delivery *Delivery) GetMyTrash(c echo.Context) error {
+	userId, ok := c.Get("user_id").(uint64)
+	if !ok {
+		c.Logger().Error("can't parse context user_id")
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	trash, err := delivery.TrashUC.GetTrash(c.Request().Context(), userId)
+
+	if err != nil {
+		c.Logger().Error(err)
+		return apierror.From(err)
+	}
+
+	return c.JSON(http.StatusOK, pkg.Response{Body: *dto.GetResponseFromModelTrash(trash)})
+}
+
+func NewDelivery(e *echo.Echo, tu trashUsecase.UsecaseI) {
+	handler := &Delivery{
+		TrashUC: tu,
+	}
+
+	v1 := e.Group(middleware.APIv1)
+	v1.GET("/me/trash", handler.GetMyTrash)
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	entryUsecase "timetracker/internal/Entry/usecase"
+	goalUsecase "timetracker/internal/Goal/usecase"
+	projectUsecase "timetracker/internal/Project/usecase"
+	tagUsecase "timetracker/internal/Tag/usecase"
+	"timetracker/internal/tracing"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+type UsecaseI interface {
+	GetTrash(ctx context.Context, userID uint64) (*models.Trash, error)
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type usecase struct {
+	entryUsecase   entryUsecase.UsecaseI
+	projectUsecase projectUsecase.UsecaseI
+	tagUsecase     tagUsecase.UsecaseI
+	goalUsecase    goalUsecase.UsecaseI
+	retention      time.Duration
+}
+
+func New(eUC entryUsecase.UsecaseI, pUC projectUsecase.UsecaseI, tUC tagUsecase.UsecaseI, gUC goalUsecase.UsecaseI, retention time.Duration) UsecaseI {
+	return &usecase{
+		entryUsecase:   eUC,
+		projectUsecase: pUC,
+		tagUsecase:     tUC,
+		goalUsecase:    gUC,
+		retention:      retention,
+	}
+}
+
+func (u *usecase) GetTrash(ctx context.Context, userID uint64) (*models.Trash, error) {
+	ctx, span := tracing.Start(ctx, "trash.Usecase.GetTrash")
+	defer span.End()
+
+	var trash models.Trash
+	var err error
+
+	trash.Entries, err = u.entryUsecase.GetUserDeletedEntries(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func trash.Usecase.GetTrash")
+	}
+
+	trash.Projects, err = u.projectUsecase.GetUserDeletedProjects(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func trash.Usecase.GetTrash")
+	}
+
+	trash.Tags, err = u.tagUsecase.GetUserDeletedTags(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func trash.Usecase.GetTrash")
+	}
+
+	trash.Goals, err = u.goalUsecase.GetUserDeletedGoals(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error in func trash.Usecase.GetTrash")
+	}
+
+	return &trash, nil
+}
+
+// PurgeExpired removes the items deleted longer than the retention period ago for good.
+// Zero retention keeps the trash forever.
+func (u *usecase) PurgeExpired(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "trash.Usecase.PurgeExpired")
+	defer span.End()
+
+	if u.retention <= 0 {
+		return 0, nil
+	}
+
+	before := time.Now().UTC().Add(-u.retention)
+	purges := []func(ctx context.Context, before time.Time) (int64, error){
+		u.entryUsecase.PurgeDeletedEntries,
+		u.goalUsecase.PurgeDeletedGoals,
+		u.tagUsecase.PurgeDeletedTags,
+		// last, the entries and goals of a purged project go with it
+		u.projectUsecase.PurgeDeletedProjects,
+	}
+
+	var purged int64
+	for _, purge := range purges {
+		count, err := purge(ctx, before)
+		if err != nil {
+			return purged, errors.Wrap(err, "Error in func trash.Usecase.PurgeExpired")
+		}
+		purged += count
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,111 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	entryMocks "timetracker/internal/Entry/repository/mocks"
+	entryUsecase "timetracker/internal/Entry/usecase"
+	goalMocks "timetracker/internal/Goal/repository/mocks"
+	goalUsecase "timetracker/internal/Goal/usecase"
+	projectMocks "timetracker/internal/Project/repository/mocks"
+	projectUsecase "timetracker/internal/Project/usecase"
+	tagMocks "timetracker/internal/Tag/repository/mocks"
+	tagUsecase "timetracker/internal/Tag/usecase"
+	taskMocks "timetracker/internal/Task/repository/mocks"
+	"timetracker/internal/Trash/usecase"
+	userMocks "timetracker/internal/User/repository/mocks"
+	"timetracker/models"
+)
+
+type trashMocks struct {
+	entry   *entryMocks.RepositoryI
+	project *projectMocks.RepositoryI
+	tag     *tagMocks.RepositoryI
+	goal    *goalMocks.RepositoryI
+}
+
+func newTrashUsecase(t *testing.T, retention time.Duration) (usecase.UsecaseI, trashMocks) {
+	m := trashMocks{
+		entry:   entryMocks.NewRepositoryI(t),
+		project: projectMocks.NewRepositoryI(t),
+		tag:     tagMocks.NewRepositoryI(t),
+		goal:    goalMocks.NewRepositoryI(t),
+	}
+
+	useCase := usecase.New(
+		entryUsecase.New(m.entry, m.tag, userMocks.NewRepositoryI(t), m.project, taskMocks.NewRepositoryI(t), nil),
+		projectUsecase.New(m.project, nil, nil, nil),
+		tagUsecase.New(m.tag),
+		goalUsecase.New(m.goal, m.project),
+		retention)
+
+	return useCase, m
+}
+
+func TestUsecaseGetTrash(t *testing.T) {
+	userID := uint64(1)
+	deletedAt := time.Now().UTC()
+	entry := &models.Entry{ID: 2, UserID: &userID, DeletedAt: &deletedAt}
+	project := &models.Project{ID: 3, UserID: &userID, DeletedAt: &deletedAt}
+	tag := &models.Tag{ID: 4, UserID: userID, DeletedAt: &deletedAt}
+
+	useCase, m := newTrashUsecase(t, time.Hour)
+	m.entry.On("GetUserDeletedEntries", mock.Anything, userID).Return([]*models.Entry{entry}, nil)
+	m.tag.On("GetEntryTags", mock.Anything, entry.ID).Return([]*models.Tag{}, nil)
+	m.project.On("GetUserDeletedProjects", mock.Anything, userID).Return([]*models.Project{project}, nil)
+	m.tag.On("GetUserDeletedTags", mock.Anything, userID).Return([]*models.Tag{tag}, nil)
+	m.goal.On("GetUserDeletedGoals", mock.Anything, userID).Return([]*models.Goal{}, nil)
+
+	trash, err := useCase.GetTrash(context.Background(), userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*models.Entry{entry}, trash.Entries)
+	assert.Equal(t, []*models.Project{project}, trash.Projects)
+	assert.Equal(t, []*models.Tag{tag}, trash.Tags)
+	assert.Empty(t, trash.Goals)
+}
+
+func TestUsecasePurgeExpired(t *testing.T) {
+	retention := 24 * time.Hour
+	errDB := errors.New("database error")
+
+	expectedBefore := mock.MatchedBy(func(before time.Time) bool {
+		cutoff := time.Now().UTC().Add(-retention)
+		return !before.After(cutoff) && before.After(cutoff.Add(-time.Minute))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		useCase, m := newTrashUsecase(t, retention)
+		m.entry.On("PurgeDeletedEntries", mock.Anything, expectedBefore).Return(int64(3), nil)
+		m.goal.On("PurgeDeletedGoals", mock.Anything, expectedBefore).Return(int64(0), nil)
+		m.tag.On("PurgeDeletedTags", mock.Anything, expectedBefore).Return(int64(1), nil)
+		m.project.On("PurgeDeletedProjects", mock.Anything, expectedBefore).Return(int64(2), nil)
+
+		purged, err := useCase.PurgeExpired(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), purged)
+	})
+
+	t.Run("error stops the purge", func(t *testing.T) {
+		useCase, m := newTrashUsecase(t, retention)
+		m.entry.On("PurgeDeletedEntries", mock.Anything, expectedBefore).Return(int64(3), nil)
+		m.goal.On("PurgeDeletedGoals", mock.Anything, expectedBefore).Return(int64(0), errDB)
+
+		purged, err := useCase.PurgeExpired(context.Background())
+		assert.ErrorIs(t, err, errDB)
+		assert.Equal(t, int64(3), purged)
+	})
+
+	t.Run("zero retention keeps the trash", func(t *testing.T) {
+		useCase, _ := newTrashUsecase(t, 0)
+
+		purged, err := useCase.PurgeExpired(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+	})
+}
@@ -4,10 +4,10 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/pkg/errors"
-
 	userUsecase "timetracker/internal/User/usecase"
+	"timetracker/internal/apierror"
 	"timetracker/internal/middleware"
+	"timetracker/internal/policy"
 	"timetracker/models"
 	"timetracker/models/dto"
 	"timetracker/pkg"
@@ -26,23 +26,23 @@ type Delivery struct {
 // @Produce  application/json
 // @Param id path int true "User ID"
 // @Success  200 {object} pkg.Response{body=dto.RespUser} "success get user"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 404 {object} echo.HTTPError "can't find user with such id"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /users/{user_id} [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 404 {object} apierror.Error "not_found: can't find user with such id"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/users/{user_id} [get]
 func (del *Delivery) GetUser(c echo.Context) error {
 	id, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.From(models.ErrBadRequest)
 	}
-	user, err := del.UserUC.GetUser(id)
+	user, err := del.UserUC.GetUser(c.Request().Context(), id)
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
 	respUser := dto.GetResponseFromModelUser(user)
@@ -52,22 +52,24 @@ func (del *Delivery) GetUser(c echo.Context) error {
 
 // GetUsers godoc
 // @Summary      GetUsers
-// @Description  get all users. Acl: admin
+// @Description  get all users. Acl: user:list
 // @Tags     users
 // @Produce  application/json
 // @Success  200 {object} pkg.Response{body=[]dto.RespUser} "success get users"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /users [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/users [get]
 func (del *Delivery) GetUsers(c echo.Context) error {
-	users, err := del.UserUC.GetUsers()
+	users, err := del.UserUC.GetUsers(c.Request().Context())
 
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
 
+	middleware.Audit(c, &models.AuditRecord{Action: models.AuditAdminRead, Details: string(models.PermUserList) + " GET /users"})
+
 	respUsers := dto.GetResponseFromModelUsers(users)
 
 	return c.JSON(http.StatusOK, pkg.Response{Body: respUsers})
@@ -79,15 +81,15 @@ func (del *Delivery) GetUsers(c echo.Context) error {
 // @Tags     users
 // @Produce  application/json
 // @Success  200 {object} pkg.Response{body=dto.RespUser} "success get users"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Router   /me [get]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Router   /api/v1/me [get]
 func (del *Delivery) GetMe(c echo.Context) error {
 	user, ok := c.Get("user").(*models.User)
 	if !ok {
 		c.Logger().Error("can't get user from context")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
 	}
 
 	respUser := dto.GetResponseFromModelUser(user)
@@ -96,61 +98,63 @@ func (del *Delivery) GetMe(c echo.Context) error {
 
 // UpdateUser godoc
 // @Summary      UpdateUser
-// @Description  update user's profile. Acl: user(owner account)
+// @Description  update user's profile. Acl: user(owner account), changing the role needs user:role:change
 // @Tags     users
 // @Accept	 application/json
 // @Produce  application/json
 // @Param user body dto.ReqUpdateUser true "user data"
 // @Success  204 "success update"
-// @Failure 405 {object} echo.HTTPError "Method Not Allowed"
-// @Failure 400 {object} echo.HTTPError "bad request"
-// @Failure 500 {object} echo.HTTPError "internal server error"
-// @Failure 404 {object} echo.HTTPError "can't find user with such id"
-// @Failure 401 {object} echo.HTTPError "no cookie"
-// @Failure 403 {object} echo.HTTPError "invalid csrf"
-// @Router   /me/edit [put]
+// @Failure 405 {object} apierror.Error "method_not_allowed"
+// @Failure 400 {object} apierror.Error "bad_request, invalid_body or validation_failed with the failed fields"
+// @Failure 500 {object} apierror.Error "internal_error"
+// @Failure 404 {object} apierror.Error "not_found: can't find user with such id"
+// @Failure 401 {object} apierror.Error "unauthorized: no cookie"
+// @Failure 403 {object} apierror.Error "invalid_csrf"
+// @Router   /api/v1/me [patch]
 func (del *Delivery) UpdateUser(c echo.Context) error {
 	var reqUser dto.ReqUpdateUser
 	err := c.Bind(&reqUser)
 	if err != nil {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.Bind(err)
 	}
 
 	if ok, err := pkg.IsRequestValid(&reqUser); !ok {
 		c.Logger().Error(err)
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
+		return apierror.Validation(err)
 	}
 
 	user, ok := c.Get("user").(*models.User)
 	if !ok {
 		c.Logger().Error("can't get user from context")
-		return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
+		return apierror.From(models.ErrInternalServerError)
+	}
+
+	if reqUser.Role != "" && reqUser.Role != user.Role {
+		if _, ok := models.RoleFromString(reqUser.Role); !ok {
+			c.Logger().Error("unknown role ", reqUser.Role)
+			return apierror.From(models.ErrBadRequest)
+		}
+
+		if !policy.HasPermission(user.Role, models.PermUserRoleChange) {
+			c.Logger().Errorf("Error: user %d has no permission %s", user.ID, models.PermUserRoleChange)
+			return apierror.From(models.ErrPermissionDenied)
+		}
 	}
 
 	modelUser := reqUser.ToModelUser()
 	modelUser.ID = user.ID
 
-	err = del.UserUC.UpdateUser(modelUser)
+	err = del.UserUC.UpdateUser(c.Request().Context(), modelUser)
 	if err != nil {
 		c.Logger().Error(err)
-		return handleError(err)
+		return apierror.From(err)
 	}
-	return c.NoContent(http.StatusNoContent)
-}
 
-func handleError(err error) *echo.HTTPError {
-	causeErr := errors.Cause(err)
-	switch {
-	case errors.Is(causeErr, models.ErrNotFound):
-		return echo.NewHTTPError(http.StatusNotFound, models.ErrNotFound.Error())
-	case errors.Is(causeErr, models.ErrBadRequest):
-		return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-	case errors.Is(causeErr, models.ErrPermissionDenied):
-		return echo.NewHTTPError(http.StatusForbidden, models.ErrPermissionDenied.Error())
-	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, causeErr.Error())
+	if reqUser.Role != "" && reqUser.Role != user.Role {
+		middleware.AuditUser(c, models.AuditRoleChange, user.ID, user.Role+" -> "+reqUser.Role)
 	}
+	return c.NoContent(http.StatusNoContent)
 }
 
 func NewDelivery(e *echo.Echo, uc userUsecase.UsecaseI, aclM *middleware.AclMiddleware) {
@@ -158,8 +162,15 @@ func NewDelivery(e *echo.Echo, uc userUsecase.UsecaseI, aclM *middleware.AclMidd
 		UserUC: uc,
 	}
 
-	e.GET("/users/:user_id", handler.GetUser, aclM.FriendsOrAdminOnly)
-	e.GET("/me", handler.GetMe)
-	e.GET("/users", handler.GetUsers, aclM.AdminOnly)
-	e.PUT("/me/edit", handler.UpdateUser)
+	v1 := e.Group(middleware.APIv1)
+	v1.GET("/users/:user_id", handler.GetUser, aclM.FriendsOrPermission(models.PermUserReadAny))
+	v1.GET("/me", handler.GetMe)
+	v1.GET("/users", handler.GetUsers, aclM.RequirePermission(models.PermUserList))
+	v1.PATCH("/me", handler.UpdateUser)
+
+	// the routes from before /api/v1
+	e.GET("/users/:user_id", handler.GetUser, middleware.Deprecated(middleware.APIv1+"/users/:user_id"), aclM.FriendsOrPermission(models.PermUserReadAny))
+	e.GET("/me", handler.GetMe, middleware.Deprecated(middleware.APIv1+"/me"))
+	e.GET("/users", handler.GetUsers, middleware.Deprecated(middleware.APIv1+"/users"), aclM.RequirePermission(models.PermUserList))
+	e.PUT("/me/edit", handler.UpdateUser, middleware.Deprecated(middleware.APIv1+"/me"))
 }
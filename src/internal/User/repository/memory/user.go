@@ -0,0 +1,338 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"timetracker/internal/User/repository"
+	memoryDB "timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+// rowOverhead approximates the fixed part of a row for the storage usage
+const rowOverhead = 64
+
+func copyUser(u *models.User) *models.User {
+	return &models.User{
+		ID:          u.ID,
+		Name:        u.Name,
+		Email:       u.Email,
+		About:       u.About,
+		Role:        u.Role,
+		Password:    u.Password,
+		Suspended:   u.Suspended,
+		DeleteAfter: memoryDB.CopyTime(u.DeleteAfter),
+	}
+}
+
+func copyUserWithoutPassword(u *models.User) *models.User {
+	user := copyUser(u)
+	user.Password = ""
+	return user
+}
+
+type userRepository struct {
+	db *memoryDB.DB
+}
+
+func (ur userRepository) CreateUser(ctx context.Context, user *models.User) error {
+	ur.db.Lock()
+	defer ur.db.Unlock()
+
+	if _, err := ur.findByEmail(user.Email); err == nil {
+		return errors.Wrap(memoryDB.ErrConstraint, "database error (table user)")
+	}
+
+	row := &memoryDB.User{User: *copyUser(user)}
+	row.ID = ur.db.NextID("users")
+	if row.Role == "" {
+		row.Role = models.DefaultUser.String()
+	}
+	ur.db.Users[row.ID] = row
+
+	user.ID = row.ID
+	return nil
+}
+
+// UpdateUser skips zero fields like gorm Updates does
+func (ur userRepository) UpdateUser(ctx context.Context, user *models.User) error {
+	ur.db.Lock()
+	defer ur.db.Unlock()
+
+	row, ok := ur.db.Users[user.ID]
+	if !ok {
+		return models.ErrNotFound
+	}
+
+	if user.Email != "" && user.Email != row.Email {
+		if _, err := ur.findByEmail(user.Email); err == nil {
+			return errors.Wrap(memoryDB.ErrConstraint, "database error (table user)")
+		}
+		row.Email = user.Email
+	}
+	if user.Name != "" {
+		row.Name = user.Name
+	}
+	if user.About != "" {
+		row.About = user.About
+	}
+	if user.Role != "" {
+		row.Role = user.Role
+	}
+	if user.Password != "" {
+		row.Password = user.Password
+	}
+	if user.Suspended {
+		row.Suspended = user.Suspended
+	}
+	if user.DeleteAfter != nil {
+		row.DeleteAfter = memoryDB.CopyTime(user.DeleteAfter)
+	}
+
+	return nil
+}
+
+func (ur userRepository) GetUser(ctx context.Context, id uint64) (*models.User, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	row, ok := ur.db.Users[id]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	return copyUser(&row.User), nil
+}
+
+func (ur userRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	row, err := ur.findByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	return copyUser(&row.User), nil
+}
+
+func (ur userRepository) findByEmail(email string) (*memoryDB.User, error) {
+	for _, row := range ur.db.Users {
+		if row.Email == email {
+			return row, nil
+		}
+	}
+
+	return nil, models.ErrNotFound
+}
+
+func (ur userRepository) GetUsers(ctx context.Context) ([]*models.User, error) {
+	return ur.findUsers(func(row *memoryDB.User) bool {
+		return true
+	}), nil
+}
+
+func (ur userRepository) GetUsersByIDs(ctx context.Context, userIDs []uint64) ([]*models.User, error) {
+	ids := make(map[uint64]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		ids[id] = struct{}{}
+	}
+
+	return ur.findUsers(func(row *memoryDB.User) bool {
+		_, ok := ids[row.ID]
+		return ok
+	}), nil
+}
+
+func (ur userRepository) SearchUsers(ctx context.Context, params *models.UserSearchParams) ([]*models.User, uint64, error) {
+	query := strings.ToLower(params.Query)
+
+	users := ur.findUsers(func(row *memoryDB.User) bool {
+		if query != "" && !strings.Contains(strings.ToLower(row.Name), query) &&
+			!strings.Contains(strings.ToLower(row.Email), query) {
+			return false
+		}
+		if params.Role != "" && row.Role != params.Role {
+			return false
+		}
+		if params.Suspended != nil && row.Suspended != *params.Suspended {
+			return false
+		}
+		return true
+	})
+
+	total := uint64(len(users))
+	if params.Offset >= len(users) {
+		return []*models.User{}, total, nil
+	}
+
+	users = users[params.Offset:]
+	if params.Limit > 0 && params.Limit < len(users) {
+		users = users[:params.Limit]
+	}
+
+	return users, total, nil
+}
+
+// findUsers returns the matching users without passwords ordered by id
+func (ur userRepository) findUsers(match func(row *memoryDB.User) bool) []*models.User {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	users := make([]*models.User, 0, 10)
+	for _, row := range ur.db.Users {
+		if match(row) {
+			users = append(users, copyUserWithoutPassword(&row.User))
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+
+	return users
+}
+
+func (ur userRepository) SetUserSuspended(ctx context.Context, userID uint64, suspended bool) error {
+	ur.db.Lock()
+	defer ur.db.Unlock()
+
+	row, ok := ur.db.Users[userID]
+	if !ok {
+		return models.ErrNotFound
+	}
+
+	row.Suspended = suspended
+	return nil
+}
+
+// DeleteUser removes the user with all the owned rows
+func (ur userRepository) DeleteUser(ctx context.Context, userID uint64) error {
+	ur.db.Lock()
+	defer ur.db.Unlock()
+
+	if !ur.db.DeleteUser(userID) {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetUserUsage estimates the storage by the length of the text fields,
+// the tombstones only count in the storage
+func (ur userRepository) GetUserUsage(ctx context.Context, userID uint64) (*models.UserUsage, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	usage := models.UserUsage{UserID: userID}
+
+	for _, entry := range ur.db.Entries {
+		if *entry.UserID == userID {
+			if entry.DeletedAt == nil {
+				usage.Entries++
+			}
+			usage.StorageBytes += rowOverhead + uint64(len(entry.Description))
+		}
+	}
+	for _, project := range ur.db.Projects {
+		if *project.UserID == userID {
+			if project.DeletedAt == nil {
+				usage.Projects++
+			}
+			usage.StorageBytes += rowOverhead + uint64(len(project.Name)+len(project.About)+len(project.Color))
+		}
+	}
+	for _, tag := range ur.db.Tags {
+		if tag.UserID == userID {
+			if tag.DeletedAt == nil {
+				usage.Tags++
+			}
+			usage.StorageBytes += rowOverhead + uint64(len(tag.Name)+len(tag.About)+len(tag.Color))
+		}
+	}
+	for _, goal := range ur.db.Goals {
+		if *goal.UserID == userID {
+			if goal.DeletedAt == nil {
+				usage.Goals++
+			}
+			usage.StorageBytes += rowOverhead + uint64(len(goal.Name)+len(goal.Description))
+		}
+	}
+
+	return &usage, nil
+}
+
+// SetPasswordResetToken drops the current password, so the account can only be
+// signed in to again after the token is redeemed.
+func (ur userRepository) SetPasswordResetToken(ctx context.Context, userID uint64, tokenHash string, expire time.Time) error {
+	ur.db.Lock()
+	defer ur.db.Unlock()
+
+	row, ok := ur.db.Users[userID]
+	if !ok {
+		return models.ErrNotFound
+	}
+
+	row.Password = ""
+	row.PasswordResetToken = tokenHash
+	row.PasswordResetExpire = expire
+	return nil
+}
+
+func (ur userRepository) ResetPassword(ctx context.Context, tokenHash string, password string) (uint64, error) {
+	ur.db.Lock()
+	defer ur.db.Unlock()
+
+	now := time.Now()
+	for _, row := range ur.db.Users {
+		if row.PasswordResetToken != "" && row.PasswordResetToken == tokenHash && row.PasswordResetExpire.After(now) {
+			row.Password = password
+			row.PasswordResetToken = ""
+			row.PasswordResetExpire = time.Time{}
+			return row.ID, nil
+		}
+	}
+
+	return 0, models.ErrNotFound
+}
+
+// ScheduleDeletion sets the moment of the hard deletion of the account, nil cancels it.
+func (ur userRepository) ScheduleDeletion(ctx context.Context, userID uint64, deleteAfter *time.Time) error {
+	ur.db.Lock()
+	defer ur.db.Unlock()
+
+	row, ok := ur.db.Users[userID]
+	if !ok {
+		return models.ErrNotFound
+	}
+
+	row.DeleteAfter = memoryDB.CopyTime(deleteAfter)
+	return nil
+}
+
+func (ur userRepository) GetUsersDueForDeletion(ctx context.Context, now time.Time) ([]uint64, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	userIDs := make([]uint64, 0, 10)
+	for _, row := range ur.db.Users {
+		if row.DeleteAfter != nil && !row.DeleteAfter.After(now) {
+			userIDs = append(userIDs, row.ID)
+		}
+	}
+
+	sort.Slice(userIDs, func(i, j int) bool {
+		return userIDs[i] < userIDs[j]
+	})
+
+	return userIDs, nil
+}
+
+func NewUserRepository(db *memoryDB.DB) repository.RepositoryI {
+	return &userRepository{
+		db: db,
+	}
+}
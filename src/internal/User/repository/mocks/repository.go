@@ -3,9 +3,13 @@
 package mocks
 
 import (
+	context "context"
+
 	models "timetracker/models"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // RepositoryI is an autogenerated mock type for the RepositoryI type
@@ -13,13 +17,13 @@ type RepositoryI struct {
 	mock.Mock
 }
 
-// CreateUser provides a mock function with given fields: user
-func (_m *RepositoryI) CreateUser(user *models.User) error {
-	ret := _m.Called(user)
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *RepositoryI) CreateUser(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,25 +31,39 @@ func (_m *RepositoryI) CreateUser(user *models.User) error {
 	return r0
 }
 
-// GetUser provides a mock function with given fields: id
-func (_m *RepositoryI) GetUser(id uint64) (*models.User, error) {
-	ret := _m.Called(id)
+// DeleteUser provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) DeleteUser(ctx context.Context, userID uint64) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUser provides a mock function with given fields: ctx, id
+func (_m *RepositoryI) GetUser(ctx context.Context, id uint64) (*models.User, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 *models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) (*models.User, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.User, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) *models.User); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.User); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -53,25 +71,25 @@ func (_m *RepositoryI) GetUser(id uint64) (*models.User, error) {
 	return r0, r1
 }
 
-// GetUserByEmail provides a mock function with given fields: email
-func (_m *RepositoryI) GetUserByEmail(email string) (*models.User, error) {
-	ret := _m.Called(email)
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *RepositoryI) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	ret := _m.Called(ctx, email)
 
 	var r0 *models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*models.User, error)); ok {
-		return rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, email)
 	}
-	if rf, ok := ret.Get(0).(func(string) *models.User); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, email)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(email)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -79,25 +97,77 @@ func (_m *RepositoryI) GetUserByEmail(email string) (*models.User, error) {
 	return r0, r1
 }
 
-// GetUsers provides a mock function with given fields:
-func (_m *RepositoryI) GetUsers() ([]*models.User, error) {
-	ret := _m.Called()
+// GetUserUsage provides a mock function with given fields: ctx, userID
+func (_m *RepositoryI) GetUserUsage(ctx context.Context, userID uint64) (*models.UserUsage, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *models.UserUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*models.UserUsage, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *models.UserUsage); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserUsage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsersDueForDeletion provides a mock function with given fields: ctx, now
+func (_m *RepositoryI) GetUsersDueForDeletion(ctx context.Context, now time.Time) ([]uint64, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 []uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]uint64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []uint64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: ctx
+func (_m *RepositoryI) GetUsers(ctx context.Context) ([]*models.User, error) {
+	ret := _m.Called(ctx)
 
 	var r0 []*models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*models.User, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.User, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []*models.User); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.User); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,25 +175,49 @@ func (_m *RepositoryI) GetUsers() ([]*models.User, error) {
 	return r0, r1
 }
 
-// GetUsersByIDs provides a mock function with given fields: userIDs
-func (_m *RepositoryI) GetUsersByIDs(userIDs []uint64) ([]*models.User, error) {
-	ret := _m.Called(userIDs)
+// GetUsersByIDs provides a mock function with given fields: ctx, userIDs
+func (_m *RepositoryI) GetUsersByIDs(ctx context.Context, userIDs []uint64) ([]*models.User, error) {
+	ret := _m.Called(ctx, userIDs)
 
 	var r0 []*models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]uint64) ([]*models.User, error)); ok {
-		return rf(userIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, []uint64) ([]*models.User, error)); ok {
+		return rf(ctx, userIDs)
 	}
-	if rf, ok := ret.Get(0).(func([]uint64) []*models.User); ok {
-		r0 = rf(userIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, []uint64) []*models.User); ok {
+		r0 = rf(ctx, userIDs)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func([]uint64) error); ok {
-		r1 = rf(userIDs)
+	if rf, ok := ret.Get(1).(func(context.Context, []uint64) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: ctx, tokenHash, password
+func (_m *RepositoryI) ResetPassword(ctx context.Context, tokenHash string, password string) (uint64, error) {
+	ret := _m.Called(ctx, tokenHash, password)
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (uint64, error)); ok {
+		return rf(ctx, tokenHash, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) uint64); ok {
+		r0 = rf(ctx, tokenHash, password)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tokenHash, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -131,13 +225,88 @@ func (_m *RepositoryI) GetUsersByIDs(userIDs []uint64) ([]*models.User, error) {
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: user
-func (_m *RepositoryI) UpdateUser(user *models.User) error {
-	ret := _m.Called(user)
+// ScheduleDeletion provides a mock function with given fields: ctx, userID, deleteAfter
+func (_m *RepositoryI) ScheduleDeletion(ctx context.Context, userID uint64, deleteAfter *time.Time) error {
+	ret := _m.Called(ctx, userID, deleteAfter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, *time.Time) error); ok {
+		r0 = rf(ctx, userID, deleteAfter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchUsers provides a mock function with given fields: ctx, params
+func (_m *RepositoryI) SearchUsers(ctx context.Context, params *models.UserSearchParams) ([]*models.User, uint64, error) {
+	ret := _m.Called(ctx, params)
+
+	var r0 []*models.User
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserSearchParams) ([]*models.User, uint64, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserSearchParams) []*models.User); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserSearchParams) uint64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.UserSearchParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetPasswordResetToken provides a mock function with given fields: ctx, userID, tokenHash, expire
+func (_m *RepositoryI) SetPasswordResetToken(ctx context.Context, userID uint64, tokenHash string, expire time.Time) error {
+	ret := _m.Called(ctx, userID, tokenHash, expire)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, tokenHash, expire)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserSuspended provides a mock function with given fields: ctx, userID, suspended
+func (_m *RepositoryI) SetUserSuspended(ctx context.Context, userID uint64, suspended bool) error {
+	ret := _m.Called(ctx, userID, suspended)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, bool) error); ok {
+		r0 = rf(ctx, userID, suspended)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user
+func (_m *RepositoryI) UpdateUser(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
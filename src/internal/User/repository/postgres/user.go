@@ -1,7 +1,10 @@
 package postgres
 
 import (
-	"fmt"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
 	"timetracker/internal/User/repository"
 	"timetracker/models"
 
@@ -10,12 +13,16 @@ import (
 )
 
 type User struct {
-	ID       uint64 `gorm:"column:id"`
-	Name     string `gorm:"column:name"`
-	Email    string `gorm:"column:email"`
-	About    string `gorm:"column:about"`
-	Role     string `gorm:"column:role"`
-	Password string `gorm:"column:password"`
+	ID                  uint64     `gorm:"column:id"`
+	Name                string     `gorm:"column:name"`
+	Email               string     `gorm:"column:email"`
+	About               string     `gorm:"column:about"`
+	Role                string     `gorm:"column:role"`
+	Password            string     `gorm:"column:password"`
+	Suspended           bool       `gorm:"column:suspended"`
+	PasswordResetToken  *string    `gorm:"column:password_reset_token"`
+	PasswordResetExpire *time.Time `gorm:"column:password_reset_expire"`
+	DeleteAfter         *time.Time `gorm:"column:delete_after"`
 }
 
 func (User) TableName() string {
@@ -24,23 +31,27 @@ func (User) TableName() string {
 
 func toPostgresUser(u *models.User) *User {
 	return &User{
-		ID:       u.ID,
-		Name:     u.Name,
-		Email:    u.Email,
-		About:    u.About,
-		Role:     u.Role,
-		Password: u.Password,
+		ID:          u.ID,
+		Name:        u.Name,
+		Email:       u.Email,
+		About:       u.About,
+		Role:        u.Role,
+		Password:    u.Password,
+		Suspended:   u.Suspended,
+		DeleteAfter: u.DeleteAfter,
 	}
 }
 
 func toModelUser(u *User) *models.User {
 	return &models.User{
-		ID:       u.ID,
-		Name:     u.Name,
-		Email:    u.Email,
-		About:    u.About,
-		Role:     u.Role,
-		Password: u.Password,
+		ID:          u.ID,
+		Name:        u.Name,
+		Email:       u.Email,
+		About:       u.About,
+		Role:        u.Role,
+		Password:    u.Password,
+		Suspended:   u.Suspended,
+		DeleteAfter: u.DeleteAfter,
 	}
 }
 
@@ -54,14 +65,36 @@ func toModelUsers(entries []*User) []*models.User {
 	return out
 }
 
+// postgresUsageQuery counts the rows that are not deleted, the tombstones still take storage
+const postgresUsageQuery = `SELECT
+	(SELECT count(*) FROM entry WHERE user_id = @id AND deleted_at IS NULL) AS entries,
+	(SELECT count(*) FROM project WHERE user_id = @id AND deleted_at IS NULL) AS projects,
+	(SELECT count(*) FROM tag WHERE user_id = @id AND deleted_at IS NULL) AS tags,
+	(SELECT count(*) FROM goal WHERE user_id = @id AND deleted_at IS NULL) AS goals,
+	(SELECT coalesce(sum(pg_column_size(e.*)), 0) FROM entry e WHERE e.user_id = @id) +
+	(SELECT coalesce(sum(pg_column_size(p.*)), 0) FROM project p WHERE p.user_id = @id) +
+	(SELECT coalesce(sum(pg_column_size(t.*)), 0) FROM tag t WHERE t.user_id = @id) +
+	(SELECT coalesce(sum(pg_column_size(g.*)), 0) FROM goal g WHERE g.user_id = @id) AS storage_bytes`
+
+// sqliteUsageQuery has no row size function, the storage is the length of the text columns
+const sqliteUsageQuery = `SELECT
+	(SELECT count(*) FROM entry WHERE user_id = @id AND deleted_at IS NULL) AS entries,
+	(SELECT count(*) FROM project WHERE user_id = @id AND deleted_at IS NULL) AS projects,
+	(SELECT count(*) FROM tag WHERE user_id = @id AND deleted_at IS NULL) AS tags,
+	(SELECT count(*) FROM goal WHERE user_id = @id AND deleted_at IS NULL) AS goals,
+	(SELECT coalesce(sum(length(description)), 0) FROM entry WHERE user_id = @id) +
+	(SELECT coalesce(sum(length(name) + length(about) + length(color)), 0) FROM project WHERE user_id = @id) +
+	(SELECT coalesce(sum(length(name) + length(about) + length(color)), 0) FROM tag WHERE user_id = @id) +
+	(SELECT coalesce(sum(length(name) + length(description)), 0) FROM goal WHERE user_id = @id) AS storage_bytes`
+
 type userRepository struct {
 	db *gorm.DB
 }
 
-func (ur userRepository) CreateUser(user *models.User) error {
+func (ur userRepository) CreateUser(ctx context.Context, user *models.User) error {
 	postgresUser := toPostgresUser(user)
 
-	tx := ur.db.Create(postgresUser)
+	tx := ur.db.WithContext(ctx).Create(postgresUser)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table user)")
@@ -71,10 +104,10 @@ func (ur userRepository) CreateUser(user *models.User) error {
 	return nil
 }
 
-func (ur userRepository) UpdateUser(user *models.User) error {
+func (ur userRepository) UpdateUser(ctx context.Context, user *models.User) error {
 	postgresUser := toPostgresUser(user)
 
-	tx := ur.db.Omit("id").Updates(postgresUser)
+	tx := ur.db.WithContext(ctx).Omit("id").Updates(postgresUser)
 
 	if tx.Error != nil {
 		return errors.Wrap(tx.Error, "database error (table user)")
@@ -83,10 +116,10 @@ func (ur userRepository) UpdateUser(user *models.User) error {
 	return nil
 }
 
-func (ur userRepository) GetUser(id uint64) (*models.User, error) {
+func (ur userRepository) GetUser(ctx context.Context, id uint64) (*models.User, error) {
 	var user User
 
-	tx := ur.db.Where(&User{ID: id}).Take(&user)
+	tx := ur.db.WithContext(ctx).Where(&User{ID: id}).Take(&user)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
@@ -97,11 +130,9 @@ func (ur userRepository) GetUser(id uint64) (*models.User, error) {
 	return toModelUser(&user), nil
 }
 
-func (ur userRepository) GetUserByEmail(email string) (*models.User, error) {
+func (ur userRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user User
-	fmt.Println("email", email)
-
-	tx := ur.db.Where(&User{Email: email}).Take(&user)
+	tx := ur.db.WithContext(ctx).Where(&User{Email: email}).Take(&user)
 
 	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
 		return nil, models.ErrNotFound
@@ -112,9 +143,9 @@ func (ur userRepository) GetUserByEmail(email string) (*models.User, error) {
 	return toModelUser(&user), nil
 }
 
-func (ur userRepository) GetUsers() ([]*models.User, error) {
+func (ur userRepository) GetUsers(ctx context.Context) ([]*models.User, error) {
 	users := make([]*User, 0, 10)
-	tx := ur.db.Omit("password").Find(&users)
+	tx := ur.db.WithContext(ctx).Omit("password").Find(&users)
 
 	if tx.Error != nil {
 		return nil, errors.Wrap(tx.Error, "database error (table users)")
@@ -123,9 +154,9 @@ func (ur userRepository) GetUsers() ([]*models.User, error) {
 	return toModelUsers(users), nil
 }
 
-func (ur userRepository) GetUsersByIDs(userIDs []uint64) ([]*models.User, error) {
+func (ur userRepository) GetUsersByIDs(ctx context.Context, userIDs []uint64) ([]*models.User, error) {
 	users := make([]*User, 0, 10)
-	tx := ur.db.Omit("password").Find(&users, userIDs)
+	tx := ur.db.WithContext(ctx).Omit("password").Find(&users, userIDs)
 
 	if tx.Error != nil {
 		return nil, errors.Wrap(tx.Error, "database error (table users)")
@@ -134,6 +165,160 @@ func (ur userRepository) GetUsersByIDs(userIDs []uint64) ([]*models.User, error)
 	return toModelUsers(users), nil
 }
 
+func (ur userRepository) SearchUsers(ctx context.Context, params *models.UserSearchParams) ([]*models.User, uint64, error) {
+	query := ur.db.WithContext(ctx).Model(&User{})
+
+	if params.Query != "" {
+		pattern := "%" + escapeLike(strings.ToLower(params.Query)) + "%"
+		query = query.Where(`LOWER(name) LIKE ? ESCAPE '\' OR LOWER(email) LIKE ? ESCAPE '\'`, pattern, pattern)
+	}
+
+	if params.Role != "" {
+		query = query.Where("role = ?", params.Role)
+	}
+
+	if params.Suspended != nil {
+		query = query.Where("suspended = ?", *params.Suspended)
+	}
+
+	var total int64
+	tx := query.Count(&total)
+	if tx.Error != nil {
+		return nil, 0, errors.Wrap(tx.Error, "database error (table users)")
+	}
+
+	users := make([]*User, 0, params.Limit)
+	tx = query.Omit("password").Order("id").Limit(params.Limit).Offset(params.Offset).Find(&users)
+	if tx.Error != nil {
+		return nil, 0, errors.Wrap(tx.Error, "database error (table users)")
+	}
+
+	return toModelUsers(users), uint64(total), nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (ur userRepository) SetUserSuspended(ctx context.Context, userID uint64, suspended bool) error {
+	tx := ur.db.WithContext(ctx).Model(&User{}).Where("id = ?", userID).Update("suspended", suspended)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table users)")
+	}
+
+	if tx.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteUser removes the user, the owned rows are removed by ON DELETE CASCADE.
+func (ur userRepository) DeleteUser(ctx context.Context, userID uint64) error {
+	tx := ur.db.WithContext(ctx).Delete(&User{}, userID)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table users)")
+	}
+
+	if tx.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ur userRepository) GetUserUsage(ctx context.Context, userID uint64) (*models.UserUsage, error) {
+	usageQuery := postgresUsageQuery
+	if ur.db.Dialector.Name() == "sqlite" {
+		usageQuery = sqliteUsageQuery
+	}
+
+	usage := models.UserUsage{UserID: userID}
+	tx := ur.db.WithContext(ctx).Raw(usageQuery, sql.Named("id", userID)).Scan(&usage)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table users)")
+	}
+
+	return &usage, nil
+}
+
+// SetPasswordResetToken drops the current password, so the account can only be
+// signed in to again after the token is redeemed.
+func (ur userRepository) SetPasswordResetToken(ctx context.Context, userID uint64, tokenHash string, expire time.Time) error {
+	tx := ur.db.WithContext(ctx).Model(&User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"password":              "",
+		"password_reset_token":  tokenHash,
+		"password_reset_expire": expire,
+	})
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table users)")
+	}
+
+	if tx.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+// ResetPassword redeems the token once. The update only matches while the token is still set,
+// so of two concurrent requests with the same token the second one gets ErrNotFound.
+func (ur userRepository) ResetPassword(ctx context.Context, tokenHash string, password string) (uint64, error) {
+	var user User
+
+	tx := ur.db.WithContext(ctx).Where("password_reset_token = ? AND password_reset_expire > ?", tokenHash, time.Now()).Take(&user)
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return 0, models.ErrNotFound
+	} else if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "database error (table users)")
+	}
+
+	tx = ur.db.WithContext(ctx).Model(&User{}).Where("id = ? AND password_reset_token = ?", user.ID, tokenHash).Updates(map[string]interface{}{
+		"password":              password,
+		"password_reset_token":  nil,
+		"password_reset_expire": nil,
+	})
+	if tx.Error != nil {
+		return 0, errors.Wrap(tx.Error, "database error (table users)")
+	}
+
+	if tx.RowsAffected == 0 {
+		return 0, models.ErrNotFound
+	}
+
+	return user.ID, nil
+}
+
+// ScheduleDeletion sets the moment of the hard deletion of the account, nil cancels it.
+func (ur userRepository) ScheduleDeletion(ctx context.Context, userID uint64, deleteAfter *time.Time) error {
+	tx := ur.db.WithContext(ctx).Model(&User{}).Where("id = ?", userID).Update("delete_after", deleteAfter)
+
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "database error (table users)")
+	}
+
+	if tx.RowsAffected == 0 {
+		return models.ErrNotFound
+	}
+
+	return nil
+}
+
+func (ur userRepository) GetUsersDueForDeletion(ctx context.Context, now time.Time) ([]uint64, error) {
+	userIDs := make([]uint64, 0, 10)
+	tx := ur.db.WithContext(ctx).Model(&User{}).Where("delete_after <= ?", now).Pluck("id", &userIDs)
+
+	if tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "database error (table users)")
+	}
+
+	return userIDs, nil
+}
+
 func NewUserRepository(db *gorm.DB) repository.RepositoryI {
 	return &userRepository{
 		db: db,
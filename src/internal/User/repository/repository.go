@@ -1,14 +1,24 @@
 package repository
 
 import (
+	"context"
+	"time"
 	"timetracker/models"
 )
 
 type RepositoryI interface {
-	CreateUser(user *models.User) error
-	UpdateUser(user *models.User) error
-	GetUser(id uint64) (*models.User, error)
-	GetUsers() ([]*models.User, error)
-	GetUserByEmail(email string) (*models.User, error)
-	GetUsersByIDs(userIDs []uint64) ([]*models.User, error)
+	CreateUser(ctx context.Context, user *models.User) error
+	UpdateUser(ctx context.Context, user *models.User) error
+	GetUser(ctx context.Context, id uint64) (*models.User, error)
+	GetUsers(ctx context.Context) ([]*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	GetUsersByIDs(ctx context.Context, userIDs []uint64) ([]*models.User, error)
+	SearchUsers(ctx context.Context, params *models.UserSearchParams) ([]*models.User, uint64, error)
+	SetUserSuspended(ctx context.Context, userID uint64, suspended bool) error
+	DeleteUser(ctx context.Context, userID uint64) error
+	GetUserUsage(ctx context.Context, userID uint64) (*models.UserUsage, error)
+	SetPasswordResetToken(ctx context.Context, userID uint64, tokenHash string, expire time.Time) error
+	ResetPassword(ctx context.Context, tokenHash string, password string) (uint64, error)
+	ScheduleDeletion(ctx context.Context, userID uint64, deleteAfter *time.Time) error
+	GetUsersDueForDeletion(ctx context.Context, now time.Time) ([]uint64, error)
 }
@@ -1,7 +1,9 @@
 package usecase
 
 import (
+	"context"
 	userRep "timetracker/internal/User/repository"
+	"timetracker/internal/tracing"
 	"timetracker/models"
 
 	"github.com/pkg/errors"
@@ -9,17 +11,20 @@ import (
 )
 
 type UsecaseI interface {
-	UpdateUser(e *models.User) error
-	GetUser(id uint64) (*models.User, error)
-	GetUsers() ([]*models.User, error)
+	UpdateUser(ctx context.Context, e *models.User) error
+	GetUser(ctx context.Context, id uint64) (*models.User, error)
+	GetUsers(ctx context.Context) ([]*models.User, error)
 }
 
 type usecase struct {
 	userRepository userRep.RepositoryI
 }
 
-func (u *usecase) UpdateUser(user *models.User) error {
-	_, err := u.userRepository.GetUser(user.ID)
+func (u *usecase) UpdateUser(ctx context.Context, user *models.User) error {
+	ctx, span := tracing.Start(ctx, "user.Usecase.UpdateUser")
+	defer span.End()
+
+	_, err := u.userRepository.GetUser(ctx, user.ID)
 	if err != nil {
 		return errors.Wrap(err, "user repository error")
 	}
@@ -33,7 +38,7 @@ func (u *usecase) UpdateUser(user *models.User) error {
 		user.Password = string(hashedPassword)
 	}
 
-	err = u.userRepository.UpdateUser(user)
+	err = u.userRepository.UpdateUser(ctx, user)
 	if err != nil {
 		return errors.Wrap(err, "Error in func user.Usecase.UpdateUser")
 	}
@@ -41,8 +46,11 @@ func (u *usecase) UpdateUser(user *models.User) error {
 	return nil
 }
 
-func (u *usecase) GetUser(id uint64) (*models.User, error) {
-	user, err := u.userRepository.GetUser(id)
+func (u *usecase) GetUser(ctx context.Context, id uint64) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "user.Usecase.GetUser")
+	defer span.End()
+
+	user, err := u.userRepository.GetUser(ctx, id)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func user.Usecase.GetUser")
 	}
@@ -50,8 +58,11 @@ func (u *usecase) GetUser(id uint64) (*models.User, error) {
 	return user, nil
 }
 
-func (u *usecase) GetUsers() ([]*models.User, error) {
-	users, err := u.userRepository.GetUsers()
+func (u *usecase) GetUsers(ctx context.Context) ([]*models.User, error) {
+	ctx, span := tracing.Start(ctx, "user.Usecase.GetUsers")
+	defer span.End()
+
+	users, err := u.userRepository.GetUsers(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "Error in func user.Usecase.GetUsers")
 	}
@@ -1,9 +1,11 @@
 package usecase_test
 
 import (
+	"context"
 	"github.com/bxcodec/faker"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"testing"
 	userMocks "timetracker/internal/User/repository/mocks"
@@ -32,7 +34,7 @@ func TestUsecaseGetUser(t *testing.T) {
 
 	mockUserRepo := userMocks.NewRepositoryI(t)
 
-	mockUserRepo.On("GetUser", mockUserRes.ID).Return(&mockUserRes, nil)
+	mockUserRepo.On("GetUser", mock.Anything, mockUserRes.ID).Return(&mockUserRes, nil)
 
 	useCase := usecase.New(mockUserRepo)
 
@@ -46,7 +48,7 @@ func TestUsecaseGetUser(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			user, err := useCase.GetUser(test.ArgData)
+			user, err := useCase.GetUser(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, err)
 
 			if err == nil {
@@ -66,10 +68,10 @@ func TestUsecaseUpdateUser(t *testing.T) {
 
 	mockUserRepo := userMocks.NewRepositoryI(t)
 
-	mockUserRepo.On("GetUser", mockUser.ID).Return(&mockUser, nil)
-	mockUserRepo.On("UpdateUser", &mockUser).Return(nil)
+	mockUserRepo.On("GetUser", mock.Anything, mockUser.ID).Return(&mockUser, nil)
+	mockUserRepo.On("UpdateUser", mock.Anything, &mockUser).Return(nil)
 
-	mockUserRepo.On("GetUser", invalidMockUser.ID).Return(nil, models.ErrNotFound)
+	mockUserRepo.On("GetUser", mock.Anything, invalidMockUser.ID).Return(nil, models.ErrNotFound)
 
 	useCase := usecase.New(mockUserRepo)
 
@@ -86,7 +88,7 @@ func TestUsecaseUpdateUser(t *testing.T) {
 
 	for name, test := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := useCase.UpdateUser(test.ArgData)
+			err := useCase.UpdateUser(context.Background(), test.ArgData)
 			require.Equal(t, test.Error, errors.Cause(err))
 		})
 	}
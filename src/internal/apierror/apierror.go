@@ -0,0 +1,240 @@
+package apierror
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/go-playground/validator.v9"
+
+	"timetracker/models"
+)
+
+// Codes are stable, clients switch on them. Messages are for humans and may change.
+const (
+	CodeBadRequest       = "bad_request"
+	CodeInvalidBody      = "invalid_body"
+	CodeValidation       = "validation_failed"
+	CodeUnauthorized     = "unauthorized"
+	CodeInvalidPassword  = "invalid_password"
+	CodeReauthRequired   = "reauth_required"
+	CodePermissionDenied = "permission_denied"
+	CodeInvalidCSRF      = "invalid_csrf"
+	CodeUserSuspended    = "user_suspended"
+	CodeNotFound         = "not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeConflictEmail    = "email_conflict"
+	CodeConflictNickname = "nickname_conflict"
+	CodeConflictFriend   = "friend_conflict"
+	CodeConflictClientID = "client_id_conflict"
+	CodeProjectDeleted   = "project_deleted"
+	CodeProjectArchived  = "project_archived"
+	CodeConflictMember   = "member_conflict"
+	CodeNotFriends       = "not_friends"
+	CodeTaskProject      = "task_project_mismatch"
+	CodeResyncRequired   = "resync_required"
+	CodeVersionMismatch  = "version_mismatch"
+	CodeIfMatchRequired  = "if_match_required"
+	CodeTooManyRequests  = "too_many_requests"
+	CodeInternal         = "internal_error"
+)
+
+// Error is the body of every error response
+type Error struct {
+	Status  int          `json:"-"`
+	Code    string       `json:"code" example:"not_found"`
+	Message string       `json:"message" example:"item is not found"`
+	Fields  []FieldError `json:"fields,omitempty"`
+	// cause is logged, it never gets to the client
+	cause error
+}
+
+// FieldError is a failed validation rule of a request field
+type FieldError struct {
+	Field   string `json:"field" example:"time_start"`
+	Rule    string `json:"rule" example:"required"`
+	Message string `json:"message" example:"is required"`
+}
+
+func New(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Code + ": " + e.cause.Error()
+	}
+
+	return e.Code + ": " + e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// domainErrors maps the errors of models to responses, the message is the text of the error
+var domainErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{models.ErrBadRequest, http.StatusBadRequest, CodeBadRequest},
+	{models.ErrUnauthorized, http.StatusUnauthorized, CodeUnauthorized},
+	{models.ErrInvalidPassword, http.StatusUnauthorized, CodeInvalidPassword},
+	{models.ErrReauthRequired, http.StatusUnauthorized, CodeReauthRequired},
+	{models.ErrPermissionDenied, http.StatusForbidden, CodePermissionDenied},
+	{models.ErrInvalidCSRF, http.StatusForbidden, CodeInvalidCSRF},
+	{models.ErrUserSuspended, http.StatusForbidden, CodeUserSuspended},
+	{models.ErrNotFound, http.StatusNotFound, CodeNotFound},
+	{models.ErrConflictEmail, http.StatusConflict, CodeConflictEmail},
+	{models.ErrConflictNickname, http.StatusConflict, CodeConflictNickname},
+	{models.ErrConflictFriend, http.StatusConflict, CodeConflictFriend},
+	{models.ErrVersionMismatch, http.StatusPreconditionFailed, CodeVersionMismatch},
+	{models.ErrIfMatchRequired, http.StatusPreconditionRequired, CodeIfMatchRequired},
+	{models.ErrClientIDRequired, http.StatusBadRequest, CodeBadRequest},
+	{models.ErrConflictClientID, http.StatusConflict, CodeConflictClientID},
+	{models.ErrProjectDeleted, http.StatusConflict, CodeProjectDeleted},
+	{models.ErrProjectArchived, http.StatusConflict, CodeProjectArchived},
+	{models.ErrConflictMember, http.StatusConflict, CodeConflictMember},
+	{models.ErrNotFriends, http.StatusForbidden, CodeNotFriends},
+	{models.ErrTaskProject, http.StatusConflict, CodeTaskProject},
+	{models.ErrResyncRequired, http.StatusGone, CodeResyncRequired},
+	{models.ErrInternalServerError, http.StatusInternalServerError, CodeInternal},
+}
+
+// statusCodes are the codes of the errors echo returns by itself, e.g. for an unknown route
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          CodeBadRequest,
+	http.StatusUnauthorized:        CodeUnauthorized,
+	http.StatusForbidden:           CodePermissionDenied,
+	http.StatusNotFound:            CodeNotFound,
+	http.StatusMethodNotAllowed:    CodeMethodNotAllowed,
+	http.StatusTooManyRequests:     CodeTooManyRequests,
+	http.StatusInternalServerError: CodeInternal,
+}
+
+// From maps err to its response. Errors that are not known are internal errors,
+// their text stays in the logs.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return Validation(err)
+	}
+
+	for _, domainErr := range domainErrors {
+		if errors.Is(err, domainErr.err) {
+			return &Error{Status: domainErr.status, Code: domainErr.code, Message: domainErr.err.Error(), cause: err}
+		}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Code < http.StatusInternalServerError {
+		code, ok := statusCodes[httpErr.Code]
+		if !ok {
+			code = strings.ReplaceAll(strings.ToLower(http.StatusText(httpErr.Code)), " ", "_")
+		}
+
+		message, ok := httpErr.Message.(string)
+		if !ok {
+			message = strings.ToLower(http.StatusText(httpErr.Code))
+		}
+
+		return &Error{Status: httpErr.Code, Code: code, Message: message, cause: err}
+	}
+
+	return &Error{
+		Status:  http.StatusInternalServerError,
+		Code:    CodeInternal,
+		Message: models.ErrInternalServerError.Error(),
+		cause:   err,
+	}
+}
+
+// Status is the status of the response to err
+func Status(err error) int {
+	return From(err).Status
+}
+
+// Bind is the response to a body that c.Bind can't decode
+func Bind(err error) *Error {
+	message := "invalid request body"
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		if m, ok := httpErr.Message.(string); ok {
+			message = m
+		}
+	}
+
+	return &Error{Status: http.StatusBadRequest, Code: CodeInvalidBody, Message: message, cause: err}
+}
+
+// Validation lists the failed rules of a request that pkg.IsRequestValid rejected
+func Validation(err error) *Error {
+	apiErr := &Error{Status: http.StatusBadRequest, Code: CodeValidation, Message: "validation failed", cause: err}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, fieldErr := range validationErrs {
+			apiErr.Fields = append(apiErr.Fields, FieldError{
+				Field:   fieldErr.Field(),
+				Rule:    fieldErr.Tag(),
+				Message: ruleMessage(fieldErr.Tag(), fieldErr.Param()),
+			})
+		}
+	}
+
+	return apiErr
+}
+
+func ruleMessage(rule, param string) string {
+	switch rule {
+	case "required":
+		return "is required"
+	case "nonzero":
+		return "must not be empty"
+	case "email":
+		return "must be an email"
+	case "min":
+		return "must be at least " + param
+	case "max":
+		return "must be at most " + param
+	case "oneof":
+		return "must be one of " + param
+	}
+
+	if param != "" {
+		return fmt.Sprintf("must satisfy %s=%s", rule, param)
+	}
+	return "must satisfy " + rule
+}
+
+// Handler writes every error as an Error, it is the HTTPErrorHandler of echo
+func Handler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	apiErr := From(err)
+
+	var writeErr error
+	if c.Request().Method == http.MethodHead {
+		writeErr = c.NoContent(apiErr.Status)
+	} else {
+		writeErr = c.JSON(apiErr.Status, apiErr)
+	}
+
+	if writeErr != nil {
+		c.Logger().Error(writeErr)
+	}
+}
@@ -0,0 +1,253 @@
+package apierror_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"timetracker/internal/apierror"
+	"timetracker/models"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		message string
+	}{
+		{
+			name:    "wrapped not found",
+			err:     errors.Wrap(models.ErrNotFound, "Error in func entry.Usecase.GetEntry"),
+			status:  http.StatusNotFound,
+			code:    apierror.CodeNotFound,
+			message: models.ErrNotFound.Error(),
+		},
+		{
+			name:    "conflict email",
+			err:     errors.Wrap(models.ErrConflictEmail, "Error in func auth.Usecase.SignUp"),
+			status:  http.StatusConflict,
+			code:    apierror.CodeConflictEmail,
+			message: models.ErrConflictEmail.Error(),
+		},
+		{
+			name:    "conflict friend",
+			err:     models.ErrConflictFriend,
+			status:  http.StatusConflict,
+			code:    apierror.CodeConflictFriend,
+			message: models.ErrConflictFriend.Error(),
+		},
+		{
+			name:    "invalid password",
+			err:     errors.WithStack(models.ErrInvalidPassword),
+			status:  http.StatusUnauthorized,
+			code:    apierror.CodeInvalidPassword,
+			message: models.ErrInvalidPassword.Error(),
+		},
+		{
+			name:    "version mismatch",
+			err:     errors.Wrap(models.ErrVersionMismatch, "Error in func entry.Usecase.UpdateEntry"),
+			status:  http.StatusPreconditionFailed,
+			code:    apierror.CodeVersionMismatch,
+			message: models.ErrVersionMismatch.Error(),
+		},
+		{
+			name:    "client id conflict",
+			err:     models.ErrConflictClientID,
+			status:  http.StatusConflict,
+			code:    apierror.CodeConflictClientID,
+			message: models.ErrConflictClientID.Error(),
+		},
+		{
+			name:    "restore in a deleted project",
+			err:     errors.Wrap(models.ErrProjectDeleted, "Error in func entry.Usecase.RestoreEntry"),
+			status:  http.StatusConflict,
+			code:    apierror.CodeProjectDeleted,
+			message: models.ErrProjectDeleted.Error(),
+		},
+		{
+			name:    "entry in an archived project",
+			err:     errors.Wrap(models.ErrProjectArchived, "Error in func entry.Usecase.CreateEntry"),
+			status:  http.StatusConflict,
+			code:    apierror.CodeProjectArchived,
+			message: models.ErrProjectArchived.Error(),
+		},
+		{
+			name:    "invite a member twice",
+			err:     errors.Wrap(models.ErrConflictMember, "Error in func project.Usecase.InviteProjectMember"),
+			status:  http.StatusConflict,
+			code:    apierror.CodeConflictMember,
+			message: models.ErrConflictMember.Error(),
+		},
+		{
+			name:    "entry on a task of another project",
+			err:     errors.Wrap(models.ErrTaskProject, "Error in func entry.Usecase.CreateEntry"),
+			status:  http.StatusConflict,
+			code:    apierror.CodeTaskProject,
+			message: models.ErrTaskProject.Error(),
+		},
+		{
+			name:    "sync cursor older than the trash",
+			err:     errors.Wrap(models.ErrResyncRequired, "Error in func sync.Usecase.GetChanges"),
+			status:  http.StatusGone,
+			code:    apierror.CodeResyncRequired,
+			message: models.ErrResyncRequired.Error(),
+		},
+		{
+			name:    "delete a passwordless account without a fresh sign in",
+			err:     models.ErrReauthRequired,
+			status:  http.StatusUnauthorized,
+			code:    apierror.CodeReauthRequired,
+			message: models.ErrReauthRequired.Error(),
+		},
+		{
+			name:    "db error does not leak",
+			err:     errors.Wrap(errors.New(`pq: relation "entry" does not exist`), "Error in func entry.Repository.GetEntry"),
+			status:  http.StatusInternalServerError,
+			code:    apierror.CodeInternal,
+			message: models.ErrInternalServerError.Error(),
+		},
+		{
+			name:    "echo error",
+			err:     echo.ErrMethodNotAllowed,
+			status:  http.StatusMethodNotAllowed,
+			code:    apierror.CodeMethodNotAllowed,
+			message: "Method Not Allowed",
+		},
+		{
+			name:    "echo internal error does not leak",
+			err:     echo.NewHTTPError(http.StatusInternalServerError, "dial tcp: connection refused"),
+			status:  http.StatusInternalServerError,
+			code:    apierror.CodeInternal,
+			message: models.ErrInternalServerError.Error(),
+		},
+		{
+			name:    "api error",
+			err:     apierror.New(http.StatusBadRequest, apierror.CodeBadRequest, "invalid secret_token"),
+			status:  http.StatusBadRequest,
+			code:    apierror.CodeBadRequest,
+			message: "invalid secret_token",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiErr := apierror.From(test.err)
+			assert.Equal(t, test.status, apiErr.Status)
+			assert.Equal(t, test.code, apiErr.Code)
+			assert.Equal(t, test.message, apiErr.Message)
+			assert.Equal(t, test.status, apierror.Status(test.err))
+		})
+	}
+
+	assert.Nil(t, apierror.From(nil))
+}
+
+type reqEntry struct {
+	Description string    `json:"description" validate:"max=5"`
+	TimeStart   time.Time `json:"time_start" validate:"required"`
+}
+
+func TestValidation(t *testing.T) {
+	ok, err := pkg.IsRequestValid(&reqEntry{Description: "too long"})
+	require.False(t, ok)
+
+	apiErr := apierror.From(err)
+	assert.Equal(t, http.StatusBadRequest, apiErr.Status)
+	assert.Equal(t, apierror.CodeValidation, apiErr.Code)
+	assert.Equal(t, []apierror.FieldError{
+		{Field: "description", Rule: "max", Message: "must be at most 5"},
+		{Field: "time_start", Rule: "required", Message: "is required"},
+	}, apiErr.Fields)
+}
+
+type reqPatchEntry struct {
+	Description *string    `json:"description"`
+	TimeStart   *time.Time `json:"time_start" validate:"omitempty,nonzero"`
+	TimeEnd     *time.Time `json:"time_end" validate:"omitempty,nonzero"`
+}
+
+func TestValidationPatch(t *testing.T) {
+	description := ""
+	ok, err := pkg.IsRequestValid(&reqPatchEntry{Description: &description})
+	assert.True(t, ok)
+	assert.NoError(t, err)
+
+	ok, err = pkg.IsRequestValid(&reqPatchEntry{TimeStart: &time.Time{}})
+	require.False(t, ok)
+
+	apiErr := apierror.From(err)
+	assert.Equal(t, apierror.CodeValidation, apiErr.Code)
+	assert.Equal(t, []apierror.FieldError{
+		{Field: "time_start", Rule: "nonzero", Message: "must not be empty"},
+	}, apiErr.Fields)
+}
+
+func TestHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = apierror.Handler
+	e.POST("/entry/create", func(c echo.Context) error {
+		var req reqEntry
+		if err := c.Bind(&req); err != nil {
+			return apierror.Bind(err)
+		}
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/entry/:id", func(c echo.Context) error {
+		return errors.Wrap(models.ErrNotFound, "Error in func entry.Usecase.GetEntry")
+	})
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+		code   string
+	}{
+		{
+			name:   "not found",
+			req:    httptest.NewRequest(http.MethodGet, "/entry/1", nil),
+			status: http.StatusNotFound,
+			code:   apierror.CodeNotFound,
+		},
+		{
+			name:   "unknown route",
+			req:    httptest.NewRequest(http.MethodGet, "/unknown", nil),
+			status: http.StatusNotFound,
+			code:   apierror.CodeNotFound,
+		},
+		{
+			name: "invalid body",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/entry/create", strings.NewReader(`{"time_start": 1`))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				return req
+			}(),
+			status: http.StatusBadRequest,
+			code:   apierror.CodeInvalidBody,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, test.req)
+			assert.Equal(t, test.status, rec.Code)
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, test.code, body["code"])
+			assert.NotEmpty(t, body["message"])
+			assert.NotContains(t, body, "fields")
+		})
+	}
+}
@@ -15,33 +15,32 @@ const (
 )
 
 type CacheStorageI interface {
-	Set(key string, data interface{}) error
-	Get(key string) ([]byte, error)
+	Set(ctx context.Context, key string, data interface{}) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
 }
 
 type StorageRedis struct {
 	db *redis.Client
 
 	ttl time.Duration
-	ctx context.Context
 }
 
 func NewStorageRedis(m *redis.Client) *StorageRedis {
 	storage := StorageRedis{
 		db:  m,
 		ttl: ttl,
-		ctx: context.Background(),
 	}
 
 	return &storage
 }
-func (sr *StorageRedis) Set(key string, data interface{}) error {
+func (sr *StorageRedis) Set(ctx context.Context, key string, data interface{}) error {
 	rawValue, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("fail to marshal data for cache: %w", err)
 	}
 
-	err = sr.db.Set(sr.ctx, key, rawValue, ttl).Err()
+	err = sr.db.Set(ctx, key, rawValue, ttl).Err()
 	if err != nil {
 		return err
 	}
@@ -49,8 +48,8 @@ func (sr *StorageRedis) Set(key string, data interface{}) error {
 	return nil
 }
 
-func (sr *StorageRedis) Get(key string) ([]byte, error) {
-	resp, err := sr.db.Get(sr.ctx, key).Bytes()
+func (sr *StorageRedis) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := sr.db.Get(ctx, key).Bytes()
 	if err != nil && err == redis.Nil{
 		return nil, models.ErrNotFound
 	} else if err != nil {
@@ -59,3 +58,7 @@ func (sr *StorageRedis) Get(key string) ([]byte, error) {
 
 	return resp, nil
 }
+
+func (sr *StorageRedis) Delete(ctx context.Context, key string) error {
+	return sr.db.Del(ctx, key).Err()
+}
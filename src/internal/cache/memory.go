@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+	"timetracker/models"
+)
+
+type memoryItem struct {
+	value  []byte
+	expire time.Time
+}
+
+// StorageMemory keeps the values in the process with the same ttl as StorageRedis.
+// Expired values are dropped on access.
+type StorageMemory struct {
+	mu    sync.RWMutex
+	items map[string]memoryItem
+
+	ttl time.Duration
+}
+
+func NewStorageMemory() *StorageMemory {
+	return &StorageMemory{
+		items: map[string]memoryItem{},
+		ttl:   ttl,
+	}
+}
+
+func (sm *StorageMemory) Set(ctx context.Context, key string, data interface{}) error {
+	rawValue, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("fail to marshal data for cache: %w", err)
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.items[key] = memoryItem{value: rawValue, expire: time.Now().Add(sm.ttl)}
+	return nil
+}
+
+func (sm *StorageMemory) Get(ctx context.Context, key string) ([]byte, error) {
+	sm.mu.RLock()
+	item, ok := sm.items[key]
+	sm.mu.RUnlock()
+
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+
+	if item.expire.Before(time.Now()) {
+		sm.mu.Lock()
+		if current, ok := sm.items[key]; ok && current.expire.Equal(item.expire) {
+			delete(sm.items, key)
+		}
+		sm.mu.Unlock()
+		return nil, models.ErrNotFound
+	}
+
+	return item.value, nil
+}
+
+func (sm *StorageMemory) Delete(ctx context.Context, key string) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	delete(sm.items, key)
+	return nil
+}
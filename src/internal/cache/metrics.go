@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type instrumentedStorage struct {
+	CacheStorageI
+
+	requests *prometheus.CounterVec
+}
+
+// WithMetrics counts the lookups of storage by result: hit, miss or error
+func WithMetrics(storage CacheStorageI, requests *prometheus.CounterVec) CacheStorageI {
+	return &instrumentedStorage{
+		CacheStorageI: storage,
+		requests:      requests,
+	}
+}
+
+func (is *instrumentedStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := is.CacheStorageI.Get(ctx, key)
+
+	switch {
+	case err == nil:
+		is.requests.WithLabelValues("hit").Inc()
+	case errors.Is(err, models.ErrNotFound):
+		is.requests.WithLabelValues("miss").Inc()
+	default:
+		is.requests.WithLabelValues("error").Inc()
+	}
+
+	return value, err
+}
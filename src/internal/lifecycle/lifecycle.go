@@ -0,0 +1,94 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type hook struct {
+	name   string
+	onStop func(ctx context.Context) error
+}
+
+// Lifecycle stops the parts of the application in the reverse order of their registration,
+// like deferred calls: whatever was started last depends on what was started before it.
+// Register the log file and the databases first and the http server last, so requests are
+// drained before the databases they use are closed.
+type Lifecycle struct {
+	mu      sync.Mutex
+	hooks   []hook
+	stopped bool
+	logger  echo.Logger
+}
+
+func New(logger echo.Logger) *Lifecycle {
+	return &Lifecycle{
+		logger: logger,
+	}
+}
+
+// OnStop registers a hook called by Stop. Hooks get the context of Stop,
+// it expires when the shutdown timeout is over.
+func (l *Lifecycle) OnStop(name string, onStop func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.hooks = append(l.hooks, hook{name: name, onStop: onStop})
+}
+
+// Go runs a background worker until Stop reaches it. The context of the worker is
+// canceled on stop, and Stop waits for the worker to return until its own context expires.
+func (l *Lifecycle) Go(name string, worker func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		worker(ctx)
+	}()
+
+	l.OnStop(name, func(stopCtx context.Context) error {
+		cancel()
+
+		select {
+		case <-done:
+			return nil
+		case <-stopCtx.Done():
+			return errors.Wrap(stopCtx.Err(), "worker did not stop in time")
+		}
+	})
+}
+
+// Stop calls every hook once even if some of them fail, and returns the first error.
+// Calling Stop again does nothing.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	if l.stopped {
+		l.mu.Unlock()
+		return nil
+	}
+	l.stopped = true
+	hooks := l.hooks
+	l.mu.Unlock()
+
+	var firstErr error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		err := hooks[i].onStop(ctx)
+		if err != nil {
+			err = errors.Wrapf(err, "can not stop %s", hooks[i].name)
+			l.logger.Error(err)
+
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		l.logger.Info("stopped ", hooks[i].name)
+	}
+
+	return firstErr
+}
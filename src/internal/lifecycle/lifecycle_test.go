@@ -0,0 +1,69 @@
+package lifecycle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"timetracker/internal/lifecycle"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStopOrder(t *testing.T) {
+	lc := lifecycle.New(echo.New().Logger)
+
+	var stopped []string
+	hook := func(name string, err error) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			stopped = append(stopped, name)
+			return err
+		}
+	}
+
+	errDB := errors.New("db error")
+	lc.OnStop("log file", hook("log file", nil))
+	lc.OnStop("db", hook("db", errDB))
+	lc.OnStop("server", hook("server", nil))
+
+	err := lc.Stop(context.Background())
+	assert.ErrorIs(t, err, errDB)
+	assert.Equal(t, []string{"server", "db", "log file"}, stopped)
+
+	assert.NoError(t, lc.Stop(context.Background()))
+	assert.Len(t, stopped, 3)
+}
+
+func TestStopWorker(t *testing.T) {
+	lc := lifecycle.New(echo.New().Logger)
+
+	done := make(chan struct{})
+	lc.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		close(done)
+	})
+
+	assert.NoError(t, lc.Stop(context.Background()))
+
+	select {
+	case <-done:
+	default:
+		t.Error("Stop returned before the worker")
+	}
+}
+
+func TestStopStuckWorker(t *testing.T) {
+	lc := lifecycle.New(echo.New().Logger)
+
+	release := make(chan struct{})
+	defer close(release)
+	lc.Go("worker", func(ctx context.Context) {
+		<-release
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, lc.Stop(ctx), context.DeadlineExceeded)
+}
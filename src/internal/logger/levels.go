@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap/zapcore"
+)
+
+var ErrUnknownLevel = errors.New("unknown log level, expected debug, info, warn or error")
+
+// Levels is the root level and the overrides of single packages, e.g. http or entry.
+// Packages without an override follow the root level. Levels can be changed at runtime.
+type Levels struct {
+	mu       sync.RWMutex
+	root     zapcore.Level
+	packages map[string]zapcore.Level
+}
+
+func NewLevels(root string, packages map[string]string) (*Levels, error) {
+	lv := &Levels{packages: make(map[string]zapcore.Level, len(packages))}
+
+	if err := lv.Set("", root); err != nil {
+		return nil, err
+	}
+
+	for pkg, level := range packages {
+		if err := lv.Set(pkg, level); err != nil {
+			return nil, errors.Wrapf(err, "package %s", pkg)
+		}
+	}
+
+	return lv, nil
+}
+
+func parseLevel(level string) (zapcore.Level, error) {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil || parsed < zapcore.DebugLevel || parsed > zapcore.ErrorLevel {
+		return 0, ErrUnknownLevel
+	}
+
+	return parsed, nil
+}
+
+// Set changes the level of pkg, the root level if pkg is empty.
+// An empty level removes the override of pkg.
+func (lv *Levels) Set(pkg string, level string) error {
+	if pkg != "" && level == "" {
+		lv.mu.Lock()
+		delete(lv.packages, pkg)
+		lv.mu.Unlock()
+		return nil
+	}
+
+	parsed, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	if pkg == "" {
+		lv.root = parsed
+	} else {
+		lv.packages[pkg] = parsed
+	}
+
+	return nil
+}
+
+// Get returns the root level and the overrides
+func (lv *Levels) Get() (string, map[string]string) {
+	lv.mu.RLock()
+	defer lv.mu.RUnlock()
+
+	packages := make(map[string]string, len(lv.packages))
+	for pkg, level := range lv.packages {
+		packages[pkg] = level.String()
+	}
+
+	return lv.root.String(), packages
+}
+
+func (lv *Levels) level(pkg string) zapcore.Level {
+	lv.mu.RLock()
+	defer lv.mu.RUnlock()
+
+	if level, ok := lv.packages[pkg]; ok {
+		return level
+	}
+
+	return lv.root
+}
+
+// enabler is the zapcore.LevelEnabler of pkg
+type enabler struct {
+	levels *Levels
+	pkg    string
+}
+
+func (e enabler) Enabled(level zapcore.Level) bool {
+	return level >= e.levels.level(e.pkg)
+}
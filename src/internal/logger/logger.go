@@ -0,0 +1,259 @@
+package logger
+
+import (
+	"io"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// sink is shared by the root logger and every logger derived from it
+type sink struct {
+	encoder zapcore.Encoder
+	out     zapcore.WriteSyncer
+	writer  io.Writer
+	levels  *Levels
+}
+
+// Logger is a structured logger that also implements echo.Logger, so c.Logger() of a request
+// and the loggers of the jobs write the same JSON lines. The level of a logger is the one
+// of its package, see Named.
+type Logger struct {
+	sink   *sink
+	pkg    string
+	fields []interface{}
+	sugar  *zap.SugaredLogger
+}
+
+// New writes to out in the json or console format
+func New(out io.Writer, format string, levels *Levels) (*Logger, error) {
+	config := zap.NewProductionEncoderConfig()
+	config.TimeKey = "time"
+	config.MessageKey = "message"
+	config.EncodeTime = zapcore.ISO8601TimeEncoder
+	config.EncodeDuration = zapcore.StringDurationEncoder
+
+	var encoder zapcore.Encoder
+	switch format {
+	case "", FormatJSON:
+		encoder = zapcore.NewJSONEncoder(config)
+	case FormatConsole:
+		encoder = zapcore.NewConsoleEncoder(config)
+	default:
+		return nil, errors.Errorf("unknown log format %q, expected %s or %s", format, FormatJSON, FormatConsole)
+	}
+
+	s := &sink{
+		encoder: encoder,
+		out:     zapcore.AddSync(out),
+		writer:  out,
+		levels:  levels,
+	}
+
+	return s.logger("", nil), nil
+}
+
+func (s *sink) logger(pkg string, fields []interface{}) *Logger {
+	core := zapcore.NewCore(s.encoder, s.out, enabler{levels: s.levels, pkg: pkg})
+	// the methods of Logger are one frame between the caller and zap
+	base := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1), zap.AddStacktrace(zapcore.DPanicLevel))
+	if pkg != "" {
+		base = base.Named(pkg)
+	}
+
+	return &Logger{
+		sink:   s,
+		pkg:    pkg,
+		fields: fields,
+		sugar:  base.Sugar().With(fields...),
+	}
+}
+
+// Named is the logger of pkg, it keeps the fields of l
+func (l *Logger) Named(pkg string) *Logger {
+	return l.sink.logger(pkg, l.fields)
+}
+
+// With adds fields, as key value pairs, to every line of the returned logger
+func (l *Logger) With(keysAndValues ...interface{}) *Logger {
+	fields := make([]interface{}, 0, len(l.fields)+len(keysAndValues))
+	fields = append(fields, l.fields...)
+	fields = append(fields, keysAndValues...)
+
+	return l.sink.logger(l.pkg, fields)
+}
+
+func (l *Logger) Levels() *Levels {
+	return l.sink.levels
+}
+
+// Infow logs msg with additional key value pairs
+func (l *Logger) Infow(msg string, keysAndValues ...interface{}) {
+	l.sugar.Infow(msg, keysAndValues...)
+}
+
+func (l *Logger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.sugar.Warnw(msg, keysAndValues...)
+}
+
+func (l *Logger) Errorw(msg string, keysAndValues ...interface{}) {
+	l.sugar.Errorw(msg, keysAndValues...)
+}
+
+func (l *Logger) Sync() error {
+	return l.sugar.Sync()
+}
+
+var _ echo.Logger = (*Logger)(nil)
+
+// The rest is echo.Logger. The output, prefix and header come from the config,
+// so their setters do nothing.
+
+func (l *Logger) Output() io.Writer {
+	return l.sink.writer
+}
+
+func (l *Logger) SetOutput(w io.Writer) {}
+
+func (l *Logger) Prefix() string {
+	return l.pkg
+}
+
+func (l *Logger) SetPrefix(p string) {}
+
+func (l *Logger) SetHeader(h string) {}
+
+var (
+	toEchoLevel = map[zapcore.Level]log.Lvl{
+		zapcore.DebugLevel: log.DEBUG,
+		zapcore.InfoLevel:  log.INFO,
+		zapcore.WarnLevel:  log.WARN,
+		zapcore.ErrorLevel: log.ERROR,
+	}
+	fromEchoLevel = map[log.Lvl]string{
+		log.DEBUG: "debug",
+		log.INFO:  "info",
+		log.WARN:  "warn",
+		log.ERROR: "error",
+	}
+)
+
+// Level of the package of l
+func (l *Logger) Level() log.Lvl {
+	return toEchoLevel[l.sink.levels.level(l.pkg)]
+}
+
+// SetLevel changes the level of the package of l
+func (l *Logger) SetLevel(v log.Lvl) {
+	if level, ok := fromEchoLevel[v]; ok {
+		l.sink.levels.Set(l.pkg, level)
+	}
+}
+
+func jsonFields(j log.JSON) []interface{} {
+	keys := make([]string, 0, len(j))
+	for key := range j {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]interface{}, 0, 2*len(j))
+	for _, key := range keys {
+		fields = append(fields, key, j[key])
+	}
+
+	return fields
+}
+
+func (l *Logger) Print(i ...interface{}) {
+	l.sugar.Info(i...)
+}
+
+func (l *Logger) Printf(format string, args ...interface{}) {
+	l.sugar.Infof(format, args...)
+}
+
+func (l *Logger) Printj(j log.JSON) {
+	l.sugar.Infow("", jsonFields(j)...)
+}
+
+func (l *Logger) Debug(i ...interface{}) {
+	l.sugar.Debug(i...)
+}
+
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.sugar.Debugf(format, args...)
+}
+
+func (l *Logger) Debugj(j log.JSON) {
+	l.sugar.Debugw("", jsonFields(j)...)
+}
+
+func (l *Logger) Info(i ...interface{}) {
+	l.sugar.Info(i...)
+}
+
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.sugar.Infof(format, args...)
+}
+
+func (l *Logger) Infoj(j log.JSON) {
+	l.sugar.Infow("", jsonFields(j)...)
+}
+
+func (l *Logger) Warn(i ...interface{}) {
+	l.sugar.Warn(i...)
+}
+
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.sugar.Warnf(format, args...)
+}
+
+func (l *Logger) Warnj(j log.JSON) {
+	l.sugar.Warnw("", jsonFields(j)...)
+}
+
+func (l *Logger) Error(i ...interface{}) {
+	l.sugar.Error(i...)
+}
+
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.sugar.Errorf(format, args...)
+}
+
+func (l *Logger) Errorj(j log.JSON) {
+	l.sugar.Errorw("", jsonFields(j)...)
+}
+
+func (l *Logger) Fatal(i ...interface{}) {
+	l.sugar.Fatal(i...)
+}
+
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.sugar.Fatalf(format, args...)
+}
+
+func (l *Logger) Fatalj(j log.JSON) {
+	l.sugar.Fatalw("", jsonFields(j)...)
+}
+
+func (l *Logger) Panic(i ...interface{}) {
+	l.sugar.Panic(i...)
+}
+
+func (l *Logger) Panicf(format string, args ...interface{}) {
+	l.sugar.Panicf(format, args...)
+}
+
+func (l *Logger) Panicj(j log.JSON) {
+	l.sugar.Panicw("", jsonFields(j)...)
+}
@@ -0,0 +1,134 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"timetracker/internal/logger"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLogger(t *testing.T, root string, packages map[string]string) (*logger.Logger, *bytes.Buffer) {
+	levels, err := logger.NewLevels(root, packages)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	log, err := logger.New(&buf, logger.FormatJSON, levels)
+	require.NoError(t, err)
+
+	return log, &buf
+}
+
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &fields), line)
+		result = append(result, fields)
+	}
+	return result
+}
+
+func TestLevels(t *testing.T) {
+	_, err := logger.NewLevels("verbose", nil)
+	assert.ErrorIs(t, err, logger.ErrUnknownLevel)
+
+	_, err = logger.NewLevels("info", map[string]string{"http": "fatal"})
+	assert.ErrorIs(t, err, logger.ErrUnknownLevel)
+
+	levels, err := logger.NewLevels("info", map[string]string{"http": "warn"})
+	require.NoError(t, err)
+
+	root, packages := levels.Get()
+	assert.Equal(t, "info", root)
+	assert.Equal(t, map[string]string{"http": "warn"}, packages)
+
+	require.NoError(t, levels.Set("", "error"))
+	require.NoError(t, levels.Set("entry", "debug"))
+	require.NoError(t, levels.Set("http", ""))
+
+	root, packages = levels.Get()
+	assert.Equal(t, "error", root)
+	assert.Equal(t, map[string]string{"entry": "debug"}, packages)
+}
+
+func TestLoggerPackageLevels(t *testing.T) {
+	log, buf := newLogger(t, "warn", map[string]string{"entry": "debug"})
+
+	log.Info("dropped")
+	log.Named("entry").With("user_id", 7).Debug("entry debug")
+	log.Named("tag").Info("dropped")
+	log.Named("tag").Warnf("tag %s", "warning")
+
+	// the root level changed at runtime applies to the packages without an override
+	require.NoError(t, log.Levels().Set("", "info"))
+	log.Named("tag").Info("tag info")
+
+	result := lines(t, buf)
+	require.Len(t, result, 3)
+
+	assert.Equal(t, "entry debug", result[0]["message"])
+	assert.Equal(t, "debug", result[0]["level"])
+	assert.Equal(t, "entry", result[0]["logger"])
+	assert.Equal(t, float64(7), result[0]["user_id"])
+	assert.Contains(t, result[0]["caller"], "logger_test.go")
+
+	assert.Equal(t, "tag warning", result[1]["message"])
+	assert.Equal(t, "tag info", result[2]["message"])
+}
+
+func TestMiddleware(t *testing.T) {
+	log, buf := newLogger(t, "info", nil)
+
+	e := echo.New()
+	e.Use(echoMiddleware.RequestID())
+	e.Use(logger.Middleware(e, log))
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", uint64(3))
+			logger.AddFields(c, "user_id", uint64(3))
+			return next(c)
+		}
+	})
+	e.GET("/entry/:id", func(c echo.Context) error {
+		c.Logger().Error("can't find entry")
+		return echo.NewHTTPError(http.StatusNotFound)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/entry/1?token=secret", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	result := lines(t, buf)
+	require.Len(t, result, 2)
+
+	requestID := rec.Header().Get(echo.HeaderXRequestID)
+	require.NotEmpty(t, requestID)
+
+	handlerLine, accessLine := result[0], result[1]
+	assert.Equal(t, "can't find entry", handlerLine["message"])
+	// the handler of the test is in timetracker/internal/logger_test
+	assert.Equal(t, "logger_test", handlerLine["logger"])
+	assert.Equal(t, requestID, handlerLine["request_id"])
+	assert.Equal(t, "/entry/:id", handlerLine["route"])
+	assert.Equal(t, float64(3), handlerLine["user_id"])
+
+	assert.Equal(t, "request", accessLine["message"])
+	assert.Equal(t, logger.PackageHTTP, accessLine["logger"])
+	assert.Equal(t, requestID, accessLine["request_id"])
+	assert.Equal(t, float64(http.StatusNotFound), accessLine["status"])
+	assert.Equal(t, "/entry/1", accessLine["path"])
+	assert.Equal(t, float64(3), accessLine["user_id"])
+	assert.NotContains(t, buf.String(), "secret")
+}
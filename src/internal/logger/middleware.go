@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"timetracker/internal/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PackageHTTP logs the requests, and the routes outside of the internal packages
+const PackageHTTP = "http"
+
+// routePackages maps a route to the package of its handler, e.g. entry for
+// timetracker/internal/Entry/delivery. It is built on the first request, when every route is added.
+type routePackages struct {
+	once     sync.Once
+	e        *echo.Echo
+	packages map[string]string
+}
+
+func (rp *routePackages) of(c echo.Context) string {
+	rp.once.Do(func() {
+		rp.packages = make(map[string]string)
+		for _, route := range rp.e.Routes() {
+			rp.packages[route.Method+" "+route.Path] = handlerPackage(route.Name)
+		}
+	})
+
+	if pkg, ok := rp.packages[c.Request().Method+" "+c.Path()]; ok {
+		return pkg
+	}
+
+	return PackageHTTP
+}
+
+// handlerPackage turns timetracker/internal/Entry/delivery.(*Delivery).CreateEntry-fm into entry
+func handlerPackage(handlerName string) string {
+	const internal = "/internal/"
+
+	i := strings.Index(handlerName, internal)
+	if i < 0 {
+		return PackageHTTP
+	}
+
+	pkg := handlerName[i+len(internal):]
+	if end := strings.IndexAny(pkg, "/."); end >= 0 {
+		pkg = pkg[:end]
+	}
+
+	return strings.ToLower(pkg)
+}
+
+// Middleware gives every request a logger of the package of its handler with the request ID,
+// the route and the trace ID, it is c.Logger(), and writes an access log line when the request is done.
+// It goes after the RequestID and the tracing middlewares.
+func Middleware(e *echo.Echo, root *Logger) echo.MiddlewareFunc {
+	access := root.Named(PackageHTTP)
+	packages := &routePackages{e: e}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			request := c.Request()
+
+			fields := []interface{}{
+				"request_id", c.Response().Header().Get(echo.HeaderXRequestID),
+				"method", request.Method,
+				"route", c.Path(),
+			}
+			if traceID := tracing.TraceID(request.Context()); traceID != "" {
+				fields = append(fields, "trace_id", traceID)
+			}
+			c.SetLogger(root.Named(packages.of(c)).With(fields...))
+
+			err := next(c)
+			if err != nil {
+				// writes the error response, so the status below is the one sent
+				c.Error(err)
+			}
+
+			fields = append(fields,
+				"path", request.URL.Path,
+				"status", c.Response().Status,
+				"latency", time.Since(start),
+				"remote_ip", c.RealIP(),
+				"bytes_out", c.Response().Size,
+			)
+			if userID, ok := c.Get("user_id").(uint64); ok {
+				fields = append(fields, "user_id", userID)
+			}
+
+			if err != nil {
+				fields = append(fields, "error", err.Error())
+			}
+
+			if c.Response().Status >= http.StatusInternalServerError {
+				access.Errorw("request", fields...)
+			} else {
+				access.Infow("request", fields...)
+			}
+
+			return err
+		}
+	}
+}
+
+// AddFields adds key value pairs to the logger of the request, e.g. the user ID once it is known
+func AddFields(c echo.Context, keysAndValues ...interface{}) {
+	if l, ok := c.Logger().(*Logger); ok {
+		c.SetLogger(l.With(keysAndValues...))
+	}
+}
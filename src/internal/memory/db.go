@@ -0,0 +1,459 @@
+package memory
+
+import (
+	"sync"
+	"time"
+	"timetracker/models"
+
+	"github.com/pkg/errors"
+)
+
+// ErrConstraint is the in-memory counterpart of a violated unique or foreign key constraint
+var ErrConstraint = errors.New("constraint violation")
+
+// User is a row of the users table, the reset token is never exposed by models.User
+type User struct {
+	models.User
+	PasswordResetToken  string
+	PasswordResetExpire time.Time
+}
+
+type FriendRelation struct {
+	SubscriberID uint64
+	UserID       uint64
+}
+
+// ProjectMemberKey is the primary key of the project_member table
+type ProjectMemberKey struct {
+	ProjectID uint64
+	UserID    uint64
+}
+
+// SyncClientKey is the primary key of the sync_client_id table
+type SyncClientKey struct {
+	UserID   uint64
+	ClientID string
+}
+
+type Session struct {
+	UserID     uint64
+	ExpireTime time.Time
+	CSRFToken  string
+}
+
+// DB is a set of tables shared by the in-memory repositories, the counterpart of *gorm.DB.
+// Repositories hold the lock for the whole operation, so every method is atomic.
+// Rows are stored as copies and must be copied again before they leave a repository.
+type DB struct {
+	sync.RWMutex
+
+	Users      map[uint64]*User
+	Projects   map[uint64]*models.Project
+	Clients    map[uint64]*models.Client
+	Tasks      map[uint64]*models.Task
+	Entries    map[uint64]*models.Entry
+	Tags       map[uint64]*models.Tag
+	TagEntries map[uint64]map[uint64]struct{}
+	Goals      map[uint64]*models.Goal
+	Friends    map[FriendRelation]struct{}
+	Sessions   map[string]*Session
+	Identities map[uint64]*models.ExternalIdentity
+	AuditLog   map[uint64]*models.AuditRecord
+	Exports    map[uint64]*models.DataExport
+	ClientIDs  map[SyncClientKey]*models.SyncClientID
+	Members    map[ProjectMemberKey]*models.ProjectMember
+	Removals   map[ProjectMemberKey]time.Time
+	Confirms   map[string]*models.Confirmation
+
+	sequences map[string]uint64
+}
+
+func NewDB() *DB {
+	return &DB{
+		Users:      map[uint64]*User{},
+		Projects:   map[uint64]*models.Project{},
+		Clients:    map[uint64]*models.Client{},
+		Tasks:      map[uint64]*models.Task{},
+		Entries:    map[uint64]*models.Entry{},
+		Tags:       map[uint64]*models.Tag{},
+		TagEntries: map[uint64]map[uint64]struct{}{},
+		Goals:      map[uint64]*models.Goal{},
+		Friends:    map[FriendRelation]struct{}{},
+		Sessions:   map[string]*Session{},
+		Identities: map[uint64]*models.ExternalIdentity{},
+		AuditLog:   map[uint64]*models.AuditRecord{},
+		Exports:    map[uint64]*models.DataExport{},
+		ClientIDs:  map[SyncClientKey]*models.SyncClientID{},
+		Members:    map[ProjectMemberKey]*models.ProjectMember{},
+		Removals:   map[ProjectMemberKey]time.Time{},
+		Confirms:   map[string]*models.Confirmation{},
+		sequences:  map[string]uint64{},
+	}
+}
+
+// NextID returns the next identity of the table, the caller must hold the write lock
+func (db *DB) NextID(table string) uint64 {
+	db.sequences[table]++
+	return db.sequences[table]
+}
+
+// AddProjectHours is the update_total_count_hours trigger: sign is 1 for an added
+// entry and -1 for a removed one, a tombstone doesn't count. The caller must hold the write lock.
+func (db *DB) AddProjectHours(entry *models.Entry, sign float64) {
+	if entry.ProjectID == nil || entry.DeletedAt != nil {
+		return
+	}
+
+	project, ok := db.Projects[*entry.ProjectID]
+	if !ok {
+		return
+	}
+
+	project.TotalCountHours += sign * entry.TimeEnd.Sub(entry.TimeStart).Hours()
+	project.UpdatedAt = time.Now().UTC()
+}
+
+// DeleteUser removes the user with every row referencing it, like ON DELETE CASCADE.
+// The caller must hold the write lock.
+func (db *DB) DeleteUser(id uint64) bool {
+	if _, ok := db.Users[id]; !ok {
+		return false
+	}
+
+	for entryID, entry := range db.Entries {
+		if *entry.UserID == id {
+			db.DeleteEntry(entryID)
+		}
+	}
+	for projectID, project := range db.Projects {
+		if *project.UserID == id {
+			db.DeleteProject(projectID)
+		}
+	}
+	for clientID, client := range db.Clients {
+		if client.UserID == id {
+			delete(db.Clients, clientID)
+		}
+	}
+	for tagID, tag := range db.Tags {
+		if tag.UserID == id {
+			db.DeleteTag(tagID)
+		}
+	}
+	for goalID, goal := range db.Goals {
+		if *goal.UserID == id {
+			delete(db.Goals, goalID)
+		}
+	}
+	for relation := range db.Friends {
+		if relation.SubscriberID == id || relation.UserID == id {
+			delete(db.Friends, relation)
+		}
+	}
+	for token, session := range db.Sessions {
+		if session.UserID == id {
+			delete(db.Sessions, token)
+		}
+	}
+	for token, confirmation := range db.Confirms {
+		if confirmation.UserID == id {
+			delete(db.Confirms, token)
+		}
+	}
+	for identityID, identity := range db.Identities {
+		if identity.UserID == id {
+			delete(db.Identities, identityID)
+		}
+	}
+	for exportID, export := range db.Exports {
+		if export.UserID == id {
+			delete(db.Exports, exportID)
+		}
+	}
+	for key := range db.ClientIDs {
+		if key.UserID == id {
+			delete(db.ClientIDs, key)
+		}
+	}
+	for key, member := range db.Members {
+		if key.UserID == id {
+			delete(db.Members, key)
+		} else if member.InvitedBy != nil && *member.InvitedBy == id {
+			member.InvitedBy = nil
+		}
+	}
+	for key := range db.Removals {
+		if key.UserID == id {
+			delete(db.Removals, key)
+		}
+	}
+
+	delete(db.Users, id)
+	return true
+}
+
+// DeleteProject removes the project with its entries, goals, tasks, members and their removals.
+// The caller must hold the write lock.
+func (db *DB) DeleteProject(id uint64) bool {
+	if _, ok := db.Projects[id]; !ok {
+		return false
+	}
+
+	for entryID, entry := range db.Entries {
+		if entry.ProjectID != nil && *entry.ProjectID == id {
+			db.DeleteEntry(entryID)
+		}
+	}
+	for goalID, goal := range db.Goals {
+		if goal.ProjectID != nil && *goal.ProjectID == id {
+			delete(db.Goals, goalID)
+		}
+	}
+	for taskID, task := range db.Tasks {
+		if task.ProjectID == id {
+			delete(db.Tasks, taskID)
+		}
+	}
+	for key := range db.Members {
+		if key.ProjectID == id {
+			delete(db.Members, key)
+		}
+	}
+	for key := range db.Removals {
+		if key.ProjectID == id {
+			delete(db.Removals, key)
+		}
+	}
+
+	delete(db.Projects, id)
+	return true
+}
+
+// AddProjectOwner makes the user of the project its owner member.
+// The caller must hold the write lock.
+func (db *DB) AddProjectOwner(project *models.Project) {
+	db.Members[ProjectMemberKey{ProjectID: project.ID, UserID: *project.UserID}] = models.NewProjectOwner(project.ID, *project.UserID, project.UpdatedAt)
+}
+
+// DeleteEntry removes the entry with its tag relations and updates the project hours.
+// The caller must hold the write lock.
+func (db *DB) DeleteEntry(id uint64) bool {
+	entry, ok := db.Entries[id]
+	if !ok {
+		return false
+	}
+
+	db.AddProjectHours(entry, -1)
+	delete(db.TagEntries, id)
+	delete(db.Entries, id)
+	return true
+}
+
+// DeleteTask removes the task, its entries lose it with a new version like in the
+// postgres repository. The caller must hold the write lock.
+func (db *DB) DeleteTask(id uint64, now time.Time) bool {
+	if _, ok := db.Tasks[id]; !ok {
+		return false
+	}
+
+	for _, entry := range db.Entries {
+		if entry.TaskID != nil && *entry.TaskID == id {
+			entry.TaskID = nil
+			entry.Version++
+			entry.UpdatedAt = now
+		}
+	}
+
+	delete(db.Tasks, id)
+	return true
+}
+
+// DeleteTag removes the tag with its entry relations.
+// The caller must hold the write lock.
+func (db *DB) DeleteTag(id uint64) bool {
+	if _, ok := db.Tags[id]; !ok {
+		return false
+	}
+
+	for _, tagIDs := range db.TagEntries {
+		delete(tagIDs, id)
+	}
+
+	delete(db.Tags, id)
+	return true
+}
+
+// SoftDeleteProject leaves a tombstone of the project and of its entries and goals.
+// The caller must hold the write lock.
+func (db *DB) SoftDeleteProject(id uint64, now time.Time) bool {
+	project, ok := db.Projects[id]
+	if !ok || project.DeletedAt != nil {
+		return false
+	}
+
+	project.DeletedAt = CopyTime(&now)
+	project.Version++
+	project.UpdatedAt = now
+
+	for entryID, entry := range db.Entries {
+		if entry.ProjectID != nil && *entry.ProjectID == id {
+			db.SoftDeleteEntry(entryID, now)
+		}
+	}
+	for goalID, goal := range db.Goals {
+		if goal.ProjectID != nil && *goal.ProjectID == id {
+			db.SoftDeleteGoal(goalID, now)
+		}
+	}
+
+	return true
+}
+
+// SoftDeleteEntry leaves a tombstone of the entry with its tag relations and takes
+// its hours off the project. The caller must hold the write lock.
+func (db *DB) SoftDeleteEntry(id uint64, now time.Time) bool {
+	entry, ok := db.Entries[id]
+	if !ok || entry.DeletedAt != nil {
+		return false
+	}
+
+	db.AddProjectHours(entry, -1)
+	entry.DeletedAt = CopyTime(&now)
+	entry.Version++
+	entry.UpdatedAt = now
+	return true
+}
+
+// SoftDeleteTag leaves a tombstone of the tag with its entry relations.
+// The caller must hold the write lock.
+func (db *DB) SoftDeleteTag(id uint64, now time.Time) bool {
+	tag, ok := db.Tags[id]
+	if !ok || tag.DeletedAt != nil {
+		return false
+	}
+
+	tag.DeletedAt = CopyTime(&now)
+	tag.Version++
+	tag.UpdatedAt = now
+	return true
+}
+
+// SoftDeleteGoal leaves a tombstone of the goal. The caller must hold the write lock.
+func (db *DB) SoftDeleteGoal(id uint64, now time.Time) bool {
+	goal, ok := db.Goals[id]
+	if !ok || goal.DeletedAt != nil {
+		return false
+	}
+
+	goal.DeletedAt = CopyTime(&now)
+	goal.Version++
+	goal.UpdatedAt = now
+	return true
+}
+
+// RestoreProject brings the tombstone of the project back with the entries and goals
+// deleted together with it. The caller must hold the write lock.
+func (db *DB) RestoreProject(id uint64, now time.Time) bool {
+	project, ok := db.Projects[id]
+	if !ok || project.DeletedAt == nil {
+		return false
+	}
+
+	deletedAt := *project.DeletedAt
+	project.DeletedAt = nil
+	project.Version++
+	project.UpdatedAt = now
+
+	for entryID, entry := range db.Entries {
+		if SameID(entry.ProjectID, &id) && entry.DeletedAt != nil && entry.DeletedAt.Equal(deletedAt) {
+			db.RestoreEntry(entryID, now)
+		}
+	}
+	for goalID, goal := range db.Goals {
+		if SameID(goal.ProjectID, &id) && goal.DeletedAt != nil && goal.DeletedAt.Equal(deletedAt) {
+			db.RestoreGoal(goalID, now)
+		}
+	}
+
+	return true
+}
+
+// RestoreEntry brings the tombstone of the entry back and puts its hours on the project.
+// The caller must hold the write lock.
+func (db *DB) RestoreEntry(id uint64, now time.Time) bool {
+	entry, ok := db.Entries[id]
+	if !ok || entry.DeletedAt == nil {
+		return false
+	}
+
+	entry.DeletedAt = nil
+	entry.Version++
+	entry.UpdatedAt = now
+	db.AddProjectHours(entry, 1)
+	return true
+}
+
+// RestoreTag brings the tombstone of the tag back. The caller must hold the write lock.
+func (db *DB) RestoreTag(id uint64, now time.Time) bool {
+	tag, ok := db.Tags[id]
+	if !ok || tag.DeletedAt == nil {
+		return false
+	}
+
+	tag.DeletedAt = nil
+	tag.Version++
+	tag.UpdatedAt = now
+	return true
+}
+
+// RestoreGoal brings the tombstone of the goal back. The caller must hold the write lock.
+func (db *DB) RestoreGoal(id uint64, now time.Time) bool {
+	goal, ok := db.Goals[id]
+	if !ok || goal.DeletedAt == nil {
+		return false
+	}
+
+	goal.DeletedAt = nil
+	goal.Version++
+	goal.UpdatedAt = now
+	return true
+}
+
+// ProjectDeleted tells if the nullable project reference is a tombstone.
+// The caller must hold the lock.
+func (db *DB) ProjectDeleted(id *uint64) bool {
+	if id == nil {
+		return false
+	}
+
+	project, ok := db.Projects[*id]
+	return ok && project.DeletedAt != nil
+}
+
+// CopyID copies a nullable reference, so stored rows don't share memory with the callers
+func CopyID(id *uint64) *uint64 {
+	if id == nil {
+		return nil
+	}
+
+	copied := *id
+	return &copied
+}
+
+// SameID compares nullable references by value
+func SameID(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func CopyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	copied := *t
+	return &copied
+}
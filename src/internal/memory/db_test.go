@@ -0,0 +1,74 @@
+package memory_test
+
+import (
+	"testing"
+	"time"
+	"timetracker/internal/memory"
+	"timetracker/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteUserCascade(t *testing.T) {
+	db := memory.NewDB()
+	userID := uint64(1)
+	projectID := uint64(1)
+	timeStart := time.Now()
+
+	db.Users[userID] = &memory.User{User: models.User{ID: userID}}
+	db.Projects[projectID] = &models.Project{ID: projectID, UserID: &userID}
+	db.Entries[1] = &models.Entry{ID: 1, UserID: &userID, ProjectID: &projectID, TimeStart: timeStart, TimeEnd: timeStart.Add(time.Hour)}
+	db.Tags[1] = &models.Tag{ID: 1, UserID: userID}
+	db.TagEntries[1] = map[uint64]struct{}{1: {}}
+	db.Goals[1] = &models.Goal{ID: 1, UserID: &userID, ProjectID: &projectID}
+	db.Sessions["token"] = &memory.Session{UserID: userID}
+	db.Confirms["token"] = &models.Confirmation{Token: "token", UserID: userID}
+	db.Removals[memory.ProjectMemberKey{ProjectID: projectID, UserID: userID}] = timeStart
+
+	assert.True(t, db.DeleteUser(userID))
+	assert.False(t, db.DeleteUser(userID))
+
+	assert.Empty(t, db.Users)
+	assert.Empty(t, db.Projects)
+	assert.Empty(t, db.Entries)
+	assert.Empty(t, db.Tags)
+	assert.Empty(t, db.TagEntries)
+	assert.Empty(t, db.Goals)
+	assert.Empty(t, db.Sessions)
+	assert.Empty(t, db.Confirms)
+	assert.Empty(t, db.Removals)
+}
+
+func TestProjectHours(t *testing.T) {
+	db := memory.NewDB()
+	userID := uint64(1)
+	projectID := uint64(1)
+	timeStart := time.Now()
+
+	db.Projects[projectID] = &models.Project{ID: projectID, UserID: &userID}
+	entry := &models.Entry{ID: 1, UserID: &userID, ProjectID: &projectID, TimeStart: timeStart, TimeEnd: timeStart.Add(90 * time.Minute)}
+	db.Entries[entry.ID] = entry
+
+	db.AddProjectHours(entry, 1)
+	assert.Equal(t, 1.5, db.Projects[projectID].TotalCountHours)
+
+	assert.True(t, db.DeleteEntry(entry.ID))
+	assert.Equal(t, 0.0, db.Projects[projectID].TotalCountHours)
+}
+
+func TestSeed(t *testing.T) {
+	db := memory.NewDB()
+
+	emails, err := db.Seed(3, "password")
+	require.NoError(t, err)
+
+	assert.Len(t, emails, 3)
+	assert.Len(t, db.Users, 3)
+	assert.Equal(t, models.Admin.String(), db.Users[1].Role)
+
+	for _, entry := range db.Entries {
+		assert.Contains(t, db.Projects, *entry.ProjectID)
+		assert.True(t, entry.TimeEnd.After(entry.TimeStart))
+	}
+}
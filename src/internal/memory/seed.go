@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"math/rand"
+	"time"
+	"timetracker/models"
+
+	"github.com/bxcodec/faker"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	seedProjectsPerUser = 3
+	seedTagsPerUser     = 4
+	seedEntriesPerUser  = 20
+	seedDays            = 14
+	maxSeedNameLen      = 35
+)
+
+var seedColors = []string{"#e57373", "#64b5f6", "#81c784", "#ffb74d", "#ba68c8", "#4db6ac"}
+
+type seedUser struct {
+	Name  string `faker:"first_name"`
+	Email string `faker:"email"`
+	About string `faker:"sentence"`
+}
+
+type seedItem struct {
+	Name  string `faker:"word"`
+	About string `faker:"sentence"`
+}
+
+// Seed fills the tables with fake users owning projects, tags, entries of the last two
+// weeks and a goal each. Every user signs in with password, the first one is an admin.
+// It returns the emails of the created users.
+func (db *DB) Seed(users int, password string) ([]string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't hash seed password")
+	}
+
+	db.Lock()
+	defer db.Unlock()
+
+	emails := make([]string, 0, users)
+	userIDs := make([]uint64, 0, users)
+	for len(userIDs) < users {
+		user, err := db.seedUser(string(hash))
+		if err != nil {
+			return nil, err
+		}
+
+		if user == nil {
+			continue
+		}
+
+		if len(userIDs) == 0 {
+			user.Role = models.Admin.String()
+		}
+
+		if err := db.seedUserData(user.ID); err != nil {
+			return nil, err
+		}
+
+		emails = append(emails, user.Email)
+		userIDs = append(userIDs, user.ID)
+	}
+
+	for _, subscriberID := range userIDs {
+		for _, userID := range userIDs {
+			if subscriberID != userID && rand.Intn(2) == 0 {
+				db.Friends[FriendRelation{SubscriberID: subscriberID, UserID: userID}] = struct{}{}
+			}
+		}
+	}
+
+	return emails, nil
+}
+
+// seedUser returns nil when faker repeated an email
+func (db *DB) seedUser(passwordHash string) (*User, error) {
+	var fake seedUser
+	if err := faker.FakeData(&fake); err != nil {
+		return nil, errors.Wrap(err, "can't fake user")
+	}
+
+	for _, user := range db.Users {
+		if user.Email == fake.Email {
+			return nil, nil
+		}
+	}
+
+	user := &User{User: models.User{
+		ID:       db.NextID("users"),
+		Name:     truncate(fake.Name),
+		Email:    fake.Email,
+		About:    fake.About,
+		Role:     models.DefaultUser.String(),
+		Password: passwordHash,
+	}}
+	db.Users[user.ID] = user
+
+	return user, nil
+}
+
+func (db *DB) seedUserData(userID uint64) error {
+	projectIDs := make([]uint64, 0, seedProjectsPerUser)
+	for i := 0; i < seedProjectsPerUser; i++ {
+		var fake seedItem
+		if err := faker.FakeData(&fake); err != nil {
+			return errors.Wrap(err, "can't fake project")
+		}
+
+		project := &models.Project{
+			ID:        db.NextID("project"),
+			UserID:    CopyID(&userID),
+			Name:      truncate(fake.Name),
+			About:     fake.About,
+			Color:     seedColors[rand.Intn(len(seedColors))],
+			IsPrivate: rand.Intn(2) == 0,
+			Version:   1,
+			UpdatedAt: time.Now(),
+		}
+		db.Projects[project.ID] = project
+		db.AddProjectOwner(project)
+		projectIDs = append(projectIDs, project.ID)
+	}
+
+	tagIDs := make([]uint64, 0, seedTagsPerUser)
+	for i := 0; i < seedTagsPerUser; i++ {
+		var fake seedItem
+		if err := faker.FakeData(&fake); err != nil {
+			return errors.Wrap(err, "can't fake tag")
+		}
+
+		tag := &models.Tag{
+			ID:        db.NextID("tag"),
+			UserID:    userID,
+			Name:      truncate(fake.Name),
+			About:     fake.About,
+			Color:     seedColors[rand.Intn(len(seedColors))],
+			Version:   1,
+			UpdatedAt: time.Now(),
+		}
+		db.Tags[tag.ID] = tag
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	for i := 0; i < seedEntriesPerUser; i++ {
+		var fake seedItem
+		if err := faker.FakeData(&fake); err != nil {
+			return errors.Wrap(err, "can't fake entry")
+		}
+
+		timeStart := today.AddDate(0, 0, -rand.Intn(seedDays)).Add(time.Duration(8+rand.Intn(10)) * time.Hour)
+		entry := &models.Entry{
+			ID:          db.NextID("entry"),
+			UserID:      CopyID(&userID),
+			ProjectID:   CopyID(&projectIDs[rand.Intn(len(projectIDs))]),
+			Description: fake.About,
+			TimeStart:   timeStart,
+			TimeEnd:     timeStart.Add(time.Duration(15+rand.Intn(165)) * time.Minute),
+			Version:     1,
+			UpdatedAt:   time.Now(),
+		}
+		db.Entries[entry.ID] = entry
+		db.AddProjectHours(entry, 1)
+
+		db.TagEntries[entry.ID] = map[uint64]struct{}{
+			tagIDs[rand.Intn(len(tagIDs))]: {},
+		}
+	}
+
+	var fake seedItem
+	if err := faker.FakeData(&fake); err != nil {
+		return errors.Wrap(err, "can't fake goal")
+	}
+
+	goal := &models.Goal{
+		ID:          db.NextID("goal"),
+		UserID:      CopyID(&userID),
+		Name:        truncate(fake.Name),
+		ProjectID:   CopyID(&projectIDs[0]),
+		HoursCount:  float64(5 + rand.Intn(20)),
+		Description: fake.About,
+		TimeStart:   today.AddDate(0, 0, -seedDays),
+		TimeEnd:     today.AddDate(0, 0, seedDays),
+		Version:     1,
+		UpdatedAt:   time.Now(),
+	}
+	db.Goals[goal.ID] = goal
+
+	return nil
+}
+
+func truncate(name string) string {
+	if len([]rune(name)) > maxSeedNameLen {
+		return string([]rune(name)[:maxSeedNameLen])
+	}
+
+	return name
+}
@@ -0,0 +1,168 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+	"timetracker/internal/apierror"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	namespace   = "timetracker"
+	registryKey = "metrics"
+
+	// gaugeTimeout bounds the queries of the gauges evaluated on scrape
+	gaugeTimeout = 2 * time.Second
+)
+
+const (
+	SignInPassword = "password"
+	SignInOIDC     = "oidc"
+	SignUp         = "signup"
+)
+
+// Registry is the set of application metrics served at /prometheus
+type Registry struct {
+	registry *prometheus.Registry
+
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	cacheRequests *prometheus.CounterVec
+	signIns       *prometheus.CounterVec
+	entries       prometheus.Counter
+}
+
+func New() *Registry {
+	r := &Registry{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route, method and status.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_requests_total",
+			Help:      "Project cache lookups by result: hit, miss or error.",
+		}, []string{"result"}),
+		signIns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "signins_total",
+			Help:      "Sign-ins and sign-ups by method and result.",
+		}, []string{"method", "result"}),
+		entries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "entries_created_total",
+			Help:      "Time entries created.",
+		}),
+	}
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.httpRequests,
+		r.httpDuration,
+		r.cacheRequests,
+		r.signIns,
+		r.entries,
+	)
+
+	return r
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
+
+// Middleware counts the requests and makes the registry available to CountSignIn and
+// CountEntryCreated. Routes are labelled by their template, e.g. /entry/:id.
+func (r *Registry) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Set(registryKey, r)
+
+		start := time.Now()
+		err := next(c)
+
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		status := c.Response().Status
+		if err != nil {
+			// the error is written to the response after the middlewares return
+			status = apierror.Status(err)
+		}
+
+		method := c.Request().Method
+		r.httpRequests.WithLabelValues(route, method, strconv.Itoa(status)).Inc()
+		r.httpDuration.WithLabelValues(route, method).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// CacheRequests is labelled by the result of the lookup: hit, miss or error
+func (r *Registry) CacheRequests() *prometheus.CounterVec {
+	return r.cacheRequests
+}
+
+// RegisterDB exports the connection pool stats of db
+func (r *Registry) RegisterDB(name string, db *sql.DB) {
+	r.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterRedis exports the connection pool stats of client
+func (r *Registry) RegisterRedis(name string, client *redis.Client) {
+	r.registry.MustRegister(newRedisPoolCollector(name, client))
+}
+
+// RegisterGauge exports a gauge computed on every scrape, value gets a context
+// bounded by gaugeTimeout. A failed value is reported to the scraper.
+func (r *Registry) RegisterGauge(name string, help string, value func(ctx context.Context) (float64, error)) {
+	r.registry.MustRegister(&gaugeCollector{
+		desc:  prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, nil, nil),
+		value: value,
+	})
+}
+
+func fromContext(c echo.Context) *Registry {
+	r, _ := c.Get(registryKey).(*Registry)
+	return r
+}
+
+// CountSignIn counts a sign-in attempt of the current request
+func CountSignIn(c echo.Context, method string, success bool) {
+	r := fromContext(c)
+	if r == nil {
+		return
+	}
+
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+
+	r.signIns.WithLabelValues(method, result).Inc()
+}
+
+func CountEntryCreated(c echo.Context) {
+	if r := fromContext(c); r != nil {
+		r.entries.Inc()
+	}
+}
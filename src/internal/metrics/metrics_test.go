@@ -0,0 +1,87 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"timetracker/internal/cache"
+	"timetracker/internal/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, registry *metrics.Registry) string {
+	rec := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prometheus", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMiddleware(t *testing.T) {
+	registry := metrics.New()
+
+	e := echo.New()
+	e.Use(registry.Middleware)
+	e.GET("/entry/:id", func(c echo.Context) error {
+		if c.Param("id") == "0" {
+			return echo.NewHTTPError(http.StatusNotFound)
+		}
+
+		metrics.CountEntryCreated(c)
+		metrics.CountSignIn(c, metrics.SignInPassword, false)
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, path := range []string{"/entry/1", "/entry/2", "/entry/0"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := scrape(t, registry)
+	assert.Contains(t, body, `timetracker_http_requests_total{method="GET",route="/entry/:id",status="200"} 2`)
+	assert.Contains(t, body, `timetracker_http_requests_total{method="GET",route="/entry/:id",status="404"} 1`)
+	assert.Contains(t, body, `timetracker_http_request_duration_seconds_count{method="GET",route="/entry/:id"} 3`)
+	assert.Contains(t, body, `timetracker_entries_created_total 2`)
+	assert.Contains(t, body, `timetracker_signins_total{method="password",result="failure"} 2`)
+}
+
+func TestRegisterGauge(t *testing.T) {
+	registry := metrics.New()
+
+	registry.RegisterGauge("active_timers", "running entries", func(ctx context.Context) (float64, error) {
+		return 3, nil
+	})
+	assert.Contains(t, scrape(t, registry), "timetracker_active_timers 3")
+
+	registry.RegisterGauge("broken", "failing gauge", func(ctx context.Context) (float64, error) {
+		return 0, errors.New("database is down")
+	})
+	body := scrape(t, registry)
+	assert.Contains(t, body, "timetracker_active_timers 3")
+	assert.False(t, strings.Contains(body, "timetracker_broken"))
+}
+
+func TestCacheMetrics(t *testing.T) {
+	registry := metrics.New()
+	storage := cache.WithMetrics(cache.NewStorageMemory(), registry.CacheRequests())
+	ctx := context.Background()
+
+	_, err := storage.Get(ctx, "projects:1")
+	assert.Error(t, err)
+
+	require.NoError(t, storage.Set(ctx, "projects:1", []int{1}))
+	_, err = storage.Get(ctx, "projects:1")
+	assert.NoError(t, err)
+
+	body := scrape(t, registry)
+	assert.Contains(t, body, `timetracker_cache_requests_total{result="hit"} 1`)
+	assert.Contains(t, body, `timetracker_cache_requests_total{result="miss"} 1`)
+}
@@ -1,9 +1,10 @@
 package middleware
 
 import (
-	"net/http"
 	"strconv"
 	friendUsecase "timetracker/internal/Friends/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/policy"
 
 	"timetracker/models"
 
@@ -18,80 +19,82 @@ func NewAclMiddleware(friendUC friendUsecase.UsecaseI) *AclMiddleware {
 	return &AclMiddleware{friendUC: friendUC}
 }
 
-func (am *AclMiddleware)AdminOnly(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		user := c.Get("user").(*models.User)
-		if user.Role != models.Admin.String() {
-			return echo.NewHTTPError(http.StatusForbidden, models.ErrPermissionDenied.Error())
-		}
-		return next(c)
-	}
-}
-
-// for all handlers with c.Param("user_id")
-func (am *AclMiddleware) FriendsOnly(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		authUser, ok := c.Get("user").(*models.User)
+// RequirePermission is the route level check for handlers that are not bound to a resource owner.
+func (am *AclMiddleware) RequirePermission(perm models.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authUser, ok := c.Get("user").(*models.User)
+			if !ok {
+				c.Logger().Error("can't get user from context")
+				return apierror.From(models.ErrInternalServerError)
+			}
+
+			if !policy.HasPermission(authUser.Role, perm) {
+				c.Logger().Errorf("Error: user %d has no permission %s", authUser.ID, perm)
+				return apierror.From(models.ErrPermissionDenied)
+			}
 
-		if !ok {
-			c.Logger().Error("can't get user from context")
-			return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
-		}
-
-		otherUserID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
-		if err != nil {
-			c.Logger().Error(err)
-			return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-		}
-
-		isFriends, err := am.friendUC.CheckIsFriends(authUser.ID, otherUserID)
-
-		if err != nil {
-			c.Logger().Error(err)
-			return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-		}
-
-		if !isFriends {
-			c.Logger().Error("Error: FriendsOnly middleware it is not a friends")
-			return echo.NewHTTPError(http.StatusForbidden, models.ErrPermissionDenied.Error())
+			return next(c)
 		}
-
-		return next(c)
 	}
 }
 
-// for all handlers with c.Param("user_id")
-func (am *AclMiddleware) FriendsOrAdminOnly(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		authUser, ok := c.Get("user").(*models.User)
-
-		if !ok {
-			c.Logger().Error("can't get user from context")
-			return echo.NewHTTPError(http.StatusInternalServerError, models.ErrInternalServerError.Error())
-		}
-
-		if authUser.Role == models.Admin.String() {
+// FriendsOrPermission lets through the requested user, their friends and users with perm.
+// Access granted only by perm is audited.
+// For all handlers with c.Param("user_id").
+func (am *AclMiddleware) FriendsOrPermission(perm models.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authUser, ok := c.Get("user").(*models.User)
+			if !ok {
+				c.Logger().Error("can't get user from context")
+				return apierror.From(models.ErrInternalServerError)
+			}
+
+			otherUserID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+			if err != nil {
+				c.Logger().Error(err)
+				return apierror.From(models.ErrBadRequest)
+			}
+
+			if authUser.ID == otherUserID {
+				return next(c)
+			}
+
+			isFriends, err := am.friendUC.CheckIsFriends(c.Request().Context(), authUser.ID, otherUserID)
+			if err != nil {
+				c.Logger().Error(err)
+				return apierror.From(models.ErrBadRequest)
+			}
+
+			if isFriends {
+				return next(c)
+			}
+
+			if !policy.HasPermission(authUser.Role, perm) {
+				c.Logger().Errorf("Error: user %d is not a friend of %d and has no permission %s", authUser.ID, otherUserID, perm)
+				return apierror.From(models.ErrPermissionDenied)
+			}
+
+			AuditAccess(c, otherUserID, perm)
 			return next(c)
 		}
+	}
+}
 
-		otherUserID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
-		if err != nil {
-			c.Logger().Error(err)
-			return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-		}
-
-		isFriends, err := am.friendUC.CheckIsFriends(authUser.ID, otherUserID)
-
-		if err != nil {
-			c.Logger().Error(err)
-			return echo.NewHTTPError(http.StatusBadRequest, models.ErrBadRequest.Error())
-		}
-
-		if !isFriends {
-			c.Logger().Error("Error: FriendsOrAdminOnly middleware it is not a friends and you are not an admin")
-			return echo.NewHTTPError(http.StatusForbidden, models.ErrPermissionDenied.Error())
-		}
+// AuthorizeResource is the resource level check used by deliveries once the resource is loaded:
+// the owner is allowed, anybody else needs perm and is audited.
+func AuthorizeResource(c echo.Context, ownerID uint64, perm models.Permission) error {
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		c.Logger().Error("can't get user from context")
+		return apierror.From(models.ErrInternalServerError)
+	}
 
-		return next(c)
+	err := policy.Authorize(user, ownerID, perm)
+	if err == nil && user.ID != ownerID {
+		AuditAccess(c, ownerID, perm)
 	}
+
+	return err
 }
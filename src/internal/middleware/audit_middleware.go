@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"time"
+	auditUsecase "timetracker/internal/Audit/usecase"
+	"timetracker/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+const auditKey = "audit"
+
+type AuditMiddleware struct {
+	auditUC auditUsecase.UsecaseI
+}
+
+func NewAuditMiddleware(auditUC auditUsecase.UsecaseI) *AuditMiddleware {
+	return &AuditMiddleware{auditUC: auditUC}
+}
+
+// Inject makes the audit log available to Audit for the rest of the request.
+func (am *AuditMiddleware) Inject(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Set(auditKey, am.auditUC)
+		return next(c)
+	}
+}
+
+// Audit appends a record for the current request. The actor defaults to the authenticated user.
+// Failures are logged and never fail the request.
+func Audit(c echo.Context, record *models.AuditRecord) {
+	auditUC, ok := c.Get(auditKey).(auditUsecase.UsecaseI)
+	if !ok {
+		return
+	}
+
+	if record.ActorID == nil {
+		if userID, ok := c.Get("user_id").(uint64); ok {
+			record.ActorID = &userID
+		}
+	}
+
+	record.IP = c.RealIP()
+	record.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+
+	// the action is already done, so the record must outlive a client that hung up meanwhile
+	if err := auditUC.Record(detachedContext{c.Request().Context()}, record); err != nil {
+		c.Logger().Error(err)
+	}
+}
+
+// detachedContext keeps the values of the parent but is never canceled
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (dc detachedContext) Value(key interface{}) interface{} {
+	return dc.parent.Value(key)
+}
+
+// AuditUser records an action targeting a user account.
+func AuditUser(c echo.Context, action models.AuditAction, userID uint64, details string) {
+	Audit(c, &models.AuditRecord{
+		Action:     action,
+		TargetType: models.AuditTargetUser,
+		TargetID:   &userID,
+		Details:    details,
+	})
+}
+
+// AuditAccess records an access to the data of another user granted by perm.
+func AuditAccess(c echo.Context, ownerID uint64, perm models.Permission) {
+	AuditUser(c, models.AuditAdminRead, ownerID, string(perm)+" "+c.Request().Method+" "+c.Request().URL.Path)
+}
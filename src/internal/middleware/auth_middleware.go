@@ -2,13 +2,37 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 	authUsecase "timetracker/internal/Auth/usecase"
+	"timetracker/internal/apierror"
+	"timetracker/internal/logger"
+	"timetracker/models"
 )
 
-const session_name = "session_token"
+const (
+	session_name = "session_token"
+	bearerPrefix = "Bearer "
+)
+
+// publicPaths don't need a session. The auth routes check the session themselves.
+var publicPaths = map[string]struct{}{
+	"/signup":                      {},
+	"/signin":                      {},
+	"/auth":                        {},
+	"/password-reset":              {},
+	APIv1 + "/auth/signup":         {},
+	APIv1 + "/auth/signin":         {},
+	APIv1 + "/auth/session":        {},
+	APIv1 + "/auth/password-reset": {},
+	"/prometheus":                  {},
+	"/favicon.ico":                 {},
+	"/healthz":                     {},
+	"/readyz":                      {},
+	"/version":                     {},
+}
 
 type Middleware struct {
 	authUC authUsecase.UsecaseI
@@ -18,33 +42,47 @@ func NewMiddleware(authUC authUsecase.UsecaseI) *Middleware {
 	return &Middleware{authUC: authUC}
 }
 
+// Auth accepts the session token either from the session cookie or from an
+// "Authorization: Bearer" header. Only cookie sessions are stored in the context
+// as "session_token", so the CSRF check skips bearer requests.
 func (m *Middleware) Auth(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		if c.Request().URL.Path == "/signup" || c.Request().URL.Path == "/signin" ||
-			c.Request().URL.Path == "/auth" || c.Request().URL.Path == "/prometheus" ||
-			c.Request().URL.Path == "/favicon.ico" {
+		if _, ok := publicPaths[c.Request().URL.Path]; ok || strings.HasPrefix(c.Request().URL.Path, "/auth/oidc/") {
 			return next(c)
 		}
 
+		if authHeader := c.Request().Header.Get(echo.HeaderAuthorization); strings.HasPrefix(authHeader, bearerPrefix) {
+			return m.authenticate(c, next, strings.TrimPrefix(authHeader, bearerPrefix))
+		}
+
 		cookie, err := c.Cookie(session_name)
 		if err == http.ErrNoCookie {
 			c.Logger().Error(err)
-			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+			return apierror.From(models.ErrUnauthorized)
 		} else if err != nil {
 			c.Logger().Error(err)
-			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			return apierror.From(models.ErrBadRequest)
 		}
 
-		user, err := m.authUC.Auth(cookie.Value)
-		if err != nil {
-			causeErr := errors.Cause(err)
-			c.Logger().Error(err)
-			return echo.NewHTTPError(http.StatusUnauthorized, causeErr.Error())
+		c.Set("session_token", cookie.Value)
+		return m.authenticate(c, next, cookie.Value)
+	}
+}
+
+func (m *Middleware) authenticate(c echo.Context, next echo.HandlerFunc, sessionToken string) error {
+	user, err := m.authUC.Auth(c.Request().Context(), sessionToken)
+	if err != nil {
+		c.Logger().Error(err)
+		// a suspended user is told so, every other failure is an unknown or expired session
+		if errors.Is(err, models.ErrUserSuspended) {
+			return apierror.From(err)
 		}
+		return apierror.From(models.ErrUnauthorized)
+	}
 
-		c.Set("user_id", user.ID)
-		c.Set("user", user)
+	c.Set("user_id", user.ID)
+	c.Set("user", user)
+	logger.AddFields(c, "user_id", user.ID)
 
-		return next(c)
-	}
+	return next(c)
 }
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"timetracker/internal/apierror"
+	"timetracker/models"
+)
+
+const CSRFHeader = "X-CSRF-Token"
+
+// CSRF checks the synchronizer token of cookie sessions on state-changing requests.
+// It has to run after Auth: requests without "session_token" in the context are
+// either whitelisted or bearer-authenticated and are not exposed to CSRF.
+func (m *Middleware) CSRF(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		switch c.Request().Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return next(c)
+		}
+
+		sessionToken, ok := c.Get("session_token").(string)
+		if !ok {
+			return next(c)
+		}
+
+		err := m.authUC.CheckCSRFToken(c.Request().Context(), sessionToken, c.Request().Header.Get(CSRFHeader))
+		if errors.Is(err, models.ErrInvalidCSRF) {
+			c.Logger().Error(err)
+			return apierror.From(models.ErrInvalidCSRF)
+		} else if err != nil {
+			c.Logger().Error(err)
+			return apierror.From(models.ErrInternalServerError)
+		}
+
+		return next(c)
+	}
+}
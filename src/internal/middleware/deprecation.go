@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// APIv1 is the prefix of the versioned routes. The routes from before it stay as deprecated aliases.
+const APIv1 = "/api/v1"
+
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderLink        = "Link"
+)
+
+// Deprecated marks the responses of a legacy route with the Deprecation header and a Link to
+// its successor, e.g. Deprecated(APIv1 + "/entries/:id"). The params of the successor are
+// filled from the request, the ones the legacy route doesn't have are left as {param}.
+func Deprecated(successor string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set(HeaderDeprecation, "true")
+			header.Add(HeaderLink, `<`+successorPath(c, successor)+`>; rel="successor-version"`)
+
+			return next(c)
+		}
+	}
+}
+
+func successorPath(c echo.Context, successor string) string {
+	segments := strings.Split(successor, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+
+		name := segment[1:]
+		if value := c.Param(name); value != "" {
+			segments[i] = value
+		} else {
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
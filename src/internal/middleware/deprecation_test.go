@@ -0,0 +1,66 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"timetracker/internal/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecated(t *testing.T) {
+	e := echo.New()
+	handler := func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	e.GET(middleware.APIv1+"/entries/:id", handler)
+	e.GET("/entry/:id", handler, middleware.Deprecated(middleware.APIv1+"/entries/:id"))
+	e.POST("/entry/edit", handler, middleware.Deprecated(middleware.APIv1+"/entries/:id"))
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		deprecated bool
+		link       string
+	}{
+		{
+			name:   "versioned route",
+			method: http.MethodGet,
+			target: "/api/v1/entries/7",
+		},
+		{
+			name:       "legacy route with the param of the successor",
+			method:     http.MethodGet,
+			target:     "/entry/7",
+			deprecated: true,
+			link:       `</api/v1/entries/7>; rel="successor-version"`,
+		},
+		{
+			name:       "legacy route with the ID in the body",
+			method:     http.MethodPost,
+			target:     "/entry/edit",
+			deprecated: true,
+			link:       `</api/v1/entries/{id}>; rel="successor-version"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(test.method, test.target, nil))
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			if test.deprecated {
+				assert.Equal(t, "true", rec.Header().Get(middleware.HeaderDeprecation))
+				assert.Equal(t, test.link, rec.Header().Get(middleware.HeaderLink))
+			} else {
+				assert.Empty(t, rec.Header().Get(middleware.HeaderDeprecation))
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"strings"
+	"timetracker/internal/apierror"
+	"timetracker/models"
+	"timetracker/pkg"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// NotModified sets the ETag of the response and reports if the If-None-Match of the
+// request already has it, then the handler answers 304 without a body
+func NotModified(c echo.Context, etag string) bool {
+	c.Response().Header().Set(HeaderETag, etag)
+
+	ifNoneMatch := c.Request().Header.Get(HeaderIfNoneMatch)
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	// If-None-Match uses the weak comparison, W/ is dropped on both sides
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IfMatch returns the version of the If-Match header of the request. It is 0 when there is
+// no header or it is *, then any version can be updated. Only one ETag is supported.
+func IfMatch(c echo.Context) (uint64, error) {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, nil
+	}
+
+	version, ok := pkg.ETagVersion(ifMatch)
+	if !ok {
+		return 0, models.ErrVersionMismatch
+	}
+
+	return version, nil
+}
+
+// RequireIfMatch rejects an update without If-Match, so a client can't overwrite
+// a change it hasn't seen
+func RequireIfMatch() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Header.Get(HeaderIfMatch) == "" {
+				return apierror.From(models.ErrIfMatchRequired)
+			}
+
+			return next(c)
+		}
+	}
+}
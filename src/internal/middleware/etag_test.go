@@ -0,0 +1,92 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"timetracker/internal/apierror"
+	"timetracker/internal/middleware"
+	"timetracker/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotModified(t *testing.T) {
+	e := echo.New()
+	handler := func(c echo.Context) error {
+		if middleware.NotModified(c, `"3"`) {
+			return c.NoContent(http.StatusNotModified)
+		}
+		return c.String(http.StatusOK, "entry")
+	}
+	e.GET("/entries/:id", handler)
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		status      int
+	}{
+		{name: "no header", status: http.StatusOK},
+		{name: "same etag", ifNoneMatch: `"3"`, status: http.StatusNotModified},
+		{name: "weak etag", ifNoneMatch: `W/"3"`, status: http.StatusNotModified},
+		{name: "list", ifNoneMatch: `"1", "3"`, status: http.StatusNotModified},
+		{name: "any", ifNoneMatch: `*`, status: http.StatusNotModified},
+		{name: "other etag", ifNoneMatch: `"2"`, status: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/entries/1", nil)
+			if test.ifNoneMatch != "" {
+				req.Header.Set(middleware.HeaderIfNoneMatch, test.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.status, rec.Code)
+			assert.Equal(t, `"3"`, rec.Header().Get(middleware.HeaderETag))
+		})
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = apierror.Handler
+	e.PATCH("/entries/:id", func(c echo.Context) error {
+		version, err := middleware.IfMatch(c)
+		if err != nil {
+			return apierror.From(err)
+		}
+		if version != 3 {
+			return apierror.From(models.ErrVersionMismatch)
+		}
+		return c.NoContent(http.StatusOK)
+	}, middleware.RequireIfMatch())
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		status  int
+	}{
+		{name: "no header", status: http.StatusPreconditionRequired},
+		{name: "same version", ifMatch: `"3"`, status: http.StatusOK},
+		{name: "same version with digest", ifMatch: `"3-9f1c"`, status: http.StatusOK},
+		{name: "other version", ifMatch: `"2"`, status: http.StatusPreconditionFailed},
+		{name: "weak etag", ifMatch: `W/"3"`, status: http.StatusPreconditionFailed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/entries/1", nil)
+			if test.ifMatch != "" {
+				req.Header.Set(middleware.HeaderIfMatch, test.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.status, rec.Code)
+		})
+	}
+}
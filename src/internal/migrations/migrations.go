@@ -0,0 +1,344 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var migrationsFS embed.FS
+
+var (
+	ErrSchemaOutdated = errors.New("database schema is outdated")
+	ErrSchemaTooNew   = errors.New("database schema is newer than the application")
+	ErrNoMigration    = errors.New("no migration to apply")
+)
+
+// fileNameRe matches migration files named <version>_<name>.<up|down>.sql, e.g. 0002_cookie.up.sql
+var fileNameRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	Version   uint64    `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"column:name"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+)`
+
+// Load reads the migrations of the fsys root sorted by version.
+// Every version must have both an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read migrations")
+	}
+
+	byVersion := map[uint64]*Migration{}
+	for _, file := range files {
+		match := fileNameRe.FindStringSubmatch(file.Name())
+		if file.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, errors.Errorf("invalid migration version in %s", file.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, file.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't read migration %s", file.Name())
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, errors.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, errors.Errorf("migration %d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrator applies versioned migrations and keeps track of them in the schema_migrations table.
+// Every migration runs in its own transaction.
+// Migrations are not locked against each other, so run them from a single process.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func New(db *gorm.DB, fsys fs.FS, dir string) (*Migrator, error) {
+	sub, err := fs.Sub(fsys, path.Clean(dir))
+	if err != nil {
+		return nil, errors.Wrap(err, "can't open migrations")
+	}
+
+	migrations, err := Load(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// NewPostgres returns a Migrator of the embedded postgres schema
+func NewPostgres(db *gorm.DB) (*Migrator, error) {
+	return New(db, migrationsFS, "postgres")
+}
+
+// NewSQLite returns a Migrator of the embedded sqlite schema, it is versioned apart from postgres
+func NewSQLite(db *gorm.DB) (*Migrator, error) {
+	return New(db, migrationsFS, "sqlite")
+}
+
+// WithContext returns a copy of the Migrator running its queries with ctx
+func (m *Migrator) WithContext(ctx context.Context) *Migrator {
+	return &Migrator{
+		db:         m.db.WithContext(ctx),
+		migrations: m.migrations,
+	}
+}
+
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Latest returns the version the application expects
+func (m *Migrator) Latest() uint64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the latest applied version, 0 for an unmanaged database
+func (m *Migrator) Version() (uint64, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+
+	var version uint64
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+
+	return version, nil
+}
+
+// Check fails unless every migration known to the application is applied
+func (m *Migrator) Check() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			return errors.Wrapf(ErrSchemaOutdated, "migration %d_%s is not applied", migration.Version, migration.Name)
+		}
+	}
+
+	for version := range applied {
+		if version > m.Latest() {
+			return errors.Wrapf(ErrSchemaTooNew, "unknown migration %d is applied", version)
+		}
+	}
+
+	return nil
+}
+
+// Status lists every known migration, AppliedAt is nil for pending ones
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Up applies all pending migrations in version order and returns them
+func (m *Migrator) Up() ([]Migration, error) {
+	err := m.db.Exec(createSchemaMigrations).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table schema_migrations)")
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err = m.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+
+			return tx.Create(&schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now().UTC(),
+			}).Error
+		})
+
+		if err != nil {
+			return done, errors.Wrapf(err, "can't apply migration %d_%s", migration.Version, migration.Name)
+		}
+
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down rolls back the latest applied migration
+func (m *Migrator) Down() (*Migration, error) {
+	version, err := m.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	if version == 0 {
+		return nil, ErrNoMigration
+	}
+
+	var migration *Migration
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			migration = &m.migrations[i]
+		}
+	}
+
+	if migration == nil {
+		return nil, errors.Wrapf(ErrSchemaTooNew, "unknown migration %d is applied", version)
+	}
+
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("version = ?", migration.Version).Delete(&schemaMigration{}).Error
+	})
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't roll back migration %d_%s", migration.Version, migration.Name)
+	}
+
+	return migration, nil
+}
+
+// applied only reads: Version and Check run on every start and GET /version, so they never
+// change the database. A database without the schema_migrations table has nothing applied.
+func (m *Migrator) applied() (map[uint64]schemaMigration, error) {
+	query := "SELECT count(*) FROM information_schema.tables WHERE table_schema = CURRENT_SCHEMA() AND table_name = ?"
+	if m.db.Dialector.Name() == "sqlite" {
+		query = "SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+	}
+
+	var tables int64
+	err := m.db.Raw(query, schemaMigration{}.TableName()).Scan(&tables).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table schema_migrations)")
+	}
+
+	if tables == 0 {
+		return map[uint64]schemaMigration{}, nil
+	}
+
+	var records []schemaMigration
+	err = m.db.Find(&records).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error (table schema_migrations)")
+	}
+
+	applied := make(map[uint64]schemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
+
+func (s Status) String() string {
+	state := "pending"
+	if s.AppliedAt != nil {
+		state = "applied " + s.AppliedAt.Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf("%04d_%s\t%s", s.Version, s.Name, state)
+}
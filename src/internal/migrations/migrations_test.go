@@ -0,0 +1,85 @@
+package migrations_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"timetracker/internal/migrations"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestCaseLoad struct {
+	FS       fstest.MapFS
+	Versions []uint64
+	Error    bool
+}
+
+func TestLoadPostgres(t *testing.T) {
+	migrator, err := migrations.NewPostgres(nil)
+	assert.NoError(t, err)
+
+	for i, migration := range migrator.Migrations() {
+		assert.Equal(t, uint64(i+1), migration.Version, "migration versions must have no gaps")
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+	}
+
+	assert.Equal(t, uint64(len(migrator.Migrations())), migrator.Latest())
+}
+
+func TestLoad(t *testing.T) {
+	cases := map[string]TestCaseLoad{
+		"sorted": {
+			FS: fstest.MapFS{
+				"0010_b.up.sql":   {Data: []byte("up b")},
+				"0010_b.down.sql": {Data: []byte("down b")},
+				"0002_a.up.sql":   {Data: []byte("up a")},
+				"0002_a.down.sql": {Data: []byte("down a")},
+				"README.md":       {Data: []byte("not a migration")},
+			},
+			Versions: []uint64{2, 10},
+		},
+		"missing_down": {
+			FS: fstest.MapFS{
+				"0001_a.up.sql": {Data: []byte("up a")},
+			},
+			Error: true,
+		},
+		"same_version": {
+			FS: fstest.MapFS{
+				"0001_a.up.sql":   {Data: []byte("up a")},
+				"0001_a.down.sql": {Data: []byte("down a")},
+				"0001_b.up.sql":   {Data: []byte("up b")},
+				"0001_b.down.sql": {Data: []byte("down b")},
+			},
+			Error: true,
+		},
+		"zero_version": {
+			FS: fstest.MapFS{
+				"0000_a.up.sql":   {Data: []byte("up a")},
+				"0000_a.down.sql": {Data: []byte("down a")},
+			},
+			Error: true,
+		},
+	}
+
+	for name, test := range cases {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			loaded, err := migrations.Load(test.FS)
+			if test.Error {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			versions := make([]uint64, 0, len(loaded))
+			for _, migration := range loaded {
+				versions = append(versions, migration.Version)
+			}
+			assert.Equal(t, test.Versions, versions)
+		})
+	}
+}
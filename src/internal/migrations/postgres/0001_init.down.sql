@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS update_total_count_hours_trigger ON entry;
+DROP FUNCTION IF EXISTS update_total_count_hours();
+
+DROP TABLE IF EXISTS friend_relation;
+DROP TABLE IF EXISTS tag_entry;
+DROP TABLE IF EXISTS entry;
+DROP TABLE IF EXISTS goal;
+DROP TABLE IF EXISTS project;
+DROP TABLE IF EXISTS tag;
+DROP TABLE IF EXISTS users;
+
+DROP TYPE IF EXISTS role_type;
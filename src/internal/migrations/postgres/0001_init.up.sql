@@ -1,4 +1,12 @@
-CREATE TYPE role_type AS ENUM ('user', 'admin');
+-- schema of the former dev/SQL/init_db.sql. Guarded with IF NOT EXISTS so databases
+-- created by that script can be brought under version control by `migrate up`
+DO $$
+BEGIN
+	CREATE TYPE role_type AS ENUM ('user', 'admin');
+EXCEPTION
+	WHEN duplicate_object THEN NULL;
+END;
+$$;
 
 CREATE TABLE IF NOT EXISTS users (
 	id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
@@ -59,22 +67,12 @@ CREATE TABLE IF NOT EXISTS friend_relation (
 	PRIMARY KEY (subscriber_id, user_id)
 );
 
-INSERT INTO
-	users (name, email, about, role, password)
-VALUES
-	('test', 'test', 'test', 'user', '');
-
--- insert into friend_relation (subscriber_id, user_id) VALUES (1, 2);
--- insert into friend_relation (subscriber_id, user_id) VALUES (2, 1);
--- select f1.subscriber_id, f1.user_id from friend_relation f1
--- join friend_relation f2 on f2.user_id = f1.subscriber_id and f2.subscriber_id = f1.user_id
--- where f1.user_id = 2;
-CREATE OR REPLACE FUNCTION update_total_count_hours() 
-RETURNS TRIGGER AS $$ 
+CREATE OR REPLACE FUNCTION update_total_count_hours()
+RETURNS TRIGGER AS $$
 BEGIN
 	IF (TG_OP = 'INSERT') THEN
 		UPDATE project
-		SET total_count_hours = total_count_hours + (EXTRACT(EPOCH FROM (NEW.time_end - NEW.time_start))) / 3600 
+		SET total_count_hours = total_count_hours + (EXTRACT(EPOCH FROM (NEW.time_end - NEW.time_start))) / 3600
 		WHERE id = NEW.project_id;
 	ELSIF (TG_OP = 'UPDATE') THEN
 		UPDATE project
@@ -89,6 +87,8 @@ BEGIN
 END;
 $$ LANGUAGE plpgsql;
 
+DROP TRIGGER IF EXISTS update_total_count_hours_trigger ON entry;
+
 CREATE TRIGGER update_total_count_hours_trigger
 AFTER INSERT OR UPDATE OR DELETE
-	ON entry FOR EACH ROW EXECUTE FUNCTION update_total_count_hours();
\ No newline at end of file
+	ON entry FOR EACH ROW EXECUTE FUNCTION update_total_count_hours();
@@ -0,0 +1,9 @@
+-- sessions of the postgres session repository (-sesseion-db=postgres)
+CREATE TABLE IF NOT EXISTS cookie (
+	session_token VARCHAR(64) PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	expire_time TIMESTAMP NOT NULL,
+	csrf_token VARCHAR(64) DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS cookie_user_id_idx ON cookie (user_id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS external_identity (
+	id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	provider VARCHAR(64) NOT NULL,
+	subject VARCHAR(255) NOT NULL,
+	email VARCHAR(254) DEFAULT '',
+	UNIQUE (provider, subject)
+);
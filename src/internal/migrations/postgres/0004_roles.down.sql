@@ -0,0 +1,10 @@
+-- enum values can't be dropped, the type is recreated instead.
+-- moderators and supports are demoted to plain users
+UPDATE users SET role = 'user' WHERE role IN ('moderator', 'support');
+
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+ALTER TYPE role_type RENAME TO role_type_old;
+CREATE TYPE role_type AS ENUM ('user', 'admin');
+ALTER TABLE users ALTER COLUMN role TYPE role_type USING role::text::role_type;
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'user';
+DROP TYPE role_type_old;
@@ -0,0 +1,2 @@
+ALTER TYPE role_type ADD VALUE IF NOT EXISTS 'moderator' BEFORE 'admin';
+ALTER TYPE role_type ADD VALUE IF NOT EXISTS 'support' BEFORE 'admin';
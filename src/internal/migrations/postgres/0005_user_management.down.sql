@@ -0,0 +1,4 @@
+ALTER TABLE users
+	DROP COLUMN IF EXISTS password_reset_expire,
+	DROP COLUMN IF EXISTS password_reset_token,
+	DROP COLUMN IF EXISTS suspended;
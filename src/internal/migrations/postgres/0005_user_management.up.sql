@@ -0,0 +1,4 @@
+ALTER TABLE users
+	ADD COLUMN IF NOT EXISTS suspended BOOLEAN NOT NULL DEFAULT false,
+	ADD COLUMN IF NOT EXISTS password_reset_token VARCHAR(64) UNIQUE,
+	ADD COLUMN IF NOT EXISTS password_reset_expire TIMESTAMP;
@@ -0,0 +1,19 @@
+-- append-only: rows are only removed by the retention purge of the application.
+-- actor_id and target_id have no foreign keys so records outlive deleted users
+CREATE TABLE IF NOT EXISTS audit_log (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	created_at TIMESTAMP NOT NULL DEFAULT now(),
+	actor_id INT,
+	action VARCHAR(64) NOT NULL,
+	target_type VARCHAR(32) DEFAULT '',
+	target_id INT,
+	ip VARCHAR(45) DEFAULT '',
+	request_id VARCHAR(64) DEFAULT '',
+	details TEXT DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX IF NOT EXISTS audit_log_actor_id_idx ON audit_log (actor_id);
+CREATE INDEX IF NOT EXISTS audit_log_target_id_idx ON audit_log (target_id);
+
+CREATE OR REPLACE RULE audit_log_no_update AS ON UPDATE TO audit_log DO INSTEAD NOTHING;
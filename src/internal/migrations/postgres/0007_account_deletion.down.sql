@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS data_export;
+DROP TYPE IF EXISTS export_status;
+
+ALTER TABLE users DROP COLUMN IF EXISTS delete_after;
@@ -0,0 +1,20 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS delete_after TIMESTAMP;
+
+DO $$
+BEGIN
+	CREATE TYPE export_status AS ENUM ('pending', 'processing', 'ready', 'failed', 'downloaded');
+EXCEPTION
+	WHEN duplicate_object THEN NULL;
+END;
+$$;
+
+CREATE TABLE IF NOT EXISTS data_export (
+	id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	status export_status NOT NULL DEFAULT 'pending',
+	created_at TIMESTAMP NOT NULL DEFAULT now(),
+	updated_at TIMESTAMP NOT NULL DEFAULT now(),
+	archive BYTEA
+);
+
+CREATE INDEX IF NOT EXISTS data_export_status_idx ON data_export (status);
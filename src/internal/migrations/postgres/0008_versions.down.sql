@@ -0,0 +1,11 @@
+ALTER TABLE goal DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE goal DROP COLUMN IF EXISTS version;
+
+ALTER TABLE tag DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE tag DROP COLUMN IF EXISTS version;
+
+ALTER TABLE project DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE project DROP COLUMN IF EXISTS version;
+
+ALTER TABLE entry DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE entry DROP COLUMN IF EXISTS version;
@@ -0,0 +1,37 @@
+-- the tombstones would come back to life without deleted_at
+DELETE FROM entry WHERE deleted_at IS NOT NULL;
+DELETE FROM goal WHERE deleted_at IS NOT NULL;
+DELETE FROM tag WHERE deleted_at IS NOT NULL;
+DELETE FROM project WHERE deleted_at IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION update_total_count_hours()
+RETURNS TRIGGER AS $$
+BEGIN
+	IF (TG_OP = 'INSERT') THEN
+		UPDATE project
+		SET total_count_hours = total_count_hours + (EXTRACT(EPOCH FROM (NEW.time_end - NEW.time_start))) / 3600
+		WHERE id = NEW.project_id;
+	ELSIF (TG_OP = 'UPDATE') THEN
+		UPDATE project
+		SET total_count_hours = total_count_hours + (EXTRACT(EPOCH FROM (NEW.time_end - NEW.time_start)) - EXTRACT(EPOCH FROM (OLD.time_end - OLD.time_start))) / 3600
+		WHERE id = NEW.project_id;
+	ELSIF (TG_OP = 'DELETE') THEN
+		UPDATE project
+		SET total_count_hours = total_count_hours - (EXTRACT(EPOCH FROM (OLD.time_end - OLD.time_start))) / 3600
+		WHERE id = OLD.project_id;
+	END IF;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS sync_client_id;
+
+DROP INDEX IF EXISTS goal_user_id_updated_at_idx;
+DROP INDEX IF EXISTS tag_user_id_updated_at_idx;
+DROP INDEX IF EXISTS project_user_id_updated_at_idx;
+DROP INDEX IF EXISTS entry_user_id_updated_at_idx;
+
+ALTER TABLE goal DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE tag DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE project DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE entry DROP COLUMN IF EXISTS deleted_at;
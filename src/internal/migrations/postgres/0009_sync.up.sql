@@ -0,0 +1,53 @@
+-- deleted_at marks a tombstone: a deleted row is kept, so the deletion reaches the synced clients
+ALTER TABLE entry ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE project ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE tag ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE goal ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS entry_user_id_updated_at_idx ON entry (user_id, updated_at);
+CREATE INDEX IF NOT EXISTS project_user_id_updated_at_idx ON project (user_id, updated_at);
+CREATE INDEX IF NOT EXISTS tag_user_id_updated_at_idx ON tag (user_id, updated_at);
+CREATE INDEX IF NOT EXISTS goal_user_id_updated_at_idx ON goal (user_id, updated_at);
+
+-- the objects created offline, a retried batch of mutations finds them by the id the client gave them
+CREATE TABLE IF NOT EXISTS sync_client_id (
+	user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	client_id VARCHAR(64) NOT NULL,
+	object_type VARCHAR(16) NOT NULL,
+	object_id INT NOT NULL,
+	PRIMARY KEY (user_id, client_id)
+);
+
+-- only the entries that are not deleted count. A change of the hours is a change of the
+-- project for the synced clients, so it moves updated_at but not the version.
+CREATE OR REPLACE FUNCTION update_total_count_hours()
+RETURNS TRIGGER AS $$
+BEGIN
+	IF (TG_OP = 'UPDATE') THEN
+		IF (NEW.project_id IS NOT DISTINCT FROM OLD.project_id AND NEW.time_start = OLD.time_start
+			AND NEW.time_end = OLD.time_end AND (NEW.deleted_at IS NULL) = (OLD.deleted_at IS NULL)) THEN
+			RETURN NEW;
+		END IF;
+	END IF;
+
+	IF (TG_OP IN ('UPDATE', 'DELETE')) THEN
+		IF (OLD.deleted_at IS NULL) THEN
+			UPDATE project
+			SET total_count_hours = total_count_hours - (EXTRACT(EPOCH FROM (OLD.time_end - OLD.time_start))) / 3600,
+				updated_at = now() AT TIME ZONE 'UTC'
+			WHERE id = OLD.project_id;
+		END IF;
+	END IF;
+
+	IF (TG_OP IN ('INSERT', 'UPDATE')) THEN
+		IF (NEW.deleted_at IS NULL) THEN
+			UPDATE project
+			SET total_count_hours = total_count_hours + (EXTRACT(EPOCH FROM (NEW.time_end - NEW.time_start))) / 3600,
+				updated_at = now() AT TIME ZONE 'UTC'
+			WHERE id = NEW.project_id;
+		END IF;
+	END IF;
+
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
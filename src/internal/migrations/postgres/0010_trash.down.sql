@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS goal_deleted_at_idx;
+DROP INDEX IF EXISTS tag_deleted_at_idx;
+DROP INDEX IF EXISTS project_deleted_at_idx;
+DROP INDEX IF EXISTS entry_deleted_at_idx;
@@ -0,0 +1,5 @@
+-- the trash purge looks for the tombstones deleted before the retention period
+CREATE INDEX IF NOT EXISTS entry_deleted_at_idx ON entry (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS project_deleted_at_idx ON project (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS tag_deleted_at_idx ON tag (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS goal_deleted_at_idx ON goal (deleted_at) WHERE deleted_at IS NOT NULL;
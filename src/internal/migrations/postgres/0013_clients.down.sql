@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS project_client_id_idx;
+ALTER TABLE project DROP COLUMN IF EXISTS client_id;
+DROP TABLE IF EXISTS client;
@@ -0,0 +1,17 @@
+-- the clients of a user, a project can be done for one of them
+CREATE TABLE IF NOT EXISTS client (
+	id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	name VARCHAR(64) NOT NULL,
+	contact TEXT NOT NULL DEFAULT '',
+	hourly_rate FLOAT NOT NULL DEFAULT 0,
+	notes TEXT NOT NULL DEFAULT '',
+	version BIGINT NOT NULL DEFAULT 1,
+	updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS client_user_id_idx ON client (user_id);
+
+ALTER TABLE project ADD COLUMN IF NOT EXISTS client_id INT REFERENCES client(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS project_client_id_idx ON project (client_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS entry_task_id_idx;
+ALTER TABLE entry DROP COLUMN IF EXISTS task_id;
+DROP TABLE IF EXISTS task;
@@ -0,0 +1,16 @@
+-- the tasks of a project, an entry can be logged on one of them
+CREATE TABLE IF NOT EXISTS task (
+	id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	project_id INT NOT NULL REFERENCES project(id) ON DELETE CASCADE,
+	name VARCHAR(64) NOT NULL,
+	estimate_hours FLOAT NOT NULL DEFAULT 0,
+	done BOOLEAN NOT NULL DEFAULT false,
+	version BIGINT NOT NULL DEFAULT 1,
+	updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS task_project_id_idx ON task (project_id);
+
+ALTER TABLE entry ADD COLUMN IF NOT EXISTS task_id INT REFERENCES task(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS entry_task_id_idx ON entry (task_id);
@@ -0,0 +1,9 @@
+-- fresh sign ins to the identity provider confirming an action, e.g. the deletion of an
+-- account without a password. A row is deleted when it is used.
+CREATE TABLE IF NOT EXISTS confirmation (
+	token VARCHAR(64) PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	expire_time TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS confirmation_user_id_idx ON confirmation (user_id);
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS data_export;
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS external_identity;
+DROP TABLE IF EXISTS cookie;
+DROP TABLE IF EXISTS friend_relation;
+DROP TABLE IF EXISTS tag_entry;
+DROP TABLE IF EXISTS entry;
+DROP TABLE IF EXISTS goal;
+DROP TABLE IF EXISTS project;
+DROP TABLE IF EXISTS tag;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,144 @@
+CREATE TABLE users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name VARCHAR(35) NOT NULL,
+	email VARCHAR(254) NOT NULL UNIQUE,
+	about TEXT DEFAULT '',
+	role TEXT DEFAULT 'user' CHECK (role IN ('user', 'moderator', 'support', 'admin')),
+	password VARCHAR(128) NOT NULL,
+	suspended BOOLEAN NOT NULL DEFAULT false,
+	password_reset_token VARCHAR(64) UNIQUE,
+	password_reset_expire TIMESTAMP,
+	delete_after TIMESTAMP
+);
+
+CREATE TABLE tag (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	name VARCHAR(35) NOT NULL,
+	about TEXT DEFAULT '',
+	color VARCHAR(10) NOT NULL
+);
+
+CREATE TABLE project (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	name VARCHAR(35) NOT NULL,
+	about TEXT DEFAULT '',
+	color VARCHAR(10) NOT NULL,
+	is_private BOOLEAN NOT NULL,
+	total_count_hours FLOAT DEFAULT 0
+);
+
+CREATE TABLE goal (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	hours_count FLOAT NOT NULL,
+	name VARCHAR(35) NOT NULL,
+	project_id INTEGER NOT NULL REFERENCES project(id) ON DELETE CASCADE,
+	description TEXT DEFAULT '',
+	time_start TIMESTAMP NOT NULL,
+	time_end TIMESTAMP NOT NULL
+);
+
+CREATE TABLE entry (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	project_id INTEGER REFERENCES project(id) ON DELETE CASCADE,
+	description TEXT DEFAULT '',
+	time_start TIMESTAMP NOT NULL,
+	time_end TIMESTAMP NOT NULL
+);
+
+CREATE INDEX entry_user_id_idx ON entry (user_id);
+
+CREATE TABLE tag_entry (
+	tag_id INTEGER NOT NULL REFERENCES tag(id) ON DELETE CASCADE,
+	entry_id INTEGER NOT NULL REFERENCES entry(id) ON DELETE CASCADE,
+	PRIMARY KEY (tag_id, entry_id)
+);
+
+CREATE TABLE friend_relation (
+	subscriber_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	PRIMARY KEY (subscriber_id, user_id)
+);
+
+CREATE TABLE cookie (
+	session_token VARCHAR(64) PRIMARY KEY,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	expire_time TIMESTAMP NOT NULL,
+	csrf_token VARCHAR(64) DEFAULT ''
+);
+
+CREATE INDEX cookie_user_id_idx ON cookie (user_id);
+
+CREATE TABLE external_identity (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	provider VARCHAR(64) NOT NULL,
+	subject VARCHAR(255) NOT NULL,
+	email VARCHAR(254) DEFAULT '',
+	UNIQUE (provider, subject)
+);
+
+-- append-only: rows are only removed by the retention purge of the application.
+-- actor_id and target_id have no foreign keys so records outlive deleted users
+CREATE TABLE audit_log (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	actor_id INTEGER,
+	action VARCHAR(64) NOT NULL,
+	target_type VARCHAR(32) DEFAULT '',
+	target_id INTEGER,
+	ip VARCHAR(45) DEFAULT '',
+	request_id VARCHAR(64) DEFAULT '',
+	details TEXT DEFAULT ''
+);
+
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX audit_log_actor_id_idx ON audit_log (actor_id);
+CREATE INDEX audit_log_target_id_idx ON audit_log (target_id);
+
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+	SELECT RAISE(IGNORE);
+END;
+
+CREATE TABLE data_export (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'ready', 'failed', 'downloaded')),
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	archive BLOB
+);
+
+CREATE INDEX data_export_status_idx ON data_export (status);
+
+-- update_total_count_hours of the postgres schema, one trigger per operation.
+-- julianday() understands the timestamps with offsets written by the driver,
+-- the duration is rounded to milliseconds to drop its floating point noise
+CREATE TRIGGER update_total_count_hours_insert AFTER INSERT ON entry
+BEGIN
+	UPDATE project
+	SET total_count_hours = total_count_hours + ROUND((julianday(NEW.time_end) - julianday(NEW.time_start)) * 86400, 3) / 3600
+	WHERE id = NEW.project_id;
+END;
+
+CREATE TRIGGER update_total_count_hours_update AFTER UPDATE OF project_id, time_start, time_end ON entry
+BEGIN
+	UPDATE project
+	SET total_count_hours = total_count_hours - ROUND((julianday(OLD.time_end) - julianday(OLD.time_start)) * 86400, 3) / 3600
+	WHERE id = OLD.project_id;
+
+	UPDATE project
+	SET total_count_hours = total_count_hours + ROUND((julianday(NEW.time_end) - julianday(NEW.time_start)) * 86400, 3) / 3600
+	WHERE id = NEW.project_id;
+END;
+
+CREATE TRIGGER update_total_count_hours_delete AFTER DELETE ON entry
+BEGIN
+	UPDATE project
+	SET total_count_hours = total_count_hours - ROUND((julianday(OLD.time_end) - julianday(OLD.time_start)) * 86400, 3) / 3600
+	WHERE id = OLD.project_id;
+END;
@@ -0,0 +1,11 @@
+ALTER TABLE goal DROP COLUMN updated_at;
+ALTER TABLE goal DROP COLUMN version;
+
+ALTER TABLE tag DROP COLUMN updated_at;
+ALTER TABLE tag DROP COLUMN version;
+
+ALTER TABLE project DROP COLUMN updated_at;
+ALTER TABLE project DROP COLUMN version;
+
+ALTER TABLE entry DROP COLUMN updated_at;
+ALTER TABLE entry DROP COLUMN version;
@@ -0,0 +1,17 @@
+-- version is bumped by every update of a row, the API returns it as the ETag.
+-- ADD COLUMN can't have a CURRENT_TIMESTAMP default, the existing rows are set after it
+ALTER TABLE entry ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE entry ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+UPDATE entry SET updated_at = CURRENT_TIMESTAMP;
+
+ALTER TABLE project ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE project ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+UPDATE project SET updated_at = CURRENT_TIMESTAMP;
+
+ALTER TABLE tag ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE tag ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+UPDATE tag SET updated_at = CURRENT_TIMESTAMP;
+
+ALTER TABLE goal ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE goal ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+UPDATE goal SET updated_at = CURRENT_TIMESTAMP;
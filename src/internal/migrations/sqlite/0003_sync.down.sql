@@ -0,0 +1,46 @@
+-- the tombstones would come back to life without deleted_at
+DELETE FROM entry WHERE deleted_at IS NOT NULL;
+DELETE FROM goal WHERE deleted_at IS NOT NULL;
+DELETE FROM tag WHERE deleted_at IS NOT NULL;
+DELETE FROM project WHERE deleted_at IS NOT NULL;
+
+DROP TRIGGER update_total_count_hours_insert;
+DROP TRIGGER update_total_count_hours_update;
+DROP TRIGGER update_total_count_hours_delete;
+
+CREATE TRIGGER update_total_count_hours_insert AFTER INSERT ON entry
+BEGIN
+	UPDATE project
+	SET total_count_hours = total_count_hours + ROUND((julianday(NEW.time_end) - julianday(NEW.time_start)) * 86400, 3) / 3600
+	WHERE id = NEW.project_id;
+END;
+
+CREATE TRIGGER update_total_count_hours_update AFTER UPDATE OF project_id, time_start, time_end ON entry
+BEGIN
+	UPDATE project
+	SET total_count_hours = total_count_hours - ROUND((julianday(OLD.time_end) - julianday(OLD.time_start)) * 86400, 3) / 3600
+	WHERE id = OLD.project_id;
+
+	UPDATE project
+	SET total_count_hours = total_count_hours + ROUND((julianday(NEW.time_end) - julianday(NEW.time_start)) * 86400, 3) / 3600
+	WHERE id = NEW.project_id;
+END;
+
+CREATE TRIGGER update_total_count_hours_delete AFTER DELETE ON entry
+BEGIN
+	UPDATE project
+	SET total_count_hours = total_count_hours - ROUND((julianday(OLD.time_end) - julianday(OLD.time_start)) * 86400, 3) / 3600
+	WHERE id = OLD.project_id;
+END;
+
+DROP TABLE sync_client_id;
+
+DROP INDEX goal_user_id_updated_at_idx;
+DROP INDEX tag_user_id_updated_at_idx;
+DROP INDEX project_user_id_updated_at_idx;
+DROP INDEX entry_user_id_updated_at_idx;
+
+ALTER TABLE goal DROP COLUMN deleted_at;
+ALTER TABLE tag DROP COLUMN deleted_at;
+ALTER TABLE project DROP COLUMN deleted_at;
+ALTER TABLE entry DROP COLUMN deleted_at;
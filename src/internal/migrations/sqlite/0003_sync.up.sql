@@ -0,0 +1,59 @@
+-- deleted_at marks a tombstone: a deleted row is kept, so the deletion reaches the synced clients
+ALTER TABLE entry ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE project ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE tag ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE goal ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX entry_user_id_updated_at_idx ON entry (user_id, updated_at);
+CREATE INDEX project_user_id_updated_at_idx ON project (user_id, updated_at);
+CREATE INDEX tag_user_id_updated_at_idx ON tag (user_id, updated_at);
+CREATE INDEX goal_user_id_updated_at_idx ON goal (user_id, updated_at);
+
+-- the objects created offline, a retried batch of mutations finds them by the id the client gave them
+CREATE TABLE sync_client_id (
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	client_id TEXT NOT NULL,
+	object_type TEXT NOT NULL,
+	object_id INTEGER NOT NULL,
+	PRIMARY KEY (user_id, client_id)
+);
+
+-- only the entries that are not deleted count. A change of the hours is a change of the
+-- project for the synced clients, so it moves updated_at but not the version. The time
+-- has the format of the driver, so it compares with the ones written by the application.
+DROP TRIGGER update_total_count_hours_insert;
+DROP TRIGGER update_total_count_hours_update;
+DROP TRIGGER update_total_count_hours_delete;
+
+CREATE TRIGGER update_total_count_hours_insert AFTER INSERT ON entry
+WHEN NEW.deleted_at IS NULL
+BEGIN
+	UPDATE project
+	SET total_count_hours = total_count_hours + ROUND((julianday(NEW.time_end) - julianday(NEW.time_start)) * 86400, 3) / 3600,
+		updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+	WHERE id = NEW.project_id;
+END;
+
+CREATE TRIGGER update_total_count_hours_update AFTER UPDATE OF project_id, time_start, time_end, deleted_at ON entry
+WHEN NEW.project_id IS NOT OLD.project_id OR NEW.time_start IS NOT OLD.time_start OR NEW.time_end IS NOT OLD.time_end
+	OR (NEW.deleted_at IS NULL) <> (OLD.deleted_at IS NULL)
+BEGIN
+	UPDATE project
+	SET total_count_hours = total_count_hours - ROUND((julianday(OLD.time_end) - julianday(OLD.time_start)) * 86400, 3) / 3600,
+		updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+	WHERE id = OLD.project_id AND OLD.deleted_at IS NULL;
+
+	UPDATE project
+	SET total_count_hours = total_count_hours + ROUND((julianday(NEW.time_end) - julianday(NEW.time_start)) * 86400, 3) / 3600,
+		updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+	WHERE id = NEW.project_id AND NEW.deleted_at IS NULL;
+END;
+
+CREATE TRIGGER update_total_count_hours_delete AFTER DELETE ON entry
+WHEN OLD.deleted_at IS NULL
+BEGIN
+	UPDATE project
+	SET total_count_hours = total_count_hours - ROUND((julianday(OLD.time_end) - julianday(OLD.time_start)) * 86400, 3) / 3600,
+		updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+	WHERE id = OLD.project_id;
+END;
@@ -0,0 +1 @@
+ALTER TABLE project DROP COLUMN archived_at;
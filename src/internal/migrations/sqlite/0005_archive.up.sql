@@ -0,0 +1,2 @@
+-- an archived project is hidden from the listings and takes no new entries or goals
+ALTER TABLE project ADD COLUMN archived_at TIMESTAMP;
@@ -0,0 +1,16 @@
+-- the users of a shared project with their role, a row without accepted_at is a pending invitation.
+-- The owner of every project is its member too.
+CREATE TABLE project_member (
+	project_id INTEGER NOT NULL REFERENCES project(id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	role VARCHAR(16) NOT NULL,
+	invited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	accepted_at TIMESTAMP,
+	PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX project_member_user_id_idx ON project_member (user_id);
+
+INSERT INTO project_member (project_id, user_id, role, accepted_at)
+SELECT id, user_id, 'owner', CURRENT_TIMESTAMP FROM project;
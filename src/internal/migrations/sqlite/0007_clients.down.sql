@@ -0,0 +1,3 @@
+DROP INDEX project_client_id_idx;
+ALTER TABLE project DROP COLUMN client_id;
+DROP TABLE client;
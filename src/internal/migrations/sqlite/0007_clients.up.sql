@@ -0,0 +1,17 @@
+-- the clients of a user, a project can be done for one of them
+CREATE TABLE client (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	name VARCHAR(64) NOT NULL,
+	contact TEXT NOT NULL DEFAULT '',
+	hourly_rate FLOAT NOT NULL DEFAULT 0,
+	notes TEXT NOT NULL DEFAULT '',
+	version INTEGER NOT NULL DEFAULT 1,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX client_user_id_idx ON client (user_id);
+
+ALTER TABLE project ADD COLUMN client_id INTEGER REFERENCES client(id) ON DELETE SET NULL;
+
+CREATE INDEX project_client_id_idx ON project (client_id);
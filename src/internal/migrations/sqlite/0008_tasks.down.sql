@@ -0,0 +1,3 @@
+DROP INDEX entry_task_id_idx;
+ALTER TABLE entry DROP COLUMN task_id;
+DROP TABLE task;
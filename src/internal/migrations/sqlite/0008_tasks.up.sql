@@ -0,0 +1,16 @@
+-- the tasks of a project, an entry can be logged on one of them
+CREATE TABLE task (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	project_id INTEGER NOT NULL REFERENCES project(id) ON DELETE CASCADE,
+	name VARCHAR(64) NOT NULL,
+	estimate_hours FLOAT NOT NULL DEFAULT 0,
+	done BOOLEAN NOT NULL DEFAULT false,
+	version INTEGER NOT NULL DEFAULT 1,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX task_project_id_idx ON task (project_id);
+
+ALTER TABLE entry ADD COLUMN task_id INTEGER REFERENCES task(id) ON DELETE SET NULL;
+
+CREATE INDEX entry_task_id_idx ON entry (task_id);
@@ -0,0 +1,10 @@
+-- the tombstones of the memberships: a removed member syncs the shared project away.
+-- Accepting a new invitation to the project drops the tombstone.
+CREATE TABLE project_member_removal (
+	project_id INTEGER NOT NULL REFERENCES project(id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	removed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX project_member_removal_user_id_idx ON project_member_removal (user_id, removed_at);
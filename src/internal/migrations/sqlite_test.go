@@ -0,0 +1,98 @@
+package migrations_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+	"timetracker/internal/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db") + "?_foreign_keys=on"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Skip("sqlite is unavailable: ", err)
+	}
+
+	return db
+}
+
+func TestSQLiteMigrations(t *testing.T) {
+	db := openSQLite(t)
+
+	migrator, err := migrations.NewSQLite(db)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, migrator.Check(), migrations.ErrSchemaOutdated)
+
+	version, err := migrator.Version()
+	require.NoError(t, err)
+	assert.Zero(t, version)
+	assert.False(t, db.Migrator().HasTable("schema_migrations"), "checking the version must not create the table")
+
+	applied, err := migrator.Up()
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrator.Migrations()))
+	assert.NoError(t, migrator.Check())
+
+	version, err = migrator.Version()
+	require.NoError(t, err)
+	assert.Equal(t, migrator.Latest(), version)
+
+	for range migrator.Migrations() {
+		_, err = migrator.Down()
+		require.NoError(t, err)
+	}
+
+	_, err = migrator.Down()
+	assert.ErrorIs(t, err, migrations.ErrNoMigration)
+}
+
+func TestSQLiteTotalCountHours(t *testing.T) {
+	db := openSQLite(t)
+
+	migrator, err := migrations.NewSQLite(db)
+	require.NoError(t, err)
+	_, err = migrator.Up()
+	require.NoError(t, err)
+
+	timeStart := time.Date(2023, 5, 1, 10, 0, 0, 0, time.FixedZone("MSK", 3*3600))
+	totalCountHours := func() float64 {
+		var hours float64
+		require.NoError(t, db.Raw("SELECT total_count_hours FROM project WHERE id = 1").Scan(&hours).Error)
+		return hours
+	}
+
+	require.NoError(t, db.Exec("INSERT INTO users (name, email, password) VALUES ('test', 'test', '')").Error)
+	require.NoError(t, db.Exec("INSERT INTO project (user_id, name, color, is_private) VALUES (1, 'p', '#fff', false)").Error)
+
+	require.NoError(t, db.Exec("INSERT INTO entry (user_id, project_id, time_start, time_end) VALUES (1, 1, ?, ?)",
+		timeStart, timeStart.Add(150*time.Minute)).Error)
+	assert.Equal(t, 2.5, totalCountHours())
+
+	require.NoError(t, db.Exec("UPDATE entry SET time_end = ? WHERE id = 1", timeStart.UTC().Add(time.Hour)).Error)
+	assert.Equal(t, 1.0, totalCountHours())
+
+	require.NoError(t, db.Exec("UPDATE entry SET description = 'not the hours' WHERE id = 1").Error)
+	assert.Equal(t, 1.0, totalCountHours())
+
+	// a tombstone doesn't count, restoring it counts again
+	require.NoError(t, db.Exec("UPDATE entry SET deleted_at = ? WHERE id = 1", timeStart).Error)
+	assert.Equal(t, 0.0, totalCountHours())
+
+	require.NoError(t, db.Exec("UPDATE entry SET deleted_at = NULL WHERE id = 1").Error)
+	assert.Equal(t, 1.0, totalCountHours())
+
+	require.NoError(t, db.Exec("DELETE FROM entry WHERE id = 1").Error)
+	assert.Equal(t, 0.0, totalCountHours())
+
+	require.NoError(t, db.Exec("INSERT INTO entry (user_id, project_id, time_start, time_end, deleted_at) VALUES (1, 1, ?, ?, ?)",
+		timeStart, timeStart.Add(time.Hour), timeStart).Error)
+	require.NoError(t, db.Exec("DELETE FROM entry WHERE id = 2").Error)
+	assert.Equal(t, 0.0, totalCountHours())
+}
@@ -0,0 +1,77 @@
+package policy
+
+import (
+	"timetracker/models"
+)
+
+var supportPermissions = []models.Permission{
+	models.PermEntryReadAny,
+	models.PermProjectReadAny,
+	models.PermTagReadAny,
+	models.PermGoalReadAny,
+	models.PermUserReadAny,
+	models.PermUserList,
+	models.PermFriendsReadAny,
+}
+
+var moderatorPermissions = append([]models.Permission{
+	models.PermUserBan,
+}, supportPermissions...)
+
+var adminPermissions = append([]models.Permission{
+	models.PermUserRoleChange,
+	models.PermUserPassReset,
+	models.PermUserDelete,
+	models.PermAuditRead,
+	models.PermLogLevel,
+}, moderatorPermissions...)
+
+var rolePermissions = map[models.RoleType]map[models.Permission]bool{
+	models.DefaultUser: {},
+	models.Support:     toSet(supportPermissions),
+	models.Moderator:   toSet(moderatorPermissions),
+	models.Admin:       toSet(adminPermissions),
+}
+
+func toSet(perms []models.Permission) map[models.Permission]bool {
+	set := make(map[models.Permission]bool, len(perms))
+	for _, perm := range perms {
+		set[perm] = true
+	}
+
+	return set
+}
+
+// HasPermission is the route level check. Unknown roles have no permissions.
+func HasPermission(role string, perm models.Permission) bool {
+	roleType, ok := models.RoleFromString(role)
+	if !ok {
+		return false
+	}
+
+	return rolePermissions[roleType][perm]
+}
+
+// Authorize is the resource level check: the owner of the resource is always allowed,
+// anybody else needs perm.
+func Authorize(user *models.User, ownerID uint64, perm models.Permission) error {
+	if user == nil {
+		return models.ErrPermissionDenied
+	}
+
+	if user.ID == ownerID || HasPermission(user.Role, perm) {
+		return nil
+	}
+
+	return models.ErrPermissionDenied
+}
+
+// AuthorizeOwner is the resource level check for writes: only the owner changes, deletes
+// or restores the resource, roles grant no writes. A resource without an owner can't be written.
+func AuthorizeOwner(userID uint64, ownerID *uint64) error {
+	if ownerID == nil || *ownerID != userID {
+		return models.ErrPermissionDenied
+	}
+
+	return nil
+}
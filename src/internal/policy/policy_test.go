@@ -0,0 +1,142 @@
+package policy_test
+
+import (
+	"testing"
+	"timetracker/internal/policy"
+	"timetracker/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestCaseHasPermission struct {
+	Role     string
+	Perm     models.Permission
+	Expected bool
+}
+
+type TestCaseAuthorize struct {
+	User    *models.User
+	OwnerID uint64
+	Error   error
+}
+
+func TestHasPermission(t *testing.T) {
+	cases := map[string]TestCaseHasPermission{
+		"user_read_any": {
+			Role:     models.DefaultUser.String(),
+			Perm:     models.PermEntryReadAny,
+			Expected: false,
+		},
+		"support_read_any": {
+			Role:     models.Support.String(),
+			Perm:     models.PermEntryReadAny,
+			Expected: true,
+		},
+		"support_ban": {
+			Role:     models.Support.String(),
+			Perm:     models.PermUserBan,
+			Expected: false,
+		},
+		"moderator_ban": {
+			Role:     models.Moderator.String(),
+			Perm:     models.PermUserBan,
+			Expected: true,
+		},
+		"moderator_role_change": {
+			Role:     models.Moderator.String(),
+			Perm:     models.PermUserRoleChange,
+			Expected: false,
+		},
+		"admin_role_change": {
+			Role:     models.Admin.String(),
+			Perm:     models.PermUserRoleChange,
+			Expected: true,
+		},
+		"moderator_log_level": {
+			Role:     models.Moderator.String(),
+			Perm:     models.PermLogLevel,
+			Expected: false,
+		},
+		"admin_log_level": {
+			Role:     models.Admin.String(),
+			Perm:     models.PermLogLevel,
+			Expected: true,
+		},
+		"unknown_role": {
+			Role:     "root",
+			Perm:     models.PermUserList,
+			Expected: false,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, policy.HasPermission(test.Role, test.Perm))
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	cases := map[string]TestCaseAuthorize{
+		"owner": {
+			User:    &models.User{ID: 1, Role: models.DefaultUser.String()},
+			OwnerID: 1,
+			Error:   nil,
+		},
+		"other_user": {
+			User:    &models.User{ID: 2, Role: models.DefaultUser.String()},
+			OwnerID: 1,
+			Error:   models.ErrPermissionDenied,
+		},
+		"support": {
+			User:    &models.User{ID: 3, Role: models.Support.String()},
+			OwnerID: 1,
+			Error:   nil,
+		},
+		"no_user": {
+			User:    nil,
+			OwnerID: 1,
+			Error:   models.ErrPermissionDenied,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Error, policy.Authorize(test.User, test.OwnerID, models.PermEntryReadAny))
+		})
+	}
+}
+
+type TestCaseAuthorizeOwner struct {
+	UserID  uint64
+	OwnerID *uint64
+	Error   error
+}
+
+func TestAuthorizeOwner(t *testing.T) {
+	var ownerID uint64 = 1
+
+	cases := map[string]TestCaseAuthorizeOwner{
+		"owner": {
+			UserID:  1,
+			OwnerID: &ownerID,
+			Error:   nil,
+		},
+		"other_user": {
+			UserID:  2,
+			OwnerID: &ownerID,
+			Error:   models.ErrPermissionDenied,
+		},
+		"no_owner": {
+			UserID:  1,
+			OwnerID: nil,
+			Error:   models.ErrPermissionDenied,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Error, policy.AuthorizeOwner(test.UserID, test.OwnerID))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type redisHook struct {
+	client attribute.KeyValue
+}
+
+// NewRedisHook starts a client span for every command and pipeline of a redis client.
+// Only the command names are recorded, the keys and values hold session tokens.
+func NewRedisHook(client string) redis.Hook {
+	return &redisHook{client: attribute.String("db.redis.client", client)}
+}
+
+func (h *redisHook) start(ctx context.Context, name string, statement string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemRedis, h.client, semconv.DBOperation(statement)),
+	)
+}
+
+func (h *redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		ctx, span := h.start(ctx, "redis.dial", "dial")
+		defer span.End()
+
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			RecordError(span, err)
+		}
+
+		return conn, err
+	}
+}
+
+func (h *redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := h.start(ctx, "redis."+cmd.Name(), cmd.Name())
+		defer span.End()
+
+		err := next(ctx, cmd)
+		if err != nil && !errors.Is(err, redis.Nil) {
+			RecordError(span, err)
+		}
+
+		return err
+	}
+}
+
+func (h *redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, cmd.Name())
+		}
+
+		ctx, span := h.start(ctx, "redis.pipeline", strings.Join(names, " "))
+		defer span.End()
+
+		err := next(ctx, cmds)
+		if err != nil && !errors.Is(err, redis.Nil) {
+			RecordError(span, err)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,170 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"timetracker/internal/tracing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceparent   = "00-" + parentTraceID + "-00f067aa0ba902b7-01"
+)
+
+func record(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+	return recorder
+}
+
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+	return names
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := record(t)
+
+	var traceID string
+	e := echo.New()
+	e.Use(tracing.Middleware("/healthz"))
+	e.GET("/entry/:id", func(c echo.Context) error {
+		ctx, span := tracing.Start(c.Request().Context(), "entry.Usecase.GetEntry")
+		defer span.End()
+
+		traceID = tracing.TraceID(ctx)
+		if c.Param("id") == "0" {
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/healthz", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/entry/1", nil)
+	req.Header.Set("traceparent", traceparent)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/entry/0", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	spans := recorder.Ended()
+	require.Equal(t, []string{"entry.Usecase.GetEntry", "GET /entry/:id", "entry.Usecase.GetEntry", "GET /entry/:id"}, spanNames(spans))
+
+	usecaseSpan, serverSpan := spans[0], spans[1]
+	assert.Equal(t, parentTraceID, serverSpan.SpanContext().TraceID().String())
+	assert.Equal(t, serverSpan.SpanContext().SpanID(), usecaseSpan.Parent().SpanID())
+	assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+	assert.Contains(t, serverSpan.Attributes(), semconv.HTTPRoute("/entry/:id"))
+	assert.Contains(t, serverSpan.Attributes(), semconv.HTTPStatusCode(http.StatusOK))
+
+	failedSpan := spans[3]
+	assert.NotEqual(t, parentTraceID, failedSpan.SpanContext().TraceID().String())
+	assert.Equal(t, failedSpan.SpanContext().TraceID().String(), traceID)
+	assert.Equal(t, codes.Error, failedSpan.Status().Code)
+	assert.Contains(t, failedSpan.Attributes(), semconv.HTTPStatusCode(http.StatusInternalServerError))
+}
+
+func TestTraceID(t *testing.T) {
+	record(t)
+
+	e := echo.New()
+	e.Use(tracing.Middleware())
+
+	var traceID string
+	e.GET("/entry/:id", func(c echo.Context) error {
+		traceID = tracing.TraceID(c.Request().Context())
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/entry/1", nil)
+	req.Header.Set("traceparent", traceparent)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, parentTraceID, traceID)
+	assert.Empty(t, tracing.TraceID(context.Background()))
+}
+
+type testEntry struct {
+	ID          uint64
+	Description string
+}
+
+func TestGormPlugin(t *testing.T) {
+	recorder := record(t)
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(tracing.NewGormPlugin(semconv.DBSystemSqlite)))
+	require.NoError(t, db.AutoMigrate(&testEntry{}))
+
+	ctx, parent := tracing.Start(context.Background(), "entry.Usecase.CreateEntry")
+	require.NoError(t, db.WithContext(ctx).Create(&testEntry{Description: "work"}).Error)
+
+	var entry testEntry
+	err = db.WithContext(ctx).Where("id = ?", 42).Take(&entry).Error
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	parent.End()
+
+	var spans []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Parent().SpanID() == parent.SpanContext().SpanID() {
+			spans = append(spans, span)
+		}
+	}
+
+	require.Equal(t, []string{"gorm.create", "gorm.query"}, spanNames(spans))
+	for _, span := range spans {
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Contains(t, span.Attributes(), semconv.DBSystemSqlite)
+		assert.Contains(t, span.Attributes(), semconv.DBSQLTable("test_entries"))
+		// a missing row is not a failure
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	}
+	assert.Contains(t, spans[1].Attributes(), semconv.DBStatement("SELECT * FROM `test_entries` WHERE id = ? LIMIT 1"))
+}
+
+func TestRedisHook(t *testing.T) {
+	recorder := record(t)
+
+	// nothing listens there, so the command fails on dial
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+	client.AddHook(tracing.NewRedisHook("cache"))
+
+	err := client.Get(context.Background(), "projects:1").Err()
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Equal(t, []string{"redis.dial", "redis.get"}, spanNames(spans))
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	for _, span := range spans {
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Contains(t, span.Attributes(), semconv.DBSystemRedis)
+	}
+}
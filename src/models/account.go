@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type ExportStatus string
+
+const (
+	ExportPending    ExportStatus = "pending"
+	ExportProcessing ExportStatus = "processing"
+	ExportReady      ExportStatus = "ready"
+	ExportFailed     ExportStatus = "failed"
+	ExportDownloaded ExportStatus = "downloaded"
+)
+
+// DataExport is an archive of the personal data of a user. It is built in the background
+// and can be downloaded exactly once, Archive is only loaded for the download.
+type DataExport struct {
+	ID        uint64
+	UserID    uint64
+	Status    ExportStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Archive   []byte
+}
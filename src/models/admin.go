@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// UserSearchParams is the filter of the admin user listing. Empty fields are not applied.
+type UserSearchParams struct {
+	Query     string
+	Role      string
+	Suspended *bool
+	Limit     int
+	Offset    int
+}
+
+// UserUsage is the amount of data owned by a user. StorageBytes is the on-disk size of the rows.
+type UserUsage struct {
+	UserID       uint64
+	Entries      uint64
+	Projects     uint64
+	Tags         uint64
+	Goals        uint64
+	StorageBytes uint64
+}
+
+// PasswordReset is handed out to the admin once, only the hash of the token is stored.
+type PasswordReset struct {
+	UserID    uint64
+	Token     string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditSignIn            AuditAction = "auth.signin"
+	AuditSignInFailed      AuditAction = "auth.signin_failed"
+	AuditSignOut           AuditAction = "auth.signout"
+	AuditPasswordReset     AuditAction = "auth.password_reset"
+	AuditAdminRead         AuditAction = "admin.read"
+	AuditRoleChange        AuditAction = "user.role_change"
+	AuditUserSuspend       AuditAction = "user.suspend"
+	AuditUserReactivate    AuditAction = "user.reactivate"
+	AuditUserPasswordReset AuditAction = "user.password_reset"
+	AuditUserDelete        AuditAction = "user.delete"
+	AuditDataExport        AuditAction = "user.data_export"
+	AuditLogLevelChange    AuditAction = "admin.log_level"
+)
+
+const AuditTargetUser = "user"
+
+// AuditRecord is an append-only log entry. ActorID is nil for anonymous requests,
+// e.g. failed sign-ins.
+type AuditRecord struct {
+	ID         uint64
+	CreatedAt  time.Time
+	ActorID    *uint64
+	Action     AuditAction
+	TargetType string
+	TargetID   *uint64
+	IP         string
+	RequestID  string
+	Details    string
+}
+
+// AuditFilter selects records of the admin query API. Zero fields are not applied.
+type AuditFilter struct {
+	ActorID  *uint64
+	TargetID *uint64
+	Action   AuditAction
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+	Offset   int
+}
@@ -7,3 +7,11 @@ type Cookie struct {
 	UserID       uint64
 	MaxAge       time.Duration
 }
+
+// Confirmation proves a fresh sign in to the identity provider, an account without
+// a password confirms its deletion with it. It can be taken once.
+type Confirmation struct {
+	Token      string
+	UserID     uint64
+	ExpireTime time.Time
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Client is a customer of the user, projects are grouped by it
+type Client struct {
+	ID      uint64
+	UserID  uint64
+	Name    string
+	Contact string
+	// HourlyRate is the default rate of the work done for the client
+	HourlyRate float64
+	Notes      string
+	Version    uint64
+	UpdatedAt  time.Time
+}
+
+// ClientPatch holds the fields of a partial update, a nil field is left as it is
+type ClientPatch struct {
+	ID     uint64
+	UserID uint64
+	// Version is the one of the If-Match header, 0 updates any version
+	Version    uint64
+	Name       *string
+	Contact    *string
+	HourlyRate *float64
+	Notes      *string
+}
+
+func (p *ClientPatch) Apply(c *Client) {
+	if p.Name != nil {
+		c.Name = *p.Name
+	}
+	if p.Contact != nil {
+		c.Contact = *p.Contact
+	}
+	if p.HourlyRate != nil {
+		c.HourlyRate = *p.HourlyRate
+	}
+	if p.Notes != nil {
+		c.Notes = *p.Notes
+	}
+}
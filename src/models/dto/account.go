@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+	"timetracker/models"
+)
+
+type ReqDeleteAccount struct {
+	Password string `json:"password"`
+}
+
+type RespDeleteAccount struct {
+	DeleteAfter time.Time `json:"delete_after"`
+}
+
+type RespConfirmation struct {
+	ExpireTime time.Time `json:"expire_time"`
+}
+
+type RespDataExport struct {
+	ID        uint64    `json:"id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func GetResponseFromModelDataExport(export *models.DataExport) *RespDataExport {
+	return &RespDataExport{
+		ID:        export.ID,
+		Status:    string(export.Status),
+		CreatedAt: export.CreatedAt,
+		UpdatedAt: export.UpdatedAt,
+	}
+}
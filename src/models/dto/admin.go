@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"time"
+	"timetracker/models"
+)
+
+type ReqChangeRole struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type RespUserPage struct {
+	Users  []*RespUser `json:"users"`
+	Total  uint64      `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+type RespUserUsage struct {
+	UserID       uint64 `json:"user_id"`
+	Entries      uint64 `json:"entries"`
+	Projects     uint64 `json:"projects"`
+	Tags         uint64 `json:"tags"`
+	Goals        uint64 `json:"goals"`
+	StorageBytes uint64 `json:"storage_bytes"`
+}
+
+type RespPasswordReset struct {
+	UserID    uint64    `json:"user_id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func GetResponseUserPage(users []*models.User, total uint64, params *models.UserSearchParams) *RespUserPage {
+	return &RespUserPage{
+		Users:  GetResponseFromModelUsers(users),
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+}
+
+func GetResponseFromModelUserUsage(usage *models.UserUsage) *RespUserUsage {
+	return &RespUserUsage{
+		UserID:       usage.UserID,
+		Entries:      usage.Entries,
+		Projects:     usage.Projects,
+		Tags:         usage.Tags,
+		Goals:        usage.Goals,
+		StorageBytes: usage.StorageBytes,
+	}
+}
+
+func GetResponseFromModelPasswordReset(reset *models.PasswordReset) *RespPasswordReset {
+	return &RespPasswordReset{
+		UserID:    reset.UserID,
+		Token:     reset.Token,
+		ExpiresAt: reset.ExpiresAt,
+	}
+}
+
+type ReqLogLevel struct {
+	// Package is empty for the root level
+	Package string `json:"package"`
+	Level   string `json:"level"`
+}
+
+type RespLogLevel struct {
+	Level    string            `json:"level"`
+	Packages map[string]string `json:"packages"`
+}
+
+func GetResponseLogLevel(level string, packages map[string]string) *RespLogLevel {
+	return &RespLogLevel{
+		Level:    level,
+		Packages: packages,
+	}
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+	"timetracker/models"
+)
+
+type RespAuditRecord struct {
+	ID         uint64    `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	ActorID    *uint64   `json:"actor_id"`
+	Action     string    `json:"action"`
+	TargetType string    `json:"target_type,omitempty"`
+	TargetID   *uint64   `json:"target_id,omitempty"`
+	IP         string    `json:"ip"`
+	RequestID  string    `json:"request_id"`
+	Details    string    `json:"details,omitempty"`
+}
+
+type RespAuditPage struct {
+	Records []*RespAuditRecord `json:"records"`
+	Total   uint64             `json:"total"`
+	Limit   int                `json:"limit"`
+	Offset  int                `json:"offset"`
+}
+
+func GetResponseFromModelAuditRecord(record *models.AuditRecord) *RespAuditRecord {
+	return &RespAuditRecord{
+		ID:         record.ID,
+		CreatedAt:  record.CreatedAt,
+		ActorID:    record.ActorID,
+		Action:     string(record.Action),
+		TargetType: record.TargetType,
+		TargetID:   record.TargetID,
+		IP:         record.IP,
+		RequestID:  record.RequestID,
+		Details:    record.Details,
+	}
+}
+
+func GetResponseAuditPage(records []*models.AuditRecord, total uint64, filter *models.AuditFilter) *RespAuditPage {
+	result := make([]*RespAuditRecord, 0, len(records))
+	for _, record := range records {
+		result = append(result, GetResponseFromModelAuditRecord(record))
+	}
+
+	return &RespAuditPage{
+		Records: result,
+		Total:   total,
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	}
+}
@@ -39,21 +39,32 @@ func (req *ReqUserSignUp) ToModelUser() *models.User {
 	}
 }
 
+type ReqPasswordReset struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type RespCSRF struct {
+	CSRFToken string `json:"csrf_token"`
+}
+
 type RespUser struct {
-	ID    uint64 `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	About string `json:"about"`
-	Role  string `json:"role"`
+	ID        uint64 `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	About     string `json:"about"`
+	Role      string `json:"role"`
+	Suspended bool   `json:"suspended"`
 }
 
 func GetResponseFromModelUser(user *models.User) *RespUser {
 	return &RespUser{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-		About: user.About,
-		Role:  user.Role,
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		About:     user.About,
+		Role:      user.Role,
+		Suspended: user.Suspended,
 	}
 }
 
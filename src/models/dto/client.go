@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+	"timetracker/models"
+	"timetracker/pkg"
+)
+
+type ReqCreateClient struct {
+	Name       string  `json:"name" validate:"required,max=64"`
+	Contact    string  `json:"contact"`
+	HourlyRate float64 `json:"hourly_rate" validate:"min=0"`
+	Notes      string  `json:"notes"`
+}
+
+func (req *ReqCreateClient) ToModelClient() *models.Client {
+	return &models.Client{
+		Name:       req.Name,
+		Contact:    req.Contact,
+		HourlyRate: req.HourlyRate,
+		Notes:      req.Notes,
+	}
+}
+
+// ReqPatchClient only changes the fields present in the body
+type ReqPatchClient struct {
+	Name       *string  `json:"name" validate:"omitempty,nonzero,max=64"`
+	Contact    *string  `json:"contact"`
+	HourlyRate *float64 `json:"hourly_rate" validate:"omitempty,min=0"`
+	Notes      *string  `json:"notes"`
+}
+
+func (req *ReqPatchClient) ToModelClientPatch() *models.ClientPatch {
+	return &models.ClientPatch{
+		Name:       req.Name,
+		Contact:    req.Contact,
+		HourlyRate: req.HourlyRate,
+		Notes:      req.Notes,
+	}
+}
+
+type RespClient struct {
+	ID         uint64    `json:"id"`
+	UserID     uint64    `json:"user_id"`
+	Name       string    `json:"name"`
+	Contact    string    `json:"contact"`
+	HourlyRate float64   `json:"hourly_rate"`
+	Notes      string    `json:"notes"`
+	Version    uint64    `json:"version"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func GetResponseFromModelClient(client *models.Client) *RespClient {
+	return &RespClient{
+		ID:         client.ID,
+		UserID:     client.UserID,
+		Name:       client.Name,
+		Contact:    client.Contact,
+		HourlyRate: client.HourlyRate,
+		Notes:      client.Notes,
+		Version:    client.Version,
+		UpdatedAt:  client.UpdatedAt,
+	}
+}
+
+func GetETagFromModelClient(client *models.Client) string {
+	return pkg.ETag(client.Version)
+}
+
+func GetResponseFromModelClients(clients []*models.Client) []*RespClient {
+	result := make([]*RespClient, 0, len(clients))
+	for _, client := range clients {
+		result = append(result, GetResponseFromModelClient(client))
+	}
+
+	return result
+}
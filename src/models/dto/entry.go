@@ -3,11 +3,13 @@ package dto
 import (
 	"time"
 	"timetracker/models"
+	"timetracker/pkg"
 )
 
 type ReqCreateUpdateEntry struct {
 	ID          uint64    `json:"id"`
 	ProjectID   *uint64   `json:"project_id"`
+	TaskID      *uint64   `json:"task_id"`
 	Description string    `json:"description"`
 	TagList     []uint64  `json:"tag_list"`
 	TimeStart   time.Time `json:"time_start" validate:"required"`
@@ -24,6 +26,7 @@ func (req *ReqCreateUpdateEntry) ToModelEntry() *models.Entry {
 	return &models.Entry{
 		ID:          req.ID,
 		ProjectID:   req.ProjectID,
+		TaskID:      req.TaskID,
 		Description: req.Description,
 		TagList:     tagListModel,
 		TimeEnd:     req.TimeEnd,
@@ -31,15 +34,53 @@ func (req *ReqCreateUpdateEntry) ToModelEntry() *models.Entry {
 	}
 }
 
+// ReqPatchEntry only changes the fields present in the body. A null project_id removes
+// the project, a null task_id the task and an empty tag_list removes all tags.
+type ReqPatchEntry struct {
+	ID          uint64                `json:"id"`
+	ProjectID   pkg.Optional[*uint64] `json:"project_id" swaggertype:"integer"`
+	TaskID      pkg.Optional[*uint64] `json:"task_id" swaggertype:"integer"`
+	Description *string               `json:"description"`
+	TagList     *[]uint64             `json:"tag_list"`
+	TimeStart   *time.Time            `json:"time_start" validate:"omitempty,nonzero"`
+	TimeEnd     *time.Time            `json:"time_end" validate:"omitempty,nonzero"`
+}
+
+func (req *ReqPatchEntry) ToModelEntryPatch() *models.EntryPatch {
+	patch := &models.EntryPatch{
+		ID:          req.ID,
+		ProjectID:   req.ProjectID,
+		TaskID:      req.TaskID,
+		Description: req.Description,
+		TimeStart:   req.TimeStart,
+		TimeEnd:     req.TimeEnd,
+	}
+
+	if req.TagList != nil {
+		tagListModel := make([]models.Tag, len(*req.TagList))
+		for idx, tagID := range *req.TagList {
+			tagListModel[idx] = models.Tag{ID: tagID}
+		}
+		patch.TagList = &tagListModel
+	}
+
+	return patch
+}
+
 type RespEntry struct {
 	ID          uint64       `json:"id"`
 	UserID      *uint64      `json:"user_id"`
 	ProjectID   *uint64      `json:"project_id"`
+	TaskID      *uint64      `json:"task_id"`
 	Description string       `json:"description"`
 	TagList     []models.Tag `json:"tag_list"`
 	TimeStart   time.Time    `json:"time_start"`
 	TimeEnd     time.Time    `json:"time_end"`
 	Duration    string       `json:"duration"`
+	Version     uint64       `json:"version"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	// DeletedAt is only set on the items in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 func GetResponseFromModelEntry(entry *models.Entry) *RespEntry {
@@ -49,14 +90,28 @@ func GetResponseFromModelEntry(entry *models.Entry) *RespEntry {
 		ID:          entry.ID,
 		UserID:      entry.UserID,
 		ProjectID:   entry.ProjectID,
+		TaskID:      entry.TaskID,
 		Description: entry.Description,
 		TagList:     entry.TagList,
 		TimeEnd:     entry.TimeEnd,
 		TimeStart:   entry.TimeStart,
 		Duration:    entry.Duration,
+		Version:     entry.Version,
+		UpdatedAt:   entry.UpdatedAt,
+		DeletedAt:   entry.DeletedAt,
 	}
 }
 
+// GetETagFromModelEntry covers the tags of the entry too, they are a part of its body
+func GetETagFromModelEntry(entry *models.Entry) string {
+	derived := make([]interface{}, 0, 2*len(entry.TagList))
+	for _, tag := range entry.TagList {
+		derived = append(derived, tag.ID, tag.Version)
+	}
+
+	return pkg.ETag(entry.Version, derived...)
+}
+
 func GetResponseFromModelEntries(entries []*models.Entry) []*RespEntry {
 	result := make([]*RespEntry, 0, 10)
 	for _, entry := range entries {
@@ -3,6 +3,7 @@ package dto
 import (
 	"time"
 	"timetracker/models"
+	"timetracker/pkg"
 )
 
 type ReqCreateUpdateGoal struct {
@@ -27,6 +28,30 @@ func (req *ReqCreateUpdateGoal) ToModelGoal() *models.Goal {
 	}
 }
 
+// ReqPatchGoal only changes the fields present in the body. A goal always has a project,
+// so a null project_id is the same as a missing one.
+type ReqPatchGoal struct {
+	ID          uint64     `json:"id"`
+	Name        *string    `json:"name" validate:"omitempty,nonzero"`
+	ProjectID   *uint64    `json:"project_id"`
+	HoursCount  *float64   `json:"hours_count" validate:"omitempty,nonzero"`
+	Description *string    `json:"description"`
+	TimeStart   *time.Time `json:"time_start" validate:"omitempty,nonzero"`
+	TimeEnd     *time.Time `json:"time_end" validate:"omitempty,nonzero"`
+}
+
+func (req *ReqPatchGoal) ToModelGoalPatch() *models.GoalPatch {
+	return &models.GoalPatch{
+		ID:          req.ID,
+		Name:        req.Name,
+		ProjectID:   req.ProjectID,
+		HoursCount:  req.HoursCount,
+		Description: req.Description,
+		TimeStart:   req.TimeStart,
+		TimeEnd:     req.TimeEnd,
+	}
+}
+
 type RespGoal struct {
 	ID          uint64    `json:"id"`
 	Name        string    `json:"name"`
@@ -36,6 +61,10 @@ type RespGoal struct {
 	Description string    `json:"description"`
 	TimeStart   time.Time `json:"time_start"`
 	TimeEnd     time.Time `json:"time_end"`
+	Version     uint64    `json:"version"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// DeletedAt is only set on the items in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 func GetResponseFromModelGoal(goal *models.Goal) *RespGoal {
@@ -48,9 +77,16 @@ func GetResponseFromModelGoal(goal *models.Goal) *RespGoal {
 		Description: goal.Description,
 		TimeEnd:     goal.TimeEnd,
 		TimeStart:   goal.TimeStart,
+		Version:     goal.Version,
+		UpdatedAt:   goal.UpdatedAt,
+		DeletedAt:   goal.DeletedAt,
 	}
 }
 
+func GetETagFromModelGoal(goal *models.Goal) string {
+	return pkg.ETag(goal.Version)
+}
+
 func GetResponseFromModelGoals(goals []*models.Goal) []*RespGoal {
 	result := make([]*RespGoal, 0, 10)
 	for _, goal := range goals {
@@ -0,0 +1,68 @@
+package dto
+
+import "timetracker/models"
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+type RespHealth struct {
+	Status string `json:"status"`
+}
+
+type RespDependency struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type RespReadiness struct {
+	Status       string            `json:"status"`
+	Dependencies []*RespDependency `json:"dependencies"`
+}
+
+type RespVersion struct {
+	Service       string  `json:"service"`
+	Version       string  `json:"version"`
+	Commit        string  `json:"commit"`
+	SchemaVersion *uint64 `json:"schema_version"`
+}
+
+func GetResponseReadiness(statuses []*models.DependencyStatus, ready bool) *RespReadiness {
+	resp := &RespReadiness{
+		Status:       StatusOK,
+		Dependencies: make([]*RespDependency, 0, len(statuses)),
+	}
+
+	if !ready {
+		resp.Status = StatusFail
+	}
+
+	for _, status := range statuses {
+		dependency := &RespDependency{
+			Name:      status.Name,
+			Status:    StatusOK,
+			LatencyMs: float64(status.Latency.Microseconds()) / 1000,
+		}
+
+		if status.Err != nil {
+			dependency.Status = StatusFail
+			dependency.Error = status.Err.Error()
+		}
+
+		resp.Dependencies = append(resp.Dependencies, dependency)
+	}
+
+	return resp
+}
+
+func GetResponseVersion(info *models.BuildInfo) *RespVersion {
+	return &RespVersion{
+		Service:       info.Service,
+		Version:       info.Version,
+		Commit:        info.Commit,
+		SchemaVersion: info.SchemaVersion,
+	}
+}
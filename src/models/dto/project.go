@@ -1,20 +1,45 @@
 package dto
 
 import (
+	"time"
 	"timetracker/models"
+	"timetracker/pkg"
 )
 
 type ReqCreateUpdateProject struct {
-	ID        uint64 `json:"id"`
-	Name      string `json:"name" validate:"required"`
-	About     string `json:"about"`
-	Color     string `json:"color"`
-	IsPrivate bool   `json:"is_private"`
+	ID        uint64  `json:"id"`
+	ClientID  *uint64 `json:"client_id"`
+	Name      string  `json:"name" validate:"required"`
+	About     string  `json:"about"`
+	Color     string  `json:"color"`
+	IsPrivate bool    `json:"is_private"`
 }
 
 func (req *ReqCreateUpdateProject) ToModelProject() *models.Project {
 	return &models.Project{
 		ID:        req.ID,
+		ClientID:  req.ClientID,
+		Name:      req.Name,
+		About:     req.About,
+		Color:     req.Color,
+		IsPrivate: req.IsPrivate,
+	}
+}
+
+// ReqPatchProject only changes the fields present in the body. A null client_id removes the client.
+type ReqPatchProject struct {
+	ID        uint64                `json:"id"`
+	ClientID  pkg.Optional[*uint64] `json:"client_id" swaggertype:"integer"`
+	Name      *string               `json:"name" validate:"omitempty,nonzero"`
+	About     *string               `json:"about"`
+	Color     *string               `json:"color"`
+	IsPrivate *bool                 `json:"is_private"`
+}
+
+func (req *ReqPatchProject) ToModelProjectPatch() *models.ProjectPatch {
+	return &models.ProjectPatch{
+		ID:        req.ID,
+		ClientID:  req.ClientID,
 		Name:      req.Name,
 		About:     req.About,
 		Color:     req.Color,
@@ -23,27 +48,65 @@ func (req *ReqCreateUpdateProject) ToModelProject() *models.Project {
 }
 
 type RespProject struct {
-	ID              uint64  `json:"id"`
-	UserID          *uint64 `json:"user_id"`
-	Name            string  `json:"name"`
-	About           string  `json:"about"`
-	Color           string  `json:"color"`
-	IsPrivate       bool    `json:"is_private"`
-	TotalCountHours float64 `json:"total_count_hours"`
+	ID              uint64    `json:"id"`
+	UserID          *uint64   `json:"user_id"`
+	ClientID        *uint64   `json:"client_id"`
+	Name            string    `json:"name"`
+	About           string    `json:"about"`
+	Color           string    `json:"color"`
+	IsPrivate       bool      `json:"is_private"`
+	TotalCountHours float64   `json:"total_count_hours"`
+	Version         uint64    `json:"version"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	// ArchivedAt is only set on the archived projects
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	// DeletedAt is only set on the items in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Members with their hours are only set on the view of a single project
+	Members []*RespProjectMember `json:"members,omitempty"`
 }
 
 func GetResponseFromModelProject(project *models.Project) *RespProject {
 	return &RespProject{
 		ID:              project.ID,
 		UserID:          project.UserID,
+		ClientID:        project.ClientID,
 		Name:            project.Name,
 		About:           project.About,
 		Color:           project.Color,
 		IsPrivate:       project.IsPrivate,
 		TotalCountHours: project.TotalCountHours,
+		Version:         project.Version,
+		UpdatedAt:       project.UpdatedAt,
+		ArchivedAt:      project.ArchivedAt,
+		DeletedAt:       project.DeletedAt,
 	}
 }
 
+// GetETagFromModelProject covers the hours of the project too, entries change them
+func GetETagFromModelProject(project *models.Project) string {
+	return pkg.ETag(project.Version, project.TotalCountHours)
+}
+
+// GetResponseFromModelProjectWithMembers is the view of a single project with the hours of every member
+func GetResponseFromModelProjectWithMembers(project *models.Project, members []*models.ProjectMember) *RespProject {
+	resp := GetResponseFromModelProject(project)
+	resp.Members = GetResponseFromModelProjectMembers(members)
+
+	return resp
+}
+
+// GetETagFromModelProjectWithMembers changes with the membership and the hours of every member
+func GetETagFromModelProjectWithMembers(project *models.Project, members []*models.ProjectMember) string {
+	derived := make([]interface{}, 0, 1+4*len(members))
+	derived = append(derived, project.TotalCountHours)
+	for _, member := range members {
+		derived = append(derived, member.UserID, member.Role, member.Accepted(), member.TotalCountHours)
+	}
+
+	return pkg.ETag(project.Version, derived...)
+}
+
 func GetResponseFromModelProjects(entries []*models.Project) []*RespProject {
 	result := make([]*RespProject, 0, 10)
 	for _, project := range entries {
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+	"timetracker/models"
+)
+
+// ReqInviteProjectMember invites a friend to the project, the owner role can't be given away
+type ReqInviteProjectMember struct {
+	UserID uint64 `json:"user_id" validate:"required"`
+	Role   string `json:"role" validate:"required,oneof=editor viewer"`
+}
+
+func (req *ReqInviteProjectMember) ToModelProjectMember(projectID uint64, invitedBy uint64) *models.ProjectMember {
+	return &models.ProjectMember{
+		ProjectID: projectID,
+		UserID:    req.UserID,
+		Role:      models.ProjectRole(req.Role),
+		InvitedBy: &invitedBy,
+	}
+}
+
+type ReqUpdateProjectMember struct {
+	Role string `json:"role" validate:"required,oneof=editor viewer"`
+}
+
+type RespProjectMember struct {
+	ProjectID uint64    `json:"project_id"`
+	UserID    uint64    `json:"user_id"`
+	Role      string    `json:"role"`
+	InvitedBy *uint64   `json:"invited_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// AcceptedAt is not set while the invitation is pending
+	AcceptedAt      *time.Time `json:"accepted_at,omitempty"`
+	TotalCountHours float64    `json:"total_count_hours"`
+}
+
+func GetResponseFromModelProjectMember(member *models.ProjectMember) *RespProjectMember {
+	return &RespProjectMember{
+		ProjectID:       member.ProjectID,
+		UserID:          member.UserID,
+		Role:            string(member.Role),
+		InvitedBy:       member.InvitedBy,
+		CreatedAt:       member.CreatedAt,
+		AcceptedAt:      member.AcceptedAt,
+		TotalCountHours: member.TotalCountHours,
+	}
+}
+
+func GetResponseFromModelProjectMembers(members []*models.ProjectMember) []*RespProjectMember {
+	result := make([]*RespProjectMember, 0, len(members))
+	for _, member := range members {
+		result = append(result, GetResponseFromModelProjectMember(member))
+	}
+
+	return result
+}
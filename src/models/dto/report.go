@@ -0,0 +1,26 @@
+package dto
+
+import "timetracker/models"
+
+// RespReportRow is the hours of a project or of a client, the row without
+// either of them holds the entries without a project or a client
+type RespReportRow struct {
+	ProjectID       *uint64 `json:"project_id,omitempty"`
+	ClientID        *uint64 `json:"client_id,omitempty"`
+	TotalCountHours float64 `json:"total_count_hours"`
+	EntriesCount    uint64  `json:"entries_count"`
+}
+
+func GetResponseFromModelReport(report []*models.ReportRow) []*RespReportRow {
+	result := make([]*RespReportRow, 0, len(report))
+	for _, row := range report {
+		result = append(result, &RespReportRow{
+			ProjectID:       row.ProjectID,
+			ClientID:        row.ClientID,
+			TotalCountHours: row.TotalCountHours,
+			EntriesCount:    row.EntriesCount,
+		})
+	}
+
+	return result
+}
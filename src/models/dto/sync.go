@@ -0,0 +1,267 @@
+package dto
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+	"timetracker/internal/apierror"
+	"timetracker/models"
+	"timetracker/pkg"
+)
+
+// FormatSyncCursor makes the opaque cursor of the changes, clients only send it back
+func FormatSyncCursor(cursor time.Time) string {
+	return strconv.FormatInt(cursor.UnixMicro(), 10)
+}
+
+// ParseSyncCursor parses a cursor of FormatSyncCursor, an empty one is the first sync
+func ParseSyncCursor(cursor string) (time.Time, error) {
+	if cursor == "" {
+		return time.Time{}, nil
+	}
+
+	micros, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || micros <= 0 {
+		return time.Time{}, models.ErrBadRequest
+	}
+
+	return time.UnixMicro(micros).UTC(), nil
+}
+
+// ReqSyncMutation is a change made offline. Data is the body of the matching create or
+// patch request, e.g. dto.ReqCreateUpdateEntry to create an entry; delete has no data.
+type ReqSyncMutation struct {
+	ClientID        string          `json:"client_id" validate:"omitempty,max=64"`
+	Type            string          `json:"type" validate:"required,oneof=entry project tag goal"`
+	Action          string          `json:"action" validate:"required,oneof=create update delete"`
+	ID              uint64          `json:"id" validate:"required_without=ClientID"`
+	Version         uint64          `json:"version"`
+	ProjectClientID string          `json:"project_client_id" validate:"omitempty,max=64"`
+	TagClientIDs    []string        `json:"tag_client_ids" validate:"omitempty,dive,max=64"`
+	Data            json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+type ReqSyncMutations struct {
+	Mutations []*ReqSyncMutation `json:"mutations" validate:"required,min=1,max=100"`
+}
+
+// ToModelSyncMutations validates every mutation on its own, an invalid one is rejected
+// in its result and doesn't fail the batch
+func (req *ReqSyncMutations) ToModelSyncMutations() []*models.SyncMutation {
+	mutations := make([]*models.SyncMutation, 0, len(req.Mutations))
+	for _, reqMutation := range req.Mutations {
+		mutations = append(mutations, reqMutation.ToModelSyncMutation())
+	}
+
+	return mutations
+}
+
+func (req *ReqSyncMutation) ToModelSyncMutation() *models.SyncMutation {
+	mutation := &models.SyncMutation{
+		ClientID:        req.ClientID,
+		Type:            models.SyncObject(req.Type),
+		Action:          models.SyncAction(req.Action),
+		ID:              req.ID,
+		Version:         req.Version,
+		ProjectClientID: req.ProjectClientID,
+		TagClientIDs:    req.TagClientIDs,
+	}
+
+	if ok, err := pkg.IsRequestValid(req); !ok {
+		mutation.Err = apierror.Validation(err)
+		return mutation
+	}
+
+	if mutation.Action == models.SyncDelete {
+		return mutation
+	}
+
+	if len(req.Data) == 0 {
+		mutation.Err = apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "data is required")
+		return mutation
+	}
+
+	var data interface{ validate() error }
+	switch {
+	case mutation.Type == models.SyncEntry && mutation.Action == models.SyncCreate:
+		data = &syncCreateEntry{}
+	case mutation.Type == models.SyncEntry:
+		data = &syncPatchEntry{}
+	case mutation.Type == models.SyncProject && mutation.Action == models.SyncCreate:
+		data = &syncCreateProject{}
+	case mutation.Type == models.SyncProject:
+		data = &syncPatchProject{}
+	case mutation.Type == models.SyncTag && mutation.Action == models.SyncCreate:
+		data = &syncCreateTag{}
+	case mutation.Type == models.SyncTag:
+		data = &syncPatchTag{}
+	case mutation.Type == models.SyncGoal && mutation.Action == models.SyncCreate:
+		data = &syncCreateGoal{projectClientID: req.ProjectClientID}
+	default:
+		data = &syncPatchGoal{}
+	}
+
+	if err := json.Unmarshal(req.Data, data); err != nil {
+		mutation.Err = apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "data is not the body of the "+req.Action+" request")
+		return mutation
+	}
+
+	if err := data.validate(); err != nil {
+		mutation.Err = apierror.Validation(err)
+		return mutation
+	}
+
+	switch data := data.(type) {
+	case *syncCreateEntry:
+		mutation.Entry = data.ToModelEntry()
+	case *syncPatchEntry:
+		mutation.EntryPatch = data.ToModelEntryPatch()
+	case *syncCreateProject:
+		mutation.Project = data.ToModelProject()
+	case *syncPatchProject:
+		mutation.ProjectPatch = data.ToModelProjectPatch()
+	case *syncCreateTag:
+		mutation.Tag = data.ToModelTag()
+	case *syncPatchTag:
+		mutation.TagPatch = data.ToModelTagPatch()
+	case *syncCreateGoal:
+		mutation.Goal = data.ToModelGoal()
+	case *syncPatchGoal:
+		mutation.GoalPatch = data.ToModelGoalPatch()
+	}
+
+	return mutation
+}
+
+type syncCreateEntry struct{ ReqCreateUpdateEntry }
+
+func (d *syncCreateEntry) validate() error { return validateRequest(&d.ReqCreateUpdateEntry) }
+
+type syncPatchEntry struct{ ReqPatchEntry }
+
+func (d *syncPatchEntry) validate() error { return validateRequest(&d.ReqPatchEntry) }
+
+type syncCreateProject struct{ ReqCreateUpdateProject }
+
+func (d *syncCreateProject) validate() error { return validateRequest(&d.ReqCreateUpdateProject) }
+
+type syncPatchProject struct{ ReqPatchProject }
+
+func (d *syncPatchProject) validate() error { return validateRequest(&d.ReqPatchProject) }
+
+type syncCreateTag struct{ ReqCreateUpdateTag }
+
+func (d *syncCreateTag) validate() error { return validateRequest(&d.ReqCreateUpdateTag) }
+
+type syncPatchTag struct{ ReqPatchTag }
+
+func (d *syncPatchTag) validate() error { return validateRequest(&d.ReqPatchTag) }
+
+// syncCreateGoal may get its project from project_client_id instead of project_id
+type syncCreateGoal struct {
+	ReqCreateUpdateGoal
+	projectClientID string
+}
+
+func (d *syncCreateGoal) validate() error {
+	req := d.ReqCreateUpdateGoal
+	if d.projectClientID != "" && req.ProjectID == nil {
+		// the usecase sets the project of the client id
+		placeholder := uint64(0)
+		req.ProjectID = &placeholder
+	}
+	return validateRequest(&req)
+}
+
+type syncPatchGoal struct{ ReqPatchGoal }
+
+func (d *syncPatchGoal) validate() error { return validateRequest(&d.ReqPatchGoal) }
+
+func validateRequest(req interface{}) error {
+	_, err := pkg.IsRequestValid(req)
+	return err
+}
+
+type RespSyncTombstone struct {
+	Type      string    `json:"type" example:"entry"`
+	ID        uint64    `json:"id"`
+	Version   uint64    `json:"version"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type RespSyncChanges struct {
+	// Cursor is the since of the next sync
+	Cursor   string              `json:"cursor" example:"1700000000000000"`
+	Entries  []*RespEntry        `json:"entries"`
+	Projects []*RespProject      `json:"projects"`
+	Tags     []*RespTag          `json:"tags"`
+	Goals    []*RespGoal         `json:"goals"`
+	Deleted  []RespSyncTombstone `json:"deleted"`
+}
+
+func GetResponseFromModelSyncChanges(changes *models.SyncChanges) *RespSyncChanges {
+	deleted := make([]RespSyncTombstone, 0, len(changes.Deleted))
+	for _, tombstone := range changes.Deleted {
+		deleted = append(deleted, RespSyncTombstone{
+			Type:      string(tombstone.Type),
+			ID:        tombstone.ID,
+			Version:   tombstone.Version,
+			DeletedAt: tombstone.DeletedAt,
+		})
+	}
+
+	return &RespSyncChanges{
+		Cursor:   FormatSyncCursor(changes.Cursor),
+		Entries:  GetResponseFromModelEntries(changes.Entries),
+		Projects: GetResponseFromModelProjects(changes.Projects),
+		Tags:     GetResponseFromModelTags(changes.Tags),
+		Goals:    GetResponseFromModelGoals(changes.Goals),
+		Deleted:  deleted,
+	}
+}
+
+type RespSyncResult struct {
+	ClientID string `json:"client_id,omitempty"`
+	Type     string `json:"type" example:"entry"`
+	Action   string `json:"action" example:"create"`
+	ID       uint64 `json:"id,omitempty"`
+	// Status is applied, conflict or rejected
+	Status string          `json:"status" example:"applied"`
+	Error  *apierror.Error `json:"error,omitempty"`
+	// Object is the object after the change, or the current one on a conflict
+	Object interface{} `json:"object,omitempty" swaggertype:"object"`
+}
+
+type RespSyncResults struct {
+	Results []*RespSyncResult `json:"results"`
+}
+
+func GetResponseFromModelSyncResults(results []*models.SyncResult) *RespSyncResults {
+	resp := &RespSyncResults{Results: make([]*RespSyncResult, 0, len(results))}
+	for _, result := range results {
+		respResult := &RespSyncResult{
+			ClientID: result.ClientID,
+			Type:     string(result.Type),
+			Action:   string(result.Action),
+			ID:       result.ID,
+			Status:   string(result.Status),
+			Error:    apierror.From(result.Err),
+		}
+
+		switch {
+		case result.Entry != nil:
+			respResult.Object = GetResponseFromModelEntry(result.Entry)
+		case result.Project != nil:
+			respResult.Object = GetResponseFromModelProject(result.Project)
+		case result.Tag != nil:
+			respResult.Object = GetResponseFromModelTag(result.Tag)
+		case result.Goal != nil:
+			respResult.Object = GetResponseFromModelGoal(result.Goal)
+		}
+
+		resp.Results = append(resp.Results, respResult)
+	}
+
+	return resp
+}
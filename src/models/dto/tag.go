@@ -1,7 +1,9 @@
 package dto
 
 import (
+	"time"
 	"timetracker/models"
+	"timetracker/pkg"
 )
 
 type ReqCreateUpdateTag struct {
@@ -20,24 +22,52 @@ func (req *ReqCreateUpdateTag) ToModelTag() *models.Tag {
 	}
 }
 
+// ReqPatchTag only changes the fields present in the body
+type ReqPatchTag struct {
+	ID    uint64  `json:"id"`
+	Name  *string `json:"name" validate:"omitempty,nonzero"`
+	About *string `json:"about"`
+	Color *string `json:"color"`
+}
+
+func (req *ReqPatchTag) ToModelTagPatch() *models.TagPatch {
+	return &models.TagPatch{
+		ID:    req.ID,
+		Name:  req.Name,
+		About: req.About,
+		Color: req.Color,
+	}
+}
+
 type RespTag struct {
-	ID     uint64 `json:"id"`
-	UserID uint64 `json:"user_id"`
-	Name   string `json:"name"`
-	About  string `json:"about"`
-	Color  string `json:"color"`
+	ID        uint64    `json:"id"`
+	UserID    uint64    `json:"user_id"`
+	Name      string    `json:"name"`
+	About     string    `json:"about"`
+	Color     string    `json:"color"`
+	Version   uint64    `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt is only set on the items in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 func GetResponseFromModelTag(tag *models.Tag) *RespTag {
 	return &RespTag{
-		ID:     tag.ID,
-		UserID: tag.UserID,
-		Name:   tag.Name,
-		About:  tag.About,
-		Color:  tag.Color,
+		ID:        tag.ID,
+		UserID:    tag.UserID,
+		Name:      tag.Name,
+		About:     tag.About,
+		Color:     tag.Color,
+		Version:   tag.Version,
+		UpdatedAt: tag.UpdatedAt,
+		DeletedAt: tag.DeletedAt,
 	}
 }
 
+func GetETagFromModelTag(tag *models.Tag) string {
+	return pkg.ETag(tag.Version)
+}
+
 func GetResponseFromModelTags(tags []*models.Tag) []*RespTag {
 	result := make([]*RespTag, 0, 10)
 	for _, tag := range tags {
@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+	"timetracker/models"
+	"timetracker/pkg"
+)
+
+type ReqCreateTask struct {
+	Name          string  `json:"name" validate:"required,max=64"`
+	EstimateHours float64 `json:"estimate_hours" validate:"min=0"`
+	Done          bool    `json:"done"`
+}
+
+func (req *ReqCreateTask) ToModelTask(projectID uint64) *models.Task {
+	return &models.Task{
+		ProjectID:     projectID,
+		Name:          req.Name,
+		EstimateHours: req.EstimateHours,
+		Done:          req.Done,
+	}
+}
+
+// ReqPatchTask only changes the fields present in the body
+type ReqPatchTask struct {
+	Name          *string  `json:"name" validate:"omitempty,nonzero,max=64"`
+	EstimateHours *float64 `json:"estimate_hours" validate:"omitempty,min=0"`
+	Done          *bool    `json:"done"`
+}
+
+func (req *ReqPatchTask) ToModelTaskPatch() *models.TaskPatch {
+	return &models.TaskPatch{
+		Name:          req.Name,
+		EstimateHours: req.EstimateHours,
+		Done:          req.Done,
+	}
+}
+
+type RespTask struct {
+	ID              uint64    `json:"id"`
+	ProjectID       uint64    `json:"project_id"`
+	Name            string    `json:"name"`
+	EstimateHours   float64   `json:"estimate_hours"`
+	Done            bool      `json:"done"`
+	TotalCountHours float64   `json:"total_count_hours"`
+	Version         uint64    `json:"version"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func GetResponseFromModelTask(task *models.Task) *RespTask {
+	return &RespTask{
+		ID:              task.ID,
+		ProjectID:       task.ProjectID,
+		Name:            task.Name,
+		EstimateHours:   task.EstimateHours,
+		Done:            task.Done,
+		TotalCountHours: task.TotalCountHours,
+		Version:         task.Version,
+		UpdatedAt:       task.UpdatedAt,
+	}
+}
+
+// GetETagFromModelTask covers the hours of the task, they change with its entries
+func GetETagFromModelTask(task *models.Task) string {
+	return pkg.ETag(task.Version, task.TotalCountHours)
+}
+
+func GetResponseFromModelTasks(tasks []*models.Task) []*RespTask {
+	result := make([]*RespTask, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, GetResponseFromModelTask(task))
+	}
+
+	return result
+}
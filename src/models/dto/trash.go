@@ -0,0 +1,19 @@
+package dto
+
+import "timetracker/models"
+
+type RespTrash struct {
+	Entries  []*RespEntry   `json:"entries"`
+	Projects []*RespProject `json:"projects"`
+	Tags     []*RespTag     `json:"tags"`
+	Goals    []*RespGoal    `json:"goals"`
+}
+
+func GetResponseFromModelTrash(trash *models.Trash) *RespTrash {
+	return &RespTrash{
+		Entries:  GetResponseFromModelEntries(trash.Entries),
+		Projects: GetResponseFromModelProjects(trash.Projects),
+		Tags:     GetResponseFromModelTags(trash.Tags),
+		Goals:    GetResponseFromModelGoals(trash.Goals),
+	}
+}
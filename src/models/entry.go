@@ -6,16 +6,58 @@ import (
 )
 
 type Entry struct {
-	ID          uint64    `json:"id"`
-	UserID      *uint64   `json:"user_id"`
-	ProjectID   *uint64   `json:"project_id"`
+	ID        uint64  `json:"id"`
+	UserID    *uint64 `json:"user_id"`
+	ProjectID *uint64 `json:"project_id"`
+	// TaskID is one of the tasks of the project, nil is no task
+	TaskID      *uint64   `json:"task_id"`
 	Description string    `json:"description"`
 	TagList     []Tag     `json:"tag_list"`
 	TimeStart   time.Time `json:"time_start"`
 	TimeEnd     time.Time `json:"time_end"`
 	Duration    string    `json:"-"`
+	Version     uint64    `json:"version"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// DeletedAt is only set on the tombstone of a deleted entry
+	DeletedAt *time.Time `json:"deleted_at"`
 }
 
 func (e *Entry) CalcDuration() {
 	e.Duration = pkg.GetPrettyDuration(e.TimeStart, e.TimeEnd)
 }
+
+// EntryPatch holds the fields of a partial update, a nil field is left as it is.
+// TagList replaces the tags of the entry, an empty list removes all of them.
+type EntryPatch struct {
+	ID     uint64
+	UserID uint64
+	// Version is the one of the If-Match header, 0 updates any version
+	Version     uint64
+	ProjectID   pkg.Optional[*uint64]
+	TaskID      pkg.Optional[*uint64]
+	Description *string
+	TagList     *[]Tag
+	TimeStart   *time.Time
+	TimeEnd     *time.Time
+}
+
+func (p *EntryPatch) Apply(e *Entry) {
+	if p.ProjectID.Set {
+		e.ProjectID = p.ProjectID.Value
+	}
+	if p.TaskID.Set {
+		e.TaskID = p.TaskID.Value
+	}
+	if p.Description != nil {
+		e.Description = *p.Description
+	}
+	if p.TagList != nil {
+		e.TagList = *p.TagList
+	}
+	if p.TimeStart != nil {
+		e.TimeStart = *p.TimeStart
+	}
+	if p.TimeEnd != nil {
+		e.TimeEnd = *p.TimeEnd
+	}
+}
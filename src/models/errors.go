@@ -12,4 +12,17 @@ var (
 	ErrUnauthorized        = errors.New("no cookie")
 	ErrInternalServerError = errors.New("internal server error")
 	ErrPermissionDenied    = errors.New("permission denied")
+	ErrInvalidCSRF         = errors.New("invalid csrf")
+	ErrUserSuspended       = errors.New("user is suspended")
+	ErrVersionMismatch     = errors.New("item was changed by another request")
+	ErrIfMatchRequired     = errors.New("If-Match header is required")
+	ErrClientIDRequired    = errors.New("client_id is required to create an object")
+	ErrConflictClientID    = errors.New("client_id is used by another object")
+	ErrProjectDeleted      = errors.New("the project is deleted, restore it first")
+	ErrProjectArchived     = errors.New("the project is archived, unarchive it first")
+	ErrConflictMember      = errors.New("the user is already a member or invited")
+	ErrNotFriends          = errors.New("only friends can be invited")
+	ErrTaskProject         = errors.New("the task belongs to another project")
+	ErrResyncRequired      = errors.New("the cursor is older than the trash retention, sync again without since")
+	ErrReauthRequired      = errors.New("sign in to the identity provider again to confirm")
 )
@@ -13,4 +13,43 @@ type Goal struct {
 	Description string
 	TimeStart   time.Time
 	TimeEnd     time.Time
+	Version     uint64
+	UpdatedAt   time.Time
+	// DeletedAt is only set on the tombstone of a deleted goal
+	DeletedAt *time.Time
+}
+
+// GoalPatch holds the fields of a partial update, a nil field is left as it is
+type GoalPatch struct {
+	ID     uint64
+	UserID uint64
+	// Version is the one of the If-Match header, 0 updates any version
+	Version     uint64
+	Name        *string
+	ProjectID   *uint64
+	HoursCount  *float64
+	Description *string
+	TimeStart   *time.Time
+	TimeEnd     *time.Time
+}
+
+func (p *GoalPatch) Apply(g *Goal) {
+	if p.Name != nil {
+		g.Name = *p.Name
+	}
+	if p.ProjectID != nil {
+		g.ProjectID = p.ProjectID
+	}
+	if p.HoursCount != nil {
+		g.HoursCount = *p.HoursCount
+	}
+	if p.Description != nil {
+		g.Description = *p.Description
+	}
+	if p.TimeStart != nil {
+		g.TimeStart = *p.TimeStart
+	}
+	if p.TimeEnd != nil {
+		g.TimeEnd = *p.TimeEnd
+	}
 }
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// DependencyStatus is the result of pinging one dependency, Err is nil when it is ready
+type DependencyStatus struct {
+	Name    string
+	Latency time.Duration
+	Err     error
+}
+
+type BuildInfo struct {
+	Service string
+	Version string
+	Commit  string
+	// SchemaVersion is the applied migration, nil for storages without a schema
+	SchemaVersion *uint64
+}
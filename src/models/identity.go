@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type ExternalIdentity struct {
+	ID            uint64
+	UserID        uint64
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	// AuthTime is when the user signed in to the provider, it is not stored
+	AuthTime time.Time
+}
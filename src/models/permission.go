@@ -0,0 +1,22 @@
+package models
+
+// Permission is "<resource>:<action>[:<scope>]". Permissions with the "any" scope
+// grant read access to resources of other users, owners never need them. Writes are
+// owner only, no permission grants them.
+type Permission string
+
+const (
+	PermEntryReadAny   Permission = "entry:read:any"
+	PermProjectReadAny Permission = "project:read:any"
+	PermTagReadAny     Permission = "tag:read:any"
+	PermGoalReadAny    Permission = "goal:read:any"
+	PermUserReadAny    Permission = "user:read:any"
+	PermUserList       Permission = "user:list"
+	PermUserBan        Permission = "user:ban"
+	PermUserRoleChange Permission = "user:role:change"
+	PermUserPassReset  Permission = "user:password:reset"
+	PermUserDelete     Permission = "user:delete"
+	PermFriendsReadAny Permission = "friends:read:any"
+	PermAuditRead      Permission = "audit:read"
+	PermLogLevel       Permission = "log:level"
+)
@@ -1,11 +1,55 @@
 package models
 
+import (
+	"time"
+	"timetracker/pkg"
+)
+
 type Project struct {
 	ID        uint64
 	UserID    *uint64
+	// ClientID is the client of the owner the project is done for
+	ClientID  *uint64
 	Name      string
 	About     string
 	Color     string
 	IsPrivate bool
 	TotalCountHours float64
+	Version   uint64
+	UpdatedAt time.Time
+	// ArchivedAt is set while the project is archived, it takes no new entries or goals
+	ArchivedAt *time.Time
+	// DeletedAt is only set on the tombstone of a deleted project
+	DeletedAt *time.Time
+}
+
+// ProjectPatch holds the fields of a partial update, a nil field is left as it is
+type ProjectPatch struct {
+	ID     uint64
+	UserID uint64
+	// Version is the one of the If-Match header, 0 updates any version
+	Version   uint64
+	ClientID  pkg.Optional[*uint64]
+	Name      *string
+	About     *string
+	Color     *string
+	IsPrivate *bool
+}
+
+func (p *ProjectPatch) Apply(project *Project) {
+	if p.ClientID.Set {
+		project.ClientID = p.ClientID.Value
+	}
+	if p.Name != nil {
+		project.Name = *p.Name
+	}
+	if p.About != nil {
+		project.About = *p.About
+	}
+	if p.Color != nil {
+		project.Color = *p.Color
+	}
+	if p.IsPrivate != nil {
+		project.IsPrivate = *p.IsPrivate
+	}
 }
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+type ProjectRole string
+
+const (
+	ProjectOwner  ProjectRole = "owner"
+	ProjectEditor ProjectRole = "editor"
+	ProjectViewer ProjectRole = "viewer"
+)
+
+// CanLog tells if the role can add entries and goals to the project
+func (r ProjectRole) CanLog() bool {
+	return r == ProjectOwner || r == ProjectEditor
+}
+
+// CanEdit tells if the role can change the project itself
+func (r ProjectRole) CanEdit() bool {
+	return r == ProjectOwner || r == ProjectEditor
+}
+
+// ProjectMember is a user on a shared project. The owner of a project is its member too,
+// an invited user only becomes a member once the invitation is accepted.
+type ProjectMember struct {
+	ProjectID uint64
+	UserID    uint64
+	Role      ProjectRole
+	// InvitedBy is nil for the owner
+	InvitedBy *uint64
+	CreatedAt time.Time
+	// AcceptedAt is nil while the invitation is pending
+	AcceptedAt *time.Time
+	// TotalCountHours are the hours the member logged on the project
+	TotalCountHours float64
+}
+
+// NewProjectOwner is the member row of the user creating the project
+func NewProjectOwner(projectID uint64, userID uint64, now time.Time) *ProjectMember {
+	return &ProjectMember{
+		ProjectID:  projectID,
+		UserID:     userID,
+		Role:       ProjectOwner,
+		CreatedAt:  now,
+		AcceptedAt: &now,
+	}
+}
+
+func (m *ProjectMember) Accepted() bool {
+	return m.AcceptedAt != nil
+}
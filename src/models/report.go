@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type ReportGroup string
+
+const (
+	ReportByProject ReportGroup = "project"
+	ReportByClient  ReportGroup = "client"
+)
+
+// ReportFilter picks the entries of a report, a zero field doesn't filter
+type ReportFilter struct {
+	// Since and Until bound the start of the entries to [Since, Until)
+	Since    time.Time
+	Until    time.Time
+	ClientID *uint64
+	GroupBy  ReportGroup
+}
+
+// ReportRow is the hours of the entries of a project or of a client.
+// The entries without one are in the row with a nil ID.
+type ReportRow struct {
+	ProjectID       *uint64
+	ClientID        *uint64
+	TotalCountHours float64
+	EntriesCount    uint64
+}
@@ -0,0 +1,100 @@
+package models
+
+import "time"
+
+type SyncObject string
+
+const (
+	SyncEntry   SyncObject = "entry"
+	SyncProject SyncObject = "project"
+	SyncTag     SyncObject = "tag"
+	SyncGoal    SyncObject = "goal"
+)
+
+// SyncChanges are the objects of a user changed since a cursor. The deleted ones are
+// only in Deleted, Cursor is the one to ask for the next changes with.
+type SyncChanges struct {
+	Cursor   time.Time
+	Entries  []*Entry
+	Projects []*Project
+	Tags     []*Tag
+	Goals    []*Goal
+	Deleted  []SyncTombstone
+}
+
+type SyncTombstone struct {
+	Type      SyncObject
+	ID        uint64
+	Version   uint64
+	DeletedAt time.Time
+}
+
+type SyncAction string
+
+const (
+	SyncCreate SyncAction = "create"
+	SyncUpdate SyncAction = "update"
+	SyncDelete SyncAction = "delete"
+)
+
+// SyncMutation is a change made offline. The payload matching Type and Action is set,
+// e.g. Entry for an entry to create or EntryPatch for an entry to update.
+type SyncMutation struct {
+	// ClientID is the id the client gave the object, it is required to create one
+	ClientID string
+	Type     SyncObject
+	Action   SyncAction
+	// ID is 0 when the client only knows the object by its ClientID
+	ID uint64
+	// Version is the one the change is based on, 0 applies it to any version
+	Version uint64
+	// ProjectClientID and TagClientIDs reference objects created offline,
+	// they are used instead of the ids of the payload
+	ProjectClientID string
+	TagClientIDs    []string
+
+	Entry        *Entry
+	EntryPatch   *EntryPatch
+	Project      *Project
+	ProjectPatch *ProjectPatch
+	Tag          *Tag
+	TagPatch     *TagPatch
+	Goal         *Goal
+	GoalPatch    *GoalPatch
+
+	// Err is set when the payload is invalid, then the mutation is rejected
+	Err error
+}
+
+type SyncStatus string
+
+const (
+	SyncApplied  SyncStatus = "applied"
+	SyncConflict SyncStatus = "conflict"
+	SyncRejected SyncStatus = "rejected"
+)
+
+// SyncResult is the outcome of a SyncMutation. The object is the one after the change,
+// or the current one on the server when the change is a conflict.
+type SyncResult struct {
+	ClientID string
+	Type     SyncObject
+	Action   SyncAction
+	ID       uint64
+	Status   SyncStatus
+	// Err is the reason of a rejected mutation
+	Err error
+
+	Entry   *Entry
+	Project *Project
+	Tag     *Tag
+	Goal    *Goal
+}
+
+// SyncClientID maps the id a client gave an object created offline to the object
+type SyncClientID struct {
+	UserID   uint64
+	ClientID string
+	Type     SyncObject
+	ObjectID uint64
+}
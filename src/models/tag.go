@@ -1,9 +1,38 @@
 package models
 
+import "time"
+
 type Tag struct {
+	ID        uint64
+	UserID    uint64
+	Name      string
+	About     string
+	Color     string
+	Version   uint64
+	UpdatedAt time.Time
+	// DeletedAt is only set on the tombstone of a deleted tag
+	DeletedAt *time.Time
+}
+
+// TagPatch holds the fields of a partial update, a nil field is left as it is
+type TagPatch struct {
 	ID     uint64
 	UserID uint64
-	Name   string
-	About  string
-	Color  string
+	// Version is the one of the If-Match header, 0 updates any version
+	Version uint64
+	Name    *string
+	About   *string
+	Color   *string
+}
+
+func (p *TagPatch) Apply(t *Tag) {
+	if p.Name != nil {
+		t.Name = *p.Name
+	}
+	if p.About != nil {
+		t.About = *p.About
+	}
+	if p.Color != nil {
+		t.Color = *p.Color
+	}
 }
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Task is a piece of work inside a project, the entries logged on it sum up to its hours
+type Task struct {
+	ID        uint64
+	ProjectID uint64
+	Name      string
+	// EstimateHours is the planned work, 0 is no estimate
+	EstimateHours float64
+	Done          bool
+	// TotalCountHours are the hours of the entries logged on the task
+	TotalCountHours float64
+	Version         uint64
+	UpdatedAt       time.Time
+}
+
+// TaskPatch holds the fields of a partial update, a nil field is left as it is
+type TaskPatch struct {
+	ID     uint64
+	UserID uint64
+	// Version is the one of the If-Match header, 0 updates any version
+	Version       uint64
+	Name          *string
+	EstimateHours *float64
+	Done          *bool
+}
+
+func (p *TaskPatch) Apply(t *Task) {
+	if p.Name != nil {
+		t.Name = *p.Name
+	}
+	if p.EstimateHours != nil {
+		t.EstimateHours = *p.EstimateHours
+	}
+	if p.Done != nil {
+		t.Done = *p.Done
+	}
+}
@@ -0,0 +1,9 @@
+package models
+
+// Trash is what a user deleted and can still restore, until the purge removes it
+type Trash struct {
+	Entries  []*Entry
+	Projects []*Project
+	Tags     []*Tag
+	Goals    []*Goal
+}
@@ -1,27 +1,50 @@
 package models
 
+import "time"
+
 type RoleType int64
 
 const (
 	DefaultUser RoleType = iota
 	Admin
+	Moderator
+	Support
 )
 
+var roles = []RoleType{DefaultUser, Moderator, Support, Admin}
+
 func (s RoleType) String() string {
 	switch s {
 	case DefaultUser:
 		return "user"
 	case Admin:
 		return "admin"
+	case Moderator:
+		return "moderator"
+	case Support:
+		return "support"
 	}
 	return "unknown"
 }
 
+func RoleFromString(role string) (RoleType, bool) {
+	for _, r := range roles {
+		if r.String() == role {
+			return r, true
+		}
+	}
+
+	return DefaultUser, false
+}
+
 type User struct {
-	ID       uint64 `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	About    string `json:"about"`
-	Role     string `json:"role"`
-	Password string `json:"password"`
+	ID        uint64 `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	About     string `json:"about"`
+	Role      string `json:"role"`
+	Password  string `json:"password"`
+	Suspended bool   `json:"suspended"`
+	// DeleteAfter is set while the account waits for the hard deletion, signing in cancels it
+	DeleteAfter *time.Time `json:"delete_after"`
 }
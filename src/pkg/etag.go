@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// ETag is the strong validator of a resource made from its version. The body of some
+// resources also has fields of other rows, e.g. the tags of an entry, their digest follows
+// the version so a change of those rows gives a new ETag without a new version.
+func ETag(version uint64, derived ...interface{}) string {
+	if len(derived) == 0 {
+		return `"` + strconv.FormatUint(version, 10) + `"`
+	}
+
+	h := fnv.New64a()
+	for _, field := range derived {
+		fmt.Fprint(h, field, ";")
+	}
+
+	return fmt.Sprintf(`"%d-%x"`, version, h.Sum64())
+}
+
+// ETagVersion returns the version of an ETag made by ETag, a weak ETag has no version
+func ETagVersion(etag string) (uint64, bool) {
+	etag = strings.TrimSpace(etag)
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, false
+	}
+
+	version, _, _ := strings.Cut(etag[1:len(etag)-1], "-")
+	parsed, err := strconv.ParseUint(version, 10, 64)
+	if err != nil || parsed == 0 {
+		return 0, false
+	}
+
+	return parsed, true
+}
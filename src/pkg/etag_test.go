@@ -0,0 +1,40 @@
+package pkg_test
+
+import (
+	"testing"
+
+	"timetracker/pkg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, `"3"`, pkg.ETag(3))
+	assert.Equal(t, pkg.ETag(3, uint64(1), uint64(2)), pkg.ETag(3, uint64(1), uint64(2)))
+	assert.NotEqual(t, pkg.ETag(3, uint64(1), uint64(2)), pkg.ETag(3, uint64(1), uint64(3)))
+	assert.NotEqual(t, pkg.ETag(3, 2.5), pkg.ETag(3, 2.75))
+
+	tests := []struct {
+		name    string
+		etag    string
+		version uint64
+		ok      bool
+	}{
+		{name: "version", etag: `"3"`, version: 3, ok: true},
+		{name: "version with digest", etag: pkg.ETag(7, 1.5), version: 7, ok: true},
+		{name: "spaces", etag: ` "3" `, version: 3, ok: true},
+		{name: "weak", etag: `W/"3"`},
+		{name: "unquoted", etag: `3`},
+		{name: "zero", etag: `"0"`},
+		{name: "not a number", etag: `"abc"`},
+		{name: "empty", etag: ``},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, ok := pkg.ETagVersion(test.etag)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.version, version)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package pkg
+
+import "encoding/json"
+
+// Optional tells a field missing from a PATCH body from a field set to null, so
+// {"project_id": null} clears the value and {} leaves it as it is.
+type Optional[T any] struct {
+	Set   bool
+	Value T
+}
+
+// Some returns an Optional that is set to value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{Set: true, Value: value}
+}
+
+// UnmarshalJSON is only called for the keys present in the body, null included.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	o.Set, o.Value = true, value
+	return nil
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Value)
+}
@@ -0,0 +1,51 @@
+package pkg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"timetracker/pkg"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptional(t *testing.T) {
+	type req struct {
+		ProjectID pkg.Optional[*uint64] `json:"project_id"`
+	}
+
+	projectID := uint64(7)
+
+	tests := []struct {
+		name     string
+		body     string
+		expected pkg.Optional[*uint64]
+	}{
+		{
+			name: "missing",
+			body: `{}`,
+		},
+		{
+			name:     "null",
+			body:     `{"project_id": null}`,
+			expected: pkg.Optional[*uint64]{Set: true},
+		},
+		{
+			name:     "value",
+			body:     `{"project_id": 7}`,
+			expected: pkg.Some(&projectID),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var r req
+			require.NoError(t, json.Unmarshal([]byte(test.body), &r))
+			assert.Equal(t, test.expected, r.ProjectID)
+		})
+	}
+
+	var r req
+	assert.Error(t, json.Unmarshal([]byte(`{"project_id": "seven"}`), &r))
+}
@@ -1,13 +1,37 @@
 package pkg
 
-import "gopkg.in/go-playground/validator.v9"
+import (
+	"reflect"
+	"strings"
+
+	"gopkg.in/go-playground/validator.v9"
+)
 
 type Response struct {
 	Body interface{} `json:"body"`
 }
 
+// validate reports the json names of the fields, so clients can match them to the request
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" || name == "" {
+			return field.Name
+		}
+		return name
+	})
+	// nonzero rejects a field of a PATCH body that is present but empty, it goes after omitempty
+	// on a pointer. required can't be used there, it only checks that the pointer isn't nil.
+	_ = v.RegisterValidation("nonzero", func(fl validator.FieldLevel) bool {
+		return !fl.Field().IsZero()
+	})
+	return v
+}
+
 func IsRequestValid(entity interface{}) (bool, error) {
-	validate := validator.New()
 	err := validate.Struct(entity)
 	if err != nil {
 		return false, err
@@ -1,6 +1,7 @@
 package testintegration
 
 import (
+	"context"
 	"flag"
 	"log"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	projectRep "timetracker/internal/Project/repository/postgres"
 	projectUsecase "timetracker/internal/Project/usecase"
 	tagRep "timetracker/internal/Tag/repository/postgres"
+	taskRep "timetracker/internal/Task/repository/postgres"
 	"timetracker/models"
 
 	"github.com/stretchr/testify/suite"
@@ -55,9 +57,9 @@ func (suite *UsecaseRepositoryTestSuite) TestUsecaseCreateProject() {
 	}
 
 	projectRepo := projectRep.NewProjectRepository(suite.db)
-	useCase := projectUsecase.New(projectRepo)
+	useCase := projectUsecase.New(projectRepo, nil, nil, nil)
 
-	suite.Assert().NoError(useCase.CreateProject(newProject))
+	suite.Assert().NoError(useCase.CreateProject(context.Background(), newProject))
 
 	id := newProject.ID // new id is created
 
@@ -77,13 +79,13 @@ func (suite *UsecaseRepositoryTestSuite) TestUsecaseGetProject() {
 	}
 
 	projectRepo := projectRep.NewProjectRepository(suite.db)
-	useCase := projectUsecase.New(projectRepo)
+	useCase := projectUsecase.New(projectRepo, nil, nil, nil)
 
-	suite.Assert().NoError(useCase.CreateProject(newProject))
+	suite.Assert().NoError(useCase.CreateProject(context.Background(), newProject))
 
 	id := newProject.ID // new id is created
 
-	result, err := useCase.GetProject(id)
+	result, err := useCase.GetProject(context.Background(), id)
 	suite.Assert().NoError(err)
 	suite.Assert().Equal(newProject, result)
 }
@@ -99,25 +101,33 @@ func (suite *UsecaseRepositoryTestSuite) TestUsecaseUpdateProject() {
 	}
 
 	projectRepo := projectRep.NewProjectRepository(suite.db)
-	useCase := projectUsecase.New(projectRepo)
+	useCase := projectUsecase.New(projectRepo, nil, nil, nil)
 
-	suite.Assert().NoError(useCase.CreateProject(newProject))
+	suite.Assert().NoError(useCase.CreateProject(context.Background(), newProject))
 
 	id := newProject.ID // new id is created
 
+	name, about, color, isPrivate := "hello", "asdasd", "aa", true
 	newProjectUpdate := &models.Project{
 		ID:        id,
 		UserID:    &_user_id,
-		Name:      "hello",
-		About:     "asdasd",
-		Color:     "aa",
-		IsPrivate: true,
+		Name:      name,
+		About:     about,
+		Color:     color,
+		IsPrivate: isPrivate,
 	}
 
-	err := useCase.UpdateProject(newProjectUpdate)
+	_, err := useCase.UpdateProject(context.Background(), &models.ProjectPatch{
+		ID:        id,
+		UserID:    _user_id,
+		Name:      &name,
+		About:     &about,
+		Color:     &color,
+		IsPrivate: &isPrivate,
+	})
 	suite.Assert().NoError(err)
 
-	result, err := useCase.GetProject(id)
+	result, err := useCase.GetProject(context.Background(), id)
 	suite.Assert().NoError(err)
 	suite.Assert().Equal(newProjectUpdate, result)
 }
@@ -133,9 +143,9 @@ func (suite *UsecaseRepositoryTestSuite) TestUsecaseCreateEntry() {
 
 	entryRepo := entryRep.NewEntryRepository(suite.db)
 	tagRepo := tagRep.NewTagRepository(suite.db)
-	useCase := entryUsecase.New(entryRepo, tagRepo, nil)
+	useCase := entryUsecase.New(entryRepo, tagRepo, nil, projectRep.NewProjectRepository(suite.db), taskRep.NewTaskRepository(suite.db), nil)
 
-	suite.Assert().NoError(useCase.CreateEntry(newEntry))
+	suite.Assert().NoError(useCase.CreateEntry(context.Background(), newEntry))
 
 	id := newEntry.ID // new id is created
 
@@ -159,13 +169,13 @@ func (suite *UsecaseRepositoryTestSuite) TestUsecaseGetEntry() {
 
 	entryRepo := entryRep.NewEntryRepository(suite.db)
 	tagRepo := tagRep.NewTagRepository(suite.db)
-	useCase := entryUsecase.New(entryRepo, tagRepo, nil)
+	useCase := entryUsecase.New(entryRepo, tagRepo, nil, projectRep.NewProjectRepository(suite.db), taskRep.NewTaskRepository(suite.db), nil)
 
-	suite.Assert().NoError(useCase.CreateEntry(newEntry))
+	suite.Assert().NoError(useCase.CreateEntry(context.Background(), newEntry))
 
 	id := newEntry.ID // new id is created
 
-	result, err := useCase.GetEntry(id)
+	result, err := useCase.GetEntry(context.Background(), id)
 	suite.Assert().NoError(err)
 	suite.Assert().Equal(newEntry.ID, result.ID)
 	suite.Assert().Equal(newEntry.UserID, result.UserID)
@@ -185,9 +195,9 @@ func (suite *UsecaseRepositoryTestSuite) TestUsecaseUpdateEntry() {
 
 	entryRepo := entryRep.NewEntryRepository(suite.db)
 	tagRepo := tagRep.NewTagRepository(suite.db)
-	useCase := entryUsecase.New(entryRepo, tagRepo, nil)
+	useCase := entryUsecase.New(entryRepo, tagRepo, nil, projectRep.NewProjectRepository(suite.db), taskRep.NewTaskRepository(suite.db), nil)
 
-	suite.Assert().NoError(useCase.CreateEntry(newEntry))
+	suite.Assert().NoError(useCase.CreateEntry(context.Background(), newEntry))
 
 	id := newEntry.ID
 
@@ -200,10 +210,16 @@ func (suite *UsecaseRepositoryTestSuite) TestUsecaseUpdateEntry() {
 		TagList:     nil,
 	}
 
-	err := useCase.UpdateEntry(newEntryUpdated)
+	_, err := useCase.UpdateEntry(context.Background(), &models.EntryPatch{
+		ID:          id,
+		UserID:      _user_id,
+		Description: &newEntryUpdated.Description,
+		TimeStart:   &newEntryUpdated.TimeStart,
+		TimeEnd:     &newEntryUpdated.TimeEnd,
+	})
 	suite.Assert().NoError(err)
 
-	result, err := useCase.GetEntry(id)
+	result, err := useCase.GetEntry(context.Background(), id)
 	suite.Assert().NoError(err)
 	suite.Assert().Equal(newEntryUpdated.ID, result.ID)
 	suite.Assert().Equal(newEntryUpdated.UserID, result.UserID)